@@ -1,57 +1,187 @@
-package main
-
-import (
-	_ "CVMatch/docs"
-	"CVMatch/internal/config"
-	"CVMatch/internal/handlers"
-	"CVMatch/internal/logger"
-	"CVMatch/internal/parser"
-	"CVMatch/internal/repository"
-	"CVMatch/internal/router"
-	"CVMatch/internal/service"
-	"CVMatch/internal/storage"
-	"os"
-
-	"github.com/joho/godotenv"
-	"go.uber.org/zap"
-)
-
-// @Title CVMatch API
-// @Version 1.0
-// @securityDefinitions.apikey BearerAuth
-// @in header
-// @name Authorization
-func main() {
-	_ = godotenv.Load()
-	isDev := os.Getenv("ENV") == "development"
-	if err := logger.Init(isDev); err != nil {
-		panic(err)
-	}
-
-	defer logger.Sync()
-
-	log := logger.L()
-
-	cfg := config.Load(log)
-	db := storage.ConnectDB(&cfg.DB, log)
-	storage.Migrate(db, log)
-
-	userRepo := repository.NewUserRepository(db)
-	userService := service.NewUserService(userRepo, log, cfg)
-	userHandler := handlers.NewUserHandler(userService)
-
-	resumeRepo := repository.NewResumeRepository(db)
-	resumeParser := parser.YandexResumeParser{}
-	resumeService := service.NewResumeService(resumeRepo, log, cfg, resumeParser)
-	resumeHandler := handlers.NewResumeHandler(resumeService)
-
-	handlers := &router.Handlers{
-		User:   userHandler,
-		Resume: resumeHandler,
-	}
-
-	r := router.Router(db, log, cfg, handlers)
-	if err := r.Run(":8080"); err != nil {
-		log.Fatal("Failed to start server", zap.Error(err))
-	}
-}
+package main
+
+import (
+	_ "CVMatch/docs"
+	"CVMatch/internal/config"
+	"CVMatch/internal/handlers"
+	"CVMatch/internal/llm"
+	"CVMatch/internal/logger"
+	"CVMatch/internal/matching"
+	"CVMatch/internal/parser"
+	"CVMatch/internal/repository"
+	"CVMatch/internal/router"
+	"CVMatch/internal/service"
+	"CVMatch/internal/storage"
+	"context"
+	"os"
+	"time"
+
+	"github.com/joho/godotenv"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// @Title CVMatch API
+// @Version 1.0
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+func main() {
+	_ = godotenv.Load()
+	isDev := os.Getenv("ENV") == "development"
+	if err := logger.Init(isDev); err != nil {
+		panic(err)
+	}
+
+	defer logger.Sync()
+
+	log := logger.L()
+
+	cfg := config.Load(log)
+	db := storage.ConnectDB(&cfg.DB, log)
+	storage.Migrate(db, log)
+
+	userRepo := repository.NewUserRepository(db)
+	userService := service.NewUserService(userRepo, log, cfg)
+	userHandler := handlers.NewUserHandler(userService)
+
+	resumeRepo := repository.NewResumeRepository(db)
+	vacancyRepo := repository.NewVacancyRepository(db)
+	profileRepo := repository.NewMatchingProfileRepository(db)
+	matchRepo := repository.NewMatchingRepository(db)
+	// Пересчёт результатов сравнения после правок резюме и вакансий
+	rematchService := service.NewRematchService(matchRepo, resumeRepo, vacancyRepo, profileRepo, log)
+	go rematchService.Run(context.Background())
+
+	prompts, err := parser.LoadPrompts(cfg.PromptsDir)
+	if err != nil {
+		log.Fatal("Failed to load prompt templates", zap.Error(err))
+	}
+	log.Info("Prompt templates loaded",
+		zap.String("version", prompts.Version),
+		zap.String("vacancy_version", prompts.VacancyVersion),
+		zap.String("recommendations_version", prompts.RecommendationsVersion),
+		zap.String("cover_letter_version", prompts.CoverLetterVersion))
+	usageService := service.NewUsageService(repository.NewLLMUsageRepository(db), userRepo, log, cfg)
+	usageHandler := handlers.NewUsageHandler(usageService)
+	llmClient := newLLMClient(cfg, db, usageService, log)
+	var resumeParser parser.ResumeParserI = parser.YandexResumeParser{Prompts: prompts, Client: llmClient, Log: log}
+	if cfg.LLM.Fallback {
+		resumeParser = parser.FallbackResumeParser{Primary: resumeParser, Fallback: parser.HeuristicResumeParser{}, Log: log}
+	}
+	resumeService := service.NewResumeService(resumeRepo, log, cfg, resumeParser, rematchService)
+	resumeHandler := handlers.NewResumeHandler(resumeService)
+
+	vacancyParser := parser.YandexVacancyParser{Prompts: prompts, Client: llmClient}
+	vacancyService := service.NewVacancyService(vacancyRepo, log, cfg, vacancyParser, rematchService)
+	vacancyHandler := handlers.NewVacancyHandler(vacancyService)
+
+	profileService := service.NewMatchingProfileService(profileRepo, vacancyRepo, log, rematchService)
+	profileHandler := handlers.NewMatchingProfileHandler(profileService)
+
+	matchService := service.NewMatchService(resumeRepo, vacancyRepo, matchRepo, profileRepo, log, cfg, newRecommender(cfg, prompts, llmClient))
+	matchHandler := handlers.NewMatchHandler(matchService)
+	coverLetterService := service.NewCoverLetterService(repository.NewCoverLetterRepository(db), matchRepo, resumeRepo, vacancyRepo, log, cfg, newCoverLetterWriter(cfg, prompts, llmClient))
+	coverLetterHandler := handlers.NewCoverLetterHandler(coverLetterService)
+	matrixService := service.NewMatrixService(repository.NewMatchJobRepository(db), matchRepo, resumeRepo, vacancyRepo, profileRepo, log, cfg)
+	matrixService.FailInterrupted()
+	matrixHandler := handlers.NewMatrixHandler(matrixService)
+	applicationRepo := repository.NewApplicationRepository(db)
+	applicationService := service.NewApplicationService(applicationRepo, resumeRepo, vacancyRepo, log)
+	applicationHandler := handlers.NewApplicationHandler(applicationService)
+	interviewService := service.NewInterviewService(repository.NewInterviewRepository(db), applicationRepo, resumeRepo, vacancyRepo, userRepo, log, cfg)
+	interviewHandler := handlers.NewInterviewHandler(interviewService)
+	annotationService := service.NewAnnotationService(resumeRepo, repository.NewNoteRepository(db), repository.NewTagRepository(db), log)
+	annotationHandler := handlers.NewAnnotationHandler(annotationService)
+	organizationService := service.NewOrganizationService(repository.NewOrganizationRepository(db), userRepo, log)
+	organizationHandler := handlers.NewOrganizationHandler(organizationService)
+
+	handlers := &router.Handlers{
+		User:         userHandler,
+		Resume:       resumeHandler,
+		Annotation:   annotationHandler,
+		Vacancy:      vacancyHandler,
+		Profile:      profileHandler,
+		Match:        matchHandler,
+		Matrix:       matrixHandler,
+		Application:  applicationHandler,
+		Interview:    interviewHandler,
+		Organization: organizationHandler,
+		CoverLetter:  coverLetterHandler,
+		Usage:        usageHandler,
+	}
+
+	r := router.Router(db, log, cfg, handlers)
+	if err := r.Run(":8080"); err != nil {
+		log.Fatal("Failed to start server", zap.Error(err))
+	}
+}
+
+// newLLMClient собирает клиент LLM: учёт расхода поверх кеша, кеш поверх предохранителя,
+// предохранитель поверх повторов. Предохранитель видит итог всех повторов, попадание в кеш
+// не зависит от здоровья провайдера, а учёт видит и ответы из кеша.
+func newLLMClient(cfg *config.Config, db *gorm.DB, usage llm.UsageStore, log *zap.Logger) llm.Client {
+	var client llm.Client = llm.NewYandexClient(cfg)
+	client = llm.NewRetryClient(client, llm.RetryPolicy{
+		MaxAttempts:    cfg.LLM.MaxAttempts,
+		Timeout:        cfg.LLM.Timeout,
+		InitialBackoff: cfg.LLM.InitialBackoff,
+		MaxBackoff:     cfg.LLM.MaxBackoff,
+	}, log)
+	client = llm.NewCircuitBreaker(client, llm.BreakerPolicy{
+		FailureThreshold: cfg.LLM.BreakerThreshold,
+		Cooldown:         cfg.LLM.BreakerCooldown,
+	})
+
+	switch cfg.LLMCache.Backend {
+	case llm.CacheBackendMemory:
+		client = llm.NewCachedClient(client, llm.NewMemoryCache(cfg.LLMCache.Size), cfg.LLMCache.TTL, log)
+	case llm.CacheBackendPostgres:
+		dbCache := llm.NewDBCache(repository.NewLLMCacheRepository(db))
+		go purgeLLMCache(dbCache, log)
+		client = llm.NewCachedClient(client, dbCache, cfg.LLMCache.TTL, log)
+	case llm.CacheBackendOff:
+	default:
+		log.Fatal("Unknown LLM cache backend", zap.String("backend", cfg.LLMCache.Backend))
+	}
+	return llm.NewMeteredClient(client, usage, log)
+}
+
+// newRecommender выбирает, кто составляет советы по резюме: без ключей YandexGPT — шаблоны,
+// с ними — LLM, а шаблоны подменяют её, пока провайдер недоступен
+func newRecommender(cfg *config.Config, prompts *parser.PromptSet, client llm.Client) matching.RecommenderI {
+	if cfg.YandexGPTIAM == "" || cfg.YandexGPTCatalog == "" {
+		return matching.TemplateRecommender{}
+	}
+	return matching.FallbackRecommender{
+		Primary:  matching.YandexRecommender{Prompts: prompts, Client: client},
+		Fallback: matching.TemplateRecommender{},
+	}
+}
+
+// purgeLLMCache раз в час удаляет просроченные ответы LLM из базы
+func purgeLLMCache(cache *llm.DBCache, log *zap.Logger) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for range ticker.C {
+		deleted, err := cache.Purge()
+		if err != nil {
+			log.Warn("Failed to purge LLM cache", zap.Error(err))
+			continue
+		}
+		if deleted > 0 {
+			log.Info("Expired LLM cache entries purged", zap.Int64("deleted", deleted))
+		}
+	}
+}
+
+// newCoverLetterWriter выбирает, кто пишет сопроводительные письма, так же как newRecommender
+func newCoverLetterWriter(cfg *config.Config, prompts *parser.PromptSet, client llm.Client) matching.CoverLetterWriterI {
+	if cfg.YandexGPTIAM == "" || cfg.YandexGPTCatalog == "" {
+		return matching.TemplateCoverLetterWriter{}
+	}
+	return matching.FallbackCoverLetterWriter{
+		Primary:  matching.YandexCoverLetterWriter{Prompts: prompts, Client: client},
+		Fallback: matching.TemplateCoverLetterWriter{},
+	}
+}
@@ -0,0 +1,140 @@
+// Команда parser-eval прогоняет парсеры резюме по размеченному набору документов
+// и сохраняет отчёт о качестве разбора в JSON и Markdown.
+//
+// Пример офлайн-прогона на записанных ответах и сравнения с эвристиками:
+//
+//	go run ./cmd/parser-eval -dataset internal/eval/testdata/golden -parsers recorded,heuristic -out report
+//
+// Для парсера yandex нужны те же переменные окружения, что и для сервера (.env).
+package main
+
+import (
+	"CVMatch/internal/config"
+	"CVMatch/internal/eval"
+	"CVMatch/internal/llm"
+	"CVMatch/internal/logger"
+	"CVMatch/internal/parser"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"go.uber.org/zap"
+)
+
+const (
+	parserRecorded  = "recorded"
+	parserHeuristic = "heuristic"
+	parserYandex    = "yandex"
+)
+
+func main() {
+	dataset := flag.String("dataset", "", "каталог с документами и ожидаемым JSON (cv.pdf + cv.json)")
+	parsers := flag.String("parsers", parserRecorded, "парсеры через запятую: recorded, heuristic, yandex")
+	recordings := flag.String("recordings", "", "каталог записанных ответов LLM для парсера recorded (по умолчанию <dataset>/recorded)")
+	recordedVersion := flag.String("recorded-version", "", "версия промпта, которым записаны ответы")
+	promptsDir := flag.String("prompts", "", "каталог шаблонов промптов для парсера yandex (по умолчанию встроенные)")
+	compare := flag.String("compare", "", "ранее сохранённые JSON-отчёты через запятую для сравнения")
+	out := flag.String("out", "parser-eval", "префикс файлов отчёта: <out>.json и <out>.md")
+	flag.Parse()
+
+	if *dataset == "" {
+		fmt.Fprintln(os.Stderr, "не задан -dataset")
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *recordings == "" {
+		*recordings = filepath.Join(*dataset, "recorded")
+	}
+
+	_ = godotenv.Load()
+	if err := logger.Init(os.Getenv("ENV") == "development"); err != nil {
+		panic(err)
+	}
+	defer logger.Sync()
+	log := logger.L()
+
+	cases, err := eval.LoadDataset(*dataset)
+	if err != nil {
+		log.Fatal("Failed to load dataset", zap.Error(err))
+	}
+
+	var reports []*eval.Report
+	for _, path := range splitList(*compare) {
+		previous, err := eval.ReadReports(path)
+		if err != nil {
+			log.Fatal("Failed to read report", zap.String("path", path), zap.Error(err))
+		}
+		reports = append(reports, previous...)
+	}
+
+	for _, name := range splitList(*parsers) {
+		p, cfg, err := newParser(name, *recordings, *recordedVersion, *promptsDir, log)
+		if err != nil {
+			log.Fatal("Failed to create parser", zap.String("parser", name), zap.Error(err))
+		}
+		report := eval.Run(context.Background(), name, *dataset, p, cfg, cases)
+		// Версия промпта в имени различает прогоны одного парсера в сравнении
+		if report.PromptVersion != "" {
+			report.Name = name + "/" + report.PromptVersion
+		}
+		log.Info("Evaluation finished",
+			zap.String("parser", name),
+			zap.Int("documents", report.Documents),
+			zap.Int("failed", report.Failed),
+			zap.Float64("f1", report.Overall.F1))
+		reports = append(reports, report)
+	}
+
+	if err := eval.WriteJSON(*out+".json", reports); err != nil {
+		log.Fatal("Failed to write JSON report", zap.Error(err))
+	}
+	md, err := os.Create(*out + ".md")
+	if err != nil {
+		log.Fatal("Failed to create Markdown report", zap.Error(err))
+	}
+	defer md.Close()
+	if err := eval.WriteMarkdown(md, reports); err != nil {
+		log.Fatal("Failed to write Markdown report", zap.Error(err))
+	}
+	log.Info("Report saved", zap.String("json", *out+".json"), zap.String("markdown", *out+".md"))
+}
+
+// newParser создаёт парсер по имени. Конфигурация загружается только для yandex,
+// офлайн-парсерам она не нужна.
+func newParser(name, recordings, recordedVersion, promptsDir string, log *zap.Logger) (parser.ResumeParserI, *config.Config, error) {
+	switch name {
+	case parserRecorded:
+		return parser.RecordedResumeParser{Dir: recordings, PromptVersion: recordedVersion}, &config.Config{}, nil
+	case parserHeuristic:
+		return parser.HeuristicResumeParser{}, &config.Config{}, nil
+	case parserYandex:
+		cfg := config.Load(log)
+		prompts, err := parser.LoadPrompts(promptsDir)
+		if err != nil {
+			return nil, nil, err
+		}
+		client := llm.NewRetryClient(llm.NewYandexClient(cfg), llm.RetryPolicy{
+			MaxAttempts:    cfg.LLM.MaxAttempts,
+			Timeout:        cfg.LLM.Timeout,
+			InitialBackoff: cfg.LLM.InitialBackoff,
+			MaxBackoff:     cfg.LLM.MaxBackoff,
+		}, log)
+		return parser.YandexResumeParser{Prompts: prompts, Client: client, Log: log}, cfg, nil
+	default:
+		return nil, nil, fmt.Errorf("неизвестный парсер %q", name)
+	}
+}
+
+func splitList(raw string) []string {
+	var items []string
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -15,45 +15,56 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth/login": {
-            "post": {
-                "description": "Вход существующего пользователя",
-                "consumes": [
-                    "application/json"
+        "/admin/usage": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Токены и стоимость вызовов LLM по всем пользователям за период. Доступно только администраторам",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "admin"
                 ],
-                "summary": "Вход пользователя",
+                "summary": "Отчёт о расходе токенов LLM",
                 "parameters": [
                     {
-                        "description": "Параметры входа пользователя",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.UserLoginRequest"
-                        }
+                        "type": "string",
+                        "description": "Начало периода, YYYY-MM-DD (по умолчанию — начало текущего месяца)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец периода включительно, YYYY-MM-DD (по умолчанию — сегодня)",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Успешный вход пользователя",
+                        "description": "Отчёт о расходе",
                         "schema": {
-                            "$ref": "#/definitions/response.TokenResponse"
+                            "$ref": "#/definitions/response.UsageReportDTO"
                         }
                     },
                     "400": {
-                        "description": "Ошибка валидации",
+                        "description": "Некорректный период",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Пользователь уже существует",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -67,9 +78,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/refresh": {
-            "post": {
-                "description": "Обновление refresh-токена",
+        "/admin/users/{id}/quota": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Задаёт месячную квоту токенов LLM пользователя. Доступно только администраторам",
                 "consumes": [
                     "application/json"
                 ],
@@ -77,26 +93,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "admin"
                 ],
-                "summary": "Обновление токена",
+                "summary": "Изменение квоты пользователя",
                 "parameters": [
                     {
-                        "description": "Параметры обновления токена",
-                        "name": "user",
+                        "type": "string",
+                        "description": "ID пользователя",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Квота",
+                        "name": "quota",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.UserRefreshRequest"
+                            "$ref": "#/definitions/handlers.QuotaUpdateRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Успешное обновление токена",
-                        "schema": {
-                            "$ref": "#/definitions/response.TokenResponse"
-                        }
+                    "204": {
+                        "description": "Квота обновлена"
                     },
                     "400": {
                         "description": "Ошибка валидации",
@@ -105,7 +125,19 @@ const docTemplate = `{
                         }
                     },
                     "401": {
-                        "description": "Неверный токен",
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -119,35 +151,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/register": {
-            "post": {
-                "description": "Регистрация нового пользователя",
-                "consumes": [
-                    "application/json"
+        "/applications": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Отклики пользователя, последние изменённые первыми",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "applications"
                 ],
-                "summary": "Регистрация пользователя",
+                "summary": "Список кандидатов в отборе",
                 "parameters": [
                     {
-                        "description": "Параметры регистрации пользователя",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.UserRegisterRequest"
-                        }
+                        "type": "string",
+                        "description": "ID вакансии",
+                        "name": "vacancy_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID резюме",
+                        "name": "resume_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Этап отбора",
+                        "name": "stage",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Успешная регистрация пользователя",
+                    "200": {
+                        "description": "Список откликов",
                         "schema": {
-                            "$ref": "#/definitions/response.UserRegisterResponse"
+                            "$ref": "#/definitions/response.ApplicationListDTO"
                         }
                     },
                     "400": {
@@ -156,8 +199,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Пользователь уже существует",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -169,28 +212,46 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/resumes/list": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Получение списка резюме для пользователя",
+                "description": "Добавляет резюме в отбор на вакансию. Кандидат попадает на первый этап вакансии, попадание записывается в историю переходов",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "resumes"
+                    "applications"
+                ],
+                "summary": "Добавление кандидата в отбор",
+                "parameters": [
+                    {
+                        "description": "Резюме и вакансия",
+                        "name": "application",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ApplicationCreateRequest"
+                        }
+                    }
                 ],
-                "summary": "Получение списка резюме",
                 "responses": {
-                    "200": {
-                        "description": "Успешное получение списка резюме",
+                    "201": {
+                        "description": "Отклик",
                         "schema": {
-                            "$ref": "#/definitions/response.ResumeListDTO"
+                            "$ref": "#/definitions/response.ApplicationDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
                     "401": {
@@ -199,6 +260,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Resume or vacancy not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Резюме уже в отборе на вакансию",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Ошибка сервера",
                         "schema": {
@@ -208,38 +281,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/resumes/upload": {
-            "post": {
+        "/applications/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Загрузка резюме для пользователя",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                "description": "Отклик с историей переходов между этапами",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "resumes"
+                    "applications"
                 ],
-                "summary": "Загрузка резюме",
+                "summary": "Получение отклика",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "Резюме",
-                        "name": "file",
-                        "in": "formData",
+                        "type": "string",
+                        "description": "ID отклика",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Успешная загрузка резюме",
+                        "description": "Отклик",
                         "schema": {
-                            "$ref": "#/definitions/response.ParsedResumeDTO"
+                            "$ref": "#/definitions/response.ApplicationDTO"
                         }
                     },
                     "400": {
@@ -254,6 +324,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Application not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Ошибка сервера",
                         "schema": {
@@ -261,27 +337,25 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/resumes/{id}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Получение резюме по ID для пользователя",
+                "description": "Убирает кандидата из отбора на вакансию вместе с историей переходов",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "resumes"
+                    "applications"
                 ],
-                "summary": "Получение резюме по ID",
+                "summary": "Удаление отклика",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID резюме",
+                        "description": "ID отклика",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -289,9 +363,15 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Успешное получение резюме",
+                        "description": "Отклик удалён",
                         "schema": {
-                            "$ref": "#/definitions/response.ParsedResumeDTO"
+                            "$ref": "#/definitions/response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
                     "401": {
@@ -301,7 +381,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Resume not found",
+                        "description": "Application not found",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -313,35 +393,55 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/applications/{id}/move": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Удаление резюме по ID для пользователя",
+                "description": "Переводит кандидата на любой этап вакансии, в том числе назад. Переход и причина записываются в историю",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "resumes"
+                    "applications"
                 ],
-                "summary": "Удаление резюме по ID",
+                "summary": "Перевод кандидата на другой этап",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID резюме",
+                        "description": "ID отклика",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Этап и причина перехода",
+                        "name": "move",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ApplicationMoveRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Успешное удаление резюме",
+                        "description": "Отклик с историей переходов",
                         "schema": {
-                            "$ref": "#/definitions/response.SuccessResponse"
+                            "$ref": "#/definitions/response.ApplicationDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
                     "401": {
@@ -350,6 +450,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Application not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Кандидат уже на этом этапе",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Ошибка сервера",
                         "schema": {
@@ -358,40 +470,4365 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "handlers.UserLoginRequest": {
-            "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
-            "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "password": {
-                    "type": "string",
-                    "minLength": 6
-                }
-            }
-        },
-        "handlers.UserRefreshRequest": {
-            "type": "object",
-            "required": [
-                "refresh_token"
-            ],
-            "properties": {
-                "refresh_token": {
-                    "type": "string"
-                }
-            }
         },
-        "handlers.UserRegisterRequest": {
-            "type": "object",
-            "required": [
-                "email",
+        "/auth/login": {
+            "post": {
+                "description": "Вход существующего пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Вход пользователя",
+                "parameters": [
+                    {
+                        "description": "Параметры входа пользователя",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UserLoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешный вход пользователя",
+                        "schema": {
+                            "$ref": "#/definitions/response.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Пользователь уже существует",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Обновление refresh-токена",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Обновление токена",
+                "parameters": [
+                    {
+                        "description": "Параметры обновления токена",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UserRefreshRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное обновление токена",
+                        "schema": {
+                            "$ref": "#/definitions/response.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неверный токен",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/register": {
+            "post": {
+                "description": "Регистрация нового пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Регистрация пользователя",
+                "parameters": [
+                    {
+                        "description": "Параметры регистрации пользователя",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UserRegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Успешная регистрация пользователя",
+                        "schema": {
+                            "$ref": "#/definitions/response.UserRegisterResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Пользователь уже существует",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/calendar/{token}": {
+            "get": {
+                "description": "Календарь iCalendar для подписки: предстоящие интервью пользователя и интервью за последние 90 дней. Авторизация — секрет в ссылке",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "interviews"
+                ],
+                "summary": "Календарь интервью по ссылке",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Секрет ссылки (с расширением .ics)",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Календарь .ics",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Calendar not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/interviews": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Интервью, которые пользователь назначил или в которых участвует, по времени начала",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "interviews"
+                ],
+                "summary": "Список интервью",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID отклика",
+                        "name": "application_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Не раньше (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Раньше (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Статус (scheduled, cancelled)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список интервью",
+                        "schema": {
+                            "$ref": "#/definitions/response.InterviewListDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Назначает интервью кандидату на этапе интервью (interview или свой этап с interview в имени). Без title название собирается из имени кандидата и вакансии",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "interviews"
+                ],
+                "summary": "Назначение интервью",
+                "parameters": [
+                    {
+                        "description": "Отклик, время, место и участники",
+                        "name": "interview",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.InterviewCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Интервью",
+                        "schema": {
+                            "$ref": "#/definitions/response.InterviewDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Application not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Кандидат не на этапе интервью",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/interviews/calendar": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ссылка для подписки на интервью пользователя из календарного приложения. Ссылка работает без авторизации, поэтому её стоит держать в секрете",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "interviews"
+                ],
+                "summary": "Ссылка на календарь интервью",
+                "responses": {
+                    "200": {
+                        "description": "Ссылка",
+                        "schema": {
+                            "$ref": "#/definitions/response.CalendarLinkDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/interviews/calendar/reset": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Выдаёт новую ссылку на календарь; прежняя перестаёт работать",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "interviews"
+                ],
+                "summary": "Новая ссылка на календарь интервью",
+                "responses": {
+                    "200": {
+                        "description": "Ссылка",
+                        "schema": {
+                            "$ref": "#/definitions/response.CalendarLinkDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/interviews/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Интервью с участниками и отзывами; доступно организатору и участникам",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "interviews"
+                ],
+                "summary": "Получение интервью",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID интервью",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Интервью",
+                        "schema": {
+                            "$ref": "#/definitions/response.InterviewDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Interview not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Переносит интервью, меняет место и участников; доступно только организатору",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "interviews"
+                ],
+                "summary": "Изменение интервью",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID интервью",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Время, место и участники",
+                        "name": "interview",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.InterviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Интервью",
+                        "schema": {
+                            "$ref": "#/definitions/response.InterviewDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Менять интервью может только организатор",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Interview not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Интервью отменено",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/interviews/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Отменяет интервью; в календарях подписчиков событие помечается отменённым. Доступно только организатору",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "interviews"
+                ],
+                "summary": "Отмена интервью",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID интервью",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Интервью",
+                        "schema": {
+                            "$ref": "#/definitions/response.InterviewDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Отменить интервью может только организатор",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Interview not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/interviews/{id}/feedback": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Рекомендация и оценки кандидата по критериям от 1 до 5. Отзыв оставляют организатор и зарегистрированные участники; повторная отправка заменяет прежний отзыв",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "interviews"
+                ],
+                "summary": "Отзыв по интервью",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID интервью",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Отзыв",
+                        "name": "feedback",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.FeedbackRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Интервью с отзывами",
+                        "schema": {
+                            "$ref": "#/definitions/response.InterviewDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Пользователь не участвует в интервью",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Interview not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Интервью отменено",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/interviews/{id}/ics": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Выгружает интервью файлом .ics для импорта в календарь",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "interviews"
+                ],
+                "summary": "Интервью в формате iCalendar",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID интервью",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Файл .ics",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Interview not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/matches": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Считает оценку соответствия резюме вакансии и сохраняет результат с разбором по компонентам и подтверждениями из резюме",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "matches"
+                ],
+                "summary": "Сравнение резюме с вакансией",
+                "parameters": [
+                    {
+                        "description": "Резюме и вакансия",
+                        "name": "match",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MatchCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Результат сравнения",
+                        "schema": {
+                            "$ref": "#/definitions/response.MatchResultDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Resume or vacancy not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/matches/matrix": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Запускает фоновый расчёт: каждое выбранное резюме сравнивается с каждой выбранной вакансией по профилю сравнения вакансии, результаты сохраняются. Резюме и вакансии задаются списками ID и/или фильтрами. Ход расчёта — в GET /matches/matrix/{id}",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "matches"
+                ],
+                "summary": "Матрица сравнений резюме и вакансий",
+                "parameters": [
+                    {
+                        "description": "Резюме и вакансии",
+                        "name": "matrix",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MatchMatrixRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Задача создана",
+                        "schema": {
+                            "$ref": "#/definitions/response.MatchJobDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации или слишком много пар",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Resume or vacancy not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/matches/matrix/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Статус задачи (pending, running, done, failed) и число посчитанных и неудавшихся пар",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "matches"
+                ],
+                "summary": "Ход расчёта матрицы сравнений",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID задачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Состояние задачи",
+                        "schema": {
+                            "$ref": "#/definitions/response.MatchJobDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Match job not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/matches/matrix/{id}/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Отдаёт оценки завершённой задачи таблицей CSV или XLSX: строка — резюме, столбец — вакансия, пустая ячейка — пару не удалось посчитать",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "matches"
+                ],
+                "summary": "Выгрузка матрицы сравнений",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID задачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Формат: csv (по умолчанию) или xlsx",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Таблица оценок",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Match job not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Расчёт ещё не завершён",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/matches/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Получение сохранённого результата сравнения резюме с вакансией",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "matches"
+                ],
+                "summary": "Получение результата сравнения",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID результата сравнения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Результат сравнения",
+                        "schema": {
+                            "$ref": "#/definitions/response.MatchResultDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Match not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/matches/{id}/cover-letter": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Пишет сопроводительное письмо по разобранному резюме, вакансии и результату сравнения и сохраняет его в историю писем. По умолчанию язык — язык резюме, тон — formal, длина — medium. Без LLM письмо собирается по шаблону",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "matches"
+                ],
+                "summary": "Сопроводительное письмо к вакансии",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID результата сравнения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Язык, тон и длина письма",
+                        "name": "letter",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CoverLetterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Сопроводительное письмо",
+                        "schema": {
+                            "$ref": "#/definitions/response.CoverLetterDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Match not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Исчерпана квота токенов LLM",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "LLM недоступна",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/matches/{id}/cover-letters": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Все письма, написанные по результату сравнения, новые первыми",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "matches"
+                ],
+                "summary": "История сопроводительных писем",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID результата сравнения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "История писем",
+                        "schema": {
+                            "$ref": "#/definitions/response.CoverLetterListDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Match not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/matches/{id}/cover-letters/{letter_id}/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Отдаёт письмо файлом: простым текстом (txt) или документом Word (docx)",
+                "produces": [
+                    "text/plain",
+                    "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+                ],
+                "tags": [
+                    "matches"
+                ],
+                "summary": "Выгрузка сопроводительного письма",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID результата сравнения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID письма",
+                        "name": "letter_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Формат: txt (по умолчанию) или docx",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Файл письма",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Cover letter not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/matches/{id}/recommendations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Сохранённые советы по результату сравнения: недостающие ключевые слова, переписанные пункты опыта и разделы, которые стоит добавить. Советы составляются через POST /matches/{id}/recommendations",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "matches"
+                ],
+                "summary": "Советы по доработке резюме под вакансию",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID результата сравнения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Советы по резюме",
+                        "schema": {
+                            "$ref": "#/definitions/response.RecommendationsDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Match or recommendations not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Составляет советы через LLM (без LLM — по шаблонам) и сохраняет их в результате сравнения, заменяя прежние",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "matches"
+                ],
+                "summary": "Составление советов по доработке резюме под вакансию",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID результата сравнения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Советы по резюме",
+                        "schema": {
+                            "$ref": "#/definitions/response.RecommendationsDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Match not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Исчерпана квота токенов LLM",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "LLM недоступна",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/matching-profiles": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Профили сравнения пользователя в порядке создания",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "matching-profiles"
+                ],
+                "summary": "Список профилей сравнения",
+                "responses": {
+                    "200": {
+                        "description": "Профили сравнения",
+                        "schema": {
+                            "$ref": "#/definitions/response.MatchingProfileListDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Создаёт профиль сравнения: веса компонентов оценки, правила отсечения и способ учёта компонентов без данных (renormalize — перераспределить вес, neutral — считать 50 баллов, strict — считать 0). Профиль с is_default применяется ко всем вакансиям без своего профиля",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "matching-profiles"
+                ],
+                "summary": "Создание профиля сравнения",
+                "parameters": [
+                    {
+                        "description": "Настройки профиля",
+                        "name": "profile",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MatchingProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Профиль сравнения",
+                        "schema": {
+                            "$ref": "#/definitions/response.MatchingProfileDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/matching-profiles/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "matching-profiles"
+                ],
+                "summary": "Профиль сравнения по ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID профиля",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Профиль сравнения",
+                        "schema": {
+                            "$ref": "#/definitions/response.MatchingProfileDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Matching profile not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Заменяет настройки профиля целиком. Уже посчитанные результаты сравнения хранят профиль на момент расчёта и не меняются",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "matching-profiles"
+                ],
+                "summary": "Изменение профиля сравнения",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID профиля",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Настройки профиля",
+                        "name": "profile",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MatchingProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Профиль сравнения",
+                        "schema": {
+                            "$ref": "#/definitions/response.MatchingProfileDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Matching profile not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаляет профиль; вакансии с этим профилем сравниваются по профилю по умолчанию",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "matching-profiles"
+                ],
+                "summary": "Удаление профиля сравнения",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID профиля",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Профиль удалён",
+                        "schema": {
+                            "$ref": "#/definitions/response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Matching profile not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Организации пользователя с его ролью; текущее рабочее пространство отмечено active",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Список организаций",
+                "responses": {
+                    "200": {
+                        "description": "Список организаций",
+                        "schema": {
+                            "$ref": "#/definitions/response.OrganizationListDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Создаёт рабочее пространство команды, пользователь становится его владельцем. Чтобы работать в нём, переключитесь через /organizations/{id}/switch",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Создание организации",
+                "parameters": [
+                    {
+                        "description": "Название",
+                        "name": "organization",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.OrganizationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Организация",
+                        "schema": {
+                            "$ref": "#/definitions/response.OrganizationDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Организация с участниками и их ролями; доступна любому участнику",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Получение организации",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID организации",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Организация",
+                        "schema": {
+                            "$ref": "#/definitions/response.OrganizationDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Organization not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{id}/members": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Добавляет зарегистрированного пользователя в организацию с ролью owner, recruiter, hiring_manager или viewer; доступно только владельцам",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Добавление участника",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID организации",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Email и роль",
+                        "name": "member",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Участник",
+                        "schema": {
+                            "$ref": "#/definitions/response.OrganizationMemberDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Только владелец управляет участниками",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Organization or user not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Пользователь уже участник",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{id}/members/{user_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Меняет роль участника; доступно только владельцам. Последнего владельца понизить нельзя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Смена роли участника",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID организации",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID пользователя",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Роль",
+                        "name": "role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MemberRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Роль изменена",
+                        "schema": {
+                            "$ref": "#/definitions/response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Только владелец управляет участниками",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Organization or member not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "В организации должен остаться владелец",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Владелец исключает любого участника, остальные могут только выйти сами. Исключённый теряет доступ к данным организации сразу",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Исключение участника",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID организации",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID пользователя",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Участник исключён",
+                        "schema": {
+                            "$ref": "#/definitions/response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Только владелец управляет участниками",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Organization or member not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "В организации должен остаться владелец",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{id}/switch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Делает организацию текущим рабочим пространством: резюме, вакансии, отбор и метки показываются и создаются в нём",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Переключение рабочего пространства",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID организации",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Текущее рабочее пространство",
+                        "schema": {
+                            "$ref": "#/definitions/response.OrganizationDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Organization not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/resumes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Получение списка резюме для пользователя",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resumes"
+                ],
+                "summary": "Получение списка резюме",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "Минимальный стаж в годах",
+                        "name": "min_years",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Максимальный стаж в годах",
+                        "name": "max_years",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Семейство должности (backend, frontend, qa, data, pm...)",
+                        "name": "title_family",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уровень (intern, junior, middle, senior, lead)",
+                        "name": "seniority",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Только резюме, требующие ручной проверки (или не требующие)",
+                        "name": "needs_review",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Язык документа (ru, en, kk, uz)",
+                        "name": "language",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Поиск по тексту резюме",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Метки через запятую, резюме должно иметь все",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Минимальная оценка (1-5)",
+                        "name": "min_rating",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное получение списка резюме",
+                        "schema": {
+                            "$ref": "#/definitions/response.ResumeListDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/resumes/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Получение списка резюме для пользователя",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resumes"
+                ],
+                "summary": "Получение списка резюме",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "Минимальный стаж в годах",
+                        "name": "min_years",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Максимальный стаж в годах",
+                        "name": "max_years",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Семейство должности (backend, frontend, qa, data, pm...)",
+                        "name": "title_family",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уровень (intern, junior, middle, senior, lead)",
+                        "name": "seniority",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Только резюме, требующие ручной проверки (или не требующие)",
+                        "name": "needs_review",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Язык документа (ru, en, kk, uz)",
+                        "name": "language",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Поиск по тексту резюме",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Метки через запятую, резюме должно иметь все",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Минимальная оценка (1-5)",
+                        "name": "min_rating",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное получение списка резюме",
+                        "schema": {
+                            "$ref": "#/definitions/response.ResumeListDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/resumes/tags": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Все метки пользователя по алфавиту с числом резюме",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resumes"
+                ],
+                "summary": "Метки пользователя",
+                "responses": {
+                    "200": {
+                        "description": "Метки",
+                        "schema": {
+                            "$ref": "#/definitions/response.TagListDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/resumes/tags/bulk": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Добавляет и снимает метки у нескольких резюме разом. Если хотя бы одно резюме не найдено, ничего не меняется",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resumes"
+                ],
+                "summary": "Массовая расстановка меток",
+                "parameters": [
+                    {
+                        "description": "Резюме и метки",
+                        "name": "bulk",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BulkTagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Итог",
+                        "schema": {
+                            "$ref": "#/definitions/response.BulkTagResultDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Resume not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/resumes/upload": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Загрузка резюме для пользователя",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resumes"
+                ],
+                "summary": "Загрузка резюме",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Резюме",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Разобрать резюме заново, не используя кеш ответов LLM",
+                        "name": "no_cache",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешная загрузка резюме",
+                        "schema": {
+                            "$ref": "#/definitions/response.ParsedResumeDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/resumes/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Получение резюме по ID для пользователя",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resumes"
+                ],
+                "summary": "Получение резюме по ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID резюме",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное получение резюме",
+                        "schema": {
+                            "$ref": "#/definitions/response.ParsedResumeDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Resume not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Заменяет разобранные данные резюме исправленными: контакты, навыки, опыт, образование и остальные разделы. Стаж, семейство должности и уровень пересчитываются. Версия резюме растёт, результаты сравнения с ним пересчитываются в фоне",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resumes"
+                ],
+                "summary": "Исправление резюме",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID резюме",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные резюме",
+                        "name": "resume",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ResumeUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Исправленное резюме",
+                        "schema": {
+                            "$ref": "#/definitions/response.ParsedResumeDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Resume not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаление резюме по ID для пользователя",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resumes"
+                ],
+                "summary": "Удаление резюме по ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID резюме",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное удаление резюме",
+                        "schema": {
+                            "$ref": "#/definitions/response.SuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/resumes/{id}/ats-report": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Оценка от 0 до 100 и замечания с советами: скан без текста, нет контактов или дат, слишком длинное резюме, колонки и таблицы, мешающие извлечению текста, плотность ключевых навыков. Проверяются только извлечённый текст и структура PDF",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resumes"
+                ],
+                "summary": "Проверка резюме на удобство для ATS",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID резюме",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Отчёт о проверке",
+                        "schema": {
+                            "$ref": "#/definitions/response.ATSReportDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Resume not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/resumes/{id}/notes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Заметки рекрутеров к резюме от старых к новым",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resumes"
+                ],
+                "summary": "Заметки к резюме",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID резюме",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Заметки",
+                        "schema": {
+                            "$ref": "#/definitions/response.NoteListDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Resume not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Добавляет заметку от имени текущего пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resumes"
+                ],
+                "summary": "Добавление заметки к резюме",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID резюме",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Текст заметки",
+                        "name": "note",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.NoteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Заметка",
+                        "schema": {
+                            "$ref": "#/definitions/response.NoteDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Resume not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/resumes/{id}/notes/{note_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Меняет текст заметки; доступно только автору",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resumes"
+                ],
+                "summary": "Изменение заметки",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID резюме",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID заметки",
+                        "name": "note_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Текст заметки",
+                        "name": "note",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.NoteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Заметка",
+                        "schema": {
+                            "$ref": "#/definitions/response.NoteDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Заметку может менять только автор",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Resume or note not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаляет заметку; доступно только автору",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resumes"
+                ],
+                "summary": "Удаление заметки",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID резюме",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID заметки",
+                        "name": "note_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Заметка удалена",
+                        "schema": {
+                            "$ref": "#/definitions/response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Заметку может удалить только автор",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Resume or note not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/resumes/{id}/rating": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Выставляет оценку резюме от 1 до 5; rating: null снимает оценку",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resumes"
+                ],
+                "summary": "Оценка резюме",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID резюме",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Оценка",
+                        "name": "rating",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RatingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Метки и оценка резюме",
+                        "schema": {
+                            "$ref": "#/definitions/response.ResumeTagsDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Resume not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/resumes/{id}/tags": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Заменяет метки резюме переданным списком. Метки приводятся к нижнему регистру, новые создаются",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resumes"
+                ],
+                "summary": "Метки резюме",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID резюме",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Метки",
+                        "name": "tags",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ResumeTagsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Метки и оценка резюме",
+                        "schema": {
+                            "$ref": "#/definitions/response.ResumeTagsDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Resume not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/resumes/{id}/text": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Текст, извлечённый из файла резюме при загрузке, с числом страниц и языком",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resumes"
+                ],
+                "summary": "Получение текста резюме",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID резюме",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Текст резюме",
+                        "schema": {
+                            "$ref": "#/definitions/response.ResumeTextDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Resume not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/usage": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Токены и стоимость вызовов LLM текущего пользователя за период с разбивкой по моделям, а также остаток месячной квоты",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "usage"
+                ],
+                "summary": "Расход токенов LLM",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Начало периода, YYYY-MM-DD (по умолчанию — начало текущего месяца)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец периода включительно, YYYY-MM-DD (по умолчанию — сегодня)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Расход токенов",
+                        "schema": {
+                            "$ref": "#/definitions/response.UsageDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный период",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vacancies": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Создание вакансии. Семейство должности и уровень определяются по названию, если не переданы. Навыки делятся на обязательные (required_skills) и желательные (preferred_skills); в skill_requirements можно задать вес и минимальный стаж",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vacancies"
+                ],
+                "summary": "Создание вакансии",
+                "parameters": [
+                    {
+                        "description": "Параметры вакансии",
+                        "name": "vacancy",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.VacancyCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное создание вакансии",
+                        "schema": {
+                            "$ref": "#/definitions/response.VacancyDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vacancies/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Получение списка вакансий пользователя",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vacancies"
+                ],
+                "summary": "Получение списка вакансий",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Семейство должности (backend, frontend, qa, data, pm...)",
+                        "name": "title_family",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уровень (intern, junior, middle, senior, lead)",
+                        "name": "seniority",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное получение списка вакансий",
+                        "schema": {
+                            "$ref": "#/definitions/response.VacancyListDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vacancies/parse": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Извлекает черновик вакансии из текста объявления (JSON с полем text или поле формы text) или из файла PDF/TXT (поле формы file). Черновик не сохраняется, его можно отправить в POST /vacancies",
+                "consumes": [
+                    "application/json",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vacancies"
+                ],
+                "summary": "Разбор текста вакансии",
+                "parameters": [
+                    {
+                        "description": "Текст объявления",
+                        "name": "vacancy",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.VacancyParseRequest"
+                        }
+                    },
+                    {
+                        "type": "file",
+                        "description": "Файл объявления (PDF или TXT)",
+                        "name": "file",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Текст объявления",
+                        "name": "text",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Черновик вакансии",
+                        "schema": {
+                            "$ref": "#/definitions/response.VacancyDraftDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Исчерпана квота токенов LLM",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "LLM недоступна",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vacancies/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Получение вакансии по ID для пользователя",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vacancies"
+                ],
+                "summary": "Получение вакансии по ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID вакансии",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное получение вакансии",
+                        "schema": {
+                            "$ref": "#/definitions/response.VacancyDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Vacancy not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Заменяет поля и навыки вакансии. Версия вакансии растёт, результаты сравнения с ней пересчитываются в фоне",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vacancies"
+                ],
+                "summary": "Изменение вакансии",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID вакансии",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Параметры вакансии",
+                        "name": "vacancy",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.VacancyCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Изменённая вакансия",
+                        "schema": {
+                            "$ref": "#/definitions/response.VacancyDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Vacancy not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаление вакансии по ID вместе с результатами сравнения",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vacancies"
+                ],
+                "summary": "Удаление вакансии по ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID вакансии",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное удаление вакансии",
+                        "schema": {
+                            "$ref": "#/definitions/response.SuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Vacancy not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vacancies/{id}/matching-profile": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Привязывает профиль сравнения к вакансии. profile_id = null отвязывает профиль, и вакансия сравнивается по профилю пользователя по умолчанию",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vacancies"
+                ],
+                "summary": "Профиль сравнения вакансии",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID вакансии",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ID профиля",
+                        "name": "profile",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AttachMatchingProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Профиль привязан",
+                        "schema": {
+                            "$ref": "#/definitions/response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Vacancy or matching profile not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vacancies/{id}/stages": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Этапы отбора по порядку с числом кандидатов на каждом",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "applications"
+                ],
+                "summary": "Этапы отбора вакансии",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID вакансии",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Этапы отбора",
+                        "schema": {
+                            "$ref": "#/definitions/response.PipelineDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Vacancy not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Задаёт этапы отбора по порядку (латиница, цифры и подчёркивания, до 32 символов). Новые кандидаты попадают на первый этап. Пустой список возвращает этапы по умолчанию: new, screening, interview, offer, hired, rejected. Этап, на котором есть кандидаты, убрать нельзя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "applications"
+                ],
+                "summary": "Настройка этапов отбора вакансии",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID вакансии",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Этапы отбора",
+                        "name": "stages",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PipelineStagesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Этапы отбора",
+                        "schema": {
+                            "$ref": "#/definitions/response.PipelineDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Vacancy not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "На убираемом этапе есть кандидаты",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handlers.ApplicationCreateRequest": {
+            "type": "object",
+            "required": [
+                "resume_id",
+                "vacancy_id"
+            ],
+            "properties": {
+                "resume_id": {
+                    "type": "string"
+                },
+                "vacancy_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.ApplicationMoveRequest": {
+            "type": "object",
+            "required": [
+                "stage"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "stage": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.AttachMatchingProfileRequest": {
+            "type": "object",
+            "properties": {
+                "profile_id": {
+                    "description": "null — профиль пользователя по умолчанию",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.BulkTagRequest": {
+            "type": "object",
+            "required": [
+                "resume_ids"
+            ],
+            "properties": {
+                "add": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "remove": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "resume_ids": {
+                    "type": "array",
+                    "maxItems": 500,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "handlers.CoverLetterRequest": {
+            "type": "object",
+            "properties": {
+                "language": {
+                    "type": "string",
+                    "enum": [
+                        "ru",
+                        "en",
+                        "kk",
+                        "uz"
+                    ]
+                },
+                "length": {
+                    "type": "string",
+                    "enum": [
+                        "short",
+                        "medium",
+                        "long"
+                    ]
+                },
+                "tone": {
+                    "type": "string",
+                    "enum": [
+                        "formal",
+                        "friendly",
+                        "enthusiastic"
+                    ]
+                }
+            }
+        },
+        "handlers.FeedbackRequest": {
+            "type": "object",
+            "required": [
+                "recommendation",
+                "scores"
+            ],
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "maxLength": 5000
+                },
+                "recommendation": {
+                    "type": "string",
+                    "enum": [
+                        "strong_yes",
+                        "yes",
+                        "no",
+                        "strong_no"
+                    ]
+                },
+                "scores": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/handlers.ScorecardRequest"
+                    }
+                }
+            }
+        },
+        "handlers.InterviewCreateRequest": {
+            "type": "object",
+            "required": [
+                "application_id",
+                "ends_at",
+                "starts_at"
+            ],
+            "properties": {
+                "application_id": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 5000
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "location": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "participants": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "$ref": "#/definitions/response.InterviewParticipantDTO"
+                    }
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "video_url": {
+                    "type": "string",
+                    "maxLength": 512
+                }
+            }
+        },
+        "handlers.InterviewRequest": {
+            "type": "object",
+            "required": [
+                "ends_at",
+                "starts_at"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 5000
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "location": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "participants": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "$ref": "#/definitions/response.InterviewParticipantDTO"
+                    }
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "video_url": {
+                    "type": "string",
+                    "maxLength": 512
+                }
+            }
+        },
+        "handlers.KnockoutRulesRequest": {
+            "type": "object",
+            "properties": {
+                "below_min_experience": {
+                    "type": "boolean"
+                },
+                "cap": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "missing_required": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "handlers.MatchCreateRequest": {
+            "type": "object",
+            "required": [
+                "resume_id",
+                "vacancy_id"
+            ],
+            "properties": {
+                "resume_id": {
+                    "type": "string"
+                },
+                "vacancy_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.MatchMatrixRequest": {
+            "type": "object",
+            "properties": {
+                "resume_filter": {
+                    "$ref": "#/definitions/handlers.MatrixResumeFilter"
+                },
+                "resume_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "vacancy_filter": {
+                    "$ref": "#/definitions/handlers.MatrixVacancyFilter"
+                },
+                "vacancy_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "handlers.MatchingProfileRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "is_default": {
+                    "type": "boolean"
+                },
+                "knockout": {
+                    "$ref": "#/definitions/handlers.KnockoutRulesRequest"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "normalization": {
+                    "type": "string",
+                    "enum": [
+                        "renormalize",
+                        "neutral",
+                        "strict"
+                    ]
+                },
+                "weights": {
+                    "$ref": "#/definitions/response.ComponentWeightsDTO"
+                }
+            }
+        },
+        "handlers.MatrixResumeFilter": {
+            "type": "object",
+            "properties": {
+                "language": {
+                    "type": "string"
+                },
+                "max_years": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "min_rating": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
+                },
+                "min_years": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "needs_review": {
+                    "type": "boolean"
+                },
+                "q": {
+                    "type": "string"
+                },
+                "seniority": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title_family": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.MatrixVacancyFilter": {
+            "type": "object",
+            "properties": {
+                "seniority": {
+                    "type": "string"
+                },
+                "title_family": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.MemberRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "role"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "role": {
+                    "description": "owner, recruiter, hiring_manager, viewer",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.MemberRoleRequest": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.NoteRequest": {
+            "type": "object",
+            "required": [
+                "text"
+            ],
+            "properties": {
+                "text": {
+                    "type": "string",
+                    "maxLength": 5000
+                }
+            }
+        },
+        "handlers.OrganizationRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "handlers.PipelineStagesRequest": {
+            "type": "object",
+            "properties": {
+                "stages": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "handlers.QuotaUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "monthly_token_quota": {
+                    "description": "Месячная квота в токенах: 0 — без ограничений, null — квота по умолчанию",
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "handlers.RatingRequest": {
+            "type": "object",
+            "properties": {
+                "rating": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
+                }
+            }
+        },
+        "handlers.ResumeTagsRequest": {
+            "type": "object",
+            "properties": {
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "handlers.ResumeUpdateRequest": {
+            "type": "object",
+            "required": [
+                "full_name"
+            ],
+            "properties": {
+                "certifications": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.CertificationDTO"
+                    }
+                },
+                "education": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.EducationDTO"
+                    }
+                },
+                "email": {
+                    "type": "string"
+                },
+                "experience": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ExperienceDTO"
+                    }
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "languages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.LanguageDTO"
+                    }
+                },
+                "links": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.LinkDTO"
+                    }
+                },
+                "location": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "projects": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ProjectDTO"
+                    }
+                },
+                "relocation": {
+                    "type": "boolean"
+                },
+                "remote": {
+                    "type": "string"
+                },
+                "salary": {
+                    "$ref": "#/definitions/response.SalaryDTO"
+                },
+                "skills": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "handlers.ScorecardRequest": {
+            "type": "object",
+            "required": [
+                "criterion",
+                "score"
+            ],
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "criterion": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "score": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
+                }
+            }
+        },
+        "handlers.UserLoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 6
+                }
+            }
+        },
+        "handlers.UserRefreshRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.UserRegisterRequest": {
+            "type": "object",
+            "required": [
+                "email",
                 "name",
                 "password"
             ],
@@ -399,164 +4836,1585 @@ const docTemplate = `{
                 "email": {
                     "type": "string"
                 },
-                "name": {
+                "name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 6
+                }
+            }
+        },
+        "handlers.VacancyCreateRequest": {
+            "type": "object",
+            "required": [
+                "title"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "employment_type": {
+                    "type": "string"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "max_experience_years": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "min_experience_years": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "preferred_skills": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "remote": {
+                    "type": "string"
+                },
+                "required_skills": {
+                    "description": "Обязательные и желательные навыки; skill_requirements задаёт также вес и минимальный стаж.\nНавыки из skills, не упомянутые в остальных списках, считаются желательными.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "salary": {
+                    "$ref": "#/definitions/response.SalaryRangeDTO"
+                },
+                "seniority": {
+                    "type": "string"
+                },
+                "skill_requirements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.SkillRequirementDTO"
+                    }
+                },
+                "skills": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "title_family": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.VacancyParseRequest": {
+            "type": "object",
+            "required": [
+                "text"
+            ],
+            "properties": {
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.ATSFindingDTO": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "severity": {
+                    "description": "critical, warning, info",
+                    "type": "string"
+                },
+                "suggestion": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.ATSReportDTO": {
+            "type": "object",
+            "properties": {
+                "findings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ATSFindingDTO"
+                    }
+                },
+                "keywords": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.KeywordStatDTO"
+                    }
+                },
+                "layout_checked": {
+                    "type": "boolean"
+                },
+                "pages": {
+                    "type": "integer"
+                },
+                "resume_id": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "integer"
+                },
+                "words": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.ApplicationDTO": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ApplicationTransitionDTO"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "resume_id": {
+                    "type": "string"
+                },
+                "stage": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "vacancy_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.ApplicationListDTO": {
+            "type": "object",
+            "properties": {
+                "applications": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ApplicationDTO"
+                    }
+                }
+            }
+        },
+        "response.ApplicationTransitionDTO": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "from_stage": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "to_stage": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.BulkTagResultDTO": {
+            "type": "object",
+            "properties": {
+                "added": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "removed": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "resumes": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.BulletRewriteDTO": {
+            "type": "object",
+            "properties": {
+                "original": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "suggested": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.CalendarLinkDTO": {
+            "type": "object",
+            "properties": {
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.CertificationDTO": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "issuer": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.ComponentWeightsDTO": {
+            "type": "object",
+            "properties": {
+                "education": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "experience": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "location": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "semantic": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "seniority": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "skills": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0
+                }
+            }
+        },
+        "response.ConfidenceMap": {
+            "type": "object",
+            "additionalProperties": {
+                "type": "number"
+            }
+        },
+        "response.CoverLetterDTO": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "language": {
+                    "type": "string"
+                },
+                "length": {
+                    "type": "string"
+                },
+                "match_id": {
+                    "type": "string"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "prompt_version": {
+                    "type": "string"
+                },
+                "resume_id": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "tone": {
+                    "type": "string"
+                },
+                "vacancy_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.CoverLetterListDTO": {
+            "type": "object",
+            "properties": {
+                "letters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.CoverLetterDTO"
+                    }
+                }
+            }
+        },
+        "response.EducationDTO": {
+            "type": "object",
+            "properties": {
+                "degree": {
+                    "type": "string"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string"
+                },
+                "institution": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.EvidenceDTO": {
+            "type": "object",
+            "properties": {
+                "requirement": {
+                    "type": "string"
+                },
+                "snippet": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.ExperienceDTO": {
+            "type": "object",
+            "properties": {
+                "company": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "is_current": {
+                    "type": "boolean"
+                },
+                "months": {
+                    "type": "integer"
+                },
+                "position": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.InterviewDTO": {
+            "type": "object",
+            "properties": {
+                "application_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "feedback": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.InterviewFeedbackDTO"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "organizer_id": {
+                    "type": "string"
+                },
+                "participants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.InterviewParticipantDTO"
+                    }
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "scheduled, cancelled",
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "video_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.InterviewFeedbackDTO": {
+            "type": "object",
+            "properties": {
+                "author_id": {
+                    "type": "string"
+                },
+                "average_score": {
+                    "type": "number"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "recommendation": {
+                    "description": "strong_yes, yes, no, strong_no",
+                    "type": "string"
+                },
+                "scores": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ScorecardDTO"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.InterviewListDTO": {
+            "type": "object",
+            "properties": {
+                "interviews": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.InterviewDTO"
+                    }
+                }
+            }
+        },
+        "response.InterviewParticipantDTO": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.KeywordStatDTO": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "density": {
+                    "description": "доля среди слов резюме, %",
+                    "type": "number"
+                },
+                "keyword": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.KnockoutRulesDTO": {
+            "type": "object",
+            "properties": {
+                "below_min_experience": {
+                    "type": "boolean"
+                },
+                "cap": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "missing_required": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "response.LanguageDTO": {
+            "type": "object",
+            "properties": {
+                "level": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.LinkDTO": {
+            "type": "object",
+            "properties": {
+                "type": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.MatchJobDTO": {
+            "type": "object",
+            "properties": {
+                "completed": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "progress": {
+                    "type": "number"
+                },
+                "resumes": {
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "vacancies": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.MatchResultDTO": {
+            "type": "object",
+            "properties": {
+                "components": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ScoreComponentDTO"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "knockout": {
+                    "description": "сработало правило отсечения профиля, оценка ограничена",
+                    "type": "boolean"
+                },
+                "matched_skills": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "missing_required": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "no_data": {
+                    "description": "ни один компонент не удалось оценить, оценка 0",
+                    "type": "boolean"
+                },
+                "profile": {
+                    "description": "профиль сравнения на момент расчёта",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.MatchingProfileDTO"
+                        }
+                    ]
+                },
+                "resume_id": {
+                    "type": "string"
+                },
+                "resume_version": {
+                    "description": "версия резюме, по которой посчитан результат",
+                    "type": "integer"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "skill_breakdown": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.SkillMatchDTO"
+                    }
+                },
+                "stale": {
+                    "description": "резюме или вакансия изменились, результат пересчитывается",
+                    "type": "boolean"
+                },
+                "unmatched_skills": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "vacancy_id": {
+                    "type": "string"
+                },
+                "vacancy_version": {
+                    "description": "версия вакансии, по которой посчитан результат",
+                    "type": "integer"
+                }
+            }
+        },
+        "response.MatchingProfileDTO": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_default": {
+                    "type": "boolean"
+                },
+                "knockout": {
+                    "$ref": "#/definitions/response.KnockoutRulesDTO"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "normalization": {
+                    "description": "renormalize, neutral или strict",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "weights": {
+                    "$ref": "#/definitions/response.ComponentWeightsDTO"
+                }
+            }
+        },
+        "response.MatchingProfileListDTO": {
+            "type": "object",
+            "properties": {
+                "profiles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.MatchingProfileDTO"
+                    }
+                }
+            }
+        },
+        "response.NoteDTO": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "description": "никнейм автора, а если его нет — email",
+                    "type": "string"
+                },
+                "author_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "resume_id": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.NoteListDTO": {
+            "type": "object",
+            "properties": {
+                "notes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.NoteDTO"
+                    }
+                }
+            }
+        },
+        "response.OrganizationDTO": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "текущее рабочее пространство пользователя",
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.OrganizationMemberDTO"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "personal": {
+                    "type": "boolean"
+                },
+                "role": {
+                    "description": "owner, recruiter, hiring_manager, viewer",
+                    "type": "string"
+                }
+            }
+        },
+        "response.OrganizationListDTO": {
+            "type": "object",
+            "properties": {
+                "organizations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.OrganizationDTO"
+                    }
+                }
+            }
+        },
+        "response.OrganizationMemberDTO": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "joined_at": {
+                    "type": "string"
+                },
+                "nickname": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.ParsedResumeDTO": {
+            "type": "object",
+            "properties": {
+                "certifications": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.CertificationDTO"
+                    }
+                },
+                "confidence": {
+                    "$ref": "#/definitions/response.ConfidenceMap"
+                },
+                "content_language": {
+                    "type": "string"
+                },
+                "education": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.EducationDTO"
+                    }
+                },
+                "email": {
+                    "type": "string"
+                },
+                "experience": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ExperienceDTO"
+                    }
+                },
+                "experience_months": {
+                    "type": "integer"
+                },
+                "file_url": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "language": {
+                    "type": "string"
+                },
+                "languages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.LanguageDTO"
+                    }
+                },
+                "links": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.LinkDTO"
+                    }
+                },
+                "location": {
+                    "type": "string"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "needs_review": {
+                    "type": "boolean"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "projects": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ProjectDTO"
+                    }
+                },
+                "prompt_version": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "relocation": {
+                    "type": "boolean"
+                },
+                "remote": {
+                    "type": "string"
+                },
+                "salary": {
+                    "$ref": "#/definitions/response.SalaryDTO"
+                },
+                "seniority": {
+                    "type": "string"
+                },
+                "skills": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title_family": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                },
+                "years_of_experience": {
+                    "type": "number"
+                }
+            }
+        },
+        "response.PipelineDTO": {
+            "type": "object",
+            "properties": {
+                "stages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.StageCountDTO"
+                    }
+                },
+                "vacancy_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.ProjectDTO": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "technologies": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.RecommendationsDTO": {
+            "type": "object",
+            "properties": {
+                "bullet_rewrites": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.BulletRewriteDTO"
+                    }
+                },
+                "generated_at": {
+                    "type": "string"
+                },
+                "language": {
+                    "type": "string"
+                },
+                "match_id": {
+                    "type": "string"
+                },
+                "missing_keywords": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "model": {
+                    "type": "string"
+                },
+                "prompt_version": {
+                    "type": "string"
+                },
+                "sections_to_add": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.SectionSuggestionDTO"
+                    }
+                },
+                "source": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.ResumeListDTO": {
+            "type": "object",
+            "properties": {
+                "resumes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ResumeListItemDTO"
+                    }
+                }
+            }
+        },
+        "response.ResumeListItemDTO": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "file_url": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "language": {
+                    "type": "string"
+                },
+                "needs_review": {
+                    "type": "boolean"
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "seniority": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title_family": {
+                    "type": "string"
+                },
+                "years_of_experience": {
+                    "type": "number"
+                }
+            }
+        },
+        "response.ResumeTagsDTO": {
+            "type": "object",
+            "properties": {
+                "rating": {
+                    "type": "integer"
+                },
+                "resume_id": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "response.ResumeTextDTO": {
+            "type": "object",
+            "properties": {
+                "extraction_method": {
+                    "type": "string"
+                },
+                "language": {
+                    "type": "string"
+                },
+                "page_count": {
+                    "type": "integer"
+                },
+                "resume_id": {
                     "type": "string"
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 6
+                "text": {
+                    "type": "string"
                 }
             }
         },
-        "response.EducationDTO": {
+        "response.SalaryDTO": {
             "type": "object",
             "properties": {
-                "degree": {
+                "amount": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.SalaryRangeDTO": {
+            "type": "object",
+            "properties": {
+                "currency": {
                     "type": "string"
                 },
-                "end_date": {
+                "from": {
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.ScoreComponentDTO": {
+            "type": "object",
+            "properties": {
+                "applicable": {
+                    "type": "boolean"
+                },
+                "contribution": {
+                    "type": "number"
+                },
+                "details": {
                     "type": "string"
                 },
-                "field": {
+                "evidence": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.EvidenceDTO"
+                    }
+                },
+                "name": {
                     "type": "string"
                 },
-                "institution": {
+                "score": {
+                    "type": "number"
+                },
+                "weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "response.ScorecardDTO": {
+            "type": "object",
+            "properties": {
+                "comment": {
                     "type": "string"
                 },
-                "start_date": {
+                "criterion": {
                     "type": "string"
+                },
+                "score": {
+                    "type": "integer"
                 }
             }
         },
-        "response.ErrorResponse": {
+        "response.SectionSuggestionDTO": {
             "type": "object",
             "properties": {
-                "error": {
+                "reason": {
+                    "type": "string"
+                },
+                "section": {
                     "type": "string"
                 }
             }
         },
-        "response.ExperienceDTO": {
+        "response.SkillMatchDTO": {
             "type": "object",
             "properties": {
-                "company": {
-                    "type": "string"
+                "credit": {
+                    "type": "number"
                 },
-                "description": {
+                "matched": {
+                    "type": "boolean"
+                },
+                "min_years": {
+                    "type": "number"
+                },
+                "name": {
                     "type": "string"
                 },
-                "end_date": {
+                "requirement": {
                     "type": "string"
                 },
-                "position": {
+                "weight": {
+                    "type": "number"
+                },
+                "years": {
+                    "type": "number"
+                }
+            }
+        },
+        "response.SkillRequirementDTO": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "min_years": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "name": {
                     "type": "string"
                 },
-                "start_date": {
+                "requirement": {
+                    "type": "string",
+                    "enum": [
+                        "required",
+                        "preferred"
+                    ]
+                },
+                "weight": {
+                    "type": "number",
+                    "maximum": 10
+                }
+            }
+        },
+        "response.StageCountDTO": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "name": {
                     "type": "string"
                 }
             }
         },
-        "response.ParsedResumeDTO": {
+        "response.SuccessResponse": {
             "type": "object",
             "properties": {
-                "education": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.TagDTO": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "resumes": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.TagListDTO": {
+            "type": "object",
+            "properties": {
+                "tags": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/response.EducationDTO"
+                        "$ref": "#/definitions/response.TagDTO"
                     }
+                }
+            }
+        },
+        "response.TokenResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
                 },
-                "email": {
+                "refresh_token": {
                     "type": "string"
+                }
+            }
+        },
+        "response.UsageByModelDTO": {
+            "type": "object",
+            "properties": {
+                "cached_requests": {
+                    "type": "integer"
                 },
-                "experience": {
+                "completion_tokens": {
+                    "type": "integer"
+                },
+                "cost": {
+                    "type": "number"
+                },
+                "input_tokens": {
+                    "type": "integer"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "requests": {
+                    "type": "integer"
+                },
+                "total_tokens": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.UsageDTO": {
+            "type": "object",
+            "properties": {
+                "by_model": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/response.ExperienceDTO"
+                        "$ref": "#/definitions/response.UsageByModelDTO"
                     }
                 },
-                "file_url": {
-                    "type": "string"
+                "cached_requests": {
+                    "type": "integer"
                 },
-                "full_name": {
-                    "type": "string"
+                "completion_tokens": {
+                    "type": "integer"
                 },
-                "id": {
+                "cost": {
+                    "type": "number"
+                },
+                "currency": {
                     "type": "string"
                 },
-                "location": {
+                "from": {
                     "type": "string"
                 },
-                "phone": {
+                "input_tokens": {
+                    "type": "integer"
+                },
+                "monthly_token_quota": {
+                    "description": "0 — без ограничений",
+                    "type": "integer"
+                },
+                "quota_remaining": {
+                    "description": "null, если квоты нет",
+                    "type": "integer"
+                },
+                "quota_used": {
+                    "description": "токены за текущий месяц",
+                    "type": "integer"
+                },
+                "requests": {
+                    "type": "integer"
+                },
+                "to": {
                     "type": "string"
                 },
-                "skills": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "total_tokens": {
+                    "type": "integer"
                 }
             }
         },
-        "response.ResumeListDTO": {
+        "response.UsageReportDTO": {
             "type": "object",
             "properties": {
-                "resumes": {
+                "cost": {
+                    "type": "number"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "total_tokens": {
+                    "type": "integer"
+                },
+                "users": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/response.ResumeListItemDTO"
+                        "$ref": "#/definitions/response.UserUsageDTO"
                     }
                 }
             }
         },
-        "response.ResumeListItemDTO": {
+        "response.UserRegisterResponse": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "file_url": {
+                "email": {
                     "type": "string"
                 },
-                "full_name": {
+                "id": {
                     "type": "string"
                 },
-                "id": {
+                "nickname": {
                     "type": "string"
                 }
             }
         },
-        "response.SuccessResponse": {
+        "response.UserUsageDTO": {
             "type": "object",
             "properties": {
-                "message": {
+                "cached_requests": {
+                    "type": "integer"
+                },
+                "completion_tokens": {
+                    "type": "integer"
+                },
+                "cost": {
+                    "type": "number"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "input_tokens": {
+                    "type": "integer"
+                },
+                "requests": {
+                    "type": "integer"
+                },
+                "total_tokens": {
+                    "type": "integer"
+                },
+                "user_id": {
                     "type": "string"
                 }
             }
         },
-        "response.TokenResponse": {
+        "response.VacancyDTO": {
             "type": "object",
             "properties": {
-                "access_token": {
+                "created_at": {
                     "type": "string"
                 },
-                "refresh_token": {
+                "description": {
+                    "type": "string"
+                },
+                "employment_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "matching_profile_id": {
+                    "description": "null — профиль пользователя по умолчанию",
+                    "type": "string"
+                },
+                "max_experience_years": {
+                    "type": "number"
+                },
+                "min_experience_years": {
+                    "type": "number"
+                },
+                "remote": {
+                    "type": "string"
+                },
+                "salary": {
+                    "$ref": "#/definitions/response.SalaryRangeDTO"
+                },
+                "seniority": {
+                    "type": "string"
+                },
+                "skill_requirements": {
+                    "description": "SkillRequirements — навыки с типом требования; при создании дополняют Skills",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.SkillRequirementDTO"
+                    }
+                },
+                "skills": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "title_family": {
                     "type": "string"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
-        "response.UserRegisterResponse": {
+        "response.VacancyDraftDTO": {
             "type": "object",
             "properties": {
-                "email": {
+                "description": {
                     "type": "string"
                 },
-                "id": {
+                "employment_type": {
                     "type": "string"
                 },
-                "nickname": {
+                "language": {
+                    "type": "string"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "max_experience_years": {
+                    "type": "number"
+                },
+                "min_experience_years": {
+                    "type": "number"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "preferred_skills": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "prompt_version": {
+                    "type": "string"
+                },
+                "remote": {
+                    "type": "string"
+                },
+                "required_skills": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "salary": {
+                    "$ref": "#/definitions/response.SalaryRangeDTO"
+                },
+                "seniority": {
+                    "type": "string"
+                },
+                "skills": {
+                    "description": "Skills — все навыки вакансии: обязательные и желательные",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "title_family": {
                     "type": "string"
                 }
             }
+        },
+        "response.VacancyListDTO": {
+            "type": "object",
+            "properties": {
+                "vacancies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.VacancyDTO"
+                    }
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -0,0 +1,214 @@
+package ats
+
+import (
+	"CVMatch/internal/parser"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Серьёзность замечаний и сколько баллов каждое снимает с оценки.
+// Критическое замечание означает, что ATS не прочитает резюме, поэтому обнуляет оценку.
+const (
+	SeverityCritical = "critical"
+	SeverityWarning  = "warning"
+	SeverityInfo     = "info"
+)
+
+var severityPenalty = map[string]int{
+	SeverityCritical: 100,
+	SeverityWarning:  15,
+	SeverityInfo:     5,
+}
+
+// Коды замечаний
+const (
+	FindingImageOnly       = "image_only"
+	FindingMissingEmail    = "missing_email"
+	FindingMissingPhone    = "missing_phone"
+	FindingMissingDates    = "missing_dates"
+	FindingTooLong         = "too_long"
+	FindingTables          = "tables"
+	FindingColumns         = "columns"
+	FindingImages          = "images"
+	FindingFewKeywords     = "few_keywords"
+	FindingKeywordStuffing = "keyword_stuffing"
+)
+
+// Пороги проверок
+const (
+	// minTextRunes — меньше символов текста бывает только у скана или картинки
+	minTextRunes = 200
+	maxPages     = 2
+	maxWords     = 1200
+	// minDates — сколько дат нужно, чтобы у опыта работы были периоды
+	minDates = 2
+	// minTableRows — с какого числа строк-таблиц замечание показывается
+	minTableRows = 3
+	// minKeywords — сколько навыков из словаря должно встречаться в тексте
+	minKeywords = 5
+	// maxKeywordDensity — доля одного навыка среди слов текста, выше которой это похоже на переспам, %
+	maxKeywordDensity = 3.0
+	// minStuffingCount — меньше упоминаний не считается переспамом даже в коротком тексте
+	minStuffingCount = 6
+)
+
+// reDate — год или месяц с годом: "2020", "09.2020", "2020-09"
+var reDate = regexp.MustCompile(`\b(?:\d{1,2}[./])?(?:19|20)\d{2}\b`)
+
+// Finding — найденная проблема и что с ней сделать
+type Finding struct {
+	Code       string
+	Severity   string
+	Message    string
+	Suggestion string
+}
+
+// Keyword — навык из словаря, его упоминания и доля среди слов текста в процентах
+type Keyword struct {
+	Keyword string
+	Count   int
+	Density float64
+}
+
+// Report — результат проверки резюме на удобство для ATS: оценка от 0 до 100 и замечания.
+// LayoutChecked — была ли проверена структура PDF (без файла проверяется только текст).
+type Report struct {
+	Score         int
+	Pages         int
+	Words         int
+	LayoutChecked bool
+	Findings      []Finding
+	Keywords      []Keyword
+}
+
+// Analyze проверяет извлечённый текст резюме и, если есть, структуру PDF.
+// pages — число страниц документа; layout может быть nil.
+func Analyze(text string, pages int, layout *parser.Layout) *Report {
+	report := &Report{
+		Pages:         pages,
+		Words:         countWords(text),
+		LayoutChecked: layout != nil,
+		Findings:      []Finding{},
+		Keywords:      []Keyword{},
+	}
+	if layout != nil && layout.Pages > 0 {
+		report.Pages = layout.Pages
+	}
+
+	// Короткий текст — признак скана, только если и в структуре PDF нет строк текста
+	if len([]rune(strings.TrimSpace(text))) < minTextRunes && (layout == nil || layout.Lines == 0) {
+		report.add(FindingImageOnly, SeverityCritical,
+			"В документе почти нет текста: скорее всего, это скан или картинка, и ATS не прочитает резюме",
+			"Сохраните резюме в PDF из текстового редактора, а не сканом или изображением")
+		report.finish()
+		return report
+	}
+
+	checkContacts(report, text)
+	if len(reDate.FindAllString(text, -1)) < minDates {
+		report.add(FindingMissingDates, SeverityWarning,
+			"В резюме не найдены даты: ATS не сможет посчитать стаж",
+			"Укажите месяц и год начала и окончания каждого места работы, например «09.2020 — 03.2023»")
+	}
+	if report.Pages > maxPages || report.Words > maxWords {
+		report.add(FindingTooLong, SeverityWarning,
+			fmt.Sprintf("Резюме слишком длинное: %d стр., %d слов", report.Pages, report.Words),
+			fmt.Sprintf("Сократите резюме до %d страниц и %d слов: оставьте опыт за последние 10 лет и результаты, важные для вакансии", maxPages, maxWords))
+	}
+	if layout != nil {
+		checkLayout(report, layout)
+	}
+	checkKeywords(report, text)
+
+	report.finish()
+	return report
+}
+
+// checkContacts ищет контакты так же, как эвристический разбор резюме
+func checkContacts(report *Report, text string) {
+	contacts := parser.ParseTextHeuristically(text)
+	if contacts.Email == "" {
+		report.add(FindingMissingEmail, SeverityWarning,
+			"Не найден адрес электронной почты",
+			"Добавьте email в начало резюме обычным текстом, не картинкой и не в колонтитуле")
+	}
+	if contacts.Phone == "" {
+		report.add(FindingMissingPhone, SeverityInfo,
+			"Не найден номер телефона",
+			"Укажите телефон в международном формате, например +7 900 123-45-67")
+	}
+}
+
+func checkLayout(report *Report, layout *parser.Layout) {
+	if layout.MultiColumnPages > 0 {
+		report.add(FindingColumns, SeverityWarning,
+			fmt.Sprintf("Текст свёрстан в две колонки на %d стр.: ATS может склеить строки соседних колонок", layout.MultiColumnPages),
+			"Используйте одну колонку, а навыки и контакты вынесите отдельными разделами")
+	}
+	if layout.TableRows >= minTableRows {
+		report.add(FindingTables, SeverityWarning,
+			fmt.Sprintf("Найдены таблицы (%d строк): их содержимое часто извлекается не по порядку", layout.TableRows),
+			"Замените таблицы списками: должность, компания и период — в одной строке")
+	}
+	if layout.Images > 0 {
+		report.add(FindingImages, SeverityInfo,
+			fmt.Sprintf("Изображений в документе: %d — текст на картинках и иконки ATS не читает", layout.Images),
+			"Продублируйте текстом всё, что показано иконками или картинками")
+	}
+}
+
+func checkKeywords(report *Report, text string) {
+	mentions := parser.SkillMentions(text)
+	sort.SliceStable(mentions, func(i, j int) bool { return mentions[i].Count > mentions[j].Count })
+	for _, m := range mentions {
+		density := 0.0
+		if report.Words > 0 {
+			density = math.Round(float64(m.Count)/float64(report.Words)*1000) / 10
+		}
+		report.Keywords = append(report.Keywords, Keyword{Keyword: m.Name, Count: m.Count, Density: density})
+	}
+
+	if len(mentions) < minKeywords {
+		report.add(FindingFewKeywords, SeverityWarning,
+			fmt.Sprintf("Найдено мало ключевых навыков: %d", len(mentions)),
+			"Перечислите технологии и инструменты отдельным разделом и упомяните их в описании опыта — по ним ATS находит резюме")
+	}
+	var stuffed []string
+	for _, k := range report.Keywords {
+		if k.Count >= minStuffingCount && k.Density > maxKeywordDensity {
+			stuffed = append(stuffed, k.Keyword)
+		}
+	}
+	if len(stuffed) > 0 {
+		report.add(FindingKeywordStuffing, SeverityInfo,
+			"Навыки повторяются слишком часто: "+strings.Join(stuffed, ", "),
+			"Упоминайте навык там, где вы его применяли: повторы ради ключевых слов ATS и рекрутеры считают переспамом")
+	}
+}
+
+func (r *Report) add(code, severity, message, suggestion string) {
+	r.Findings = append(r.Findings, Finding{Code: code, Severity: severity, Message: message, Suggestion: suggestion})
+}
+
+// finish считает оценку и ставит самые серьёзные замечания первыми
+func (r *Report) finish() {
+	r.Score = 100
+	for _, f := range r.Findings {
+		r.Score -= severityPenalty[f.Severity]
+	}
+	if r.Score < 0 {
+		r.Score = 0
+	}
+	rank := map[string]int{SeverityCritical: 0, SeverityWarning: 1, SeverityInfo: 2}
+	sort.SliceStable(r.Findings, func(i, j int) bool { return rank[r.Findings[i].Severity] < rank[r.Findings[j].Severity] })
+}
+
+func countWords(text string) int {
+	return len(strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}))
+}
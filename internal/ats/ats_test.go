@@ -0,0 +1,67 @@
+package ats
+
+import (
+	"CVMatch/internal/parser"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const goodResume = `Иван Петров
+ivan.petrov@example.com, +7 900 123-45-67, Москва
+
+Опыт работы
+09.2020 — по настоящее время, Ozon, Go-разработчик
+Разрабатывал сервис заказов на Golang: PostgreSQL, Kafka, Redis, Docker, Kubernetes.
+Сократил время ответа API на 30%.
+
+03.2018 — 08.2020, Тинькофф, Backend-разработчик
+Поддерживал платёжные сервисы, настроил CI/CD в GitLab и мониторинг.
+
+Образование
+МГУ, факультет ВМК, бакалавр, 2018`
+
+func codes(report *Report) []string {
+	result := []string{}
+	for _, f := range report.Findings {
+		result = append(result, f.Code)
+	}
+	return result
+}
+
+func TestAnalyze_GoodResume(t *testing.T) {
+	report := Analyze(goodResume, 1, &parser.Layout{Pages: 1, Lines: 12})
+	require.Empty(t, codes(report))
+	require.Equal(t, 100, report.Score)
+	require.True(t, report.LayoutChecked)
+	require.Equal(t, "Go", report.Keywords[0].Keyword)
+	require.Equal(t, 2, report.Keywords[0].Count)
+
+	report = Analyze(strings.Replace(goodResume, "+7 900 123-45-67, ", "", 1), 1, nil)
+	require.Equal(t, []string{FindingMissingPhone}, codes(report))
+	require.Equal(t, 95, report.Score)
+	require.False(t, report.LayoutChecked)
+}
+
+func TestAnalyze_ImageOnly(t *testing.T) {
+	report := Analyze("  \n", 2, nil)
+	require.Equal(t, []string{FindingImageOnly}, codes(report))
+	require.Equal(t, 0, report.Score)
+	require.False(t, report.LayoutChecked)
+	// Короткое резюме с текстовым слоем сканом не считается
+	report = Analyze("Иван Петров, ivan@example.com, Go", 1, &parser.Layout{Pages: 1, Lines: 3})
+	require.NotContains(t, codes(report), FindingImageOnly)
+	require.Positive(t, report.Score)
+}
+
+func TestAnalyze_Problems(t *testing.T) {
+	text := "Иван Петров, разработчик.\n" + strings.Repeat("Писал на Python и Python, ещё Python. Люблю Python и команду. ", 12)
+	report := Analyze(text, 3, &parser.Layout{Pages: 3, MultiColumnPages: 1, TableRows: 4, Images: 2})
+	require.Equal(t, []string{
+		FindingMissingEmail, FindingMissingDates, FindingTooLong, FindingColumns, FindingTables, FindingFewKeywords,
+		FindingMissingPhone, FindingImages, FindingKeywordStuffing,
+	}, codes(report))
+	require.Equal(t, 0, report.Score)
+	require.Equal(t, "Python", report.Keywords[0].Keyword)
+}
@@ -1,83 +1,223 @@
-package config
-
-import (
-	"log"
-	"os"
-	"strings"
-	"time"
-
-	"go.uber.org/zap"
-)
-
-type Config struct {
-	DB               DBConfig
-	OModel           string
-	JWT              JWTConfig
-	YandexGPTIAM     string
-	YandexGPTCatalog string
-	BaseURL          string
-}
-
-type JWTConfig struct {
-	Access     string
-	AccessExp  time.Duration
-	Refresh    string
-	RefreshExp time.Duration
-}
-
-type DBConfig struct {
-	Host     string
-	Port     string
-	User     string
-	Password string
-	Name     string
-	SSLMode  string
-}
-
-func Load(log *zap.Logger) *Config {
-	return &Config{
-		DB: DBConfig{
-			Host:     getEnv("DB_HOST", log),
-			Port:     getEnv("DB_PORT", log),
-			User:     getEnv("DB_USER", log),
-			Password: getEnv("DB_PASSWORD", log),
-			Name:     getEnv("DB_NAME", log),
-			SSLMode:  getEnv("DB_SSLMODE", log),
-		},
-		JWT: JWTConfig{
-			Access:     getEnv("ACCESS_SECRET", log),
-			AccessExp:  parseDurationWithDays(getEnv("ACCESS_EXP", log)),
-			Refresh:    getEnv("REFRESH_SECRET", log),
-			RefreshExp: parseDurationWithDays(getEnv("REFRESH_EXP", log)),
-		},
-		YandexGPTIAM:     getEnv("YANDEXGPT_IAM", log),
-		YandexGPTCatalog: getEnv("YANDEXGPT_CATALOG_ID", log),
-		BaseURL:          getEnv("BASE_URL", log),
-	}
-}
-
-func getEnv(key string, log *zap.Logger) string {
-	if val, exists := os.LookupEnv(key); exists {
-		return val
-	}
-	log.Error("Обязательное значение для ключа не установлено", zap.String("key", key))
-	panic("Обязательное значение для ключа не установлено")
-}
-
-func parseDurationWithDays(s string) time.Duration {
-	if strings.HasSuffix(s, "d") {
-		daysStr := strings.TrimSuffix(s, "d")
-		days, err := time.ParseDuration(daysStr + "h")
-		if err != nil {
-			log.Printf("Ошибка парсинга TTL: %v", err)
-			return 0
-		}
-		return time.Duration(24) * days
-	}
-
-	duration, err := time.ParseDuration(s)
-	if err != nil {
-		return 0
-	}
-	return duration
-}
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type Config struct {
+	DB               DBConfig
+	OModel           string
+	JWT              JWTConfig
+	YandexGPTIAM     string
+	YandexGPTCatalog string
+	YandexGPTModel   string // yandexgpt-lite, yandexgpt, yandexgpt/rc...
+	BaseURL          string
+	// Порог уверенности, ниже которого ключевое поле отправляет резюме на ручную проверку
+	ReviewThreshold float64
+	// Язык, на который переводится содержимое резюме при разборе (ru, en, kk, uz); пусто — без перевода
+	ResumeTargetLanguage string
+	// Каталог с шаблонами промптов, переопределяющими встроенные; пусто — только встроенные
+	PromptsDir string
+	LLMCache   LLMCacheConfig
+	LLM        LLMConfig
+	Matrix     MatrixConfig
+}
+
+// MatrixConfig — фоновый расчёт матрицы сравнений резюме и вакансий
+type MatrixConfig struct {
+	Workers  int // сколько пар считается одновременно
+	MaxPairs int // предел пар резюме и вакансии в одной задаче
+}
+
+// LLMConfig — политика обращений к LLM: таймауты, повторы, предохранитель и запасной парсер
+type LLMConfig struct {
+	BaseURL          string
+	Timeout          time.Duration // таймаут одной попытки
+	MaxAttempts      int
+	InitialBackoff   time.Duration
+	MaxBackoff       time.Duration
+	BreakerThreshold int           // неудач подряд, после которых провайдер считается недоступным
+	BreakerCooldown  time.Duration // сколько не обращаться к недоступному провайдеру
+	Fallback         bool          // разбирать резюме эвристиками, пока провайдер недоступен
+	// Цена 1000 токенов по моделям в валюте Currency, для оценки стоимости вызовов
+	Prices   map[string]float64
+	Currency string
+	// Месячная квота токенов пользователя по умолчанию, 0 — без ограничений
+	MonthlyTokenQuota int64
+	// Запись (record) или воспроизведение (replay) обменов с провайдером в каталоге FixturesDir
+	FixturesMode string
+	FixturesDir  string
+}
+
+// LLMCacheConfig — кеш ответов LLM
+type LLMCacheConfig struct {
+	Backend string // memory, postgres или off
+	TTL     time.Duration
+	Size    int // максимум записей для кеша в памяти
+}
+
+type JWTConfig struct {
+	Access     string
+	AccessExp  time.Duration
+	Refresh    string
+	RefreshExp time.Duration
+}
+
+type DBConfig struct {
+	Host     string
+	Port     string
+	User     string
+	Password string
+	Name     string
+	SSLMode  string
+}
+
+func Load(log *zap.Logger) *Config {
+	return &Config{
+		DB: DBConfig{
+			Host:     getEnv("DB_HOST", log),
+			Port:     getEnv("DB_PORT", log),
+			User:     getEnv("DB_USER", log),
+			Password: getEnv("DB_PASSWORD", log),
+			Name:     getEnv("DB_NAME", log),
+			SSLMode:  getEnv("DB_SSLMODE", log),
+		},
+		JWT: JWTConfig{
+			Access:     getEnv("ACCESS_SECRET", log),
+			AccessExp:  parseDurationWithDays(getEnv("ACCESS_EXP", log)),
+			Refresh:    getEnv("REFRESH_SECRET", log),
+			RefreshExp: parseDurationWithDays(getEnv("REFRESH_EXP", log)),
+		},
+		YandexGPTIAM:     getEnv("YANDEXGPT_IAM", log),
+		YandexGPTCatalog: getEnv("YANDEXGPT_CATALOG_ID", log),
+		YandexGPTModel:   getEnvDefault("YANDEXGPT_MODEL", "yandexgpt-lite"),
+		BaseURL:          getEnv("BASE_URL", log),
+		ReviewThreshold:  getEnvFloat("REVIEW_CONFIDENCE_THRESHOLD", 0.6, log),
+
+		ResumeTargetLanguage: getEnvDefault("RESUME_TARGET_LANGUAGE", ""),
+		PromptsDir:           getEnvDefault("PROMPTS_DIR", ""),
+		LLMCache: LLMCacheConfig{
+			Backend: getEnvDefault("LLM_CACHE", "memory"),
+			TTL:     parseDurationWithDays(getEnvDefault("LLM_CACHE_TTL", "30d")),
+			Size:    getEnvInt("LLM_CACHE_SIZE", 1000, log),
+		},
+		LLM: LLMConfig{
+			BaseURL:          getEnvDefault("YANDEXGPT_BASE_URL", ""),
+			Timeout:          parseDurationWithDays(getEnvDefault("LLM_TIMEOUT", "60s")),
+			MaxAttempts:      getEnvInt("LLM_MAX_ATTEMPTS", 3, log),
+			InitialBackoff:   parseDurationWithDays(getEnvDefault("LLM_INITIAL_BACKOFF", "500ms")),
+			MaxBackoff:       parseDurationWithDays(getEnvDefault("LLM_MAX_BACKOFF", "10s")),
+			BreakerThreshold: getEnvInt("LLM_BREAKER_THRESHOLD", 5, log),
+			BreakerCooldown:  parseDurationWithDays(getEnvDefault("LLM_BREAKER_COOLDOWN", "30s")),
+			Fallback:         getEnvBool("LLM_FALLBACK", true, log),
+
+			Prices:            parsePrices(getEnvDefault("LLM_PRICES", "yandexgpt-lite=0.2,yandexgpt=1.2"), log),
+			Currency:          getEnvDefault("LLM_CURRENCY", "RUB"),
+			MonthlyTokenQuota: int64(getEnvInt("LLM_MONTHLY_TOKEN_QUOTA", 0, log)),
+
+			FixturesMode: getEnvDefault("LLM_FIXTURES_MODE", ""),
+			FixturesDir:  getEnvDefault("LLM_FIXTURES_DIR", "testdata/fixtures"),
+		},
+		Matrix: MatrixConfig{
+			Workers:  getEnvInt("MATRIX_WORKERS", 4, log),
+			MaxPairs: getEnvInt("MATRIX_MAX_PAIRS", 50000, log),
+		},
+	}
+}
+
+func getEnv(key string, log *zap.Logger) string {
+	if val, exists := os.LookupEnv(key); exists {
+		return val
+	}
+	log.Error("Обязательное значение для ключа не установлено", zap.String("key", key))
+	panic("Обязательное значение для ключа не установлено")
+}
+
+// getEnvDefault возвращает значение необязательной переменной окружения
+func getEnvDefault(key, def string) string {
+	if val, exists := os.LookupEnv(key); exists && val != "" {
+		return val
+	}
+	return def
+}
+
+func getEnvFloat(key string, def float64, log *zap.Logger) float64 {
+	raw := getEnvDefault(key, "")
+	if raw == "" {
+		return def
+	}
+	val, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		log.Warn("Некорректное значение переменной, используется значение по умолчанию", zap.String("key", key), zap.Float64("default", def))
+		return def
+	}
+	return val
+}
+
+func getEnvInt(key string, def int, log *zap.Logger) int {
+	raw := getEnvDefault(key, "")
+	if raw == "" {
+		return def
+	}
+	val, err := strconv.Atoi(raw)
+	if err != nil {
+		log.Warn("Некорректное значение переменной, используется значение по умолчанию", zap.String("key", key), zap.Int("default", def))
+		return def
+	}
+	return val
+}
+
+func getEnvBool(key string, def bool, log *zap.Logger) bool {
+	raw := getEnvDefault(key, "")
+	if raw == "" {
+		return def
+	}
+	val, err := strconv.ParseBool(raw)
+	if err != nil {
+		log.Warn("Некорректное значение переменной, используется значение по умолчанию", zap.String("key", key), zap.Bool("default", def))
+		return def
+	}
+	return val
+}
+
+// parsePrices разбирает список вида "yandexgpt-lite=0.2,yandexgpt=1.2"
+func parsePrices(raw string, log *zap.Logger) map[string]float64 {
+	prices := make(map[string]float64)
+	for _, pair := range strings.Split(raw, ",") {
+		model, price, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			continue
+		}
+		val, err := strconv.ParseFloat(strings.TrimSpace(price), 64)
+		if err != nil {
+			log.Warn("Некорректная цена модели", zap.String("model", model), zap.String("price", price))
+			continue
+		}
+		prices[strings.TrimSpace(model)] = val
+	}
+	return prices
+}
+
+func parseDurationWithDays(s string) time.Duration {
+	if strings.HasSuffix(s, "d") {
+		daysStr := strings.TrimSuffix(s, "d")
+		days, err := time.ParseDuration(daysStr + "h")
+		if err != nil {
+			log.Printf("Ошибка парсинга TTL: %v", err)
+			return 0
+		}
+		return time.Duration(24) * days
+	}
+
+	duration, err := time.ParseDuration(s)
+	if err != nil {
+		return 0
+	}
+	return duration
+}
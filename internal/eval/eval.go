@@ -0,0 +1,318 @@
+// Package eval оценивает качество разбора резюме на размеченном наборе документов:
+// для каждого поля считаются точность и полнота относительно ожидаемого JSON.
+package eval
+
+import (
+	"CVMatch/internal/config"
+	"CVMatch/internal/parser"
+	"CVMatch/internal/response"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Поля, по которым считаются метрики, в порядке вывода в отчёте
+const (
+	FieldFullName             = "full_name"
+	FieldEmail                = "email"
+	FieldPhone                = "phone"
+	FieldLocation             = "location"
+	FieldSkills               = "skills"
+	FieldExperienceCompany    = "experience.company"
+	FieldExperiencePosition   = "experience.position"
+	FieldExperienceStartDate  = "experience.start_date"
+	FieldExperienceEndDate    = "experience.end_date"
+	FieldEducationInstitution = "education.institution"
+	FieldEducationEndDate     = "education.end_date"
+	FieldLanguages            = "languages"
+	FieldLinks                = "links"
+)
+
+var Fields = []string{
+	FieldFullName,
+	FieldEmail,
+	FieldPhone,
+	FieldLocation,
+	FieldSkills,
+	FieldExperienceCompany,
+	FieldExperiencePosition,
+	FieldExperienceStartDate,
+	FieldExperienceEndDate,
+	FieldEducationInstitution,
+	FieldEducationEndDate,
+	FieldLanguages,
+	FieldLinks,
+}
+
+// entryMatchThreshold — минимальная похожесть записей опыта или образования,
+// при которой они считаются одной и той же записью
+const entryMatchThreshold = 0.5
+
+// Case — документ набора и ожидаемый результат его разбора
+type Case struct {
+	Name     string
+	Path     string
+	Expected *response.ParsedResumeDTO
+}
+
+// LoadDataset читает набор из каталога: для каждого документа cv.pdf рядом лежит cv.json
+// с ожидаемым результатом. Парсеры читают только PDF, поэтому остальные файлы, документы без
+// разметки и подкаталоги пропускаются.
+func LoadDataset(dir string) ([]Case, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var cases []Case
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(entry.Name()), ".pdf") {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		content, err := os.ReadFile(filepath.Join(dir, name+".json"))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		var expected response.ParsedResumeDTO
+		if err := json.Unmarshal(content, &expected); err != nil {
+			return nil, fmt.Errorf("разметка %s.json: %w", name, err)
+		}
+		cases = append(cases, Case{Name: name, Path: filepath.Join(dir, entry.Name()), Expected: &expected})
+	}
+	if len(cases) == 0 {
+		return nil, fmt.Errorf("в каталоге %s нет размеченных документов", dir)
+	}
+	sort.Slice(cases, func(i, j int) bool { return cases[i].Name < cases[j].Name })
+	return cases, nil
+}
+
+// FieldScore — метрики поля. При отсутствии ответов точность считается равной 1,
+// при отсутствии ожидаемых значений — полнота.
+type FieldScore struct {
+	Counts
+	Precision float64 `json:"precision"`
+	Recall    float64 `json:"recall"`
+	F1        float64 `json:"f1"`
+}
+
+func newFieldScore(c Counts) FieldScore {
+	score := FieldScore{Counts: c, Precision: 1, Recall: 1}
+	if c.TP+c.FP > 0 {
+		score.Precision = float64(c.TP) / float64(c.TP+c.FP)
+	}
+	if c.TP+c.FN > 0 {
+		score.Recall = float64(c.TP) / float64(c.TP+c.FN)
+	}
+	if score.Precision+score.Recall > 0 {
+		score.F1 = 2 * score.Precision * score.Recall / (score.Precision + score.Recall)
+	}
+	score.Precision, score.Recall, score.F1 = round4(score.Precision), round4(score.Recall), round4(score.F1)
+	return score
+}
+
+// DocumentResult — результат по одному документу
+type DocumentResult struct {
+	Name     string            `json:"name"`
+	Error    string            `json:"error,omitempty"`
+	Duration time.Duration     `json:"duration_ns"`
+	Fields   map[string]Counts `json:"fields,omitempty"`
+}
+
+// Report — итог прогона одного парсера по набору
+type Report struct {
+	Name          string                `json:"name"`
+	Model         string                `json:"model,omitempty"`
+	PromptVersion string                `json:"prompt_version,omitempty"`
+	Dataset       string                `json:"dataset"`
+	GeneratedAt   time.Time             `json:"generated_at"`
+	Documents     int                   `json:"documents"`
+	Failed        int                   `json:"failed"`
+	Overall       FieldScore            `json:"overall"` // микро-усреднение по всем полям
+	Fields        map[string]FieldScore `json:"fields"`
+	Results       []DocumentResult      `json:"results"`
+}
+
+// Run разбирает каждый документ набора парсером p и сравнивает результат с разметкой.
+// Ошибка разбора документа не прерывает прогон: все ожидаемые значения документа
+// засчитываются как пропущенные.
+func Run(ctx context.Context, name, dataset string, p parser.ResumeParserI, cfg *config.Config, cases []Case) *Report {
+	report := &Report{
+		Name:        name,
+		Dataset:     dataset,
+		GeneratedAt: time.Now().UTC(),
+		Documents:   len(cases),
+		Fields:      make(map[string]FieldScore, len(Fields)),
+	}
+	totals := make(map[string]Counts, len(Fields))
+
+	for _, c := range cases {
+		start := time.Now()
+		predicted, result, err := parseCase(ctx, p, cfg, c.Path)
+		doc := DocumentResult{Name: c.Name, Duration: time.Since(start)}
+		if err != nil {
+			doc.Error = err.Error()
+			report.Failed++
+			predicted = &response.ParsedResumeDTO{}
+		} else {
+			if report.Model == "" {
+				report.Model = result.Model
+			}
+			if report.PromptVersion == "" {
+				report.PromptVersion = result.PromptVersion
+			}
+		}
+		doc.Fields = Compare(c.Expected, predicted)
+		for field, counts := range doc.Fields {
+			total := totals[field]
+			total.add(counts)
+			totals[field] = total
+		}
+		report.Results = append(report.Results, doc)
+	}
+
+	var overall Counts
+	for _, field := range Fields {
+		report.Fields[field] = newFieldScore(totals[field])
+		overall.add(totals[field])
+	}
+	report.Overall = newFieldScore(overall)
+	return report
+}
+
+func parseCase(ctx context.Context, p parser.ResumeParserI, cfg *config.Config, path string) (*response.ParsedResumeDTO, *parser.ParseResult, error) {
+	result, err := p.ParseResume(ctx, path, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	dto, err := parser.DecodeOutput(result.Output)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ответ парсера не является JSON резюме: %w", err)
+	}
+	return dto, result, nil
+}
+
+// Compare сравнивает разобранное резюме с ожидаемым по всем полям
+func Compare(expected, predicted *response.ParsedResumeDTO) map[string]Counts {
+	fields := make(map[string]Counts, len(Fields))
+	score := func(field string, fn func(c *Counts)) {
+		c := fields[field]
+		fn(&c)
+		fields[field] = c
+	}
+
+	score(FieldFullName, func(c *Counts) { scoreScalar(c, expected.FullName, predicted.FullName, sameName) })
+	score(FieldEmail, func(c *Counts) { scoreScalar(c, expected.Email, predicted.Email, exactFold) })
+	score(FieldPhone, func(c *Counts) { scoreScalar(c, expected.Phone, predicted.Phone, samePhone) })
+	score(FieldLocation, func(c *Counts) { scoreScalar(c, expected.Location, predicted.Location, sameLocation) })
+	score(FieldSkills, func(c *Counts) { scoreSet(c, expected.Skills, predicted.Skills, sameSkill) })
+	score(FieldLanguages, func(c *Counts) {
+		scoreSet(c, languageNames(expected.Languages), languageNames(predicted.Languages), sameText)
+	})
+	score(FieldLinks, func(c *Counts) { scoreSet(c, linkURLs(expected.Links), linkURLs(predicted.Links), sameURL) })
+
+	experienceKey := func(e response.ExperienceDTO) string { return e.Company + " " + e.Position }
+	expPairs := align(expected.Experience, predicted.Experience, experienceKey)
+	for _, pair := range expPairs {
+		var exp, pred response.ExperienceDTO
+		if pair.expected >= 0 {
+			exp = expected.Experience[pair.expected]
+		}
+		if pair.predicted >= 0 {
+			pred = predicted.Experience[pair.predicted]
+		}
+		score(FieldExperienceCompany, func(c *Counts) { scoreScalar(c, exp.Company, pred.Company, sameCompany) })
+		score(FieldExperiencePosition, func(c *Counts) { scoreScalar(c, exp.Position, pred.Position, sameText) })
+		score(FieldExperienceStartDate, func(c *Counts) { scoreScalar(c, exp.StartDate, pred.StartDate, sameDate) })
+		score(FieldExperienceEndDate, func(c *Counts) { scoreScalar(c, exp.EndDate, pred.EndDate, sameDate) })
+	}
+
+	educationKey := func(e response.EducationDTO) string { return e.Institution }
+	eduPairs := align(expected.Education, predicted.Education, educationKey)
+	for _, pair := range eduPairs {
+		var exp, pred response.EducationDTO
+		if pair.expected >= 0 {
+			exp = expected.Education[pair.expected]
+		}
+		if pair.predicted >= 0 {
+			pred = predicted.Education[pair.predicted]
+		}
+		score(FieldEducationInstitution, func(c *Counts) { scoreScalar(c, exp.Institution, pred.Institution, sameCompany) })
+		score(FieldEducationEndDate, func(c *Counts) { scoreScalar(c, exp.EndDate, pred.EndDate, sameDate) })
+	}
+	return fields
+}
+
+// entryPair — сопоставленные записи ожидаемого и разобранного списков; -1 — записи нет
+type entryPair struct {
+	expected  int
+	predicted int
+}
+
+// align сопоставляет записи двух списков жадно, начиная с самых похожих.
+// Несопоставленные записи возвращаются в паре с -1.
+func align[T any](expected, predicted []T, key func(T) string) []entryPair {
+	type candidate struct {
+		i, j  int
+		score float64
+	}
+	var candidates []candidate
+	for i := range expected {
+		for j := range predicted {
+			s := similarity(normalizeCompany(key(expected[i])), normalizeCompany(key(predicted[j])))
+			if s >= entryMatchThreshold {
+				candidates = append(candidates, candidate{i, j, s})
+			}
+		}
+	}
+	sort.SliceStable(candidates, func(a, b int) bool { return candidates[a].score > candidates[b].score })
+
+	usedExp := make([]bool, len(expected))
+	usedPred := make([]bool, len(predicted))
+	var pairs []entryPair
+	for _, c := range candidates {
+		if usedExp[c.i] || usedPred[c.j] {
+			continue
+		}
+		usedExp[c.i], usedPred[c.j] = true, true
+		pairs = append(pairs, entryPair{c.i, c.j})
+	}
+	for i, used := range usedExp {
+		if !used {
+			pairs = append(pairs, entryPair{i, -1})
+		}
+	}
+	for j, used := range usedPred {
+		if !used {
+			pairs = append(pairs, entryPair{-1, j})
+		}
+	}
+	return pairs
+}
+
+func languageNames(languages []response.LanguageDTO) []string {
+	names := make([]string, 0, len(languages))
+	for _, l := range languages {
+		names = append(names, l.Name)
+	}
+	return names
+}
+
+func linkURLs(links []response.LinkDTO) []string {
+	urls := make([]string, 0, len(links))
+	for _, l := range links {
+		urls = append(urls, l.URL)
+	}
+	return urls
+}
+
+func round4(v float64) float64 {
+	return float64(int64(v*10000+0.5)) / 10000
+}
@@ -0,0 +1,92 @@
+package eval
+
+import (
+	"CVMatch/internal/parser"
+	"CVMatch/internal/response"
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const goldenDataset = "testdata/golden"
+
+func TestMatchers(t *testing.T) {
+	require.True(t, sameName("Иван Иванов", "Иванов Иван"))
+	require.False(t, sameName("Иван Иванов", "Пётр Петров"))
+	require.True(t, sameCompany("ООО «Яндекс»", "Яндекс"))
+	require.True(t, sameCompany("Acme Inc.", "ACME"))
+	require.True(t, samePhone("+7 (999) 123-45-67", "89991234567"))
+	require.True(t, sameLocation("г. Москва", "Москва"))
+	require.True(t, sameDate("03.2021", "март 2021"))
+	require.True(t, sameDate("по настоящее время", "present"))
+	require.True(t, sameDate("2021", "02.2021"))
+	require.False(t, sameDate("03.2021", "04.2021"))
+	require.True(t, sameURL("https://github.com/user/", "github.com/user"))
+}
+
+func TestCompare(t *testing.T) {
+	expected := &response.ParsedResumeDTO{
+		FullName: "Анна Петрова",
+		Email:    "anna@example.com",
+		Skills:   []string{"Go", "Kafka", "Docker"},
+		Experience: []response.ExperienceDTO{
+			{Company: "Яндекс", Position: "Backend-разработчик", StartDate: "03.2021", EndDate: "по н.в."},
+		},
+	}
+	predicted := &response.ParsedResumeDTO{
+		FullName: "Петрова Анна",
+		Location: "Москва",
+		Skills:   []string{"go", "Kafka", "Python"},
+		Experience: []response.ExperienceDTO{
+			{Company: "ООО Яндекс", Position: "Backend-разработчик", StartDate: "2020-03", EndDate: "present"},
+			{Company: "Сбер", Position: "Стажёр"},
+		},
+	}
+
+	fields := Compare(expected, predicted)
+	require.Equal(t, Counts{TP: 1}, fields[FieldFullName])
+	require.Equal(t, Counts{FN: 1}, fields[FieldEmail])
+	require.Equal(t, Counts{FP: 1}, fields[FieldLocation])
+	require.Equal(t, Counts{TP: 2, FP: 1, FN: 1}, fields[FieldSkills])
+	require.Equal(t, Counts{TP: 1, FP: 1}, fields[FieldExperienceCompany])
+	require.Equal(t, Counts{FP: 1, FN: 1}, fields[FieldExperienceStartDate])
+	require.Equal(t, Counts{TP: 1}, fields[FieldExperienceEndDate])
+}
+
+func TestRun_GoldenDataset(t *testing.T) {
+	cases, err := LoadDataset(goldenDataset)
+	require.NoError(t, err)
+	require.Len(t, cases, 2)
+
+	recorded := Run(context.Background(), "recorded", goldenDataset,
+		parser.RecordedResumeParser{Dir: filepath.Join(goldenDataset, "recorded"), PromptVersion: "v1"}, nil, cases)
+	require.Equal(t, 0, recorded.Failed)
+	require.Equal(t, parser.ModelRecorded, recorded.Model)
+	require.Equal(t, "v1", recorded.PromptVersion)
+	require.Equal(t, 1.0, recorded.Fields[FieldFullName].F1)
+	require.Equal(t, 1.0, recorded.Fields[FieldPhone].F1)
+	require.Less(t, recorded.Fields[FieldSkills].Recall, 1.0)
+
+	// Документ, который не удалось прочитать, засчитывается как ошибка
+	broken := append(cases, Case{Name: "missing", Path: filepath.Join(goldenDataset, "missing.pdf"), Expected: cases[0].Expected})
+	heuristic := Run(context.Background(), "heuristic", goldenDataset, parser.HeuristicResumeParser{}, nil, broken)
+	require.Equal(t, 1, heuristic.Failed)
+	require.Greater(t, heuristic.Fields[FieldEmail].F1, 0.0)
+	require.Less(t, heuristic.Overall.F1, recorded.Overall.F1)
+
+	path := filepath.Join(t.TempDir(), "report.json")
+	require.NoError(t, WriteJSON(path, []*Report{recorded, heuristic}))
+	reports, err := ReadReports(path)
+	require.NoError(t, err)
+	require.Len(t, reports, 2)
+	require.Equal(t, recorded.Fields, reports[0].Fields)
+
+	var md bytes.Buffer
+	require.NoError(t, WriteMarkdown(&md, reports))
+	require.Contains(t, md.String(), "| skills |")
+	require.Contains(t, md.String(), "recorded F1")
+	require.Contains(t, md.String(), "heuristic / missing")
+}
@@ -0,0 +1,231 @@
+package eval
+
+import (
+	"CVMatch/internal/parser"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// fuzzyThreshold — минимальная похожесть строк, при которой значения считаются совпавшими
+const fuzzyThreshold = 0.85
+
+// legalForms — организационно-правовые формы, которые не влияют на сравнение компаний
+var legalForms = map[string]struct{}{
+	"ооо": {}, "оао": {}, "зао": {}, "пао": {}, "ао": {}, "ип": {}, "нко": {}, "фгуп": {}, "гуп": {},
+	"llc": {}, "inc": {}, "ltd": {}, "gmbh": {}, "corp": {}, "co": {}, "plc": {}, "ag": {},
+}
+
+// locationMarkers — слова, которые не меняют смысла места проживания: "г. Москва" и "Москва" совпадают
+var locationMarkers = map[string]struct{}{
+	"г": {}, "город": {}, "city": {},
+}
+
+// Counts — совпадения и ошибки по одному полю
+type Counts struct {
+	TP int `json:"tp"`
+	FP int `json:"fp"`
+	FN int `json:"fn"`
+}
+
+func (c *Counts) add(other Counts) {
+	c.TP += other.TP
+	c.FP += other.FP
+	c.FN += other.FN
+}
+
+// scoreScalar сравнивает одиночное значение: пустое ожидание при непустом ответе — лишнее значение,
+// неверный ответ одновременно и лишний, и пропущенный
+func scoreScalar(c *Counts, expected, predicted string, equal func(a, b string) bool) {
+	expected, predicted = strings.TrimSpace(expected), strings.TrimSpace(predicted)
+	switch {
+	case expected == "" && predicted == "":
+	case expected == "":
+		c.FP++
+	case predicted == "":
+		c.FN++
+	case equal(expected, predicted):
+		c.TP++
+	default:
+		c.FP++
+		c.FN++
+	}
+}
+
+// scoreSet сравнивает списки как множества: каждому ожидаемому значению
+// сопоставляется не больше одного значения из ответа
+func scoreSet(c *Counts, expected, predicted []string, equal func(a, b string) bool) {
+	expected, predicted = nonEmpty(expected), nonEmpty(predicted)
+	used := make([]bool, len(predicted))
+	for _, exp := range expected {
+		found := false
+		for i, pred := range predicted {
+			if !used[i] && equal(exp, pred) {
+				used[i] = true
+				found = true
+				break
+			}
+		}
+		if found {
+			c.TP++
+		} else {
+			c.FN++
+		}
+	}
+	for _, u := range used {
+		if !u {
+			c.FP++
+		}
+	}
+}
+
+func nonEmpty(values []string) []string {
+	var result []string
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func exactFold(a, b string) bool {
+	return strings.EqualFold(strings.TrimSpace(a), strings.TrimSpace(b))
+}
+
+func sameSkill(a, b string) bool {
+	return normalizeText(a) == normalizeText(b)
+}
+
+func samePhone(a, b string) bool {
+	a, b = digits(a), digits(b)
+	// +7 и 8 в начале российских номеров равнозначны, сравниваем последние 10 цифр
+	if len(a) > 10 {
+		a = a[len(a)-10:]
+	}
+	if len(b) > 10 {
+		b = b[len(b)-10:]
+	}
+	return a != "" && a == b
+}
+
+func sameURL(a, b string) bool {
+	return normalizeURL(a) == normalizeURL(b)
+}
+
+// sameName сравнивает ФИО без учёта порядка слов: "Иванов Иван" и "Иван Иванов" совпадают
+func sameName(a, b string) bool {
+	return similarity(sortedTokens(a), sortedTokens(b)) >= fuzzyThreshold
+}
+
+func sameText(a, b string) bool {
+	return similarity(normalizeText(a), normalizeText(b)) >= fuzzyThreshold
+}
+
+func sameLocation(a, b string) bool {
+	return similarity(dropWords(normalizeText(a), locationMarkers), dropWords(normalizeText(b), locationMarkers)) >= fuzzyThreshold
+}
+
+// sameCompany сравнивает названия организаций без кавычек и правовой формы
+func sameCompany(a, b string) bool {
+	return similarity(normalizeCompany(a), normalizeCompany(b)) >= fuzzyThreshold
+}
+
+// sameDate сравнивает даты после нормализации: "03.2021" и "март 2021" совпадают.
+// Если у одной из дат известен только год, сравнивается год.
+func sameDate(a, b string) bool {
+	da, db := parser.NormalizeDate(a), parser.NormalizeDate(b)
+	if da.IsZero() || db.IsZero() {
+		return normalizeText(a) == normalizeText(b)
+	}
+	if da.Present || db.Present {
+		return da.Present == db.Present
+	}
+	if da.Time.Year() != db.Time.Year() {
+		return false
+	}
+	if da.Precision == parser.PrecisionYear || db.Precision == parser.PrecisionYear {
+		return true
+	}
+	return da.Time.Month() == db.Time.Month()
+}
+
+// normalizeText приводит строку к нижнему регистру, заменяет пунктуацию пробелами и схлопывает пробелы
+func normalizeText(s string) string {
+	s = strings.ToLower(s)
+	s = strings.ReplaceAll(s, "ё", "е")
+	return strings.Join(strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '+' && r != '#'
+	}), " ")
+}
+
+func normalizeCompany(s string) string {
+	return dropWords(normalizeText(s), legalForms)
+}
+
+func dropWords(s string, words map[string]struct{}) string {
+	var kept []string
+	for _, word := range strings.Fields(s) {
+		if _, ok := words[word]; !ok {
+			kept = append(kept, word)
+		}
+	}
+	return strings.Join(kept, " ")
+}
+
+func normalizeURL(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for _, prefix := range []string{"https://", "http://", "www."} {
+		s = strings.TrimPrefix(s, prefix)
+	}
+	return strings.TrimSuffix(s, "/")
+}
+
+func sortedTokens(s string) string {
+	tokens := strings.Fields(normalizeText(s))
+	sort.Strings(tokens)
+	return strings.Join(tokens, " ")
+}
+
+func digits(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// similarity — 1 минус расстояние Левенштейна, отнесённое к длине большей строки
+func similarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
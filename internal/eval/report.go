@@ -0,0 +1,94 @@
+package eval
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// WriteJSON сохраняет отчёты в файл: JSON-массив, который потом можно передать в ReadReports
+func WriteJSON(path string, reports []*Report) error {
+	content, err := json.MarshalIndent(reports, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(content, '\n'), 0o644)
+}
+
+// ReadReports читает отчёты, сохранённые WriteJSON
+func ReadReports(path string) ([]*Report, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var reports []*Report
+	if err := json.Unmarshal(content, &reports); err != nil {
+		return nil, fmt.Errorf("отчёт %s: %w", path, err)
+	}
+	return reports, nil
+}
+
+// WriteMarkdown выводит отчёты таблицами рядом друг с другом, чтобы сравнить
+// парсеры или версии промпта: сводка, метрики по полям и ошибки разбора
+func WriteMarkdown(w io.Writer, reports []*Report) error {
+	var b strings.Builder
+	b.WriteString("# Качество разбора резюме\n\n")
+
+	b.WriteString("| Прогон | Модель | Промпт | Документов | Ошибок | Precision | Recall | F1 |\n")
+	b.WriteString("|---|---|---|---:|---:|---:|---:|---:|\n")
+	for _, r := range reports {
+		fmt.Fprintf(&b, "| %s | %s | %s | %d | %d | %.3f | %.3f | %.3f |\n",
+			escape(r.Name), orDash(r.Model), orDash(r.PromptVersion), r.Documents, r.Failed,
+			r.Overall.Precision, r.Overall.Recall, r.Overall.F1)
+	}
+
+	b.WriteString("\n## Метрики по полям\n\n| Поле |")
+	for _, r := range reports {
+		fmt.Fprintf(&b, " %s P | %s R | %s F1 |", escape(r.Name), escape(r.Name), escape(r.Name))
+	}
+	b.WriteString("\n|---|")
+	b.WriteString(strings.Repeat("---:|---:|---:|", len(reports)))
+	b.WriteString("\n")
+	for _, field := range Fields {
+		fmt.Fprintf(&b, "| %s |", field)
+		for _, r := range reports {
+			score, ok := r.Fields[field]
+			if !ok || score.TP+score.FP+score.FN == 0 {
+				b.WriteString(" — | — | — |")
+				continue
+			}
+			fmt.Fprintf(&b, " %.3f | %.3f | %.3f |", score.Precision, score.Recall, score.F1)
+		}
+		b.WriteString("\n")
+	}
+
+	var failures []string
+	for _, r := range reports {
+		for _, doc := range r.Results {
+			if doc.Error != "" {
+				failures = append(failures, fmt.Sprintf("- %s / %s: %s", escape(r.Name), doc.Name, doc.Error))
+			}
+		}
+	}
+	if len(failures) > 0 {
+		b.WriteString("\n## Ошибки разбора\n\n")
+		b.WriteString(strings.Join(failures, "\n"))
+		b.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func escape(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "—"
+	}
+	return escape(s)
+}
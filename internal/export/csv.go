@@ -0,0 +1,45 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// ContentTypeCSV — MIME-тип таблицы CSV
+const ContentTypeCSV = "text/csv; charset=utf-8"
+
+// utf8BOM нужен Excel, чтобы открыть CSV с кириллицей в UTF-8, а не в системной кодировке
+const utf8BOM = "\ufeff"
+
+// WriteCSV записывает таблицу в CSV с теми же типами ячеек, что и WriteXLSX
+func WriteCSV(w io.Writer, rows [][]any) error {
+	if _, err := io.WriteString(w, utf8BOM); err != nil {
+		return err
+	}
+	cw := csv.NewWriter(w)
+	for _, row := range rows {
+		record := make([]string, len(row))
+		for i, value := range row {
+			switch v := value.(type) {
+			case nil:
+			case string:
+				record[i] = v
+			case bool:
+				record[i] = strconv.FormatBool(v)
+			case int:
+				record[i] = strconv.Itoa(v)
+			case float64:
+				record[i] = strconv.FormatFloat(v, 'f', -1, 64)
+			default:
+				return fmt.Errorf("unsupported cell type %T", value)
+			}
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
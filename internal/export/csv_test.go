@@ -0,0 +1,14 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteCSV(&buf, [][]any{{"Кандидат", "Go, Kafka"}, {"Иван", 72.5, nil, false, 3}}))
+	require.Equal(t, "\ufeffКандидат,\"Go, Kafka\"\nИван,72.5,,false,3\n", buf.String())
+}
@@ -0,0 +1,97 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+)
+
+// ContentTypeDOCX — MIME-тип документа Word
+const ContentTypeDOCX = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+
+const docxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>
+</Types>`
+
+const docxRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>
+</Relationships>`
+
+// WriteDOCX записывает текст документом Word: абзацы разделяются пустой строкой,
+// переносы строк внутри абзаца сохраняются. Оформление — стиль документа по умолчанию.
+func WriteDOCX(w io.Writer, text string) error {
+	return writeZip(w, []zipFile{
+		{"[Content_Types].xml", []byte(docxContentTypes)},
+		{"_rels/.rels", []byte(docxRels)},
+		{"word/document.xml", docxDocument(text)},
+	})
+}
+
+// zipFile — часть пакета Office Open XML
+type zipFile struct {
+	name    string
+	content []byte
+}
+
+func writeZip(w io.Writer, files []zipFile) error {
+	zw := zip.NewWriter(w)
+	for _, f := range files {
+		fw, err := zw.Create(f.name)
+		if err != nil {
+			return err
+		}
+		if _, err := fw.Write(f.content); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func docxDocument(text string) []byte {
+	var b bytes.Buffer
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	b.WriteString(`<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>`)
+	for _, paragraph := range Paragraphs(text) {
+		b.WriteString("<w:p><w:r>")
+		for i, line := range strings.Split(paragraph, "\n") {
+			if i > 0 {
+				b.WriteString("<w:br/>")
+			}
+			b.WriteString(`<w:t xml:space="preserve">`)
+			_ = xml.EscapeText(&b, []byte(line))
+			b.WriteString("</w:t>")
+		}
+		b.WriteString("</w:r></w:p>")
+	}
+	b.WriteString("</w:body></w:document>")
+	return b.Bytes()
+}
+
+// Paragraphs делит текст на абзацы по пустым строкам
+func Paragraphs(text string) []string {
+	text = strings.ReplaceAll(strings.ReplaceAll(text, "\r\n", "\n"), "\r", "\n")
+	var paragraphs []string
+	var current []string
+	flush := func() {
+		if len(current) > 0 {
+			paragraphs = append(paragraphs, strings.Join(current, "\n"))
+			current = nil
+		}
+	}
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimRight(line, " \t")
+		if strings.TrimSpace(line) == "" {
+			flush()
+			continue
+		}
+		current = append(current, line)
+	}
+	flush()
+	return paragraphs
+}
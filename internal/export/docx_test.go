@@ -0,0 +1,37 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteDOCX(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteDOCX(&buf, "Здравствуйте!\n\nОпыт <Go> & Kafka\nвторая строка\n\n\nС уважением,\nИван"))
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	names := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		names[f.Name] = string(content)
+	}
+	require.Contains(t, names, "[Content_Types].xml")
+	require.Contains(t, names, "_rels/.rels")
+	document := names["word/document.xml"]
+	require.Equal(t, 3, bytes.Count([]byte(document), []byte("<w:p>")))
+	require.Contains(t, document, "Опыт &lt;Go&gt; &amp; Kafka</w:t><w:br/>")
+}
+
+func TestParagraphs(t *testing.T) {
+	require.Equal(t, []string{"a\nb", "c"}, Paragraphs("a\r\nb  \n \n\nc\n"))
+	require.Nil(t, Paragraphs("  \n"))
+}
@@ -0,0 +1,130 @@
+package export
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ContentTypeICS — MIME-тип календаря iCalendar
+const ContentTypeICS = "text/calendar; charset=utf-8"
+
+const (
+	icsTimeLayout = "20060102T150405Z"
+	icsLineLimit  = 75 // максимальная длина строки в октетах по RFC 5545
+)
+
+// CalendarEvent — событие календаря. UID должен быть постоянным, а Sequence расти
+// при каждом изменении, иначе календарь не обновит уже импортированное событие.
+type CalendarEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+	Start       time.Time
+	End         time.Time
+	Updated     time.Time
+	Sequence    int
+	Cancelled   bool
+	Attendees   []CalendarAttendee
+}
+
+type CalendarAttendee struct {
+	Name  string
+	Email string
+}
+
+// WriteICS записывает события в календарь iCalendar (RFC 5545); name показывается
+// в календарных приложениях как название подписки
+func WriteICS(w io.Writer, name string, events []CalendarEvent) error {
+	var b strings.Builder
+	writeICSLine(&b, "BEGIN:VCALENDAR")
+	writeICSLine(&b, "VERSION:2.0")
+	writeICSLine(&b, "PRODID:-//CVMatch//Interviews//RU")
+	writeICSLine(&b, "CALSCALE:GREGORIAN")
+	writeICSLine(&b, "METHOD:PUBLISH")
+	if name != "" {
+		writeICSLine(&b, "X-WR-CALNAME:"+escapeICSText(name))
+	}
+	for _, event := range events {
+		writeICSLine(&b, "BEGIN:VEVENT")
+		writeICSLine(&b, "UID:"+event.UID)
+		writeICSLine(&b, "DTSTAMP:"+event.Updated.UTC().Format(icsTimeLayout))
+		writeICSLine(&b, "DTSTART:"+event.Start.UTC().Format(icsTimeLayout))
+		writeICSLine(&b, "DTEND:"+event.End.UTC().Format(icsTimeLayout))
+		writeICSLine(&b, "SEQUENCE:"+strconv.Itoa(event.Sequence))
+		writeICSLine(&b, "SUMMARY:"+escapeICSText(event.Summary))
+		if event.Description != "" {
+			writeICSLine(&b, "DESCRIPTION:"+escapeICSText(event.Description))
+		}
+		if event.Location != "" {
+			writeICSLine(&b, "LOCATION:"+escapeICSText(event.Location))
+		}
+		if event.URL != "" {
+			writeICSLine(&b, "URL:"+stripICSControl(event.URL))
+		}
+		for _, attendee := range event.Attendees {
+			line := "ATTENDEE;ROLE=REQ-PARTICIPANT"
+			if attendee.Name != "" {
+				line += `;CN="` + escapeICSParam(attendee.Name) + `"`
+			}
+			writeICSLine(&b, line+":mailto:"+attendee.Email)
+		}
+		if event.Cancelled {
+			writeICSLine(&b, "STATUS:CANCELLED")
+		} else {
+			writeICSLine(&b, "STATUS:CONFIRMED")
+		}
+		writeICSLine(&b, "END:VEVENT")
+	}
+	writeICSLine(&b, "END:VCALENDAR")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// escapeICSParam готовит значение параметра в кавычках: кавычки в нём недопустимы, а управляющие
+// символы заменяются пробелом, чтобы перевод строки в имени не добавил в событие чужие свойства
+func escapeICSParam(value string) string {
+	return stripICSControl(strings.ReplaceAll(value, `"`, "'"))
+}
+
+var icsTextReplacer = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// escapeICSText экранирует спецсимволы текстовых значений. Переводы строк становятся \n,
+// остальные управляющие символы, включая одиночный \r, — пробелом
+func escapeICSText(value string) string {
+	return stripICSControl(icsTextReplacer.Replace(value))
+}
+
+// stripICSControl заменяет управляющие символы пробелом: в содержимом строки календаря они
+// могут разорвать её и добавить в событие чужие свойства
+func stripICSControl(value string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return ' '
+		}
+		return r
+	}, value)
+}
+
+// writeICSLine пишет строку с CRLF, перенося её по 75 октетов без разрыва символов UTF-8
+func writeICSLine(b *strings.Builder, line string) {
+	limit := icsLineLimit
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// Продолжение начинается с пробела, он тоже занимает октет
+		limit = icsLineLimit - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
@@ -0,0 +1,84 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteICS(t *testing.T) {
+	start := time.Date(2026, 3, 10, 12, 0, 0, 0, time.FixedZone("MSK", 3*60*60))
+	var buf bytes.Buffer
+	require.NoError(t, WriteICS(&buf, "Интервью", []CalendarEvent{{
+		UID:         "42@cvmatch",
+		Summary:     "Интервью: Иван, Go Developer",
+		Description: "Техническое интервью\nвторой этап",
+		Location:    "Москва; переговорная 3",
+		URL:         "https://meet.example.com/abc",
+		Start:       start,
+		End:         start.Add(time.Hour),
+		Updated:     start,
+		Sequence:    2,
+		Attendees:   []CalendarAttendee{{Name: "Анна", Email: "anna@example.com"}},
+	}}))
+
+	ics := buf.String()
+	require.True(t, strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\n"))
+	require.True(t, strings.HasSuffix(ics, "END:VCALENDAR\r\n"))
+	require.Contains(t, ics, "DTSTART:20260310T090000Z\r\n")
+	require.Contains(t, ics, "DTEND:20260310T100000Z\r\n")
+	require.Contains(t, ics, "SEQUENCE:2\r\n")
+	require.Contains(t, ics, `SUMMARY:Интервью: Иван\, Go Developer`)
+	require.Contains(t, ics, `DESCRIPTION:Техническое интервью\nвторой этап`)
+	require.Contains(t, ics, `LOCATION:Москва\; переговорная 3`)
+	require.Contains(t, ics, `ATTENDEE;ROLE=REQ-PARTICIPANT;CN="Анна":mailto:anna@example.com`)
+	require.Contains(t, ics, "STATUS:CONFIRMED\r\n")
+}
+
+func TestWriteICS_FoldsLongLines(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteICS(&buf, "", []CalendarEvent{{UID: "1", Summary: strings.Repeat("Собеседование ", 20), Cancelled: true}}))
+
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n") {
+		require.LessOrEqual(t, len(line), icsLineLimit)
+		require.True(t, utf8.ValidString(line))
+	}
+	unfolded := strings.ReplaceAll(buf.String(), "\r\n ", "")
+	require.Contains(t, unfolded, "SUMMARY:"+strings.Repeat("Собеседование ", 20)+"\r\n")
+	require.Contains(t, unfolded, "STATUS:CANCELLED\r\n")
+}
+
+func TestWriteICS_SanitizesAttendeeName(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteICS(&buf, "", []CalendarEvent{{
+		UID:       "1",
+		Summary:   "Интервью",
+		Attendees: []CalendarAttendee{{Name: "Eve \"Boss\"\r\nATTENDEE:mailto:x@evil.com", Email: "eve@example.com"}},
+	}}))
+
+	ics := strings.ReplaceAll(buf.String(), "\r\n ", "")
+	require.Contains(t, ics, `ATTENDEE;ROLE=REQ-PARTICIPANT;CN="Eve 'Boss'  ATTENDEE:mailto:x@evil.com":mailto:eve@example.com`+"\r\n")
+	require.NotContains(t, ics, "\r\nATTENDEE:mailto:x@evil.com")
+}
+
+func TestWriteICS_StripsControlCharactersFromText(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteICS(&buf, "", []CalendarEvent{{
+		UID:         "1",
+		Summary:     "Интервью\rATTENDEE:mailto:x@evil.com",
+		Description: "первая строка\r\nвторая\x00строка",
+		Location:    "Москва\vофис",
+	}}))
+
+	ics := buf.String()
+	require.Contains(t, ics, "SUMMARY:Интервью ATTENDEE:mailto:x@evil.com\r\n")
+	require.Contains(t, ics, `DESCRIPTION:первая строка\nвторая строка`)
+	require.Contains(t, ics, "LOCATION:Москва офис\r\n")
+	for _, line := range strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+		require.NotContains(t, line, "\r")
+	}
+}
@@ -0,0 +1,47 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteXLSX(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteXLSX(&buf, "Матрица", [][]any{
+		{"Кандидат", "Go <backend>"},
+		{"Иван", 72.5, nil, true},
+	}))
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		files[f.Name] = string(content)
+	}
+	require.Contains(t, files, "xl/_rels/workbook.xml.rels")
+	require.Contains(t, files["xl/workbook.xml"], `<sheet name="Матрица"`)
+	sheet := files["xl/worksheets/sheet1.xml"]
+	require.Contains(t, sheet, `<c r="B1" t="inlineStr"><is><t xml:space="preserve">Go &lt;backend&gt;</t></is></c>`)
+	require.Contains(t, sheet, `<c r="B2"><v>72.5</v></c>`)
+	require.NotContains(t, sheet, `r="C2"`)
+	require.Contains(t, sheet, `<c r="D2" t="b"><v>1</v></c>`)
+
+	require.Error(t, WriteXLSX(io.Discard, "x", [][]any{{struct{}{}}}))
+}
+
+func TestColumnName(t *testing.T) {
+	require.Equal(t, "A", ColumnName(0))
+	require.Equal(t, "Z", ColumnName(25))
+	require.Equal(t, "AA", ColumnName(26))
+	require.Equal(t, "AZ", ColumnName(51))
+	require.Equal(t, "BA", ColumnName(52))
+}
@@ -0,0 +1,453 @@
+package handlers
+
+import (
+	"CVMatch/internal/response"
+	"CVMatch/internal/service"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AnnotationHandler struct {
+	service *service.AnnotationService
+}
+
+func NewAnnotationHandler(service *service.AnnotationService) *AnnotationHandler {
+	return &AnnotationHandler{
+		service: service,
+	}
+}
+
+type NoteRequest struct {
+	Text string `json:"text" binding:"required,max=5000"`
+}
+
+// RatingRequest — оценка от 1 до 5; null снимает оценку
+type RatingRequest struct {
+	Rating *int `json:"rating" binding:"omitempty,min=1,max=5"`
+}
+
+// ResumeTagsRequest — полный список меток резюме; пустой список снимает все метки
+type ResumeTagsRequest struct {
+	Tags []string `json:"tags"`
+}
+
+type BulkTagRequest struct {
+	ResumeIDs []string `json:"resume_ids" binding:"required,min=1,max=500,dive,uuid"`
+	Add       []string `json:"add"`
+	Remove    []string `json:"remove"`
+}
+
+// ListNotesHandler godoc
+// @Summary Заметки к резюме
+// @Description Заметки рекрутеров к резюме от старых к новым
+// @Security BearerAuth
+// @Tags resumes
+// @Produce json
+// @Param id path string true "ID резюме"
+// @Success 200 {object} response.NoteListDTO "Заметки"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Resume not found"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /resumes/{id}/notes [get]
+func (h *AnnotationHandler) ListNotesHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+	resumeUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid resume id"})
+		return
+	}
+
+	notes, err := h.service.ListNotes(userUUID, resumeUUID)
+	if err != nil {
+		if errors.Is(err, service.ErrResumeNotFound) {
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Resume not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error getting notes"})
+		return
+	}
+
+	c.JSON(http.StatusOK, notes)
+}
+
+// CreateNoteHandler godoc
+// @Summary Добавление заметки к резюме
+// @Description Добавляет заметку от имени текущего пользователя
+// @Security BearerAuth
+// @Tags resumes
+// @Accept json
+// @Produce json
+// @Param id path string true "ID резюме"
+// @Param note body NoteRequest true "Текст заметки"
+// @Success 201 {object} response.NoteDTO "Заметка"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Resume not found"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /resumes/{id}/notes [post]
+func (h *AnnotationHandler) CreateNoteHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+	resumeUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid resume id"})
+		return
+	}
+
+	var req NoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	note, err := h.service.AddNote(userUUID, resumeUUID, req.Text)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrEmptyNote):
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		case errors.Is(err, service.ErrResumeNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Resume not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error creating note"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, note)
+}
+
+// UpdateNoteHandler godoc
+// @Summary Изменение заметки
+// @Description Меняет текст заметки; доступно только автору
+// @Security BearerAuth
+// @Tags resumes
+// @Accept json
+// @Produce json
+// @Param id path string true "ID резюме"
+// @Param note_id path string true "ID заметки"
+// @Param note body NoteRequest true "Текст заметки"
+// @Success 200 {object} response.NoteDTO "Заметка"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Заметку может менять только автор"
+// @Failure 404 {object} response.ErrorResponse "Resume or note not found"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /resumes/{id}/notes/{note_id} [put]
+func (h *AnnotationHandler) UpdateNoteHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+	resumeUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid resume id"})
+		return
+	}
+	noteUUID, err := uuid.Parse(c.Param("note_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid note id"})
+		return
+	}
+
+	var req NoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	note, err := h.service.UpdateNote(userUUID, resumeUUID, noteUUID, req.Text)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrEmptyNote):
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		case errors.Is(err, service.ErrResumeNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Resume not found"})
+		case errors.Is(err, service.ErrNoteNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Note not found"})
+		case errors.Is(err, service.ErrNoteForbidden):
+			c.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only the author can change the note"})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error updating note"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, note)
+}
+
+// DeleteNoteHandler godoc
+// @Summary Удаление заметки
+// @Description Удаляет заметку; доступно только автору
+// @Security BearerAuth
+// @Tags resumes
+// @Produce json
+// @Param id path string true "ID резюме"
+// @Param note_id path string true "ID заметки"
+// @Success 200 {object} response.SuccessResponse "Заметка удалена"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Заметку может удалить только автор"
+// @Failure 404 {object} response.ErrorResponse "Resume or note not found"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /resumes/{id}/notes/{note_id} [delete]
+func (h *AnnotationHandler) DeleteNoteHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+	resumeUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid resume id"})
+		return
+	}
+	noteUUID, err := uuid.Parse(c.Param("note_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid note id"})
+		return
+	}
+
+	if err := h.service.DeleteNote(userUUID, resumeUUID, noteUUID); err != nil {
+		switch {
+		case errors.Is(err, service.ErrResumeNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Resume not found"})
+		case errors.Is(err, service.ErrNoteNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Note not found"})
+		case errors.Is(err, service.ErrNoteForbidden):
+			c.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only the author can change the note"})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error deleting note"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse{Message: "Note deleted successfully"})
+}
+
+// SetRatingHandler godoc
+// @Summary Оценка резюме
+// @Description Выставляет оценку резюме от 1 до 5; rating: null снимает оценку
+// @Security BearerAuth
+// @Tags resumes
+// @Accept json
+// @Produce json
+// @Param id path string true "ID резюме"
+// @Param rating body RatingRequest true "Оценка"
+// @Success 200 {object} response.ResumeTagsDTO "Метки и оценка резюме"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Resume not found"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /resumes/{id}/rating [put]
+func (h *AnnotationHandler) SetRatingHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+	resumeUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid resume id"})
+		return
+	}
+
+	var req RatingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	result, err := h.service.SetRating(userUUID, resumeUUID, req.Rating)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidRating):
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		case errors.Is(err, service.ErrResumeNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Resume not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error setting rating"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// SetResumeTagsHandler godoc
+// @Summary Метки резюме
+// @Description Заменяет метки резюме переданным списком. Метки приводятся к нижнему регистру, новые создаются
+// @Security BearerAuth
+// @Tags resumes
+// @Accept json
+// @Produce json
+// @Param id path string true "ID резюме"
+// @Param tags body ResumeTagsRequest true "Метки"
+// @Success 200 {object} response.ResumeTagsDTO "Метки и оценка резюме"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Resume not found"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /resumes/{id}/tags [put]
+func (h *AnnotationHandler) SetResumeTagsHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+	resumeUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid resume id"})
+		return
+	}
+
+	var req ResumeTagsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	result, err := h.service.SetTags(userUUID, resumeUUID, req.Tags)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidTag):
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		case errors.Is(err, service.ErrResumeNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Resume not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error setting tags"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// BulkTagHandler godoc
+// @Summary Массовая расстановка меток
+// @Description Добавляет и снимает метки у нескольких резюме разом. Если хотя бы одно резюме не найдено, ничего не меняется
+// @Security BearerAuth
+// @Tags resumes
+// @Accept json
+// @Produce json
+// @Param bulk body BulkTagRequest true "Резюме и метки"
+// @Success 200 {object} response.BulkTagResultDTO "Итог"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Resume not found"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /resumes/tags/bulk [post]
+func (h *AnnotationHandler) BulkTagHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+
+	var req BulkTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if len(req.Add) == 0 && len(req.Remove) == 0 {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Nothing to add or remove"})
+		return
+	}
+	resumeIDs := make([]uuid.UUID, 0, len(req.ResumeIDs))
+	for _, raw := range req.ResumeIDs {
+		resumeIDs = append(resumeIDs, uuid.MustParse(raw))
+	}
+
+	result, err := h.service.BulkTag(userUUID, resumeIDs, req.Add, req.Remove)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidTag):
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		case errors.Is(err, service.ErrResumeNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Resume not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error tagging resumes"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ListTagsHandler godoc
+// @Summary Метки пользователя
+// @Description Все метки пользователя по алфавиту с числом резюме
+// @Security BearerAuth
+// @Tags resumes
+// @Produce json
+// @Success 200 {object} response.TagListDTO "Метки"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /resumes/tags [get]
+func (h *AnnotationHandler) ListTagsHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+
+	tags, err := h.service.ListTags(userUUID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error getting tags"})
+		return
+	}
+
+	c.JSON(http.StatusOK, tags)
+}
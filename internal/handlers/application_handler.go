@@ -0,0 +1,393 @@
+package handlers
+
+import (
+	"CVMatch/internal/repository"
+	"CVMatch/internal/response"
+	"CVMatch/internal/service"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ApplicationHandler struct {
+	service *service.ApplicationService
+}
+
+func NewApplicationHandler(service *service.ApplicationService) *ApplicationHandler {
+	return &ApplicationHandler{
+		service: service,
+	}
+}
+
+type ApplicationCreateRequest struct {
+	ResumeID  string `json:"resume_id" binding:"required,uuid"`
+	VacancyID string `json:"vacancy_id" binding:"required,uuid"`
+}
+
+type ApplicationMoveRequest struct {
+	Stage  string `json:"stage" binding:"required"`
+	Reason string `json:"reason" binding:"max=2000"`
+}
+
+// PipelineStagesRequest — этапы отбора по порядку; пустой список возвращает этапы по умолчанию
+type PipelineStagesRequest struct {
+	Stages []string `json:"stages"`
+}
+
+// CreateApplicationHandler godoc
+// @Summary Добавление кандидата в отбор
+// @Description Добавляет резюме в отбор на вакансию. Кандидат попадает на первый этап вакансии, попадание записывается в историю переходов
+// @Security BearerAuth
+// @Tags applications
+// @Accept json
+// @Produce json
+// @Param application body ApplicationCreateRequest true "Резюме и вакансия"
+// @Success 201 {object} response.ApplicationDTO "Отклик"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Resume or vacancy not found"
+// @Failure 409 {object} response.ErrorResponse "Резюме уже в отборе на вакансию"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /applications [post]
+func (h *ApplicationHandler) CreateApplicationHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+
+	var req ApplicationCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	application, err := h.service.CreateApplication(userUUID, uuid.MustParse(req.ResumeID), uuid.MustParse(req.VacancyID))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrResumeNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Resume not found"})
+		case errors.Is(err, service.ErrVacancyNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Vacancy not found"})
+		case errors.Is(err, service.ErrApplicationExists):
+			c.JSON(http.StatusConflict, response.ErrorResponse{Error: "Resume already applied to the vacancy"})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error creating application"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, application)
+}
+
+// ListApplicationsHandler godoc
+// @Summary Список кандидатов в отборе
+// @Description Отклики пользователя, последние изменённые первыми
+// @Security BearerAuth
+// @Tags applications
+// @Produce json
+// @Param vacancy_id query string false "ID вакансии"
+// @Param resume_id query string false "ID резюме"
+// @Param stage query string false "Этап отбора"
+// @Success 200 {object} response.ApplicationListDTO "Список откликов"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /applications [get]
+func (h *ApplicationHandler) ListApplicationsHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+
+	filter, err := parseApplicationFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	applications, err := h.service.ListApplications(userUUID, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error getting application list"})
+		return
+	}
+
+	c.JSON(http.StatusOK, applications)
+}
+
+// GetApplicationHandler godoc
+// @Summary Получение отклика
+// @Description Отклик с историей переходов между этапами
+// @Security BearerAuth
+// @Tags applications
+// @Produce json
+// @Param id path string true "ID отклика"
+// @Success 200 {object} response.ApplicationDTO "Отклик"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Application not found"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /applications/{id} [get]
+func (h *ApplicationHandler) GetApplicationHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+	applicationUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid application id"})
+		return
+	}
+
+	application, err := h.service.GetApplication(userUUID, applicationUUID)
+	if err != nil {
+		if errors.Is(err, service.ErrApplicationNotFound) {
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Application not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error getting application"})
+		return
+	}
+
+	c.JSON(http.StatusOK, application)
+}
+
+// MoveApplicationHandler godoc
+// @Summary Перевод кандидата на другой этап
+// @Description Переводит кандидата на любой этап вакансии, в том числе назад. Переход и причина записываются в историю
+// @Security BearerAuth
+// @Tags applications
+// @Accept json
+// @Produce json
+// @Param id path string true "ID отклика"
+// @Param move body ApplicationMoveRequest true "Этап и причина перехода"
+// @Success 200 {object} response.ApplicationDTO "Отклик с историей переходов"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Application not found"
+// @Failure 409 {object} response.ErrorResponse "Кандидат уже на этом этапе"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /applications/{id}/move [post]
+func (h *ApplicationHandler) MoveApplicationHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+	applicationUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid application id"})
+		return
+	}
+
+	var req ApplicationMoveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	application, err := h.service.MoveApplication(userUUID, applicationUUID, req.Stage, req.Reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrApplicationNotFound), errors.Is(err, service.ErrVacancyNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Application not found"})
+		case errors.Is(err, service.ErrUnknownStage):
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: fmt.Sprintf("Stage %q is not configured for the vacancy", req.Stage)})
+		case errors.Is(err, service.ErrSameStage):
+			c.JSON(http.StatusConflict, response.ErrorResponse{Error: "Application is already at this stage"})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error moving application"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, application)
+}
+
+// DeleteApplicationHandler godoc
+// @Summary Удаление отклика
+// @Description Убирает кандидата из отбора на вакансию вместе с историей переходов
+// @Security BearerAuth
+// @Tags applications
+// @Produce json
+// @Param id path string true "ID отклика"
+// @Success 200 {object} response.SuccessResponse "Отклик удалён"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Application not found"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /applications/{id} [delete]
+func (h *ApplicationHandler) DeleteApplicationHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+	applicationUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid application id"})
+		return
+	}
+
+	if err := h.service.DeleteApplication(userUUID, applicationUUID); err != nil {
+		if errors.Is(err, service.ErrApplicationNotFound) {
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Application not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error deleting application"})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse{Message: "Application deleted successfully"})
+}
+
+// GetPipelineHandler godoc
+// @Summary Этапы отбора вакансии
+// @Description Этапы отбора по порядку с числом кандидатов на каждом
+// @Security BearerAuth
+// @Tags applications
+// @Produce json
+// @Param id path string true "ID вакансии"
+// @Success 200 {object} response.PipelineDTO "Этапы отбора"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Vacancy not found"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /vacancies/{id}/stages [get]
+func (h *ApplicationHandler) GetPipelineHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+	vacancyUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid vacancy id"})
+		return
+	}
+
+	pipeline, err := h.service.GetPipeline(userUUID, vacancyUUID)
+	if err != nil {
+		if errors.Is(err, service.ErrVacancyNotFound) {
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Vacancy not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error getting pipeline"})
+		return
+	}
+
+	c.JSON(http.StatusOK, pipeline)
+}
+
+// SetPipelineStagesHandler godoc
+// @Summary Настройка этапов отбора вакансии
+// @Description Задаёт этапы отбора по порядку (латиница, цифры и подчёркивания, до 32 символов). Новые кандидаты попадают на первый этап. Пустой список возвращает этапы по умолчанию: new, screening, interview, offer, hired, rejected. Этап, на котором есть кандидаты, убрать нельзя
+// @Security BearerAuth
+// @Tags applications
+// @Accept json
+// @Produce json
+// @Param id path string true "ID вакансии"
+// @Param stages body PipelineStagesRequest true "Этапы отбора"
+// @Success 200 {object} response.PipelineDTO "Этапы отбора"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Vacancy not found"
+// @Failure 409 {object} response.ErrorResponse "На убираемом этапе есть кандидаты"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /vacancies/{id}/stages [put]
+func (h *ApplicationHandler) SetPipelineStagesHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+	vacancyUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid vacancy id"})
+		return
+	}
+
+	var req PipelineStagesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	pipeline, err := h.service.SetPipelineStages(userUUID, vacancyUUID, req.Stages)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrVacancyNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Vacancy not found"})
+		case errors.Is(err, service.ErrInvalidStages):
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Stages must be unique names of latin letters, digits and underscores"})
+		case errors.Is(err, service.ErrStageInUse):
+			c.JSON(http.StatusConflict, response.ErrorResponse{Error: "A removed stage still has applications"})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error saving pipeline stages"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, pipeline)
+}
+
+// parseApplicationFilter собирает фильтр списка откликов из query-параметров
+func parseApplicationFilter(c *gin.Context) (repository.ApplicationFilter, error) {
+	var filter repository.ApplicationFilter
+	if raw := c.Query("vacancy_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return filter, errors.New("invalid vacancy_id")
+		}
+		filter.VacancyID = &id
+	}
+	if raw := c.Query("resume_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return filter, errors.New("invalid resume_id")
+		}
+		filter.ResumeID = &id
+	}
+	filter.Stage = c.Query("stage")
+	return filter, nil
+}
@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"CVMatch/internal/llm"
+	"CVMatch/internal/matching"
+	"CVMatch/internal/response"
+	"CVMatch/internal/service"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type CoverLetterHandler struct {
+	service *service.CoverLetterService
+}
+
+func NewCoverLetterHandler(service *service.CoverLetterService) *CoverLetterHandler {
+	return &CoverLetterHandler{
+		service: service,
+	}
+}
+
+type CoverLetterRequest struct {
+	Language string `json:"language" binding:"omitempty,oneof=ru en kk uz"`
+	Tone     string `json:"tone" binding:"omitempty,oneof=formal friendly enthusiastic"`
+	Length   string `json:"length" binding:"omitempty,oneof=short medium long"`
+}
+
+// CreateCoverLetterHandler godoc
+// @Summary Сопроводительное письмо к вакансии
+// @Description Пишет сопроводительное письмо по разобранному резюме, вакансии и результату сравнения и сохраняет его в историю писем. По умолчанию язык — язык резюме, тон — formal, длина — medium. Без LLM письмо собирается по шаблону
+// @Security BearerAuth
+// @Tags matches
+// @Accept json
+// @Produce json
+// @Param id path string true "ID результата сравнения"
+// @Param letter body CoverLetterRequest false "Язык, тон и длина письма"
+// @Success 201 {object} response.CoverLetterDTO "Сопроводительное письмо"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Match not found"
+// @Failure 429 {object} response.ErrorResponse "Исчерпана квота токенов LLM"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Failure 503 {object} response.ErrorResponse "LLM недоступна"
+// @Router /matches/{id}/cover-letter [post]
+func (h *CoverLetterHandler) CreateCoverLetterHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+	matchUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid match id"})
+		return
+	}
+
+	var req CoverLetterRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	letter, err := h.service.Generate(c.Request.Context(), userUUID, matchUUID, matching.LetterOptions{
+		Language: req.Language,
+		Tone:     req.Tone,
+		Length:   req.Length,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrMatchNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Match not found"})
+		case errors.Is(err, service.ErrResumeNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Resume not found"})
+		case errors.Is(err, service.ErrVacancyNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Vacancy not found"})
+		case errors.Is(err, llm.ErrQuotaExceeded):
+			c.JSON(http.StatusTooManyRequests, response.ErrorResponse{Error: "Monthly LLM token quota exceeded"})
+		case llm.IsUnavailable(err):
+			c.JSON(http.StatusServiceUnavailable, response.ErrorResponse{Error: "LLM provider is unavailable"})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error generating cover letter"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, letter)
+}
+
+// ListCoverLettersHandler godoc
+// @Summary История сопроводительных писем
+// @Description Все письма, написанные по результату сравнения, новые первыми
+// @Security BearerAuth
+// @Tags matches
+// @Produce json
+// @Param id path string true "ID результата сравнения"
+// @Success 200 {object} response.CoverLetterListDTO "История писем"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Match not found"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /matches/{id}/cover-letters [get]
+func (h *CoverLetterHandler) ListCoverLettersHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+	matchUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid match id"})
+		return
+	}
+
+	letters, err := h.service.List(userUUID, matchUUID)
+	if err != nil {
+		if errors.Is(err, service.ErrMatchNotFound) {
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Match not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error getting cover letters"})
+		return
+	}
+
+	c.JSON(http.StatusOK, letters)
+}
+
+// ExportCoverLetterHandler godoc
+// @Summary Выгрузка сопроводительного письма
+// @Description Отдаёт письмо файлом: простым текстом (txt) или документом Word (docx)
+// @Security BearerAuth
+// @Tags matches
+// @Produce plain
+// @Produce application/vnd.openxmlformats-officedocument.wordprocessingml.document
+// @Param id path string true "ID результата сравнения"
+// @Param letter_id path string true "ID письма"
+// @Param format query string false "Формат: txt (по умолчанию) или docx"
+// @Success 200 {file} file "Файл письма"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Cover letter not found"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /matches/{id}/cover-letters/{letter_id}/export [get]
+func (h *CoverLetterHandler) ExportCoverLetterHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+	matchUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid match id"})
+		return
+	}
+	letterUUID, err := uuid.Parse(c.Param("letter_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid cover letter id"})
+		return
+	}
+
+	file, err := h.service.Export(userUUID, matchUUID, letterUUID, c.DefaultQuery("format", service.FormatTXT))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrUnsupportedFormat):
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Unsupported format, use txt or docx"})
+		case errors.Is(err, service.ErrCoverLetterNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Cover letter not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error exporting cover letter"})
+		}
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="`+file.Name+`"`)
+	c.Data(http.StatusOK, file.ContentType, file.Content)
+}
@@ -0,0 +1,555 @@
+package handlers
+
+import (
+	"CVMatch/internal/models"
+	"CVMatch/internal/repository"
+	"CVMatch/internal/response"
+	"CVMatch/internal/service"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type InterviewHandler struct {
+	service *service.InterviewService
+}
+
+func NewInterviewHandler(service *service.InterviewService) *InterviewHandler {
+	return &InterviewHandler{
+		service: service,
+	}
+}
+
+// InterviewRequest — время, место и участники интервью. Участники, зарегистрированные в сервисе,
+// находятся по email и видят интервью в своём календаре.
+type InterviewRequest struct {
+	Title        string                             `json:"title" binding:"max=255"`
+	StartsAt     time.Time                          `json:"starts_at" binding:"required"`
+	EndsAt       time.Time                          `json:"ends_at" binding:"required"`
+	Location     string                             `json:"location" binding:"max=255"`
+	VideoURL     string                             `json:"video_url" binding:"omitempty,url,max=512"`
+	Description  string                             `json:"description" binding:"max=5000"`
+	Participants []response.InterviewParticipantDTO `json:"participants" binding:"max=50,dive"`
+}
+
+type InterviewCreateRequest struct {
+	ApplicationID string `json:"application_id" binding:"required,uuid"`
+	InterviewRequest
+}
+
+type ScorecardRequest struct {
+	Criterion string `json:"criterion" binding:"required,max=255"`
+	Score     int    `json:"score" binding:"required,min=1,max=5"`
+	Comment   string `json:"comment" binding:"max=2000"`
+}
+
+type FeedbackRequest struct {
+	Recommendation string             `json:"recommendation" binding:"required,oneof=strong_yes yes no strong_no"`
+	Scores         []ScorecardRequest `json:"scores" binding:"required,min=1,max=50,dive"`
+	Comment        string             `json:"comment" binding:"max=5000"`
+}
+
+func (r InterviewRequest) toInput() service.InterviewInput {
+	return service.InterviewInput{
+		Title:        r.Title,
+		StartsAt:     r.StartsAt,
+		EndsAt:       r.EndsAt,
+		Location:     r.Location,
+		VideoURL:     r.VideoURL,
+		Description:  r.Description,
+		Participants: r.Participants,
+	}
+}
+
+// ScheduleInterviewHandler godoc
+// @Summary Назначение интервью
+// @Description Назначает интервью кандидату на этапе интервью (interview или свой этап с interview в имени). Без title название собирается из имени кандидата и вакансии
+// @Security BearerAuth
+// @Tags interviews
+// @Accept json
+// @Produce json
+// @Param interview body InterviewCreateRequest true "Отклик, время, место и участники"
+// @Success 201 {object} response.InterviewDTO "Интервью"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Application not found"
+// @Failure 409 {object} response.ErrorResponse "Кандидат не на этапе интервью"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /interviews [post]
+func (h *InterviewHandler) ScheduleInterviewHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+
+	var req InterviewCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	interview, err := h.service.ScheduleInterview(userUUID, uuid.MustParse(req.ApplicationID), req.toInput())
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidSlot), errors.Is(err, service.ErrInvalidParticipant):
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		case errors.Is(err, service.ErrApplicationNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Application not found"})
+		case errors.Is(err, service.ErrResumeNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Resume not found"})
+		case errors.Is(err, service.ErrVacancyNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Vacancy not found"})
+		case errors.Is(err, service.ErrNotInterviewStage):
+			c.JSON(http.StatusConflict, response.ErrorResponse{Error: "Application is not at an interview stage"})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error scheduling interview"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, interview)
+}
+
+// ListInterviewsHandler godoc
+// @Summary Список интервью
+// @Description Интервью, которые пользователь назначил или в которых участвует, по времени начала
+// @Security BearerAuth
+// @Tags interviews
+// @Produce json
+// @Param application_id query string false "ID отклика"
+// @Param from query string false "Не раньше (RFC 3339)"
+// @Param to query string false "Раньше (RFC 3339)"
+// @Param status query string false "Статус (scheduled, cancelled)"
+// @Success 200 {object} response.InterviewListDTO "Список интервью"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /interviews [get]
+func (h *InterviewHandler) ListInterviewsHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+
+	filter, err := parseInterviewFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	interviews, err := h.service.ListInterviews(userUUID, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error getting interview list"})
+		return
+	}
+
+	c.JSON(http.StatusOK, interviews)
+}
+
+func parseInterviewFilter(c *gin.Context) (repository.InterviewFilter, error) {
+	var filter repository.InterviewFilter
+	if raw := c.Query("application_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return filter, fmt.Errorf("invalid application_id")
+		}
+		filter.ApplicationID = &id
+	}
+	var err error
+	if filter.From, err = parseTimeParam(c, "from"); err != nil {
+		return filter, err
+	}
+	if filter.To, err = parseTimeParam(c, "to"); err != nil {
+		return filter, err
+	}
+	filter.Status = c.Query("status")
+	if filter.Status != "" && filter.Status != models.InterviewScheduled && filter.Status != models.InterviewCancelled {
+		return filter, fmt.Errorf("invalid status: %s", filter.Status)
+	}
+	return filter, nil
+}
+
+// parseTimeParam читает время в формате RFC 3339 из query-параметра
+func parseTimeParam(c *gin.Context, key string) (*time.Time, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s", key)
+	}
+	return &t, nil
+}
+
+// GetInterviewHandler godoc
+// @Summary Получение интервью
+// @Description Интервью с участниками и отзывами; доступно организатору и участникам
+// @Security BearerAuth
+// @Tags interviews
+// @Produce json
+// @Param id path string true "ID интервью"
+// @Success 200 {object} response.InterviewDTO "Интервью"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Interview not found"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /interviews/{id} [get]
+func (h *InterviewHandler) GetInterviewHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+	interviewUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid interview id"})
+		return
+	}
+
+	interview, err := h.service.GetInterview(userUUID, interviewUUID)
+	if err != nil {
+		if errors.Is(err, service.ErrInterviewNotFound) {
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Interview not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error getting interview"})
+		return
+	}
+
+	c.JSON(http.StatusOK, interview)
+}
+
+// UpdateInterviewHandler godoc
+// @Summary Изменение интервью
+// @Description Переносит интервью, меняет место и участников; доступно только организатору
+// @Security BearerAuth
+// @Tags interviews
+// @Accept json
+// @Produce json
+// @Param id path string true "ID интервью"
+// @Param interview body InterviewRequest true "Время, место и участники"
+// @Success 200 {object} response.InterviewDTO "Интервью"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Менять интервью может только организатор"
+// @Failure 404 {object} response.ErrorResponse "Interview not found"
+// @Failure 409 {object} response.ErrorResponse "Интервью отменено"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /interviews/{id} [put]
+func (h *InterviewHandler) UpdateInterviewHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+	interviewUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid interview id"})
+		return
+	}
+
+	var req InterviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	interview, err := h.service.UpdateInterview(userUUID, interviewUUID, req.toInput())
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidSlot), errors.Is(err, service.ErrInvalidParticipant):
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		case errors.Is(err, service.ErrInterviewForbidden):
+			c.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only the organizer can change the interview"})
+		case errors.Is(err, service.ErrInterviewNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Interview not found"})
+		case errors.Is(err, service.ErrInterviewCancelled):
+			c.JSON(http.StatusConflict, response.ErrorResponse{Error: "Interview is cancelled"})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error updating interview"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, interview)
+}
+
+// CancelInterviewHandler godoc
+// @Summary Отмена интервью
+// @Description Отменяет интервью; в календарях подписчиков событие помечается отменённым. Доступно только организатору
+// @Security BearerAuth
+// @Tags interviews
+// @Produce json
+// @Param id path string true "ID интервью"
+// @Success 200 {object} response.InterviewDTO "Интервью"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Отменить интервью может только организатор"
+// @Failure 404 {object} response.ErrorResponse "Interview not found"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /interviews/{id}/cancel [post]
+func (h *InterviewHandler) CancelInterviewHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+	interviewUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid interview id"})
+		return
+	}
+
+	interview, err := h.service.CancelInterview(userUUID, interviewUUID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInterviewForbidden):
+			c.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only the organizer can change the interview"})
+		case errors.Is(err, service.ErrInterviewNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Interview not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error cancelling interview"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, interview)
+}
+
+// SubmitFeedbackHandler godoc
+// @Summary Отзыв по интервью
+// @Description Рекомендация и оценки кандидата по критериям от 1 до 5. Отзыв оставляют организатор и зарегистрированные участники; повторная отправка заменяет прежний отзыв
+// @Security BearerAuth
+// @Tags interviews
+// @Accept json
+// @Produce json
+// @Param id path string true "ID интервью"
+// @Param feedback body FeedbackRequest true "Отзыв"
+// @Success 200 {object} response.InterviewDTO "Интервью с отзывами"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Пользователь не участвует в интервью"
+// @Failure 404 {object} response.ErrorResponse "Interview not found"
+// @Failure 409 {object} response.ErrorResponse "Интервью отменено"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /interviews/{id}/feedback [post]
+func (h *InterviewHandler) SubmitFeedbackHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+	interviewUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid interview id"})
+		return
+	}
+
+	var req FeedbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	input := service.FeedbackInput{Recommendation: req.Recommendation, Comment: req.Comment}
+	for _, score := range req.Scores {
+		input.Scores = append(input.Scores, models.Scorecard{Criterion: score.Criterion, Score: score.Score, Comment: score.Comment})
+	}
+
+	interview, err := h.service.SubmitFeedback(userUUID, interviewUUID, input)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidFeedback):
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		case errors.Is(err, service.ErrNotInterviewer):
+			c.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only interview participants can leave feedback"})
+		case errors.Is(err, service.ErrInterviewNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Interview not found"})
+		case errors.Is(err, service.ErrInterviewCancelled):
+			c.JSON(http.StatusConflict, response.ErrorResponse{Error: "Interview is cancelled"})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error saving feedback"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, interview)
+}
+
+// ExportInterviewHandler godoc
+// @Summary Интервью в формате iCalendar
+// @Description Выгружает интервью файлом .ics для импорта в календарь
+// @Security BearerAuth
+// @Tags interviews
+// @Produce text/calendar
+// @Param id path string true "ID интервью"
+// @Success 200 {file} file "Файл .ics"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Interview not found"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /interviews/{id}/ics [get]
+func (h *InterviewHandler) ExportInterviewHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+	interviewUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid interview id"})
+		return
+	}
+
+	file, err := h.service.ExportInterview(userUUID, interviewUUID)
+	if err != nil {
+		if errors.Is(err, service.ErrInterviewNotFound) {
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Interview not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error exporting interview"})
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="`+file.Name+`"`)
+	c.Data(http.StatusOK, file.ContentType, file.Content)
+}
+
+// GetCalendarLinkHandler godoc
+// @Summary Ссылка на календарь интервью
+// @Description Ссылка для подписки на интервью пользователя из календарного приложения. Ссылка работает без авторизации, поэтому её стоит держать в секрете
+// @Security BearerAuth
+// @Tags interviews
+// @Produce json
+// @Success 200 {object} response.CalendarLinkDTO "Ссылка"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "User not found"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /interviews/calendar [get]
+func (h *InterviewHandler) GetCalendarLinkHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+
+	link, err := h.service.CalendarLink(userUUID)
+	if err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "User not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error getting calendar link"})
+		return
+	}
+
+	c.JSON(http.StatusOK, link)
+}
+
+// ResetCalendarLinkHandler godoc
+// @Summary Новая ссылка на календарь интервью
+// @Description Выдаёт новую ссылку на календарь; прежняя перестаёт работать
+// @Security BearerAuth
+// @Tags interviews
+// @Produce json
+// @Success 200 {object} response.CalendarLinkDTO "Ссылка"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "User not found"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /interviews/calendar/reset [post]
+func (h *InterviewHandler) ResetCalendarLinkHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+
+	link, err := h.service.ResetCalendarLink(userUUID)
+	if err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "User not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error resetting calendar link"})
+		return
+	}
+
+	c.JSON(http.StatusOK, link)
+}
+
+// CalendarFeedHandler godoc
+// @Summary Календарь интервью по ссылке
+// @Description Календарь iCalendar для подписки: предстоящие интервью пользователя и интервью за последние 90 дней. Авторизация — секрет в ссылке
+// @Tags interviews
+// @Produce text/calendar
+// @Param token path string true "Секрет ссылки (с расширением .ics)"
+// @Success 200 {file} file "Календарь .ics"
+// @Failure 404 {object} response.ErrorResponse "Calendar not found"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /calendar/{token} [get]
+func (h *InterviewHandler) CalendarFeedHandler(c *gin.Context) {
+	file, err := h.service.CalendarFeed(c.Param("token"))
+	if err != nil {
+		if errors.Is(err, service.ErrCalendarNotFound) {
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Calendar not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error getting calendar"})
+		return
+	}
+
+	c.Data(http.StatusOK, file.ContentType, file.Content)
+}
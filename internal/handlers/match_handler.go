@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"CVMatch/internal/llm"
+	"CVMatch/internal/response"
+	"CVMatch/internal/service"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type MatchHandler struct {
+	service *service.MatchService
+}
+
+func NewMatchHandler(service *service.MatchService) *MatchHandler {
+	return &MatchHandler{
+		service: service,
+	}
+}
+
+type MatchCreateRequest struct {
+	ResumeID  string `json:"resume_id" binding:"required,uuid"`
+	VacancyID string `json:"vacancy_id" binding:"required,uuid"`
+}
+
+// CreateMatchHandler godoc
+// @Summary Сравнение резюме с вакансией
+// @Description Считает оценку соответствия резюме вакансии и сохраняет результат с разбором по компонентам и подтверждениями из резюме
+// @Security BearerAuth
+// @Tags matches
+// @Accept json
+// @Produce json
+// @Param match body MatchCreateRequest true "Резюме и вакансия"
+// @Success 200 {object} response.MatchResultDTO "Результат сравнения"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Resume or vacancy not found"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /matches [post]
+func (h *MatchHandler) CreateMatchHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+
+	var req MatchCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	result, err := h.service.CreateMatch(userUUID, uuid.MustParse(req.ResumeID), uuid.MustParse(req.VacancyID))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrResumeNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Resume not found"})
+		case errors.Is(err, service.ErrVacancyNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Vacancy not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error matching resume"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetMatchHandler godoc
+// @Summary Получение результата сравнения
+// @Description Получение сохранённого результата сравнения резюме с вакансией
+// @Security BearerAuth
+// @Tags matches
+// @Produce json
+// @Param id path string true "ID результата сравнения"
+// @Success 200 {object} response.MatchResultDTO "Результат сравнения"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Match not found"
+// @Router /matches/{id} [get]
+func (h *MatchHandler) GetMatchHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+	matchUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid match id"})
+		return
+	}
+
+	result, err := h.service.GetMatchByID(userUUID, matchUUID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Match not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetRecommendationsHandler godoc
+// @Summary Советы по доработке резюме под вакансию
+// @Description Сохранённые советы по результату сравнения: недостающие ключевые слова, переписанные пункты опыта и разделы, которые стоит добавить. Советы составляются через POST /matches/{id}/recommendations
+// @Security BearerAuth
+// @Tags matches
+// @Produce json
+// @Param id path string true "ID результата сравнения"
+// @Success 200 {object} response.RecommendationsDTO "Советы по резюме"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Match or recommendations not found"
+// @Router /matches/{id}/recommendations [get]
+func (h *MatchHandler) GetRecommendationsHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+	matchUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid match id"})
+		return
+	}
+
+	recommendations, err := h.service.GetRecommendations(userUUID, matchUUID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrRecommendationsNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Recommendations are not generated yet"})
+		default:
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Match not found"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, recommendations)
+}
+
+// GenerateRecommendationsHandler godoc
+// @Summary Составление советов по доработке резюме под вакансию
+// @Description Составляет советы через LLM (без LLM — по шаблонам) и сохраняет их в результате сравнения, заменяя прежние
+// @Security BearerAuth
+// @Tags matches
+// @Produce json
+// @Param id path string true "ID результата сравнения"
+// @Success 200 {object} response.RecommendationsDTO "Советы по резюме"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Match not found"
+// @Failure 429 {object} response.ErrorResponse "Исчерпана квота токенов LLM"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Failure 503 {object} response.ErrorResponse "LLM недоступна"
+// @Router /matches/{id}/recommendations [post]
+func (h *MatchHandler) GenerateRecommendationsHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+	matchUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid match id"})
+		return
+	}
+
+	recommendations, err := h.service.GenerateRecommendations(c.Request.Context(), userUUID, matchUUID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrMatchNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Match not found"})
+		case errors.Is(err, service.ErrResumeNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Resume not found"})
+		case errors.Is(err, service.ErrVacancyNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Vacancy not found"})
+		case errors.Is(err, llm.ErrQuotaExceeded):
+			c.JSON(http.StatusTooManyRequests, response.ErrorResponse{Error: "Monthly LLM token quota exceeded"})
+		case llm.IsUnavailable(err):
+			c.JSON(http.StatusServiceUnavailable, response.ErrorResponse{Error: "LLM provider is unavailable"})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error generating recommendations"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, recommendations)
+}
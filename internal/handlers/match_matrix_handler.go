@@ -0,0 +1,256 @@
+package handlers
+
+import (
+	"CVMatch/internal/parser"
+	"CVMatch/internal/repository"
+	"CVMatch/internal/response"
+	"CVMatch/internal/service"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type MatrixHandler struct {
+	service *service.MatrixService
+}
+
+func NewMatrixHandler(service *service.MatrixService) *MatrixHandler {
+	return &MatrixHandler{
+		service: service,
+	}
+}
+
+// MatchMatrixRequest — резюме и вакансии для матрицы. Без ID и фильтров берутся все резюме
+// или все вакансии пользователя; фильтр сужает и список ID.
+type MatchMatrixRequest struct {
+	ResumeIDs     []string             `json:"resume_ids"`
+	VacancyIDs    []string             `json:"vacancy_ids"`
+	ResumeFilter  *MatrixResumeFilter  `json:"resume_filter"`
+	VacancyFilter *MatrixVacancyFilter `json:"vacancy_filter"`
+}
+
+// MatrixResumeFilter — те же условия, что у списка резюме
+type MatrixResumeFilter struct {
+	MinYears    *float64 `json:"min_years" binding:"omitempty,gte=0"`
+	MaxYears    *float64 `json:"max_years" binding:"omitempty,gte=0"`
+	TitleFamily string   `json:"title_family"`
+	Seniority   string   `json:"seniority"`
+	NeedsReview *bool    `json:"needs_review"`
+	Language    string   `json:"language"`
+	Query       string   `json:"q"`
+	Tags        []string `json:"tags"`
+	MinRating   *int     `json:"min_rating" binding:"omitempty,min=1,max=5"`
+}
+
+// MatrixVacancyFilter — те же условия, что у списка вакансий
+type MatrixVacancyFilter struct {
+	TitleFamily string `json:"title_family"`
+	Seniority   string `json:"seniority"`
+}
+
+// CreateMatrixHandler godoc
+// @Summary Матрица сравнений резюме и вакансий
+// @Description Запускает фоновый расчёт: каждое выбранное резюме сравнивается с каждой выбранной вакансией по профилю сравнения вакансии, результаты сохраняются. Резюме и вакансии задаются списками ID и/или фильтрами. Ход расчёта — в GET /matches/matrix/{id}
+// @Security BearerAuth
+// @Tags matches
+// @Accept json
+// @Produce json
+// @Param matrix body MatchMatrixRequest false "Резюме и вакансии"
+// @Success 202 {object} response.MatchJobDTO "Задача создана"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации или слишком много пар"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Resume or vacancy not found"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /matches/matrix [post]
+func (h *MatrixHandler) CreateMatrixHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+
+	var req MatchMatrixRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	selection, err := parseMatrixSelection(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	job, err := h.service.StartMatrix(userUUID, selection)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrResumeNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Resume not found"})
+		case errors.Is(err, service.ErrVacancyNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Vacancy not found"})
+		case errors.Is(err, service.ErrEmptyMatrix):
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "No resumes or vacancies match the selection"})
+		case errors.Is(err, service.ErrMatrixTooLarge):
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Too many resume and vacancy pairs"})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error creating match job"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusAccepted, job)
+}
+
+// GetMatrixJobHandler godoc
+// @Summary Ход расчёта матрицы сравнений
+// @Description Статус задачи (pending, running, done, failed) и число посчитанных и неудавшихся пар
+// @Security BearerAuth
+// @Tags matches
+// @Produce json
+// @Param id path string true "ID задачи"
+// @Success 200 {object} response.MatchJobDTO "Состояние задачи"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Match job not found"
+// @Router /matches/matrix/{id} [get]
+func (h *MatrixHandler) GetMatrixJobHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+	jobUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid match job id"})
+		return
+	}
+
+	job, err := h.service.GetJob(userUUID, jobUUID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Match job not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+// ExportMatrixHandler godoc
+// @Summary Выгрузка матрицы сравнений
+// @Description Отдаёт оценки завершённой задачи таблицей CSV или XLSX: строка — резюме, столбец — вакансия, пустая ячейка — пару не удалось посчитать
+// @Security BearerAuth
+// @Tags matches
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param id path string true "ID задачи"
+// @Param format query string false "Формат: csv (по умолчанию) или xlsx"
+// @Success 200 {file} file "Таблица оценок"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Match job not found"
+// @Failure 409 {object} response.ErrorResponse "Расчёт ещё не завершён"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /matches/matrix/{id}/export [get]
+func (h *MatrixHandler) ExportMatrixHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+	jobUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid match job id"})
+		return
+	}
+
+	file, err := h.service.Export(userUUID, jobUUID, c.DefaultQuery("format", service.FormatCSV))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrUnsupportedFormat):
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Unsupported format, use csv or xlsx"})
+		case errors.Is(err, service.ErrMatchJobNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Match job not found"})
+		case errors.Is(err, service.ErrJobNotFinished):
+			c.JSON(http.StatusConflict, response.ErrorResponse{Error: "Match job is not finished"})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error exporting match matrix"})
+		}
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="`+file.Name+`"`)
+	c.Data(http.StatusOK, file.ContentType, file.Content)
+}
+
+// parseMatrixSelection проверяет ID и фильтры запроса так же, как списки резюме и вакансий
+func parseMatrixSelection(req *MatchMatrixRequest) (service.MatrixSelection, error) {
+	var selection service.MatrixSelection
+	for _, raw := range req.ResumeIDs {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return selection, fmt.Errorf("invalid resume id: %s", raw)
+		}
+		selection.Resumes.IDs = append(selection.Resumes.IDs, id)
+	}
+	for _, raw := range req.VacancyIDs {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return selection, fmt.Errorf("invalid vacancy id: %s", raw)
+		}
+		selection.Vacancies.IDs = append(selection.Vacancies.IDs, id)
+	}
+
+	if f := req.ResumeFilter; f != nil {
+		if err := validateTitleFamilyAndSeniority(f.TitleFamily, f.Seniority); err != nil {
+			return selection, err
+		}
+		if f.Language != "" && !parser.IsSupportedLanguage(f.Language) {
+			return selection, fmt.Errorf("invalid language: %s", f.Language)
+		}
+		selection.Resumes.MinExperienceMonths = yearsToMonths(f.MinYears)
+		selection.Resumes.MaxExperienceMonths = yearsToMonths(f.MaxYears)
+		selection.Resumes.TitleFamily = f.TitleFamily
+		selection.Resumes.Seniority = f.Seniority
+		selection.Resumes.NeedsReview = f.NeedsReview
+		selection.Resumes.Language = f.Language
+		selection.Resumes.Query = f.Query
+		tags, err := service.NormalizeTags(f.Tags)
+		if err != nil {
+			return selection, err
+		}
+		selection.Resumes.Tags = tags
+		selection.Resumes.MinRating = f.MinRating
+	}
+	if f := req.VacancyFilter; f != nil {
+		if err := validateTitleFamilyAndSeniority(f.TitleFamily, f.Seniority); err != nil {
+			return selection, err
+		}
+		selection.Vacancies = repository.VacancyFilter{IDs: selection.Vacancies.IDs, TitleFamily: f.TitleFamily, Seniority: f.Seniority}
+	}
+	return selection, nil
+}
+
+func yearsToMonths(years *float64) *int {
+	if years == nil {
+		return nil
+	}
+	months := int(math.Round(*years * 12))
+	return &months
+}
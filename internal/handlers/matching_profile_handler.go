@@ -0,0 +1,337 @@
+package handlers
+
+import (
+	"CVMatch/internal/response"
+	"CVMatch/internal/service"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type MatchingProfileHandler struct {
+	service *service.MatchingProfileService
+}
+
+func NewMatchingProfileHandler(service *service.MatchingProfileService) *MatchingProfileHandler {
+	return &MatchingProfileHandler{
+		service: service,
+	}
+}
+
+// MatchingProfileRequest — настройки профиля сравнения. Без weights берутся веса встроенного профиля,
+// незаданные поля knockout — из его правил отсечения, нормализация по умолчанию — renormalize.
+type MatchingProfileRequest struct {
+	Name          string                        `json:"name" binding:"required"`
+	IsDefault     bool                          `json:"is_default"`
+	Weights       *response.ComponentWeightsDTO `json:"weights"`
+	Knockout      *KnockoutRulesRequest         `json:"knockout"`
+	Normalization string                        `json:"normalization" binding:"omitempty,oneof=renormalize neutral strict"`
+}
+
+// KnockoutRulesRequest — правила отсечения; каждое поле можно задать отдельно от остальных
+type KnockoutRulesRequest struct {
+	MissingRequired    *bool    `json:"missing_required"`
+	BelowMinExperience *bool    `json:"below_min_experience"`
+	Cap                *float64 `json:"cap" binding:"omitempty,gte=0,lte=100"`
+}
+
+type AttachMatchingProfileRequest struct {
+	ProfileID *string `json:"profile_id"` // null — профиль пользователя по умолчанию
+}
+
+// CreateProfileHandler godoc
+// @Summary Создание профиля сравнения
+// @Description Создаёт профиль сравнения: веса компонентов оценки, правила отсечения и способ учёта компонентов без данных (renormalize — перераспределить вес, neutral — считать 50 баллов, strict — считать 0). Профиль с is_default применяется ко всем вакансиям без своего профиля
+// @Security BearerAuth
+// @Tags matching-profiles
+// @Accept json
+// @Produce json
+// @Param profile body MatchingProfileRequest true "Настройки профиля"
+// @Success 201 {object} response.MatchingProfileDTO "Профиль сравнения"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /matching-profiles [post]
+func (h *MatchingProfileHandler) CreateProfileHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+
+	var req MatchingProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	profile, err := h.service.CreateProfile(userUUID, profileRequestToDTO(&req))
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidWeights) {
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "At least one component weight must be positive"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error creating matching profile"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, profile)
+}
+
+// ListProfilesHandler godoc
+// @Summary Список профилей сравнения
+// @Description Профили сравнения пользователя в порядке создания
+// @Security BearerAuth
+// @Tags matching-profiles
+// @Produce json
+// @Success 200 {object} response.MatchingProfileListDTO "Профили сравнения"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /matching-profiles [get]
+func (h *MatchingProfileHandler) ListProfilesHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+
+	profiles, err := h.service.ListProfiles(userUUID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error getting matching profiles"})
+		return
+	}
+
+	c.JSON(http.StatusOK, profiles)
+}
+
+// GetProfileHandler godoc
+// @Summary Профиль сравнения по ID
+// @Security BearerAuth
+// @Tags matching-profiles
+// @Produce json
+// @Param id path string true "ID профиля"
+// @Success 200 {object} response.MatchingProfileDTO "Профиль сравнения"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Matching profile not found"
+// @Router /matching-profiles/{id} [get]
+func (h *MatchingProfileHandler) GetProfileHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+	profileUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid matching profile id"})
+		return
+	}
+
+	profile, err := h.service.GetProfile(userUUID, profileUUID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Matching profile not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// UpdateProfileHandler godoc
+// @Summary Изменение профиля сравнения
+// @Description Заменяет настройки профиля целиком. Уже посчитанные результаты сравнения хранят профиль на момент расчёта и не меняются
+// @Security BearerAuth
+// @Tags matching-profiles
+// @Accept json
+// @Produce json
+// @Param id path string true "ID профиля"
+// @Param profile body MatchingProfileRequest true "Настройки профиля"
+// @Success 200 {object} response.MatchingProfileDTO "Профиль сравнения"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Matching profile not found"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /matching-profiles/{id} [put]
+func (h *MatchingProfileHandler) UpdateProfileHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+	profileUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid matching profile id"})
+		return
+	}
+
+	var req MatchingProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	profile, err := h.service.UpdateProfile(userUUID, profileUUID, profileRequestToDTO(&req))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrMatchingProfileNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Matching profile not found"})
+		case errors.Is(err, service.ErrInvalidWeights):
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "At least one component weight must be positive"})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error updating matching profile"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// DeleteProfileHandler godoc
+// @Summary Удаление профиля сравнения
+// @Description Удаляет профиль; вакансии с этим профилем сравниваются по профилю по умолчанию
+// @Security BearerAuth
+// @Tags matching-profiles
+// @Produce json
+// @Param id path string true "ID профиля"
+// @Success 200 {object} response.SuccessResponse "Профиль удалён"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Matching profile not found"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /matching-profiles/{id} [delete]
+func (h *MatchingProfileHandler) DeleteProfileHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+	profileUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid matching profile id"})
+		return
+	}
+
+	if err := h.service.DeleteProfile(userUUID, profileUUID); err != nil {
+		if errors.Is(err, service.ErrMatchingProfileNotFound) {
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Matching profile not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error deleting matching profile"})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse{Message: "Matching profile deleted successfully"})
+}
+
+// AttachToVacancyHandler godoc
+// @Summary Профиль сравнения вакансии
+// @Description Привязывает профиль сравнения к вакансии. profile_id = null отвязывает профиль, и вакансия сравнивается по профилю пользователя по умолчанию
+// @Security BearerAuth
+// @Tags vacancies
+// @Accept json
+// @Produce json
+// @Param id path string true "ID вакансии"
+// @Param profile body AttachMatchingProfileRequest true "ID профиля"
+// @Success 200 {object} response.SuccessResponse "Профиль привязан"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Vacancy or matching profile not found"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /vacancies/{id}/matching-profile [put]
+func (h *MatchingProfileHandler) AttachToVacancyHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+	vacancyUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid vacancy id"})
+		return
+	}
+
+	var req AttachMatchingProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	var profileUUID *uuid.UUID
+	if req.ProfileID != nil {
+		id, err := uuid.Parse(*req.ProfileID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid matching profile id"})
+			return
+		}
+		profileUUID = &id
+	}
+
+	if err := h.service.AttachToVacancy(userUUID, vacancyUUID, profileUUID); err != nil {
+		switch {
+		case errors.Is(err, service.ErrVacancyNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Vacancy not found"})
+		case errors.Is(err, service.ErrMatchingProfileNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Matching profile not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error attaching matching profile"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse{Message: "Matching profile attached successfully"})
+}
+
+// profileRequestToDTO дополняет запрос настройками встроенного профиля
+func profileRequestToDTO(req *MatchingProfileRequest) *response.MatchingProfileDTO {
+	dto := service.DefaultMatchingProfile()
+	dto.Name = req.Name
+	dto.IsDefault = req.IsDefault
+	if req.Weights != nil {
+		dto.Weights = *req.Weights
+	}
+	if req.Knockout != nil {
+		if req.Knockout.MissingRequired != nil {
+			dto.Knockout.MissingRequired = *req.Knockout.MissingRequired
+		}
+		if req.Knockout.BelowMinExperience != nil {
+			dto.Knockout.BelowMinExperience = *req.Knockout.BelowMinExperience
+		}
+		if req.Knockout.Cap != nil {
+			dto.Knockout.Cap = *req.Knockout.Cap
+		}
+	}
+	if req.Normalization != "" {
+		dto.Normalization = req.Normalization
+	}
+	return dto
+}
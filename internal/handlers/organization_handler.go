@@ -0,0 +1,388 @@
+package handlers
+
+import (
+	"CVMatch/internal/response"
+	"CVMatch/internal/service"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type OrganizationHandler struct {
+	service *service.OrganizationService
+}
+
+func NewOrganizationHandler(service *service.OrganizationService) *OrganizationHandler {
+	return &OrganizationHandler{
+		service: service,
+	}
+}
+
+type OrganizationRequest struct {
+	Name string `json:"name" binding:"required,max=255"`
+}
+
+// MemberRequest — приглашение зарегистрированного пользователя в организацию
+type MemberRequest struct {
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role" binding:"required"` // owner, recruiter, hiring_manager, viewer
+}
+
+type MemberRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+// CreateOrganizationHandler godoc
+// @Summary Создание организации
+// @Description Создаёт рабочее пространство команды, пользователь становится его владельцем. Чтобы работать в нём, переключитесь через /organizations/{id}/switch
+// @Security BearerAuth
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Param organization body OrganizationRequest true "Название"
+// @Success 201 {object} response.OrganizationDTO "Организация"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /organizations [post]
+func (h *OrganizationHandler) CreateOrganizationHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+
+	var req OrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	organization, err := h.service.CreateOrganization(userUUID, req.Name)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidOrganization):
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error creating organization"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, organization)
+}
+
+// ListOrganizationsHandler godoc
+// @Summary Список организаций
+// @Description Организации пользователя с его ролью; текущее рабочее пространство отмечено active
+// @Security BearerAuth
+// @Tags organizations
+// @Produce json
+// @Success 200 {object} response.OrganizationListDTO "Список организаций"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /organizations [get]
+func (h *OrganizationHandler) ListOrganizationsHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+
+	organizations, err := h.service.ListOrganizations(userUUID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error getting organization list"})
+		return
+	}
+
+	c.JSON(http.StatusOK, organizations)
+}
+
+// GetOrganizationHandler godoc
+// @Summary Получение организации
+// @Description Организация с участниками и их ролями; доступна любому участнику
+// @Security BearerAuth
+// @Tags organizations
+// @Produce json
+// @Param id path string true "ID организации"
+// @Success 200 {object} response.OrganizationDTO "Организация"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Organization not found"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /organizations/{id} [get]
+func (h *OrganizationHandler) GetOrganizationHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+	organizationUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid organization id"})
+		return
+	}
+
+	organization, err := h.service.GetOrganization(userUUID, organizationUUID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrOrganizationNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Organization not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error getting organization"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, organization)
+}
+
+// SwitchWorkspaceHandler godoc
+// @Summary Переключение рабочего пространства
+// @Description Делает организацию текущим рабочим пространством: резюме, вакансии, отбор и метки показываются и создаются в нём
+// @Security BearerAuth
+// @Tags organizations
+// @Produce json
+// @Param id path string true "ID организации"
+// @Success 200 {object} response.OrganizationDTO "Текущее рабочее пространство"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Organization not found"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /organizations/{id}/switch [post]
+func (h *OrganizationHandler) SwitchWorkspaceHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+	organizationUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid organization id"})
+		return
+	}
+
+	organization, err := h.service.SwitchWorkspace(userUUID, organizationUUID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrOrganizationNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Organization not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error switching workspace"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, organization)
+}
+
+// AddMemberHandler godoc
+// @Summary Добавление участника
+// @Description Добавляет зарегистрированного пользователя в организацию с ролью owner, recruiter, hiring_manager или viewer; доступно только владельцам
+// @Security BearerAuth
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Param id path string true "ID организации"
+// @Param member body MemberRequest true "Email и роль"
+// @Success 201 {object} response.OrganizationMemberDTO "Участник"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Только владелец управляет участниками"
+// @Failure 404 {object} response.ErrorResponse "Organization or user not found"
+// @Failure 409 {object} response.ErrorResponse "Пользователь уже участник"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /organizations/{id}/members [post]
+func (h *OrganizationHandler) AddMemberHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+	organizationUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid organization id"})
+		return
+	}
+
+	var req MemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	member, err := h.service.AddMember(userUUID, organizationUUID, req.Email, req.Role)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidOrgRole), errors.Is(err, service.ErrPersonalWorkspace):
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		case errors.Is(err, service.ErrOrganizationNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Organization not found"})
+		case errors.Is(err, service.ErrUserNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "User not found"})
+		case errors.Is(err, service.ErrOrganizationForbidden):
+			c.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only owners can manage members"})
+		case errors.Is(err, service.ErrMemberExists):
+			c.JSON(http.StatusConflict, response.ErrorResponse{Error: "User is already a member"})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error adding member"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, member)
+}
+
+// UpdateMemberRoleHandler godoc
+// @Summary Смена роли участника
+// @Description Меняет роль участника; доступно только владельцам. Последнего владельца понизить нельзя
+// @Security BearerAuth
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Param id path string true "ID организации"
+// @Param user_id path string true "ID пользователя"
+// @Param role body MemberRoleRequest true "Роль"
+// @Success 200 {object} response.SuccessResponse "Роль изменена"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Только владелец управляет участниками"
+// @Failure 404 {object} response.ErrorResponse "Organization or member not found"
+// @Failure 409 {object} response.ErrorResponse "В организации должен остаться владелец"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /organizations/{id}/members/{user_id} [put]
+func (h *OrganizationHandler) UpdateMemberRoleHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+	organizationUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid organization id"})
+		return
+	}
+	memberUUID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid member id"})
+		return
+	}
+
+	var req MemberRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if err := h.service.UpdateMemberRole(userUUID, organizationUUID, memberUUID, req.Role); err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidOrgRole):
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		case errors.Is(err, service.ErrOrganizationNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Organization not found"})
+		case errors.Is(err, service.ErrMemberNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Member not found"})
+		case errors.Is(err, service.ErrOrganizationForbidden):
+			c.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only owners can manage members"})
+		case errors.Is(err, service.ErrLastOwner):
+			c.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error updating member role"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse{Message: "Member role updated successfully"})
+}
+
+// RemoveMemberHandler godoc
+// @Summary Исключение участника
+// @Description Владелец исключает любого участника, остальные могут только выйти сами. Исключённый теряет доступ к данным организации сразу
+// @Security BearerAuth
+// @Tags organizations
+// @Produce json
+// @Param id path string true "ID организации"
+// @Param user_id path string true "ID пользователя"
+// @Success 200 {object} response.SuccessResponse "Участник исключён"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Только владелец управляет участниками"
+// @Failure 404 {object} response.ErrorResponse "Organization or member not found"
+// @Failure 409 {object} response.ErrorResponse "В организации должен остаться владелец"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /organizations/{id}/members/{user_id} [delete]
+func (h *OrganizationHandler) RemoveMemberHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+	organizationUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid organization id"})
+		return
+	}
+	memberUUID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid member id"})
+		return
+	}
+
+	if err := h.service.RemoveMember(userUUID, organizationUUID, memberUUID); err != nil {
+		switch {
+		case errors.Is(err, service.ErrPersonalWorkspace):
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		case errors.Is(err, service.ErrOrganizationNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Organization not found"})
+		case errors.Is(err, service.ErrMemberNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Member not found"})
+		case errors.Is(err, service.ErrOrganizationForbidden):
+			c.JSON(http.StatusForbidden, response.ErrorResponse{Error: "Only owners can manage members"})
+		case errors.Is(err, service.ErrLastOwner):
+			c.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error removing member"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse{Message: "Member removed successfully"})
+}
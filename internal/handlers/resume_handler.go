@@ -1,180 +1,455 @@
-package handlers
-
-import (
-	"CVMatch/internal/response"
-	"CVMatch/internal/service"
-	"net/http"
-	"os"
-
-	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
-)
-
-type ResumeHandler struct {
-	service *service.ResumeService
-}
-
-func NewResumeHandler(service *service.ResumeService) *ResumeHandler {
-	return &ResumeHandler{
-		service: service,
-	}
-}
-
-// UploadResumeHandler godoc
-// @Summary Загрузка резюме
-// @Description Загрузка резюме для пользователя
-// @Security BearerAuth
-// @Tags resumes
-// @Accept multipart/form-data
-// @Produce json
-// @Param file formData file true "Резюме"
-// @Success 200 {object} response.ParsedResumeDTO "Успешная загрузка резюме"
-// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
-// @Failure 401 {object} response.ErrorResponse "Unauthorized"
-// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
-// @Router /resumes/upload [post]
-func (h *ResumeHandler) UploadResumeHandler(c *gin.Context) {
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
-		return
-	}
-
-	file, err := c.FormFile("file")
-	if err != nil {
-		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
-		return
-	}
-
-	filename := "resume_" + uuid.New().String() + ".pdf"
-	path := "./uploads/" + filename
-	if err := c.SaveUploadedFile(file, path); err != nil {
-		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error saving file"})
-		return
-	}
-
-	// Парсим userID в uuid.UUID
-	userUUID, err := uuid.Parse(userID.(string))
-	if err != nil {
-		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
-		return
-	}
-
-	resume, err := h.service.CreateResumeWithUser(path, userUUID)
-	if err != nil {
-		os.Remove(path)
-		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error creating resume"})
-		return
-	}
-
-	c.JSON(http.StatusOK, resume)
-}
-
-// ListResumesHandler godoc
-// @Summary Получение списка резюме
-// @Description Получение списка резюме для пользователя
-// @Security BearerAuth
-// @Tags resumes
-// @Produce json
-// @Success 200 {object} response.ResumeListDTO "Успешное получение списка резюме"
-// @Failure 401 {object} response.ErrorResponse "Unauthorized"
-// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
-// @Router /resumes/list [get]
-func (h *ResumeHandler) ListResumesHandler(c *gin.Context) {
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
-		return
-	}
-
-	userUUID, err := uuid.Parse(userID.(string))
-	if err != nil {
-		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
-		return
-	}
-
-	resumes, err := h.service.GetListResume(userUUID)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error getting resume list"})
-		return
-	}
-
-	c.JSON(http.StatusOK, resumes)
-}
-
-// GetResumeHandler godoc
-// @Summary Получение резюме по ID
-// @Description Получение резюме по ID для пользователя
-// @Security BearerAuth
-// @Tags resumes
-// @Produce json
-// @Param id path string true "ID резюме"
-// @Success 200 {object} response.ParsedResumeDTO "Успешное получение резюме"
-// @Failure 401 {object} response.ErrorResponse "Unauthorized"
-// @Failure 404 {object} response.ErrorResponse "Resume not found"
-// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
-// @Router /resumes/{id} [get]
-func (h *ResumeHandler) GetResumeHandler(c *gin.Context) {
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
-		return
-	}
-
-	resumeID := c.Param("id")
-	resumeUUID, err := uuid.Parse(resumeID)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid resume id"})
-		return
-	}
-	userUUID, err := uuid.Parse(userID.(string))
-	if err != nil {
-		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
-		return
-	}
-
-	resume, err := h.service.GetResumeByID(userUUID, resumeUUID)
-	if err != nil {
-		c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Resume not found"})
-		return
-	}
-
-	c.JSON(http.StatusOK, resume)
-}
-
-// DeleteResumeHandler godoc
-// @Summary Удаление резюме по ID
-// @Description Удаление резюме по ID для пользователя
-// @Security BearerAuth
-// @Tags resumes
-// @Produce json
-// @Param id path string true "ID резюме"
-// @Success 200 {object} response.SuccessResponse "Успешное удаление резюме"
-// @Failure 401 {object} response.ErrorResponse "Unauthorized"
-// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
-// @Router /resumes/{id} [delete]
-func (h *ResumeHandler) DeleteResumeHandler(c *gin.Context) {
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
-		return
-	}
-	userUUID, err := uuid.Parse(userID.(string))
-	if err != nil {
-		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
-		return
-	}
-	resumeID := c.Param("id")
-	resumeUUID, err := uuid.Parse(resumeID)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid resume id"})
-		return
-	}
-
-	if err := h.service.DeleteResume(userUUID, resumeUUID); err != nil {
-		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error deleting resume"})
-		return
-	}
-
-	c.JSON(http.StatusOK, response.SuccessResponse{Message: "Resume deleted successfully"})
-}
+package handlers
+
+import (
+	"CVMatch/internal/llm"
+	"CVMatch/internal/parser"
+	"CVMatch/internal/repository"
+	"CVMatch/internal/response"
+	"CVMatch/internal/service"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ResumeHandler struct {
+	service *service.ResumeService
+}
+
+func NewResumeHandler(service *service.ResumeService) *ResumeHandler {
+	return &ResumeHandler{
+		service: service,
+	}
+}
+
+// ResumeUpdateRequest — исправленные данные резюме; заменяют разобранные целиком
+type ResumeUpdateRequest struct {
+	FullName   string                   `json:"full_name" binding:"required"`
+	Email      string                   `json:"email"`
+	Phone      string                   `json:"phone"`
+	Location   string                   `json:"location"`
+	Skills     []string                 `json:"skills"`
+	Experience []response.ExperienceDTO `json:"experience"`
+	Education  []response.EducationDTO  `json:"education"`
+
+	Languages      []response.LanguageDTO      `json:"languages"`
+	Certifications []response.CertificationDTO `json:"certifications"`
+	Projects       []response.ProjectDTO       `json:"projects"`
+	Links          []response.LinkDTO          `json:"links"`
+	Salary         *response.SalaryDTO         `json:"salary"`
+	Relocation     *bool                       `json:"relocation"`
+	Remote         string                      `json:"remote"`
+}
+
+// UploadResumeHandler godoc
+// @Summary Загрузка резюме
+// @Description Загрузка резюме для пользователя
+// @Security BearerAuth
+// @Tags resumes
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "Резюме"
+// @Param no_cache query bool false "Разобрать резюме заново, не используя кеш ответов LLM"
+// @Success 200 {object} response.ParsedResumeDTO "Успешная загрузка резюме"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /resumes/upload [post]
+func (h *ResumeHandler) UploadResumeHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	ctx := c.Request.Context()
+	if raw := c.Query("no_cache"); raw != "" {
+		noCache, err := strconv.ParseBool(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid no_cache"})
+			return
+		}
+		if noCache {
+			ctx = llm.WithCacheBypass(ctx)
+		}
+	}
+
+	filename := "resume_" + uuid.New().String() + ".pdf"
+	path := "./uploads/" + filename
+	if err := c.SaveUploadedFile(file, path); err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error saving file"})
+		return
+	}
+
+	// Парсим userID в uuid.UUID
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+
+	resume, err := h.service.CreateResumeWithUser(ctx, path, userUUID)
+	if err != nil {
+		os.Remove(path)
+		if errors.Is(err, llm.ErrQuotaExceeded) {
+			c.JSON(http.StatusTooManyRequests, response.ErrorResponse{Error: "Monthly LLM token quota exceeded"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error creating resume"})
+		return
+	}
+
+	c.JSON(http.StatusOK, resume)
+}
+
+// ListResumesHandler godoc
+// @Summary Получение списка резюме
+// @Description Получение списка резюме для пользователя
+// @Security BearerAuth
+// @Tags resumes
+// @Produce json
+// @Param min_years query number false "Минимальный стаж в годах"
+// @Param max_years query number false "Максимальный стаж в годах"
+// @Param title_family query string false "Семейство должности (backend, frontend, qa, data, pm...)"
+// @Param seniority query string false "Уровень (intern, junior, middle, senior, lead)"
+// @Param needs_review query bool false "Только резюме, требующие ручной проверки (или не требующие)"
+// @Param language query string false "Язык документа (ru, en, kk, uz)"
+// @Param q query string false "Поиск по тексту резюме"
+// @Param tags query string false "Метки через запятую, резюме должно иметь все"
+// @Param min_rating query int false "Минимальная оценка (1-5)"
+// @Success 200 {object} response.ResumeListDTO "Успешное получение списка резюме"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /resumes [get]
+// @Router /resumes/list [get]
+func (h *ResumeHandler) ListResumesHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+
+	filter, err := parseResumeFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	resumes, err := h.service.GetListResume(userUUID, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error getting resume list"})
+		return
+	}
+
+	c.JSON(http.StatusOK, resumes)
+}
+
+// parseResumeFilter собирает фильтр списка резюме из query-параметров
+func parseResumeFilter(c *gin.Context) (repository.ResumeFilter, error) {
+	var filter repository.ResumeFilter
+	var err error
+	if filter.MinExperienceMonths, err = parseYearsParam(c, "min_years"); err != nil {
+		return filter, err
+	}
+	if filter.MaxExperienceMonths, err = parseYearsParam(c, "max_years"); err != nil {
+		return filter, err
+	}
+	filter.TitleFamily = c.Query("title_family")
+	filter.Seniority = c.Query("seniority")
+	if err := validateTitleFamilyAndSeniority(filter.TitleFamily, filter.Seniority); err != nil {
+		return filter, err
+	}
+	if raw := c.Query("needs_review"); raw != "" {
+		needsReview, err := strconv.ParseBool(raw)
+		if err != nil {
+			return filter, fmt.Errorf("invalid needs_review")
+		}
+		filter.NeedsReview = &needsReview
+	}
+	filter.Language = c.Query("language")
+	if filter.Language != "" && !parser.IsSupportedLanguage(filter.Language) {
+		return filter, fmt.Errorf("invalid language: %s", filter.Language)
+	}
+	filter.Query = c.Query("q")
+	if raw := c.Query("tags"); raw != "" {
+		if filter.Tags, err = service.NormalizeTags(strings.Split(raw, ",")); err != nil {
+			return filter, err
+		}
+	}
+	if raw := c.Query("min_rating"); raw != "" {
+		minRating, err := strconv.Atoi(raw)
+		if err != nil || minRating < 1 || minRating > 5 {
+			return filter, fmt.Errorf("invalid min_rating")
+		}
+		filter.MinRating = &minRating
+	}
+	return filter, nil
+}
+
+// parseYearsParam переводит стаж в годах из query-параметра в месяцы
+func parseYearsParam(c *gin.Context, key string) (*int, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return nil, nil
+	}
+	years, err := strconv.ParseFloat(raw, 64)
+	if err != nil || years < 0 {
+		return nil, fmt.Errorf("invalid %s", key)
+	}
+	months := int(math.Round(years * 12))
+	return &months, nil
+}
+
+// GetResumeHandler godoc
+// @Summary Получение резюме по ID
+// @Description Получение резюме по ID для пользователя
+// @Security BearerAuth
+// @Tags resumes
+// @Produce json
+// @Param id path string true "ID резюме"
+// @Success 200 {object} response.ParsedResumeDTO "Успешное получение резюме"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Resume not found"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /resumes/{id} [get]
+func (h *ResumeHandler) GetResumeHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+
+	resumeID := c.Param("id")
+	resumeUUID, err := uuid.Parse(resumeID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid resume id"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+
+	resume, err := h.service.GetResumeByID(userUUID, resumeUUID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Resume not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, resume)
+}
+
+// GetResumeTextHandler godoc
+// @Summary Получение текста резюме
+// @Description Текст, извлечённый из файла резюме при загрузке, с числом страниц и языком
+// @Security BearerAuth
+// @Tags resumes
+// @Produce json
+// @Param id path string true "ID резюме"
+// @Success 200 {object} response.ResumeTextDTO "Текст резюме"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Resume not found"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /resumes/{id}/text [get]
+func (h *ResumeHandler) GetResumeTextHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+	resumeUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid resume id"})
+		return
+	}
+
+	text, err := h.service.GetResumeText(userUUID, resumeUUID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrResumeNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Resume not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error getting resume text"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, text)
+}
+
+// GetATSReportHandler godoc
+// @Summary Проверка резюме на удобство для ATS
+// @Description Оценка от 0 до 100 и замечания с советами: скан без текста, нет контактов или дат, слишком длинное резюме, колонки и таблицы, мешающие извлечению текста, плотность ключевых навыков. Проверяются только извлечённый текст и структура PDF
+// @Security BearerAuth
+// @Tags resumes
+// @Produce json
+// @Param id path string true "ID резюме"
+// @Success 200 {object} response.ATSReportDTO "Отчёт о проверке"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Resume not found"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /resumes/{id}/ats-report [get]
+func (h *ResumeHandler) GetATSReportHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+	resumeUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid resume id"})
+		return
+	}
+
+	report, err := h.service.GetATSReport(userUUID, resumeUUID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrResumeNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Resume not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error checking resume"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// UpdateResumeHandler godoc
+// @Summary Исправление резюме
+// @Description Заменяет разобранные данные резюме исправленными: контакты, навыки, опыт, образование и остальные разделы. Стаж, семейство должности и уровень пересчитываются. Версия резюме растёт, результаты сравнения с ним пересчитываются в фоне
+// @Security BearerAuth
+// @Tags resumes
+// @Accept json
+// @Produce json
+// @Param id path string true "ID резюме"
+// @Param resume body ResumeUpdateRequest true "Данные резюме"
+// @Success 200 {object} response.ParsedResumeDTO "Исправленное резюме"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Resume not found"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /resumes/{id} [put]
+func (h *ResumeHandler) UpdateResumeHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+	resumeUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid resume id"})
+		return
+	}
+
+	var req ResumeUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if req.Remote != "" && parser.NormalizeRemotePreference(req.Remote) != req.Remote {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: fmt.Sprintf("invalid remote: %s", req.Remote)})
+		return
+	}
+
+	resume, err := h.service.UpdateResume(userUUID, resumeUUID, &response.ParsedResumeDTO{
+		FullName:       req.FullName,
+		Email:          req.Email,
+		Phone:          req.Phone,
+		Location:       req.Location,
+		Skills:         req.Skills,
+		Experience:     req.Experience,
+		Education:      req.Education,
+		Languages:      req.Languages,
+		Certifications: req.Certifications,
+		Projects:       req.Projects,
+		Links:          req.Links,
+		Salary:         req.Salary,
+		Relocation:     req.Relocation,
+		Remote:         req.Remote,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrResumeNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Resume not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error updating resume"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, resume)
+}
+
+// DeleteResumeHandler godoc
+// @Summary Удаление резюме по ID
+// @Description Удаление резюме по ID для пользователя
+// @Security BearerAuth
+// @Tags resumes
+// @Produce json
+// @Param id path string true "ID резюме"
+// @Success 200 {object} response.SuccessResponse "Успешное удаление резюме"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /resumes/{id} [delete]
+func (h *ResumeHandler) DeleteResumeHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+	resumeID := c.Param("id")
+	resumeUUID, err := uuid.Parse(resumeID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid resume id"})
+		return
+	}
+
+	if err := h.service.DeleteResume(userUUID, resumeUUID); err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error deleting resume"})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse{Message: "Resume deleted successfully"})
+}
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"CVMatch/internal/response"
+	"CVMatch/internal/service"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const usageDateLayout = "2006-01-02"
+
+type UsageHandler struct {
+	service *service.UsageService
+}
+
+func NewUsageHandler(service *service.UsageService) *UsageHandler {
+	return &UsageHandler{
+		service: service,
+	}
+}
+
+type QuotaUpdateRequest struct {
+	// Месячная квота в токенах: 0 — без ограничений, null — квота по умолчанию
+	MonthlyTokenQuota *int64 `json:"monthly_token_quota" binding:"omitempty,min=0"`
+}
+
+// GetUsageHandler godoc
+// @Summary Расход токенов LLM
+// @Description Токены и стоимость вызовов LLM текущего пользователя за период с разбивкой по моделям, а также остаток месячной квоты
+// @Security BearerAuth
+// @Tags usage
+// @Produce json
+// @Param from query string false "Начало периода, YYYY-MM-DD (по умолчанию — начало текущего месяца)"
+// @Param to query string false "Конец периода включительно, YYYY-MM-DD (по умолчанию — сегодня)"
+// @Success 200 {object} response.UsageDTO "Расход токенов"
+// @Failure 400 {object} response.ErrorResponse "Некорректный период"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /usage [get]
+func (h *UsageHandler) GetUsageHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+	from, to, err := parseUsagePeriod(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	usage, err := h.service.GetUserUsage(userUUID, from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error getting usage"})
+		return
+	}
+	c.JSON(http.StatusOK, usage)
+}
+
+// GetUsageReportHandler godoc
+// @Summary Отчёт о расходе токенов LLM
+// @Description Токены и стоимость вызовов LLM по всем пользователям за период. Доступно только администраторам
+// @Security BearerAuth
+// @Tags admin
+// @Produce json
+// @Param from query string false "Начало периода, YYYY-MM-DD (по умолчанию — начало текущего месяца)"
+// @Param to query string false "Конец периода включительно, YYYY-MM-DD (по умолчанию — сегодня)"
+// @Success 200 {object} response.UsageReportDTO "Отчёт о расходе"
+// @Failure 400 {object} response.ErrorResponse "Некорректный период"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /admin/usage [get]
+func (h *UsageHandler) GetUsageReportHandler(c *gin.Context) {
+	from, to, err := parseUsagePeriod(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	report, err := h.service.GetUsageReport(from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error getting usage report"})
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// UpdateQuotaHandler godoc
+// @Summary Изменение квоты пользователя
+// @Description Задаёт месячную квоту токенов LLM пользователя. Доступно только администраторам
+// @Security BearerAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "ID пользователя"
+// @Param quota body QuotaUpdateRequest true "Квота"
+// @Success 204 "Квота обновлена"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} response.ErrorResponse "User not found"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /admin/users/{id}/quota [put]
+func (h *UsageHandler) UpdateQuotaHandler(c *gin.Context) {
+	userUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+	var req QuotaUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if err := h.service.SetUserQuota(userUUID, req.MonthlyTokenQuota); err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "User not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error updating quota"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// parseUsagePeriod разбирает период [from, to]; to включается целиком,
+// поэтому возвращается как начало следующего дня
+func parseUsagePeriod(c *gin.Context) (time.Time, time.Time, error) {
+	now := time.Now().UTC()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	if raw := c.Query("from"); raw != "" {
+		parsed, err := time.Parse(usageDateLayout, raw)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("invalid from, expected YYYY-MM-DD")
+		}
+		from = parsed
+	}
+	if raw := c.Query("to"); raw != "" {
+		parsed, err := time.Parse(usageDateLayout, raw)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("invalid to, expected YYYY-MM-DD")
+		}
+		to = parsed
+	}
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, errors.New("to must not be before from")
+	}
+	return from, to.AddDate(0, 0, 1), nil
+}
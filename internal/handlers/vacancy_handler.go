@@ -0,0 +1,419 @@
+package handlers
+
+import (
+	"CVMatch/internal/llm"
+	"CVMatch/internal/models"
+	"CVMatch/internal/parser"
+	"CVMatch/internal/repository"
+	"CVMatch/internal/response"
+	"CVMatch/internal/service"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type VacancyHandler struct {
+	service *service.VacancyService
+}
+
+func NewVacancyHandler(service *service.VacancyService) *VacancyHandler {
+	return &VacancyHandler{
+		service: service,
+	}
+}
+
+type VacancyCreateRequest struct {
+	Title       string   `json:"title" binding:"required"`
+	Description string   `json:"description"`
+	Location    string   `json:"location"`
+	TitleFamily string   `json:"title_family"`
+	Seniority   string   `json:"seniority"`
+	Skills      []string `json:"skills"`
+	// Обязательные и желательные навыки; skill_requirements задаёт также вес и минимальный стаж.
+	// Навыки из skills, не упомянутые в остальных списках, считаются желательными.
+	RequiredSkills    []string                       `json:"required_skills"`
+	PreferredSkills   []string                       `json:"preferred_skills"`
+	SkillRequirements []response.SkillRequirementDTO `json:"skill_requirements" binding:"omitempty,dive"`
+
+	MinExperienceYears *float64                 `json:"min_experience_years" binding:"omitempty,min=0"`
+	MaxExperienceYears *float64                 `json:"max_experience_years" binding:"omitempty,min=0"`
+	Salary             *response.SalaryRangeDTO `json:"salary"`
+	EmploymentType     string                   `json:"employment_type"`
+	Remote             string                   `json:"remote"`
+}
+
+type VacancyParseRequest struct {
+	Text string `json:"text" binding:"required"`
+}
+
+// CreateVacancyHandler godoc
+// @Summary Создание вакансии
+// @Description Создание вакансии. Семейство должности и уровень определяются по названию, если не переданы. Навыки делятся на обязательные (required_skills) и желательные (preferred_skills); в skill_requirements можно задать вес и минимальный стаж
+// @Security BearerAuth
+// @Tags vacancies
+// @Accept json
+// @Produce json
+// @Param vacancy body VacancyCreateRequest true "Параметры вакансии"
+// @Success 200 {object} response.VacancyDTO "Успешное создание вакансии"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /vacancies [post]
+func (h *VacancyHandler) CreateVacancyHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+
+	var req VacancyCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if err := validateTitleFamilyAndSeniority(req.TitleFamily, req.Seniority); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if err := validateVacancyConditions(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	vacancy, err := h.service.CreateVacancy(userUUID, vacancyRequestToDTO(&req))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error creating vacancy"})
+		return
+	}
+
+	c.JSON(http.StatusOK, vacancy)
+}
+
+// UpdateVacancyHandler godoc
+// @Summary Изменение вакансии
+// @Description Заменяет поля и навыки вакансии. Версия вакансии растёт, результаты сравнения с ней пересчитываются в фоне
+// @Security BearerAuth
+// @Tags vacancies
+// @Accept json
+// @Produce json
+// @Param id path string true "ID вакансии"
+// @Param vacancy body VacancyCreateRequest true "Параметры вакансии"
+// @Success 200 {object} response.VacancyDTO "Изменённая вакансия"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Vacancy not found"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /vacancies/{id} [put]
+func (h *VacancyHandler) UpdateVacancyHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+	vacancyUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid vacancy id"})
+		return
+	}
+
+	var req VacancyCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if err := validateTitleFamilyAndSeniority(req.TitleFamily, req.Seniority); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if err := validateVacancyConditions(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	vacancy, err := h.service.UpdateVacancy(userUUID, vacancyUUID, vacancyRequestToDTO(&req))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrVacancyNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Vacancy not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error updating vacancy"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, vacancy)
+}
+
+// ParseVacancyHandler godoc
+// @Summary Разбор текста вакансии
+// @Description Извлекает черновик вакансии из текста объявления (JSON с полем text или поле формы text) или из файла PDF/TXT (поле формы file). Черновик не сохраняется, его можно отправить в POST /vacancies
+// @Security BearerAuth
+// @Tags vacancies
+// @Accept json,multipart/form-data
+// @Produce json
+// @Param vacancy body VacancyParseRequest false "Текст объявления"
+// @Param file formData file false "Файл объявления (PDF или TXT)"
+// @Param text formData string false "Текст объявления"
+// @Success 200 {object} response.VacancyDraftDTO "Черновик вакансии"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 429 {object} response.ErrorResponse "Исчерпана квота токенов LLM"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Failure 503 {object} response.ErrorResponse "LLM недоступна"
+// @Router /vacancies/parse [post]
+func (h *VacancyHandler) ParseVacancyHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	var draft *response.VacancyDraftDTO
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		if file, fileErr := c.FormFile("file"); fileErr == nil {
+			ext := strings.ToLower(filepath.Ext(file.Filename))
+			if ext != ".pdf" && ext != ".txt" {
+				c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Only PDF and TXT files are supported"})
+				return
+			}
+			path := filepath.Join(os.TempDir(), "vacancy_"+uuid.New().String()+ext)
+			if err := c.SaveUploadedFile(file, path); err != nil {
+				c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error saving file"})
+				return
+			}
+			defer os.Remove(path)
+			draft, err = h.service.ParseVacancyFile(ctx, userUUID, path)
+		} else {
+			draft, err = h.service.ParseVacancyText(ctx, userUUID, c.PostForm("text"))
+		}
+	} else {
+		var req VacancyParseRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+			return
+		}
+		draft, err = h.service.ParseVacancyText(ctx, userUUID, req.Text)
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrEmptyDocument):
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "No vacancy text found"})
+		case errors.Is(err, service.ErrVacancyTextTooLong):
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Vacancy text is too long"})
+		case errors.Is(err, parser.ErrUnsupportedDocument):
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Unsupported document"})
+		case errors.Is(err, llm.ErrQuotaExceeded):
+			c.JSON(http.StatusTooManyRequests, response.ErrorResponse{Error: "Monthly LLM token quota exceeded"})
+		case llm.IsUnavailable(err):
+			c.JSON(http.StatusServiceUnavailable, response.ErrorResponse{Error: "LLM provider is unavailable"})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error parsing vacancy"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, draft)
+}
+
+// ListVacanciesHandler godoc
+// @Summary Получение списка вакансий
+// @Description Получение списка вакансий пользователя
+// @Security BearerAuth
+// @Tags vacancies
+// @Produce json
+// @Param title_family query string false "Семейство должности (backend, frontend, qa, data, pm...)"
+// @Param seniority query string false "Уровень (intern, junior, middle, senior, lead)"
+// @Success 200 {object} response.VacancyListDTO "Успешное получение списка вакансий"
+// @Failure 400 {object} response.ErrorResponse "Ошибка валидации"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /vacancies/list [get]
+func (h *VacancyHandler) ListVacanciesHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+
+	filter := repository.VacancyFilter{
+		TitleFamily: c.Query("title_family"),
+		Seniority:   c.Query("seniority"),
+	}
+	if err := validateTitleFamilyAndSeniority(filter.TitleFamily, filter.Seniority); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	vacancies, err := h.service.GetListVacancy(userUUID, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error getting vacancy list"})
+		return
+	}
+
+	c.JSON(http.StatusOK, vacancies)
+}
+
+// GetVacancyHandler godoc
+// @Summary Получение вакансии по ID
+// @Description Получение вакансии по ID для пользователя
+// @Security BearerAuth
+// @Tags vacancies
+// @Produce json
+// @Param id path string true "ID вакансии"
+// @Success 200 {object} response.VacancyDTO "Успешное получение вакансии"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Vacancy not found"
+// @Router /vacancies/{id} [get]
+func (h *VacancyHandler) GetVacancyHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+	vacancyUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid vacancy id"})
+		return
+	}
+
+	vacancy, err := h.service.GetVacancyByID(userUUID, vacancyUUID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Vacancy not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, vacancy)
+}
+
+// DeleteVacancyHandler godoc
+// @Summary Удаление вакансии по ID
+// @Description Удаление вакансии по ID вместе с результатами сравнения
+// @Security BearerAuth
+// @Tags vacancies
+// @Produce json
+// @Param id path string true "ID вакансии"
+// @Success 200 {object} response.SuccessResponse "Успешное удаление вакансии"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Vacancy not found"
+// @Failure 500 {object} response.ErrorResponse "Ошибка сервера"
+// @Router /vacancies/{id} [delete]
+func (h *VacancyHandler) DeleteVacancyHandler(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid user id"})
+		return
+	}
+	vacancyUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "Invalid vacancy id"})
+		return
+	}
+
+	if err := h.service.DeleteVacancy(userUUID, vacancyUUID); err != nil {
+		switch {
+		case errors.Is(err, service.ErrVacancyNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "Vacancy not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: "Error deleting vacancy"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse{Message: "Vacancy deleted successfully"})
+}
+
+// validateVacancyConditions проверяет условия вакансии: тип занятости, формат работы
+// и согласованность диапазонов опыта и зарплаты. Незаданные условия допустимы.
+func validateVacancyConditions(req *VacancyCreateRequest) error {
+	if req.EmploymentType != "" && !parser.IsValidEmploymentType(req.EmploymentType) {
+		return fmt.Errorf("invalid employment_type: %s", req.EmploymentType)
+	}
+	if req.Remote != "" && parser.NormalizeRemotePreference(req.Remote) != req.Remote {
+		return fmt.Errorf("invalid remote: %s", req.Remote)
+	}
+	if req.MinExperienceYears != nil && req.MaxExperienceYears != nil && *req.MaxExperienceYears < *req.MinExperienceYears {
+		return errors.New("max_experience_years must not be less than min_experience_years")
+	}
+	if req.Salary != nil && req.Salary.From != nil && req.Salary.To != nil && *req.Salary.To < *req.Salary.From {
+		return errors.New("salary.to must not be less than salary.from")
+	}
+	return nil
+}
+
+func vacancyRequestToDTO(req *VacancyCreateRequest) *response.VacancyDTO {
+	return &response.VacancyDTO{
+		Title:              req.Title,
+		Description:        req.Description,
+		Location:           req.Location,
+		TitleFamily:        req.TitleFamily,
+		Seniority:          req.Seniority,
+		Skills:             req.Skills,
+		SkillRequirements:  collectSkillRequirements(req),
+		MinExperienceYears: req.MinExperienceYears,
+		MaxExperienceYears: req.MaxExperienceYears,
+		Salary:             req.Salary,
+		EmploymentType:     req.EmploymentType,
+		Remote:             req.Remote,
+	}
+}
+
+// collectSkillRequirements сводит skill_requirements, required_skills и preferred_skills в один список;
+// повторы убирает сервис, оставляя первое упоминание
+func collectSkillRequirements(req *VacancyCreateRequest) []response.SkillRequirementDTO {
+	result := append([]response.SkillRequirementDTO{}, req.SkillRequirements...)
+	for _, name := range req.RequiredSkills {
+		result = append(result, response.SkillRequirementDTO{Name: name, Requirement: models.SkillRequired})
+	}
+	for _, name := range req.PreferredSkills {
+		result = append(result, response.SkillRequirementDTO{Name: name, Requirement: models.SkillPreferred})
+	}
+	return result
+}
+
+// validateTitleFamilyAndSeniority проверяет значения фильтров и полей вакансии, пустые значения допустимы
+func validateTitleFamilyAndSeniority(family, seniority string) error {
+	if family != "" && !parser.IsValidTitleFamily(family) {
+		return fmt.Errorf("invalid title_family: %s", family)
+	}
+	if seniority != "" && !parser.IsValidSeniority(seniority) {
+		return fmt.Errorf("invalid seniority: %s", seniority)
+	}
+	return nil
+}
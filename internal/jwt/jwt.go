@@ -1,67 +1,67 @@
-package jwt
-
-import (
-	"CVMatch/internal/config"
-	"errors"
-	"time"
-
-	"github.com/golang-jwt/jwt/v5"
-)
-
-type Claims struct {
-	UserID string `json:"user_id"`
-	Type   string `json:"type"`
-	jwt.RegisteredClaims
-}
-
-func GenerateAccessToken(userID string, cfg *config.JWTConfig) (string, error) {
-	claims := Claims{
-		UserID: userID,
-		Type:   "access",
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(cfg.AccessExp)),
-		},
-	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(cfg.Access))
-}
-
-func GenerateRefreshToken(userID string, cfg *config.JWTConfig) (string, error) {
-	claims := Claims{
-		UserID: userID,
-		Type:   "refresh",
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(cfg.RefreshExp)),
-		},
-	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(cfg.Refresh))
-}
-
-func ParseAccessToken(tokenStr string, secret string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenStr, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		return []byte(secret), nil
-	})
-	if err != nil {
-		return nil, err
-	}
-	claims, ok := token.Claims.(*Claims)
-	if !ok || !token.Valid || claims.Type != "access" {
-		return nil, errors.New("invalid access token")
-	}
-	return claims, nil
-}
-
-func ParseRefreshToken(tokenStr string, secret string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenStr, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		return []byte(secret), nil
-	})
-	if err != nil {
-		return nil, err
-	}
-	claims, ok := token.Claims.(*Claims)
-	if !ok || !token.Valid || claims.Type != "refresh" {
-		return nil, errors.New("invalid refresh token")
-	}
-	return claims, nil
-}
+package jwt
+
+import (
+	"CVMatch/internal/config"
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type Claims struct {
+	UserID string `json:"user_id"`
+	Type   string `json:"type"`
+	jwt.RegisteredClaims
+}
+
+func GenerateAccessToken(userID string, cfg *config.JWTConfig) (string, error) {
+	claims := Claims{
+		UserID: userID,
+		Type:   "access",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(cfg.AccessExp)),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(cfg.Access))
+}
+
+func GenerateRefreshToken(userID string, cfg *config.JWTConfig) (string, error) {
+	claims := Claims{
+		UserID: userID,
+		Type:   "refresh",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(cfg.RefreshExp)),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(cfg.Refresh))
+}
+
+func ParseAccessToken(tokenStr string, secret string) (*Claims, error) {
+	token, err := jwt.ParseWithClaims(tokenStr, &Claims{}, func(token *jwt.Token) (interface{}, error) {
+		return []byte(secret), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	claims, ok := token.Claims.(*Claims)
+	if !ok || !token.Valid || claims.Type != "access" {
+		return nil, errors.New("invalid access token")
+	}
+	return claims, nil
+}
+
+func ParseRefreshToken(tokenStr string, secret string) (*Claims, error) {
+	token, err := jwt.ParseWithClaims(tokenStr, &Claims{}, func(token *jwt.Token) (interface{}, error) {
+		return []byte(secret), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	claims, ok := token.Claims.(*Claims)
+	if !ok || !token.Valid || claims.Type != "refresh" {
+		return nil, errors.New("invalid refresh token")
+	}
+	return claims, nil
+}
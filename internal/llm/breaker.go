@@ -0,0 +1,124 @@
+package llm
+
+import (
+	"CVMatch/internal/metrics"
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	breakerOpened   = metrics.NewCounter("llm_breaker_opened_total", "Сколько раз провайдер LLM был признан недоступным")
+	breakerRejected = metrics.NewCounter("llm_breaker_rejected_total", "Запросы к LLM, отклонённые без обращения к провайдеру")
+)
+
+// Состояния предохранителя
+const (
+	BreakerClosed   = "closed"
+	BreakerOpen     = "open"
+	BreakerHalfOpen = "half_open"
+)
+
+// BreakerPolicy — после FailureThreshold неудач подряд запросы не отправляются в течение Cooldown,
+// затем пропускается один пробный запрос
+type BreakerPolicy struct {
+	FailureThreshold int
+	Cooldown         time.Duration
+}
+
+// CircuitBreaker защищает от обращений к недоступному провайдеру. Неудачей считаются только
+// временные ошибки (IsRetryable): некорректный запрос или отмена клиентом не говорят о здоровье провайдера.
+type CircuitBreaker struct {
+	next   Client
+	policy BreakerPolicy
+	now    func() time.Time
+
+	mu       sync.Mutex
+	state    string
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func NewCircuitBreaker(next Client, policy BreakerPolicy) *CircuitBreaker {
+	if policy.FailureThreshold < 1 {
+		policy.FailureThreshold = 1
+	}
+	b := &CircuitBreaker{
+		next:   next,
+		policy: policy,
+		now:    time.Now,
+		state:  BreakerClosed,
+	}
+	metrics.NewGaugeFunc("llm_breaker_open", "1, если провайдер LLM признан недоступным", func() float64 {
+		if b.State() == BreakerOpen {
+			return 1
+		}
+		return 0
+	})
+	return b
+}
+
+func (b *CircuitBreaker) Complete(ctx context.Context, req Request) (*Response, error) {
+	if !b.allow() {
+		breakerRejected.Inc()
+		return nil, ErrCircuitOpen
+	}
+	resp, err := b.next.Complete(ctx, req)
+	b.record(err)
+	return resp, err
+}
+
+func (b *CircuitBreaker) State() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+func (b *CircuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case BreakerOpen:
+		if b.now().Sub(b.openedAt) < b.policy.Cooldown {
+			return false
+		}
+		b.state = BreakerHalfOpen
+		b.probing = true
+		return true
+	case BreakerHalfOpen:
+		// Пока пробный запрос не завершился, остальные отклоняются
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+func (b *CircuitBreaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+	// Отмена ничего не говорит о провайдере: состояние и счётчик неудач не меняются,
+	// а следующий запрос станет пробным
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	if err == nil || !IsRetryable(err) {
+		b.state = BreakerClosed
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.state == BreakerHalfOpen || b.failures >= b.policy.FailureThreshold {
+		if b.state != BreakerOpen {
+			breakerOpened.Inc()
+		}
+		b.state = BreakerOpen
+		b.openedAt = b.now()
+	}
+}
@@ -0,0 +1,111 @@
+package llm
+
+import (
+	"CVMatch/internal/metrics"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Бэкенды кеша ответов LLM
+const (
+	CacheBackendMemory   = "memory"
+	CacheBackendPostgres = "postgres"
+	CacheBackendOff      = "off"
+)
+
+var (
+	cacheHits   = metrics.NewCounter("llm_cache_hits_total", "Ответы LLM, взятые из кеша")
+	cacheMisses = metrics.NewCounter("llm_cache_misses_total", "Запросы к LLM, которых не было в кеше")
+	cacheBypass = metrics.NewCounter("llm_cache_bypass_total", "Запросы к LLM в обход кеша")
+	cacheErrors = metrics.NewCounter("llm_cache_errors_total", "Ошибки чтения и записи кеша LLM")
+)
+
+// Cache хранит ответы LLM по ключу CacheKey
+type Cache interface {
+	Get(ctx context.Context, key string) (*Response, bool, error)
+	Set(ctx context.Context, key string, resp *Response, ttl time.Duration) error
+}
+
+// CacheKey — хеш текста запроса вместе с версией промпта, моделью и параметрами генерации.
+// Одинаковый документ, разобранный тем же промптом и моделью, даёт тот же ключ.
+func CacheKey(req Request) string {
+	h := sha256.New()
+	for _, part := range []string{
+		req.Model,
+		req.PromptVersion,
+		strconv.FormatFloat(req.Temperature, 'f', -1, 64),
+		strconv.Itoa(req.MaxTokens),
+		req.System,
+		req.Prompt,
+	} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+type cacheBypassKey struct{}
+
+// WithCacheBypass помечает контекст: ответ нужно получить от модели заново, минуя кеш.
+// Свежий ответ всё равно сохраняется в кеш.
+func WithCacheBypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheBypassKey{}, true)
+}
+
+func CacheBypassed(ctx context.Context) bool {
+	bypass, _ := ctx.Value(cacheBypassKey{}).(bool)
+	return bypass
+}
+
+// CachedClient отвечает из кеша, а при промахе обращается к следующему клиенту.
+// Ошибки кеша не ломают запрос: они логируются и учитываются в метриках.
+type CachedClient struct {
+	next  Client
+	cache Cache
+	ttl   time.Duration
+	log   *zap.Logger
+}
+
+func NewCachedClient(next Client, cache Cache, ttl time.Duration, log *zap.Logger) *CachedClient {
+	return &CachedClient{
+		next:  next,
+		cache: cache,
+		ttl:   ttl,
+		log:   log,
+	}
+}
+
+func (c *CachedClient) Complete(ctx context.Context, req Request) (*Response, error) {
+	key := CacheKey(req)
+	if CacheBypassed(ctx) {
+		cacheBypass.Inc()
+	} else {
+		resp, ok, err := c.cache.Get(ctx, key)
+		switch {
+		case err != nil:
+			cacheErrors.Inc()
+			c.log.Warn("Failed to read LLM cache", zap.Error(err))
+		case ok:
+			cacheHits.Inc()
+			resp.Cached = true
+			resp.Usage = Usage{}
+			return resp, nil
+		}
+		cacheMisses.Inc()
+	}
+
+	resp, err := c.next.Complete(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.cache.Set(ctx, key, resp, c.ttl); err != nil {
+		cacheErrors.Inc()
+		c.log.Warn("Failed to write LLM cache", zap.Error(err))
+	}
+	return resp, nil
+}
@@ -0,0 +1,126 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeClient struct {
+	calls int
+	err   error
+}
+
+func (f *fakeClient) Complete(_ context.Context, req Request) (*Response, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &Response{Text: "answer", Model: req.Model, PromptVersion: req.PromptVersion, Usage: Usage{InputTokens: 10, CompletionTokens: 5, TotalTokens: 15}}, nil
+}
+
+type brokenCache struct{}
+
+func (brokenCache) Get(context.Context, string) (*Response, bool, error) {
+	return nil, false, errors.New("cache down")
+}
+
+func (brokenCache) Set(context.Context, string, *Response, time.Duration) error {
+	return errors.New("cache down")
+}
+
+func TestCacheKey(t *testing.T) {
+	req := Request{Model: "yandexgpt-lite", PromptVersion: "v1", Prompt: "text", Temperature: 0.7, MaxTokens: 2000}
+	require.Equal(t, CacheKey(req), CacheKey(req))
+
+	for _, changed := range []Request{
+		{Model: "yandexgpt", PromptVersion: "v1", Prompt: "text", Temperature: 0.7, MaxTokens: 2000},
+		{Model: "yandexgpt-lite", PromptVersion: "v2", Prompt: "text", Temperature: 0.7, MaxTokens: 2000},
+		{Model: "yandexgpt-lite", PromptVersion: "v1", Prompt: "other", Temperature: 0.7, MaxTokens: 2000},
+		{Model: "yandexgpt-lite", PromptVersion: "v1", Prompt: "text", Temperature: 0.2, MaxTokens: 2000},
+	} {
+		require.NotEqual(t, CacheKey(req), CacheKey(changed))
+	}
+}
+
+func TestMemoryCache_LRU(t *testing.T) {
+	ctx := context.Background()
+	cache := NewMemoryCache(2)
+	require.NoError(t, cache.Set(ctx, "a", &Response{Text: "A"}, 0))
+	require.NoError(t, cache.Set(ctx, "b", &Response{Text: "B"}, 0))
+
+	// Обращение к "a" делает "b" самой старой записью
+	_, ok, _ := cache.Get(ctx, "a")
+	require.True(t, ok)
+	require.NoError(t, cache.Set(ctx, "c", &Response{Text: "C"}, 0))
+
+	_, ok, _ = cache.Get(ctx, "b")
+	require.False(t, ok)
+	resp, ok, _ := cache.Get(ctx, "a")
+	require.True(t, ok)
+	require.Equal(t, "A", resp.Text)
+	require.Equal(t, 2, cache.Len())
+}
+
+func TestMemoryCache_TTL(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := NewMemoryCache(10)
+	cache.now = func() time.Time { return now }
+
+	require.NoError(t, cache.Set(ctx, "a", &Response{Text: "A"}, time.Hour))
+	_, ok, _ := cache.Get(ctx, "a")
+	require.True(t, ok)
+
+	now = now.Add(time.Hour)
+	_, ok, _ = cache.Get(ctx, "a")
+	require.False(t, ok)
+	require.Equal(t, 0, cache.Len())
+}
+
+func TestCachedClient(t *testing.T) {
+	ctx := context.Background()
+	next := &fakeClient{}
+	client := NewCachedClient(next, NewMemoryCache(10), time.Hour, zap.NewNop())
+	req := Request{Model: "yandexgpt-lite", PromptVersion: "v1", Prompt: "text"}
+	hits, misses, bypass := cacheHits.Value(), cacheMisses.Value(), cacheBypass.Value()
+
+	resp, err := client.Complete(ctx, req)
+	require.NoError(t, err)
+	require.False(t, resp.Cached)
+	require.Equal(t, 15, resp.Usage.TotalTokens)
+
+	resp, err = client.Complete(ctx, req)
+	require.NoError(t, err)
+	require.True(t, resp.Cached)
+	require.Zero(t, resp.Usage.TotalTokens)
+	require.Equal(t, 1, next.calls)
+
+	resp, err = client.Complete(WithCacheBypass(ctx), req)
+	require.NoError(t, err)
+	require.False(t, resp.Cached)
+	require.Equal(t, 2, next.calls)
+
+	require.Equal(t, hits+1, cacheHits.Value())
+	require.Equal(t, misses+1, cacheMisses.Value())
+	require.Equal(t, bypass+1, cacheBypass.Value())
+}
+
+func TestCachedClient_CacheErrorsDoNotFailRequest(t *testing.T) {
+	next := &fakeClient{}
+	client := NewCachedClient(next, brokenCache{}, time.Hour, zap.NewNop())
+	errs := cacheErrors.Value()
+
+	resp, err := client.Complete(context.Background(), Request{Prompt: "text"})
+	require.NoError(t, err)
+	require.Equal(t, "answer", resp.Text)
+	require.Equal(t, errs+2, cacheErrors.Value())
+
+	next.err = errors.New("provider down")
+	_, err = client.Complete(context.Background(), Request{Prompt: "text"})
+	require.Error(t, err)
+}
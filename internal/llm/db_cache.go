@@ -0,0 +1,65 @@
+package llm
+
+import (
+	"CVMatch/internal/models"
+	"CVMatch/internal/repository"
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DBCache хранит ответы LLM в PostgreSQL, кеш переживает перезапуски и общий для всех реплик
+type DBCache struct {
+	repo repository.LLMCacheRepositoryI
+	now  func() time.Time
+}
+
+func NewDBCache(repo repository.LLMCacheRepositoryI) *DBCache {
+	return &DBCache{
+		repo: repo,
+		now:  time.Now,
+	}
+}
+
+func (c *DBCache) Get(_ context.Context, key string) (*Response, bool, error) {
+	entry, err := c.repo.Get(key, c.now())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return &Response{
+		Text:          entry.Response,
+		Model:         entry.Model,
+		PromptVersion: entry.PromptVersion,
+		Usage: Usage{
+			InputTokens:      entry.InputTokens,
+			CompletionTokens: entry.CompletionTokens,
+			TotalTokens:      entry.InputTokens + entry.CompletionTokens,
+		},
+	}, true, nil
+}
+
+func (c *DBCache) Set(_ context.Context, key string, resp *Response, ttl time.Duration) error {
+	entry := &models.LLMCacheEntry{
+		Key:              key,
+		Model:            resp.Model,
+		PromptVersion:    resp.PromptVersion,
+		Response:         resp.Text,
+		InputTokens:      resp.Usage.InputTokens,
+		CompletionTokens: resp.Usage.CompletionTokens,
+	}
+	if ttl > 0 {
+		expiresAt := c.now().Add(ttl)
+		entry.ExpiresAt = &expiresAt
+	}
+	return c.repo.Upsert(entry)
+}
+
+// Purge удаляет просроченные записи
+func (c *DBCache) Purge() (int64, error) {
+	return c.repo.DeleteExpired(c.now())
+}
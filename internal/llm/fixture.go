@@ -0,0 +1,261 @@
+package llm
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Режимы транспорта фикстур
+const (
+	FixtureModeOff    = ""
+	FixtureModeRecord = "record" // запросы уходят провайдеру, обмен сохраняется в файл
+	FixtureModeReplay = "replay" // ответ читается из файла, сеть не используется
+)
+
+// ErrFixtureNotFound — в режиме воспроизведения для запроса нет записанного обмена
+var ErrFixtureNotFound = errors.New("llm fixture not found")
+
+// redactedValue заменяет значения секретных заголовков в фикстурах
+const redactedValue = "REDACTED"
+
+// sensitiveHeaders не попадают в фикстуры как есть
+var sensitiveHeaders = map[string]struct{}{
+	"Authorization":       {},
+	"X-Folder-Id":         {},
+	"X-Api-Key":           {},
+	"Cookie":              {},
+	"Set-Cookie":          {},
+	"X-Client-Request-Id": {},
+	"X-Request-Id":        {},
+}
+
+// skippedHeaders не сохраняются: при воспроизведении они не соответствовали бы телу
+var skippedHeaders = map[string]struct{}{
+	"Content-Length":    {},
+	"Transfer-Encoding": {},
+	"Date":              {},
+}
+
+// Fixture — записанный обмен с провайдером
+type Fixture struct {
+	Request  FixtureRequest  `json:"request"`
+	Response FixtureResponse `json:"response"`
+}
+
+// Тело хранится как JSON, чтобы фикстуры было удобно читать в ревью; тело, не являющееся JSON,
+// хранится строкой в BodyText
+type FixtureRequest struct {
+	Method   string              `json:"method"`
+	Path     string              `json:"path"`
+	Headers  map[string][]string `json:"headers,omitempty"`
+	Body     json.RawMessage     `json:"body,omitempty"`
+	BodyText string              `json:"body_text,omitempty"`
+}
+
+type FixtureResponse struct {
+	Status   int                 `json:"status"`
+	Headers  map[string][]string `json:"headers,omitempty"`
+	Body     json.RawMessage     `json:"body,omitempty"`
+	BodyText string              `json:"body_text,omitempty"`
+}
+
+// FixtureTransport записывает обмены с провайдером LLM в файлы и воспроизводит их.
+// Перед записью секреты заменяются метками: значения из Secrets — в URL, заголовках и теле,
+// заголовки авторизации — целиком. Файл фикстуры определяется хешем метода, пути и тела
+// запроса после замены секретов, поэтому записанные с настоящими ключами фикстуры
+// воспроизводятся с любыми тестовыми ключами, если те тоже перечислены в Secrets.
+type FixtureTransport struct {
+	Mode string
+	Dir  string
+	// Next — транспорт для режима записи; nil — http.DefaultTransport
+	Next http.RoundTripper
+	// Secrets — секретное значение → метка, которой оно заменяется
+	Secrets map[string]string
+
+	mu sync.Mutex
+}
+
+func NewFixtureTransport(mode, dir string, next http.RoundTripper, secrets map[string]string) *FixtureTransport {
+	return &FixtureTransport{
+		Mode:    mode,
+		Dir:     dir,
+		Next:    next,
+		Secrets: secrets,
+	}
+}
+
+func (t *FixtureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	path := t.scrub(req.URL.RequestURI())
+	scrubbedBody := t.scrub(string(body))
+	file := filepath.Join(t.Dir, fixtureKey(req.Method, path, scrubbedBody)+".json")
+
+	switch t.Mode {
+	case FixtureModeReplay:
+		return t.replay(req, file)
+	case FixtureModeRecord:
+		fixtureReq := FixtureRequest{
+			Method:  req.Method,
+			Path:    path,
+			Headers: t.scrubHeaders(req.Header),
+		}
+		fixtureReq.Body, fixtureReq.BodyText = encodeBody(scrubbedBody)
+		return t.record(req, file, fixtureReq)
+	default:
+		return t.next().RoundTrip(req)
+	}
+}
+
+func (t *FixtureTransport) record(req *http.Request, file string, fixtureReq FixtureRequest) (*http.Response, error) {
+	resp, err := t.next().RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	fixture := Fixture{
+		Request: fixtureReq,
+		Response: FixtureResponse{
+			Status:  resp.StatusCode,
+			Headers: t.scrubHeaders(resp.Header),
+		},
+	}
+	fixture.Response.Body, fixture.Response.BodyText = encodeBody(t.scrub(string(body)))
+	content, err := json.MarshalIndent(fixture, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err := os.MkdirAll(t.Dir, 0o755); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(file, append(content, '\n'), 0o644); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (t *FixtureTransport) replay(req *http.Request, file string) (*http.Response, error) {
+	content, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s %s (%s), перезапишите фикстуры в режиме %s", ErrFixtureNotFound, req.Method, req.URL.Path, file, FixtureModeRecord)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var fixture Fixture
+	if err := json.Unmarshal(content, &fixture); err != nil {
+		return nil, fmt.Errorf("фикстура %s: %w", file, err)
+	}
+
+	header := make(http.Header, len(fixture.Response.Headers))
+	for key, values := range fixture.Response.Headers {
+		header[key] = values
+	}
+	body := []byte(fixture.Response.Body)
+	if fixture.Response.BodyText != "" {
+		body = []byte(fixture.Response.BodyText)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", fixture.Response.Status, http.StatusText(fixture.Response.Status)),
+		StatusCode:    fixture.Response.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+func (t *FixtureTransport) next() http.RoundTripper {
+	if t.Next != nil {
+		return t.Next
+	}
+	return http.DefaultTransport
+}
+
+// scrub заменяет секретные значения метками. Длинные значения заменяются первыми,
+// чтобы секрет, содержащий другой секрет, не оставил хвоста.
+func (t *FixtureTransport) scrub(s string) string {
+	secrets := make([]string, 0, len(t.Secrets))
+	for secret := range t.Secrets {
+		if secret != "" {
+			secrets = append(secrets, secret)
+		}
+	}
+	sort.Slice(secrets, func(i, j int) bool { return len(secrets[i]) > len(secrets[j]) })
+	for _, secret := range secrets {
+		s = strings.ReplaceAll(s, secret, t.Secrets[secret])
+	}
+	return s
+}
+
+func (t *FixtureTransport) scrubHeaders(h http.Header) map[string][]string {
+	result := make(map[string][]string, len(h))
+	for key, values := range h {
+		key = http.CanonicalHeaderKey(key)
+		if _, ok := skippedHeaders[key]; ok {
+			continue
+		}
+		if _, ok := sensitiveHeaders[key]; ok {
+			result[key] = []string{redactedValue}
+			continue
+		}
+		scrubbed := make([]string, 0, len(values))
+		for _, v := range values {
+			scrubbed = append(scrubbed, t.scrub(v))
+		}
+		result[key] = scrubbed
+	}
+	return result
+}
+
+func fixtureKey(method, path, body string) string {
+	h := sha256.New()
+	h.Write([]byte(method))
+	h.Write([]byte{0})
+	h.Write([]byte(path))
+	h.Write([]byte{0})
+	h.Write([]byte(body))
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// encodeBody возвращает тело как JSON, если оно им является, иначе как текст
+func encodeBody(body string) (json.RawMessage, string) {
+	if body == "" {
+		return nil, ""
+	}
+	if json.Valid([]byte(body)) {
+		return json.RawMessage(body), ""
+	}
+	return nil, body
+}
@@ -0,0 +1,80 @@
+package llm
+
+import (
+	"CVMatch/internal/config"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixtureTransport_RecordAndReplay(t *testing.T) {
+	dir := t.TempDir()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Set-Cookie", "session=secret")
+		_, _ = io.WriteString(w, completionOK)
+	}))
+	cfg := &config.Config{YandexGPTIAM: "real-api-key", YandexGPTCatalog: "b1grealfolder", LLM: config.LLMConfig{BaseURL: server.URL}}
+
+	recorder := NewFixtureTransport(FixtureModeRecord, dir, nil, YandexSecrets(cfg))
+	recorded, err := NewYandexClientWithHTTP(cfg, &http.Client{Transport: recorder}).Complete(context.Background(), testRequest)
+	require.NoError(t, err)
+	server.Close()
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	content, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	for _, secret := range []string{"real-api-key", "b1grealfolder", "session=secret"} {
+		require.NotContains(t, string(content), secret)
+	}
+	require.Contains(t, string(content), "gpt://YANDEXGPT_CATALOG_ID/yandexgpt-lite")
+
+	// Воспроизведение с другими ключами: сервер уже остановлен, ответ берётся из файла
+	replayCfg := &config.Config{YandexGPTIAM: "test-key", YandexGPTCatalog: "test-folder", LLM: config.LLMConfig{BaseURL: "http://provider.invalid"}}
+	replayer := NewFixtureTransport(FixtureModeReplay, dir, nil, YandexSecrets(replayCfg))
+	client := NewYandexClientWithHTTP(replayCfg, &http.Client{Transport: replayer})
+	replayed, err := client.Complete(context.Background(), testRequest)
+	require.NoError(t, err)
+	require.Equal(t, recorded, replayed)
+
+	changed := testRequest
+	changed.Prompt = "another prompt"
+	_, err = client.Complete(context.Background(), changed)
+	require.ErrorIs(t, err, ErrFixtureNotFound)
+}
+
+func TestFixtureTransport_NonJSONBody(t *testing.T) {
+	dir := t.TempDir()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = io.WriteString(w, "bad gateway")
+	}))
+	defer server.Close()
+
+	request := func(transport http.RoundTripper) (int, string) {
+		req, err := http.NewRequest(http.MethodPost, server.URL+"/completion", strings.NewReader("plain"))
+		require.NoError(t, err)
+		resp, err := transport.RoundTrip(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(body)
+	}
+
+	status, body := request(NewFixtureTransport(FixtureModeRecord, dir, nil, nil))
+	require.Equal(t, http.StatusBadGateway, status)
+	require.Equal(t, "bad gateway", body)
+
+	status, body = request(NewFixtureTransport(FixtureModeReplay, dir, nil, nil))
+	require.Equal(t, http.StatusBadGateway, status)
+	require.Equal(t, "bad gateway", body)
+}
@@ -0,0 +1,78 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+var (
+	// ErrEmptyResponse — провайдер ответил без вариантов текста
+	ErrEmptyResponse = errors.New("llm returned no alternatives")
+	// ErrCircuitOpen — провайдер признан недоступным, запрос не отправлялся
+	ErrCircuitOpen = errors.New("llm circuit breaker is open")
+)
+
+// APIError — провайдер ответил HTTP-ошибкой
+type APIError struct {
+	StatusCode int
+	Message    string
+	RetryAfter time.Duration // подсказка провайдера, через сколько повторить запрос
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("llm provider returned %d: %s", e.StatusCode, e.Message)
+}
+
+// IsRetryable сообщает, что ошибка временная и запрос стоит повторить:
+// 429, 5xx, сетевые ошибки и истечение таймаута попытки
+func IsRetryable(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= http.StatusInternalServerError
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// IsUnavailable сообщает, что провайдер сейчас не может ответить и стоит перейти на запасной парсер
+func IsUnavailable(err error) bool {
+	return errors.Is(err, ErrCircuitOpen) || IsRetryable(err)
+}
+
+// Request — запрос на генерацию текста
+type Request struct {
+	Model         string
+	PromptVersion string
+	System        string
+	Prompt        string
+	Temperature   float64
+	MaxTokens     int
+}
+
+// Usage — расход токенов на запрос
+type Usage struct {
+	InputTokens      int
+	CompletionTokens int
+	TotalTokens      int
+}
+
+// Response — ответ модели
+type Response struct {
+	Text          string
+	Model         string
+	PromptVersion string
+	Usage         Usage
+	Cached        bool // ответ взят из кеша, токены не тратились
+}
+
+// Client — провайдер LLM
+type Client interface {
+	Complete(ctx context.Context, req Request) (*Response, error)
+}
@@ -0,0 +1,89 @@
+package llm
+
+import (
+	"CVMatch/internal/metrics"
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryCache — LRU-кеш ответов в памяти процесса с ограничением по числу записей
+type MemoryCache struct {
+	mu       sync.Mutex
+	capacity int
+	ll       *list.List
+	items    map[string]*list.Element
+	now      func() time.Time
+}
+
+type memoryEntry struct {
+	key       string
+	resp      Response
+	expiresAt time.Time // нулевое значение — без срока
+}
+
+func NewMemoryCache(capacity int) *MemoryCache {
+	if capacity <= 0 {
+		capacity = 1
+	}
+	c := &MemoryCache{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+		now:      time.Now,
+	}
+	metrics.NewGaugeFunc("llm_cache_entries", "Число записей в кеше LLM в памяти", func() float64 {
+		return float64(c.Len())
+	})
+	return c
+}
+
+func (c *MemoryCache) Get(_ context.Context, key string) (*Response, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false, nil
+	}
+	entry := el.Value.(*memoryEntry)
+	if !entry.expiresAt.IsZero() && !c.now().Before(entry.expiresAt) {
+		c.removeElement(el)
+		return nil, false, nil
+	}
+	c.ll.MoveToFront(el)
+	resp := entry.resp
+	return &resp, true, nil
+}
+
+func (c *MemoryCache) Set(_ context.Context, key string, resp *Response, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = c.now().Add(ttl)
+	}
+	if el, ok := c.items[key]; ok {
+		entry := el.Value.(*memoryEntry)
+		entry.resp = *resp
+		entry.expiresAt = expiresAt
+		c.ll.MoveToFront(el)
+		return nil
+	}
+	c.items[key] = c.ll.PushFront(&memoryEntry{key: key, resp: *resp, expiresAt: expiresAt})
+	for c.ll.Len() > c.capacity {
+		c.removeElement(c.ll.Back())
+	}
+	return nil
+}
+
+func (c *MemoryCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
+
+func (c *MemoryCache) removeElement(el *list.Element) {
+	c.ll.Remove(el)
+	delete(c.items, el.Value.(*memoryEntry).key)
+}
@@ -0,0 +1,96 @@
+package llm
+
+import (
+	"CVMatch/internal/metrics"
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var llmRetries = metrics.NewCounter("llm_retries_total", "Повторные запросы к LLM после временных ошибок")
+
+// RetryPolicy — таймаут одной попытки и экспоненциальная задержка между попытками
+type RetryPolicy struct {
+	MaxAttempts    int           // всего попыток, включая первую
+	Timeout        time.Duration // таймаут одной попытки, 0 — без таймаута
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// RetryClient повторяет запрос при 429, 5xx и таймаутах. Отмена родительского контекста
+// (клиент ушёл, задача остановлена) прекращает повторы сразу.
+type RetryClient struct {
+	next   Client
+	policy RetryPolicy
+	log    *zap.Logger
+}
+
+func NewRetryClient(next Client, policy RetryPolicy, log *zap.Logger) *RetryClient {
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 1
+	}
+	return &RetryClient{
+		next:   next,
+		policy: policy,
+		log:    log,
+	}
+}
+
+func (c *RetryClient) Complete(ctx context.Context, req Request) (*Response, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := c.attempt(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		if ctx.Err() != nil {
+			return nil, errors.Join(err, ctx.Err())
+		}
+		if !IsRetryable(err) || attempt >= c.policy.MaxAttempts {
+			return nil, err
+		}
+
+		delay := c.backoff(attempt, err)
+		llmRetries.Inc()
+		c.log.Warn("LLM request failed, retrying", zap.Int("attempt", attempt), zap.Duration("delay", delay), zap.Error(err))
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, errors.Join(err, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+func (c *RetryClient) attempt(ctx context.Context, req Request) (*Response, error) {
+	if c.policy.Timeout <= 0 {
+		return c.next.Complete(ctx, req)
+	}
+	callCtx, cancel := context.WithTimeout(ctx, c.policy.Timeout)
+	defer cancel()
+	return c.next.Complete(callCtx, req)
+}
+
+// backoff — InitialBackoff * 2^(attempt-1) с разбросом ±25%, не больше MaxBackoff.
+// Retry-After провайдера имеет приоритет, если он больше расчётной задержки.
+func (c *RetryClient) backoff(attempt int, err error) time.Duration {
+	delay := c.policy.InitialBackoff << (attempt - 1)
+	if delay <= 0 || (c.policy.MaxBackoff > 0 && delay > c.policy.MaxBackoff) {
+		delay = c.policy.MaxBackoff
+	}
+	if delay > 0 {
+		delay += time.Duration(rand.Int64N(int64(delay)/2+1)) - delay/4
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > delay {
+		delay = apiErr.RetryAfter
+	}
+	if c.policy.MaxBackoff > 0 && delay > c.policy.MaxBackoff {
+		delay = c.policy.MaxBackoff
+	}
+	return delay
+}
@@ -0,0 +1,114 @@
+package llm
+
+import (
+	"CVMatch/internal/metrics"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// ErrQuotaExceeded — пользователь израсходовал месячную квоту токенов
+var ErrQuotaExceeded = errors.New("monthly llm token quota exceeded")
+
+// Операции, на которые тратятся токены
+const (
+	OperationResumeParse  = "resume_parse"
+	OperationVacancyParse = "vacancy_parse"
+	OperationRecommend    = "match_recommendations"
+	OperationCoverLetter  = "cover_letter"
+)
+
+var (
+	llmRequests = metrics.NewCounter("llm_requests_total", "Запросы к LLM, включая ответы из кеша")
+	llmTokens   = metrics.NewCounter("llm_tokens_total", "Потраченные токены LLM")
+)
+
+// Owner — кому засчитывается вызов LLM
+type Owner struct {
+	UserID    uuid.UUID
+	ResumeID  *uuid.UUID
+	JobID     *uuid.UUID
+	Operation string
+}
+
+type ownerKey struct{}
+
+// WithOwner привязывает вызовы LLM в контексте к пользователю и резюме или задаче
+func WithOwner(ctx context.Context, owner Owner) context.Context {
+	return context.WithValue(ctx, ownerKey{}, owner)
+}
+
+func OwnerFromContext(ctx context.Context) (Owner, bool) {
+	owner, ok := ctx.Value(ownerKey{}).(Owner)
+	return owner, ok && owner.UserID != uuid.Nil
+}
+
+// UsageRecord — итог одного вызова LLM
+type UsageRecord struct {
+	Owner         Owner
+	Model         string
+	PromptVersion string
+	Usage         Usage
+	Latency       time.Duration
+	Cached        bool
+	Success       bool
+}
+
+// UsageStore сохраняет расход и проверяет квоты
+type UsageStore interface {
+	CheckQuota(ctx context.Context, userID uuid.UUID) error
+	Record(ctx context.Context, record UsageRecord) error
+}
+
+// MeteredClient проверяет квоту владельца перед вызовом и записывает расход после.
+// Вызовы без владельца в контексте не учитываются по пользователям, только в метриках.
+type MeteredClient struct {
+	next  Client
+	store UsageStore
+	log   *zap.Logger
+	now   func() time.Time
+}
+
+func NewMeteredClient(next Client, store UsageStore, log *zap.Logger) *MeteredClient {
+	return &MeteredClient{
+		next:  next,
+		store: store,
+		log:   log,
+		now:   time.Now,
+	}
+}
+
+func (c *MeteredClient) Complete(ctx context.Context, req Request) (*Response, error) {
+	owner, hasOwner := OwnerFromContext(ctx)
+	if hasOwner {
+		if err := c.store.CheckQuota(ctx, owner.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	start := c.now()
+	resp, err := c.next.Complete(ctx, req)
+	record := UsageRecord{
+		Owner:         owner,
+		Model:         req.Model,
+		PromptVersion: req.PromptVersion,
+		Latency:       c.now().Sub(start),
+		Success:       err == nil,
+	}
+	if resp != nil {
+		record.Usage = resp.Usage
+		record.Cached = resp.Cached
+	}
+
+	llmRequests.Inc()
+	llmTokens.Add(int64(record.Usage.TotalTokens))
+	if hasOwner {
+		if recErr := c.store.Record(ctx, record); recErr != nil {
+			c.log.Warn("Failed to record LLM usage", zap.Error(recErr))
+		}
+	}
+	return resp, err
+}
@@ -0,0 +1,52 @@
+package llm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeUsageStore struct {
+	quotaErr error
+	records  []UsageRecord
+}
+
+func (s *fakeUsageStore) CheckQuota(context.Context, uuid.UUID) error {
+	return s.quotaErr
+}
+
+func (s *fakeUsageStore) Record(_ context.Context, record UsageRecord) error {
+	s.records = append(s.records, record)
+	return nil
+}
+
+func TestMeteredClient(t *testing.T) {
+	next := &fakeClient{}
+	store := &fakeUsageStore{}
+	client := NewMeteredClient(next, store, zap.NewNop())
+	req := Request{Model: "yandexgpt-lite", PromptVersion: "v1", Prompt: "text"}
+
+	// Без владельца вызов не записывается
+	_, err := client.Complete(context.Background(), req)
+	require.NoError(t, err)
+	require.Empty(t, store.records)
+
+	resumeID := uuid.New()
+	ctx := WithOwner(context.Background(), Owner{UserID: uuid.New(), ResumeID: &resumeID, Operation: OperationResumeParse})
+	_, err = client.Complete(ctx, req)
+	require.NoError(t, err)
+	require.Len(t, store.records, 1)
+	require.Equal(t, &resumeID, store.records[0].Owner.ResumeID)
+	require.Equal(t, 15, store.records[0].Usage.TotalTokens)
+	require.True(t, store.records[0].Success)
+
+	// При исчерпанной квоте провайдер не вызывается
+	store.quotaErr = ErrQuotaExceeded
+	_, err = client.Complete(ctx, req)
+	require.ErrorIs(t, err, ErrQuotaExceeded)
+	require.Equal(t, 2, next.calls)
+	require.Len(t, store.records, 1)
+}
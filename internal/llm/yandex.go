@@ -0,0 +1,153 @@
+package llm
+
+import (
+	"CVMatch/internal/config"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sheeiavellie/go-yandexgpt"
+)
+
+// DefaultYandexBaseURL — адрес Foundation Models API
+const DefaultYandexBaseURL = "https://llm.api.cloud.yandex.net/foundationModels/v1"
+
+// maxErrorBody — сколько байт тела ответа с ошибкой читается для сообщения
+const maxErrorBody = 64 << 10
+
+// YandexClient обращается к YandexGPT по HTTP. Запросы и ответы описаны типами go-yandexgpt,
+// а транспорт свой, чтобы передавать контекст, видеть HTTP-статус и подменять http.Client в тестах.
+type YandexClient struct {
+	httpClient *http.Client
+	baseURL    string
+	apiKey     string
+	catalog    string
+}
+
+// NewYandexClient создаёт клиент YandexGPT. Если задан режим фикстур (LLM_FIXTURES_MODE),
+// обмены с провайдером записываются в каталог фикстур или воспроизводятся из него.
+func NewYandexClient(cfg *config.Config) *YandexClient {
+	httpClient := &http.Client{}
+	if cfg.LLM.FixturesMode != FixtureModeOff {
+		httpClient.Transport = NewFixtureTransport(cfg.LLM.FixturesMode, cfg.LLM.FixturesDir, nil, YandexSecrets(cfg))
+	}
+	return NewYandexClientWithHTTP(cfg, httpClient)
+}
+
+// YandexSecrets — значения, которые не должны попадать в фикстуры, и их метки
+func YandexSecrets(cfg *config.Config) map[string]string {
+	return map[string]string{
+		cfg.YandexGPTIAM:     "YANDEXGPT_IAM",
+		cfg.YandexGPTCatalog: "YANDEXGPT_CATALOG_ID",
+	}
+}
+
+func NewYandexClientWithHTTP(cfg *config.Config, httpClient *http.Client) *YandexClient {
+	baseURL := cfg.LLM.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultYandexBaseURL
+	}
+	return &YandexClient{
+		httpClient: httpClient,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		apiKey:     cfg.YandexGPTIAM,
+		catalog:    cfg.YandexGPTCatalog,
+	}
+}
+
+func (c *YandexClient) Complete(ctx context.Context, req Request) (*Response, error) {
+	body, err := json.Marshal(yandexgpt.YandexGPTRequest{
+		ModelURI: fmt.Sprintf("gpt://%s/%s", c.catalog, req.Model),
+		CompletionOptions: yandexgpt.YandexGPTCompletionOptions{
+			Stream:      false,
+			Temperature: float32(req.Temperature),
+			MaxTokens:   req.MaxTokens,
+		},
+		Messages: []yandexgpt.YandexGPTMessage{
+			{
+				Role: yandexgpt.YandexGPTMessageRoleSystem,
+				Text: req.System,
+			},
+			{
+				Role: yandexgpt.YandexGPTMessageRoleUser,
+				Text: req.Prompt,
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/completion", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Api-Key "+c.apiKey)
+	httpReq.Header.Set("x-folder-id", c.catalog)
+
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode < http.StatusOK || httpResp.StatusCode >= http.StatusMultipleChoices {
+		return nil, newAPIError(httpResp)
+	}
+
+	var response yandexgpt.YandexGPTResponse
+	if err := json.NewDecoder(httpResp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decode yandexgpt response: %w", err)
+	}
+	if len(response.Result.Alternatives) == 0 {
+		return nil, ErrEmptyResponse
+	}
+	return &Response{
+		Text:          response.Result.Alternatives[0].Message.Text,
+		Model:         req.Model,
+		PromptVersion: req.PromptVersion,
+		Usage:         parseUsage(response.Result.Usage),
+	}, nil
+}
+
+func newAPIError(resp *http.Response) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+	}
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	var bad yandexgpt.YandexGPTResponseBad
+	if err := json.Unmarshal(raw, &bad); err == nil && bad.Error.Message != "" {
+		apiErr.Message = bad.Error.Message
+	} else {
+		apiErr.Message = strings.TrimSpace(string(raw))
+	}
+	return apiErr
+}
+
+// parseRetryAfter понимает Retry-After в секундах; дату HTTP провайдер не присылает
+func parseRetryAfter(value string) time.Duration {
+	seconds, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// parseUsage — YandexGPT отдаёт счётчики токенов строками
+func parseUsage(u yandexgpt.YandexGPTUsage) Usage {
+	input, _ := strconv.Atoi(u.InputTokens)
+	completion, _ := strconv.Atoi(u.CompletionTokens)
+	total, _ := strconv.Atoi(u.TotalTokens)
+	if total == 0 {
+		total = input + completion
+	}
+	return Usage{InputTokens: input, CompletionTokens: completion, TotalTokens: total}
+}
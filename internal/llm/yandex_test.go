@@ -0,0 +1,219 @@
+package llm
+
+import (
+	"CVMatch/internal/config"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const completionOK = `{"result":{"alternatives":[{"message":{"role":"assistant","text":"{\"full_name\":\"Иван\"}"},"status":"ALTERNATIVE_STATUS_FINAL"}],"usage":{"inputTextTokens":"120","completionTokens":"30","totalTokens":"150"},"modelVersion":"23.10.2024"}}`
+
+// fakeYandex отвечает заранее заданными статусами по порядку, последний повторяется
+type fakeYandex struct {
+	calls    atomic.Int32
+	statuses []int
+	delay    time.Duration // задержка первого ответа
+	body     string
+}
+
+func (f *fakeYandex) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	n := int(f.calls.Add(1))
+	if n == 1 && f.delay > 0 {
+		select {
+		case <-time.After(f.delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	status := f.statuses[len(f.statuses)-1]
+	if n <= len(f.statuses) {
+		status = f.statuses[n-1]
+	}
+	if status != http.StatusOK {
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(status)
+		_, _ = fmt.Fprintf(w, `{"error":{"httpCode":%d,"message":"provider says no","httpStatus":%q}}`, status, http.StatusText(status))
+		return
+	}
+	body := f.body
+	if body == "" {
+		body = completionOK
+	}
+	_, _ = io.WriteString(w, body)
+}
+
+func newTestYandexClient(t *testing.T, handler http.Handler) *YandexClient {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	cfg := &config.Config{YandexGPTIAM: "secret-key", YandexGPTCatalog: "b1gcatalog", LLM: config.LLMConfig{BaseURL: server.URL}}
+	return NewYandexClientWithHTTP(cfg, server.Client())
+}
+
+var testRequest = Request{Model: "yandexgpt-lite", PromptVersion: "v1", System: "system", Prompt: "prompt", Temperature: 0.7, MaxTokens: 2000}
+
+func TestYandexClient_Complete(t *testing.T) {
+	var got map[string]any
+	client := newTestYandexClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/completion", r.URL.Path)
+		require.Equal(t, "Api-Key secret-key", r.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		_, _ = io.WriteString(w, completionOK)
+	}))
+
+	resp, err := client.Complete(context.Background(), testRequest)
+	require.NoError(t, err)
+	require.Equal(t, `{"full_name":"Иван"}`, resp.Text)
+	require.Equal(t, Usage{InputTokens: 120, CompletionTokens: 30, TotalTokens: 150}, resp.Usage)
+	require.Equal(t, "v1", resp.PromptVersion)
+	require.Equal(t, "gpt://b1gcatalog/yandexgpt-lite", got["modelUri"])
+	require.Len(t, got["messages"], 2)
+}
+
+func TestYandexClient_EmptyAlternatives(t *testing.T) {
+	client := newTestYandexClient(t, &fakeYandex{statuses: []int{http.StatusOK}, body: `{"result":{"alternatives":[]}}`})
+	_, err := client.Complete(context.Background(), testRequest)
+	require.ErrorIs(t, err, ErrEmptyResponse)
+}
+
+func TestYandexClient_APIError(t *testing.T) {
+	client := newTestYandexClient(t, &fakeYandex{statuses: []int{http.StatusBadRequest}})
+	_, err := client.Complete(context.Background(), testRequest)
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	require.Equal(t, "provider says no", apiErr.Message)
+	require.False(t, IsRetryable(err))
+}
+
+func testRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 3, Timeout: time.Second, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+}
+
+func TestRetryClient_RetriesRetryableErrors(t *testing.T) {
+	fake := &fakeYandex{statuses: []int{http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusOK}}
+	client := NewRetryClient(newTestYandexClient(t, fake), testRetryPolicy(), zap.NewNop())
+
+	resp, err := client.Complete(context.Background(), testRequest)
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.Text)
+	require.Equal(t, int32(3), fake.calls.Load())
+}
+
+func TestRetryClient_GivesUp(t *testing.T) {
+	fake := &fakeYandex{statuses: []int{http.StatusInternalServerError}}
+	client := NewRetryClient(newTestYandexClient(t, fake), testRetryPolicy(), zap.NewNop())
+
+	_, err := client.Complete(context.Background(), testRequest)
+	require.True(t, IsUnavailable(err))
+	require.Equal(t, int32(3), fake.calls.Load())
+}
+
+func TestRetryClient_DoesNotRetryClientErrors(t *testing.T) {
+	fake := &fakeYandex{statuses: []int{http.StatusBadRequest}}
+	client := NewRetryClient(newTestYandexClient(t, fake), testRetryPolicy(), zap.NewNop())
+
+	_, err := client.Complete(context.Background(), testRequest)
+	require.Error(t, err)
+	require.Equal(t, int32(1), fake.calls.Load())
+}
+
+func TestRetryClient_AttemptTimeout(t *testing.T) {
+	fake := &fakeYandex{statuses: []int{http.StatusOK}, delay: 300 * time.Millisecond}
+	policy := testRetryPolicy()
+	policy.Timeout = 50 * time.Millisecond
+	client := NewRetryClient(newTestYandexClient(t, fake), policy, zap.NewNop())
+
+	resp, err := client.Complete(context.Background(), testRequest)
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.Text)
+	require.Equal(t, int32(2), fake.calls.Load())
+}
+
+func TestRetryClient_StopsOnCanceledContext(t *testing.T) {
+	fake := &fakeYandex{statuses: []int{http.StatusServiceUnavailable}}
+	policy := testRetryPolicy()
+	policy.InitialBackoff, policy.MaxBackoff = time.Minute, time.Minute
+	client := NewRetryClient(newTestYandexClient(t, fake), policy, zap.NewNop())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := client.Complete(ctx, testRequest)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), 5*time.Second)
+	require.Equal(t, int32(1), fake.calls.Load())
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	fake := &fakeYandex{statuses: []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK}}
+	breaker := NewCircuitBreaker(newTestYandexClient(t, fake), BreakerPolicy{FailureThreshold: 2, Cooldown: time.Minute})
+	now := time.Now()
+	breaker.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		_, err := breaker.Complete(context.Background(), testRequest)
+		require.Error(t, err)
+	}
+	require.Equal(t, BreakerOpen, breaker.State())
+
+	// Пока предохранитель открыт, провайдер не вызывается
+	_, err := breaker.Complete(context.Background(), testRequest)
+	require.ErrorIs(t, err, ErrCircuitOpen)
+	require.True(t, IsUnavailable(err))
+	require.Equal(t, int32(2), fake.calls.Load())
+
+	// После паузы пробный запрос проходит и закрывает предохранитель
+	now = now.Add(time.Minute)
+	_, err = breaker.Complete(context.Background(), testRequest)
+	require.NoError(t, err)
+	require.Equal(t, BreakerClosed, breaker.State())
+}
+
+func TestCircuitBreaker_IgnoresClientErrors(t *testing.T) {
+	breaker := NewCircuitBreaker(&fakeClient{err: errors.New("bad prompt")}, BreakerPolicy{FailureThreshold: 1, Cooldown: time.Minute})
+	for i := 0; i < 3; i++ {
+		_, err := breaker.Complete(context.Background(), testRequest)
+		require.EqualError(t, err, "bad prompt")
+	}
+	require.Equal(t, BreakerClosed, breaker.State())
+}
+
+func TestCircuitBreaker_IgnoresCancellation(t *testing.T) {
+	client := &fakeClient{err: &APIError{StatusCode: http.StatusServiceUnavailable}}
+	breaker := NewCircuitBreaker(client, BreakerPolicy{FailureThreshold: 2, Cooldown: time.Minute})
+	now := time.Now()
+	breaker.now = func() time.Time { return now }
+
+	// Отмена между неудачами не сбрасывает счётчик подряд идущих неудач
+	_, _ = breaker.Complete(context.Background(), testRequest)
+	client.err = context.Canceled
+	_, _ = breaker.Complete(context.Background(), testRequest)
+	client.err = &APIError{StatusCode: http.StatusServiceUnavailable}
+	_, _ = breaker.Complete(context.Background(), testRequest)
+	require.Equal(t, BreakerOpen, breaker.State())
+
+	// Клиент отключился во время пробного запроса: предохранитель не закрывается,
+	// и следующий запрос снова становится пробным
+	now = now.Add(time.Minute)
+	client.err = context.Canceled
+	_, err := breaker.Complete(context.Background(), testRequest)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, BreakerHalfOpen, breaker.State())
+
+	client.err = &APIError{StatusCode: http.StatusServiceUnavailable}
+	_, err = breaker.Complete(context.Background(), testRequest)
+	require.NotErrorIs(t, err, ErrCircuitOpen)
+	require.Equal(t, BreakerOpen, breaker.State())
+	require.Equal(t, 5, client.calls)
+}
@@ -0,0 +1,148 @@
+package matching
+
+import (
+	"CVMatch/internal/models"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// higherEducationMarkers — признаки высшего образования в степени или названии учебного заведения
+var higherEducationMarkers = []string{
+	"бакалавр", "магистр", "специалист", "аспирант", "кандидат", "доктор", "высшее",
+	"университет", "институт", "академия",
+	"bachelor", "master", "phd", "mba", "b.sc", "m.sc", "bsc", "msc", "university", "institute",
+}
+
+// scoreEducation оценивает наличие образования: высшее — 100, курсы и прочее — 60.
+// Вакансии пока не задают требований к образованию, поэтому компонент весит немного.
+// Резюме без образования компонент не штрафует: он просто не учитывается.
+func scoreEducation(resume *models.Resume) models.ScoreComponent {
+	component := models.ScoreComponent{Name: models.ComponentEducation}
+	for _, edu := range resume.Education {
+		text := strings.Join(nonEmpty(edu.Degree, edu.Field, edu.Institution), ", ")
+		if text == "" {
+			continue
+		}
+		score, details := 60.0, "указано образование"
+		lower := strings.ToLower(text)
+		for _, marker := range higherEducationMarkers {
+			if strings.Contains(lower, marker) {
+				score, details = 100, "высшее образование"
+				break
+			}
+		}
+		if !component.Applicable || score > component.Score {
+			component.Applicable = true
+			component.Score = score
+			component.Details = details
+			component.Evidence = []models.Evidence{{Requirement: details, Source: SourceEducation, Snippet: text}}
+		}
+	}
+	return component
+}
+
+// scoreLocation сравнивает город кандидата с городом вакансии с учётом формата работы и готовности к переезду
+func scoreLocation(resume *models.Resume, vacancy *models.Vacancy) models.ScoreComponent {
+	component := models.ScoreComponent{Name: models.ComponentLocation}
+	if vacancy.RemotePolicy == "remote" {
+		component.Applicable = true
+		component.Score = 100
+		component.Details = "удалённая работа"
+		return component
+	}
+	if vacancy.Location == "" || resume.Location == "" {
+		return component
+	}
+
+	component.Applicable = true
+	component.Evidence = []models.Evidence{{Requirement: vacancy.Location, Source: SourceResume, Snippet: resume.Location}}
+	switch {
+	case normalizeCity(resume.Location) == normalizeCity(vacancy.Location):
+		component.Score = 100
+		component.Details = "тот же город"
+	case resume.ReadyToRelocate != nil && *resume.ReadyToRelocate:
+		component.Score = 70
+		component.Details = "другой город, готов к переезду"
+	case vacancy.RemotePolicy == "hybrid":
+		component.Score = 30
+		component.Details = "другой город, гибридный формат"
+	default:
+		component.Details = "другой город"
+	}
+	return component
+}
+
+// normalizeCity оставляет название города без приставки "г." и уточнений после запятой
+func normalizeCity(location string) string {
+	city := strings.ToLower(strings.TrimSpace(location))
+	if i := strings.IndexAny(city, ",("); i >= 0 {
+		city = city[:i]
+	}
+	for _, prefix := range []string{"город ", "г. ", "г.", "г "} {
+		city = strings.TrimPrefix(city, prefix)
+	}
+	return strings.TrimSpace(city)
+}
+
+// semanticSaturation — косинус, начиная с которого тексты считаются полностью близкими:
+// у резюме и вакансии разная длина и лексика, поэтому полного совпадения не бывает
+const semanticSaturation = 0.5
+
+// scoreSemantic оценивает близость текста резюме к описанию вакансии по косинусу частот слов.
+// Если текст документа не сохранён, используются должности и описания мест работы.
+func scoreSemantic(resume *models.Resume, vacancy *models.Vacancy) models.ScoreComponent {
+	component := models.ScoreComponent{Name: models.ComponentSemantic}
+	vacancyText := vacancy.Title + " " + vacancy.Description
+	for _, skill := range vacancy.Skills {
+		vacancyText += " " + skill.Name
+	}
+	resumeText := resume.File.RawText
+	if strings.TrimSpace(resumeText) == "" {
+		for _, exp := range resume.Experience {
+			resumeText += " " + exp.Position + " " + exp.Description
+		}
+		for _, skill := range resume.Skills {
+			resumeText += " " + skill.Name
+		}
+	}
+
+	a, b := termFrequencies(vacancyText), termFrequencies(resumeText)
+	if len(a) == 0 || len(b) == 0 || strings.TrimSpace(vacancy.Description) == "" {
+		return component
+	}
+	similarity := cosine(a, b)
+	component.Applicable = true
+	component.Score = round1(100 * math.Min(1, similarity/semanticSaturation))
+	component.Details = fmt.Sprintf("косинусная близость текстов %.2f", similarity)
+	return component
+}
+
+func termFrequencies(text string) map[string]float64 {
+	terms := make(map[string]float64)
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '+' && r != '#'
+	}) {
+		if len([]rune(word)) < 2 {
+			continue
+		}
+		terms[word]++
+	}
+	return terms
+}
+
+func cosine(a, b map[string]float64) float64 {
+	var dot, normA, normB float64
+	for term, x := range a {
+		dot += x * b[term]
+		normA += x * x
+	}
+	for _, y := range b {
+		normB += y * y
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(normA*normB)
+}
@@ -0,0 +1,353 @@
+package matching
+
+import (
+	"CVMatch/internal/config"
+	"CVMatch/internal/llm"
+	"CVMatch/internal/models"
+	"CVMatch/internal/parser"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ErrEmptyCoverLetter — LLM вернула пустое письмо
+var ErrEmptyCoverLetter = errors.New("llm returned an empty cover letter")
+
+// LetterOptions — язык, тон и длина сопроводительного письма
+type LetterOptions struct {
+	Language string
+	Tone     string // models.Tone*
+	Length   string // models.Length*
+}
+
+// letterMaxTokens — ограничение ответа LLM по длине письма
+var letterMaxTokens = map[string]int{
+	models.LengthShort:  600,
+	models.LengthMedium: 1000,
+	models.LengthLong:   1600,
+}
+
+// CoverLetterWriterI пишет сопроводительное письмо к вакансии по резюме и результату сравнения.
+// Возвращает письмо без идентификаторов: их заполняет сервис.
+type CoverLetterWriterI interface {
+	Write(ctx context.Context, resume *models.Resume, vacancy *models.Vacancy, breakdown models.ScoreBreakdown, opts LetterOptions, cfg *config.Config) (*models.CoverLetter, error)
+}
+
+// YandexCoverLetterWriter просит YandexGPT написать письмо; Prompts и Client
+// подставляются так же, как в YandexRecommender
+type YandexCoverLetterWriter struct {
+	Prompts *parser.PromptSet
+	Client  llm.Client
+}
+
+func (w YandexCoverLetterWriter) Write(ctx context.Context, resume *models.Resume, vacancy *models.Vacancy, breakdown models.ScoreBreakdown, opts LetterOptions, cfg *config.Config) (*models.CoverLetter, error) {
+	prompts := w.Prompts
+	if prompts == nil {
+		prompts = parser.DefaultPrompts()
+	}
+	client := w.Client
+	if client == nil {
+		client = llm.NewYandexClient(cfg)
+	}
+
+	opts = normalizeLetterOptions(resume, opts)
+	prompt, err := prompts.CoverLetter(resumeAdviceText(resume), vacancyAdviceText(vacancy, opts.Language),
+		breakdown.MatchedSkills(), breakdown.MissingRequired(), opts.Tone, opts.Length, opts.Language)
+	if err != nil {
+		return nil, err
+	}
+	systemPrompt, err := prompts.CoverLetterSystem(opts.Language)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Complete(ctx, llm.Request{
+		Model:         cfg.YandexGPTModel,
+		PromptVersion: prompts.CoverLetterVersion,
+		System:        systemPrompt,
+		Prompt:        prompt,
+		Temperature:   0.7,
+		MaxTokens:     letterMaxTokens[opts.Length],
+	})
+	if err != nil {
+		return nil, err
+	}
+	content := cleanLetter(resp.Text)
+	if content == "" {
+		return nil, ErrEmptyCoverLetter
+	}
+	return &models.CoverLetter{
+		Language:      opts.Language,
+		Tone:          opts.Tone,
+		Length:        opts.Length,
+		Content:       content,
+		Source:        models.SourceLLM,
+		Model:         cfg.YandexGPTModel,
+		PromptVersion: prompts.CoverLetterVersion,
+	}, nil
+}
+
+// FallbackCoverLetterWriter пишет письмо основным способом, а если провайдер LLM недоступен — запасным
+type FallbackCoverLetterWriter struct {
+	Primary  CoverLetterWriterI
+	Fallback CoverLetterWriterI
+}
+
+func (w FallbackCoverLetterWriter) Write(ctx context.Context, resume *models.Resume, vacancy *models.Vacancy, breakdown models.ScoreBreakdown, opts LetterOptions, cfg *config.Config) (*models.CoverLetter, error) {
+	letter, err := w.Primary.Write(ctx, resume, vacancy, breakdown, opts, cfg)
+	if err == nil || !llm.IsUnavailable(err) || ctx.Err() != nil {
+		return letter, err
+	}
+	letter, fallbackErr := w.Fallback.Write(ctx, resume, vacancy, breakdown, opts, cfg)
+	if fallbackErr != nil {
+		return nil, errors.Join(err, fallbackErr)
+	}
+	return letter, nil
+}
+
+// TemplateCoverLetterWriter собирает письмо из готовых фраз, без LLM: должность вакансии,
+// стаж и последнее место работы, совпавшие навыки и пример из опыта. Тон меняет приветствие
+// и заключение, длина — число абзацев. Для одних и тех же данных письмо всегда одинаковое.
+type TemplateCoverLetterWriter struct{}
+
+func (TemplateCoverLetterWriter) Write(_ context.Context, resume *models.Resume, vacancy *models.Vacancy, breakdown models.ScoreBreakdown, opts LetterOptions, _ *config.Config) (*models.CoverLetter, error) {
+	opts = normalizeLetterOptions(resume, opts)
+	// Для языков без готовых фраз письмо пишется по-русски и так и сохраняется
+	var phrases letterTexts
+	phrases, opts.Language = letterPhrasesFor(opts.Language)
+	tone := phrases.tones[opts.Tone]
+
+	intro := fmt.Sprintf(tone.intro, vacancy.Title)
+	var experience []string
+	if years := resume.ExperienceMonths / 12; years > 0 {
+		experience = append(experience, fmt.Sprintf(phrases.years, years, phrases.yearsWord(years)))
+	}
+	if len(resume.Experience) > 0 {
+		last := resume.Experience[0]
+		switch {
+		case last.Position != "" && last.Company != "":
+			experience = append(experience, fmt.Sprintf(phrases.lastJob, last.Position, last.Company))
+		case last.Position != "":
+			experience = append(experience, fmt.Sprintf(phrases.lastPosition, last.Position))
+		}
+	}
+	if len(experience) > 0 {
+		experience = []string{capitalize(strings.Join(experience, ", "))}
+	}
+	var skills []string
+	if matched := limit(breakdown.MatchedSkills(), 5); len(matched) > 0 {
+		skills = append(skills, fmt.Sprintf(phrases.skills, strings.Join(matched, ", ")))
+	}
+	var example []string
+	for _, exp := range resume.Experience {
+		if sentence := firstSentence(exp.Description); sentence != "" {
+			example = append(example, fmt.Sprintf(phrases.example, strings.TrimRight(sentence, ".!;")))
+			break
+		}
+	}
+
+	paragraphs := []string{tone.greeting}
+	switch opts.Length {
+	case models.LengthShort:
+		paragraphs = append(paragraphs, joinSentences(append([]string{intro}, skills...)))
+	case models.LengthLong:
+		var growth []string
+		if missing := breakdown.MissingRequired(); len(missing) > 0 {
+			growth = append(growth, fmt.Sprintf(phrases.missing, strings.Join(limit(missing, 3), ", ")))
+		}
+		for _, edu := range resume.Education {
+			if text := strings.Join(nonEmpty(edu.Degree, edu.Field, edu.Institution), ", "); text != "" {
+				growth = append(growth, fmt.Sprintf(phrases.education, text))
+				break
+			}
+		}
+		paragraphs = append(paragraphs, intro, joinSentences(append(experience, example...)), joinSentences(skills), joinSentences(growth))
+	default:
+		paragraphs = append(paragraphs, intro, joinSentences(append(append(experience, skills...), example...)))
+	}
+	paragraphs = append(paragraphs, tone.closing, strings.Join(nonEmpty(tone.signOff, resume.FullName), "\n"))
+
+	return &models.CoverLetter{
+		Language: opts.Language,
+		Tone:     opts.Tone,
+		Length:   opts.Length,
+		Content:  strings.Join(nonEmpty(paragraphs...), "\n\n"),
+		Source:   models.SourceTemplate,
+	}, nil
+}
+
+// letterTone — приветствие, вступление и заключение письма в одном тоне
+type letterTone struct {
+	greeting string
+	intro    string // %s — название вакансии
+	closing  string
+	signOff  string
+}
+
+// letterTexts — формулировки шаблонного письма на одном языке
+type letterTexts struct {
+	tones        map[string]letterTone
+	years        string // %d — полных лет стажа, %s — слово "год" в нужной форме
+	yearsWord    func(n int) string
+	lastJob      string
+	lastPosition string
+	skills       string
+	example      string
+	missing      string
+	education    string
+}
+
+var letterPhrases = map[string]letterTexts{
+	parser.LangRussian: {
+		tones: map[string]letterTone{
+			models.ToneFormal: {
+				greeting: "Здравствуйте!",
+				intro:    "Прошу рассмотреть мою кандидатуру на вакансию «%s».",
+				closing:  "Благодарю за внимание к моей кандидатуре и надеюсь на приглашение на собеседование.",
+				signOff:  "С уважением,",
+			},
+			models.ToneFriendly: {
+				greeting: "Добрый день!",
+				intro:    "Меня заинтересовала вакансия «%s», и я хочу предложить свою кандидатуру.",
+				closing:  "Спасибо за внимание — с удовольствием расскажу подробнее на встрече.",
+				signOff:  "Хорошего дня,",
+			},
+			models.ToneEnthusiastic: {
+				greeting: "Здравствуйте!",
+				intro:    "С большим интересом откликаюсь на вакансию «%s» — задачи вашей команды мне очень близки.",
+				closing:  "Очень хочу присоединиться к вашей команде и с радостью расскажу о своём опыте на собеседовании!",
+				signOff:  "С наилучшими пожеланиями,",
+			},
+		},
+		years:        "У меня %d %s опыта работы",
+		yearsWord:    russianYears,
+		lastJob:      "последняя должность — %s в компании %s",
+		lastPosition: "последняя должность — %s",
+		skills:       "Из требований вакансии у меня есть практический опыт с %s",
+		example:      "Например: %s",
+		missing:      "Навыки %s планирую освоить в ближайшее время",
+		education:    "Образование: %s",
+	},
+	parser.LangEnglish: {
+		tones: map[string]letterTone{
+			models.ToneFormal: {
+				greeting: "Dear Hiring Manager,",
+				intro:    "I would like to apply for the %s position.",
+				closing:  "Thank you for considering my application. I look forward to the opportunity to discuss it in an interview.",
+				signOff:  "Sincerely,",
+			},
+			models.ToneFriendly: {
+				greeting: "Hello,",
+				intro:    "The %s position caught my attention, and I would love to be considered for it.",
+				closing:  "Thanks for reading — I would be happy to tell you more in a call.",
+				signOff:  "Best regards,",
+			},
+			models.ToneEnthusiastic: {
+				greeting: "Hello!",
+				intro:    "I am excited to apply for the %s position — your team's work is exactly what I want to do.",
+				closing:  "I would be thrilled to join your team and can't wait to talk about my experience in an interview!",
+				signOff:  "Best wishes,",
+			},
+		},
+		years:        "I have %d %s of work experience",
+		yearsWord:    englishYears,
+		lastJob:      "most recently as %s at %s",
+		lastPosition: "most recently as %s",
+		skills:       "From the job requirements, I have hands-on experience with %s",
+		example:      "For example: %s",
+		missing:      "I am ready to get up to speed with %s",
+		education:    "Education: %s",
+	},
+}
+
+// letterPhrasesFor возвращает формулировки письма на языке lang, а если их нет — на русском,
+// вместе с языком, на котором они на самом деле написаны
+func letterPhrasesFor(lang string) (letterTexts, string) {
+	if phrases, ok := letterPhrases[lang]; ok {
+		return phrases, lang
+	}
+	return letterPhrases[parser.LangRussian], parser.LangRussian
+}
+
+// normalizeLetterOptions подставляет язык резюме, деловой тон и среднюю длину, если они не заданы
+func normalizeLetterOptions(resume *models.Resume, opts LetterOptions) LetterOptions {
+	if !parser.IsSupportedLanguage(opts.Language) {
+		opts.Language = adviceLanguage(resume)
+	}
+	switch opts.Tone {
+	case models.ToneFormal, models.ToneFriendly, models.ToneEnthusiastic:
+	default:
+		opts.Tone = models.ToneFormal
+	}
+	if _, ok := letterMaxTokens[opts.Length]; !ok {
+		opts.Length = models.LengthMedium
+	}
+	return opts
+}
+
+// joinSentences соединяет предложения в абзац, дописывая точку там, где её нет
+func joinSentences(parts []string) string {
+	var sentences []string
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if !strings.ContainsAny(part[len(part)-1:], ".!?") {
+			part += "."
+		}
+		sentences = append(sentences, part)
+	}
+	return strings.Join(sentences, " ")
+}
+
+// cleanLetter убирает из ответа LLM обрамление блоком кода и лишние пустые строки
+func cleanLetter(text string) string {
+	text = strings.TrimSpace(text)
+	if strings.HasPrefix(text, "```") {
+		text = strings.TrimPrefix(text, "```")
+		if i := strings.IndexByte(text, '\n'); i >= 0 {
+			text = text[i+1:]
+		}
+		text = strings.TrimSuffix(strings.TrimSpace(text), "```")
+	}
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	var cleaned []string
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t")
+		if line == "" && (len(cleaned) == 0 || cleaned[len(cleaned)-1] == "") {
+			continue
+		}
+		cleaned = append(cleaned, line)
+	}
+	return strings.TrimSpace(strings.Join(cleaned, "\n"))
+}
+
+func capitalize(s string) string {
+	r := []rune(s)
+	if len(r) == 0 {
+		return s
+	}
+	return string(unicode.ToUpper(r[0])) + string(r[1:])
+}
+
+func russianYears(n int) string {
+	switch {
+	case n%100 >= 11 && n%100 <= 14:
+		return "лет"
+	case n%10 == 1:
+		return "год"
+	case n%10 >= 2 && n%10 <= 4:
+		return "года"
+	default:
+		return "лет"
+	}
+}
+
+func englishYears(n int) string {
+	if n == 1 {
+		return "year"
+	}
+	return "years"
+}
@@ -0,0 +1,90 @@
+package matching
+
+import (
+	"CVMatch/internal/config"
+	"CVMatch/internal/llm"
+	"CVMatch/internal/models"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateCoverLetterWriter(t *testing.T) {
+	resume, vacancy, breakdown := adviceFixture()
+	resume.FullName = "John Smith"
+
+	letter, err := TemplateCoverLetterWriter{}.Write(context.Background(), resume, vacancy, breakdown, LetterOptions{}, &config.Config{})
+	require.NoError(t, err)
+	require.Equal(t, models.SourceTemplate, letter.Source)
+	require.Equal(t, "en", letter.Language)
+	require.Equal(t, models.ToneFormal, letter.Tone)
+	require.Equal(t, models.LengthMedium, letter.Length)
+	require.Equal(t, "Dear Hiring Manager,\n\n"+
+		"I would like to apply for the Go developer position.\n\n"+
+		"I have 3 years of work experience, most recently as Backend developer at Acme. "+
+		"From the job requirements, I have hands-on experience with Go. "+
+		"For example: Built the billing service in Go.\n\n"+
+		"Thank you for considering my application. I look forward to the opportunity to discuss it in an interview.\n\n"+
+		"Sincerely,\nJohn Smith", letter.Content)
+
+	short, err := TemplateCoverLetterWriter{}.Write(context.Background(), resume, vacancy, breakdown, LetterOptions{Length: models.LengthShort, Tone: models.ToneFriendly}, &config.Config{})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(short.Content, "Hello,\n\n"))
+	require.Len(t, strings.Split(short.Content, "\n\n"), 4)
+
+	long, err := TemplateCoverLetterWriter{}.Write(context.Background(), resume, vacancy, breakdown, LetterOptions{Length: models.LengthLong}, &config.Config{})
+	require.NoError(t, err)
+	require.Contains(t, long.Content, "I am ready to get up to speed with Kafka.")
+}
+
+func TestTemplateCoverLetterWriter_Russian(t *testing.T) {
+	resume, vacancy, breakdown := adviceFixture()
+	resume.ExperienceMonths = 26
+
+	letter, err := TemplateCoverLetterWriter{}.Write(context.Background(), resume, vacancy, breakdown, LetterOptions{Language: "ru", Tone: models.ToneEnthusiastic}, &config.Config{})
+	require.NoError(t, err)
+	require.Equal(t, "ru", letter.Language)
+	require.Contains(t, letter.Content, "С большим интересом откликаюсь на вакансию «Go developer»")
+	require.Contains(t, letter.Content, "У меня 2 года опыта работы, последняя должность — Backend developer в компании Acme.")
+	require.True(t, strings.HasSuffix(letter.Content, "С наилучшими пожеланиями,"))
+}
+
+func TestYandexCoverLetterWriter(t *testing.T) {
+	resume, vacancy, breakdown := adviceFixture()
+	client := &fakeClient{text: "```\nDear Hiring Manager,\n\n\n\nI am applying for the Go developer position.  \n```"}
+
+	letter, err := YandexCoverLetterWriter{Client: client}.Write(context.Background(), resume, vacancy, breakdown, LetterOptions{Tone: models.ToneFriendly, Length: models.LengthLong}, &config.Config{YandexGPTModel: "yandexgpt-lite"})
+	require.NoError(t, err)
+	require.Equal(t, models.SourceLLM, letter.Source)
+	require.Equal(t, "Dear Hiring Manager,\n\nI am applying for the Go developer position.", letter.Content)
+	require.Equal(t, 1600, client.req.MaxTokens)
+	require.Contains(t, client.req.Prompt, "highlight the skills that match the job: Go;")
+	require.Contains(t, client.req.Prompt, "do not claim the candidate knows Kafka")
+
+	_, err = YandexCoverLetterWriter{Client: &fakeClient{text: "  "}}.Write(context.Background(), resume, vacancy, breakdown, LetterOptions{}, &config.Config{})
+	require.ErrorIs(t, err, ErrEmptyCoverLetter)
+}
+
+func TestFallbackCoverLetterWriter_UsesTemplateWhenUnavailable(t *testing.T) {
+	resume, vacancy, breakdown := adviceFixture()
+	writer := FallbackCoverLetterWriter{
+		Primary:  YandexCoverLetterWriter{Client: &fakeClient{err: llm.ErrCircuitOpen}},
+		Fallback: TemplateCoverLetterWriter{},
+	}
+
+	letter, err := writer.Write(context.Background(), resume, vacancy, breakdown, LetterOptions{}, &config.Config{})
+	require.NoError(t, err)
+	require.Equal(t, models.SourceTemplate, letter.Source)
+}
+
+func TestTemplateCoverLetterWriter_RecordsFallbackLanguage(t *testing.T) {
+	resume, vacancy, breakdown := adviceFixture()
+
+	// Готовых фраз на казахском нет: письмо пишется по-русски и не выдаётся за казахское
+	letter, err := TemplateCoverLetterWriter{}.Write(context.Background(), resume, vacancy, breakdown, LetterOptions{Language: "kk"}, &config.Config{})
+	require.NoError(t, err)
+	require.Equal(t, "ru", letter.Language)
+	require.Contains(t, letter.Content, "«Go developer»")
+}
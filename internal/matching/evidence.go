@@ -0,0 +1,115 @@
+package matching
+
+import (
+	"CVMatch/internal/models"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Разделы резюме, из которых берутся подтверждения
+const (
+	SourceExperience = "experience"
+	SourceEducation  = "education"
+	SourceSkills     = "skills"
+	SourceResume     = "resume"
+	SourceResumeText = "resume_text"
+)
+
+// snippetRadius — сколько символов текста показывать по обе стороны от упоминания
+const snippetRadius = 80
+
+// skillEvidence ищет упоминание навыка в опыте работы, затем в тексте документа;
+// если навык есть только в списке навыков, подтверждением служит сам список
+func skillEvidence(resume *models.Resume, skill string) models.Evidence {
+	needle := normalizeSkill(skill)
+	for _, exp := range resume.Experience {
+		text := strings.Join(nonEmpty(exp.Position, exp.Description), ". ")
+		if snippet, ok := snippetAround(text, needle); ok {
+			if exp.Company != "" {
+				snippet = exp.Company + ": " + snippet
+			}
+			return models.Evidence{Requirement: skill, Source: SourceExperience, Snippet: snippet}
+		}
+	}
+	if snippet, ok := snippetAround(resume.File.RawText, needle); ok {
+		return models.Evidence{Requirement: skill, Source: SourceResumeText, Snippet: snippet}
+	}
+	return models.Evidence{Requirement: skill, Source: SourceSkills, Snippet: skill}
+}
+
+// skillYears суммирует стаж мест работы, в должности или описании которых упоминается навык
+func skillYears(experience []models.Experience, skill string) *float64 {
+	needle := normalizeSkill(skill)
+	if needle == "" {
+		return nil
+	}
+	months, found := 0, false
+	for _, exp := range experience {
+		if mentionIndex(strings.ToLower(exp.Position+" "+exp.Description), needle) >= 0 {
+			months += exp.Months
+			found = true
+		}
+	}
+	if !found {
+		return nil
+	}
+	years := round1(float64(months) / 12)
+	return &years
+}
+
+// snippetAround вырезает фрагмент текста вокруг первого упоминания навыка
+func snippetAround(text, needle string) (string, bool) {
+	if needle == "" {
+		return "", false
+	}
+	lower := strings.ToLower(text)
+	if len(lower) != len(text) {
+		// Смена регистра изменила длину в байтах — показываем фрагмент в нижнем регистре
+		text = lower
+	}
+	i := mentionIndex(lower, needle)
+	if i < 0 {
+		return "", false
+	}
+
+	start, end := i, i+len(needle)
+	for n := 0; n < snippetRadius && start > 0; n++ {
+		_, size := utf8.DecodeLastRuneInString(text[:start])
+		start -= size
+	}
+	for n := 0; n < snippetRadius && end < len(text); n++ {
+		_, size := utf8.DecodeRuneInString(text[end:])
+		end += size
+	}
+	snippet := strings.Join(strings.Fields(text[start:end]), " ")
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+	if end < len(text) {
+		snippet += "…"
+	}
+	return snippet, true
+}
+
+// mentionIndex ищет навык как отдельное слово, чтобы "go" не находился в "google"
+func mentionIndex(text, needle string) int {
+	for offset := 0; offset < len(text); {
+		i := strings.Index(text[offset:], needle)
+		if i < 0 {
+			return -1
+		}
+		start, end := offset+i, offset+i+len(needle)
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if !isWordRune(before) && !isWordRune(after) {
+			return start
+		}
+		offset = start + 1
+	}
+	return -1
+}
+
+func isWordRune(r rune) bool {
+	return r != utf8.RuneError && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
@@ -0,0 +1,316 @@
+package matching
+
+import (
+	"CVMatch/internal/models"
+	"CVMatch/internal/parser"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// KnockoutCap — предел итоговой оценки по умолчанию, если сработало правило отсечения
+const KnockoutCap = 40.0
+
+// neutralScore — оценка компонента без данных при нормализации neutral
+const neutralScore = 50.0
+
+// DefaultWeights — веса компонентов по умолчанию, в сумме 1
+var DefaultWeights = models.ComponentWeights{
+	Skills:     0.45,
+	Experience: 0.2,
+	Seniority:  0.1,
+	Education:  0.05,
+	Location:   0.1,
+	Semantic:   0.1,
+}
+
+// DefaultProfileName — название встроенного профиля сравнения
+const DefaultProfileName = "default"
+
+// DefaultProfile — встроенный профиль: веса по умолчанию, отсечение без обязательного навыка
+// и перераспределение весов компонентов без данных
+func DefaultProfile() models.ProfileSnapshot {
+	return models.ProfileSnapshot{
+		Name:          DefaultProfileName,
+		Weights:       DefaultWeights,
+		Knockout:      models.KnockoutRules{MissingRequired: true, Cap: KnockoutCap},
+		Normalization: models.NormalizationRenormalize,
+	}
+}
+
+// Snapshot фиксирует сохранённый профиль для записи в результат сравнения
+func Snapshot(profile *models.MatchingProfile) models.ProfileSnapshot {
+	id := profile.ID
+	return models.ProfileSnapshot{
+		ID:            &id,
+		Name:          profile.Name,
+		Weights:       profile.Weights,
+		Knockout:      profile.Knockout,
+		Normalization: profile.Normalization,
+	}
+}
+
+// Result — результат сравнения резюме и вакансии
+type Result struct {
+	Score float64
+	// Knockout — сработало правило отсечения профиля, Score ограничен его пределом
+	Knockout  bool
+	Breakdown models.ScoreBreakdown
+}
+
+// Calculate считает оценку соответствия резюме вакансии по встроенному профилю
+func Calculate(resume *models.Resume, vacancy *models.Vacancy) Result {
+	return CalculateWithProfile(resume, vacancy, DefaultProfile())
+}
+
+// CalculateWithProfile считает оценку соответствия резюме вакансии от 0 до 100 — взвешенную сумму
+// компонентов (навыки, стаж, уровень, образование, локация, близость текстов) с весами профиля.
+// Компоненты без данных учитываются по способу нормализации профиля. Если не удалось оценить
+// ни один компонент, оценка 0, а разбор помечается NoData: пустое резюме не должно оказаться
+// выше кандидатов, которых действительно удалось оценить.
+// Если срабатывает правило отсечения, оценка не превышает предел профиля.
+func CalculateWithProfile(resume *models.Resume, vacancy *models.Vacancy, profile models.ProfileSnapshot) Result {
+	w := profile.Weights
+	skills, skillsComponent := scoreSkills(resume, vacancy)
+	components := []models.ScoreComponent{
+		weighted(skillsComponent, w.Skills),
+		weighted(scoreExperience(resume, vacancy), w.Experience),
+		weighted(scoreSeniority(resume, vacancy), w.Seniority),
+		weighted(scoreEducation(resume), w.Education),
+		weighted(scoreLocation(resume, vacancy), w.Location),
+		weighted(scoreSemantic(resume, vacancy), w.Semantic),
+	}
+
+	// Без данных компонент при neutral и strict получает фиксированную оценку и сохраняет вес
+	for i := range components {
+		c := &components[i]
+		if c.Applicable {
+			continue
+		}
+		switch profile.Normalization {
+		case models.NormalizationNeutral:
+			c.Score = neutralScore
+			c.Details = "нет данных, нейтральная оценка"
+		case models.NormalizationStrict:
+			c.Score = 0
+			c.Details = "нет данных"
+		}
+	}
+	counted := func(c models.ScoreComponent) bool {
+		return c.Applicable || profile.Normalization == models.NormalizationNeutral || profile.Normalization == models.NormalizationStrict
+	}
+
+	var total float64
+	for _, c := range components {
+		if counted(c) {
+			total += c.Weight
+		}
+	}
+	var score float64
+	for i := range components {
+		c := &components[i]
+		if !counted(*c) || total == 0 {
+			c.Weight = 0
+			continue
+		}
+		weight := c.Weight / total
+		score += c.Score * weight
+		c.Weight = round2(weight)
+		c.Contribution = round1(c.Score * weight)
+	}
+
+	result := Result{
+		Score:     round1(score),
+		Breakdown: models.ScoreBreakdown{Components: components, Skills: skills},
+	}
+	if total == 0 {
+		result.Breakdown.NoData = true
+	}
+	if knockedOut(resume, vacancy, result.Breakdown, profile.Knockout) {
+		result.Knockout = true
+		result.Score = math.Min(result.Score, profile.Knockout.Cap)
+	}
+	return result
+}
+
+// knockedOut проверяет правила отсечения профиля
+func knockedOut(resume *models.Resume, vacancy *models.Vacancy, breakdown models.ScoreBreakdown, rules models.KnockoutRules) bool {
+	if rules.MissingRequired && len(breakdown.MissingRequired()) > 0 {
+		return true
+	}
+	min := vacancy.MinExperienceYears
+	return rules.BelowMinExperience && min != nil && float64(resume.ExperienceMonths)/12 < *min
+}
+
+func weighted(c models.ScoreComponent, weight float64) models.ScoreComponent {
+	c.Weight = weight
+	if c.Evidence == nil {
+		c.Evidence = []models.Evidence{}
+	}
+	return c
+}
+
+// scoreSkills считает взвешенную долю покрытых навыков вакансии.
+// Навык с минимальным стажем засчитывается пропорционально стажу, если его удалось оценить.
+func scoreSkills(resume *models.Resume, vacancy *models.Vacancy) ([]models.SkillMatch, models.ScoreComponent) {
+	have := make(map[string]struct{}, len(resume.Skills))
+	for _, skill := range resume.Skills {
+		have[normalizeSkill(skill.Name)] = struct{}{}
+	}
+	requirements := make(map[uuid.UUID]models.VacancySkill, len(vacancy.Requirements))
+	for _, req := range vacancy.Requirements {
+		requirements[req.SkillID] = req
+	}
+
+	component := models.ScoreComponent{Name: models.ComponentSkills, Applicable: len(vacancy.Skills) > 0}
+	skills := []models.SkillMatch{}
+	var total, credited float64
+	matched := 0
+	for _, skill := range vacancy.Skills {
+		match := models.SkillMatch{Name: skill.Name, Requirement: models.SkillPreferred, Weight: 1}
+		if req, ok := requirements[skill.ID]; ok {
+			match.Requirement = req.Requirement
+			match.Weight = req.Weight
+			match.MinYears = req.MinYears
+		}
+		if match.Weight <= 0 {
+			match.Weight = models.DefaultSkillWeight(match.Requirement)
+		}
+
+		if _, ok := have[normalizeSkill(skill.Name)]; ok {
+			match.Matched = true
+			match.Credit = 1
+			match.Years = skillYears(resume.Experience, skill.Name)
+			if match.MinYears != nil && *match.MinYears > 0 && match.Years != nil && *match.Years < *match.MinYears {
+				match.Credit = round2(*match.Years / *match.MinYears)
+			}
+			component.Evidence = append(component.Evidence, skillEvidence(resume, skill.Name))
+			matched++
+		}
+		total += match.Weight
+		credited += match.Weight * match.Credit
+		skills = append(skills, match)
+	}
+
+	if total > 0 {
+		component.Score = round1(100 * credited / total)
+		component.Details = fmt.Sprintf("%d из %d навыков вакансии", matched, len(vacancy.Skills))
+	}
+	return skills, component
+}
+
+// scoreExperience сравнивает общий стаж с требованием вакансии в годах
+func scoreExperience(resume *models.Resume, vacancy *models.Vacancy) models.ScoreComponent {
+	component := models.ScoreComponent{Name: models.ComponentExperience}
+	min, max := vacancy.MinExperienceYears, vacancy.MaxExperienceYears
+	if min == nil && max == nil {
+		return component
+	}
+	component.Applicable = true
+	years := round1(float64(resume.ExperienceMonths) / 12)
+
+	component.Score = 100
+	switch {
+	case min != nil && *min > 0 && years < *min:
+		component.Score = round1(100 * years / *min)
+		component.Details = fmt.Sprintf("стаж %.1f лет при требовании от %.1f", years, *min)
+	case max != nil && years > *max+overqualifiedYears:
+		component.Score = 90
+		component.Details = fmt.Sprintf("стаж %.1f лет при требовании до %.1f", years, *max)
+	default:
+		component.Details = fmt.Sprintf("стаж %.1f лет соответствует требованию", years)
+	}
+
+	requirement := experienceRequirement(min, max)
+	for _, exp := range resume.Experience {
+		if len(component.Evidence) == maxExperienceEvidence {
+			break
+		}
+		snippet := strings.TrimSpace(strings.Join(nonEmpty(exp.Position, exp.Company), ", "))
+		if snippet == "" {
+			continue
+		}
+		if exp.Months > 0 {
+			snippet += fmt.Sprintf(" — %d мес.", exp.Months)
+		}
+		component.Evidence = append(component.Evidence, models.Evidence{Requirement: requirement, Source: SourceExperience, Snippet: snippet})
+	}
+	return component
+}
+
+// overqualifiedYears — на сколько лет стаж может превышать верхнюю границу вакансии без штрафа
+const overqualifiedYears = 3
+
+// maxExperienceEvidence — сколько мест работы показывать в подтверждение стажа
+const maxExperienceEvidence = 3
+
+func experienceRequirement(min, max *float64) string {
+	switch {
+	case min != nil && max != nil:
+		return fmt.Sprintf("опыт %.1f–%.1f лет", *min, *max)
+	case min != nil:
+		return fmt.Sprintf("опыт от %.1f лет", *min)
+	default:
+		return fmt.Sprintf("опыт до %.1f лет", *max)
+	}
+}
+
+// scoreSeniority переводит множитель SeniorityFactor в баллы
+func scoreSeniority(resume *models.Resume, vacancy *models.Vacancy) models.ScoreComponent {
+	component := models.ScoreComponent{Name: models.ComponentSeniority}
+	if parser.SeniorityRank(resume.Seniority) < 0 || parser.SeniorityRank(vacancy.Seniority) < 0 {
+		return component
+	}
+	component.Applicable = true
+	component.Score = round1(100 * SeniorityFactor(resume.Seniority, vacancy.Seniority))
+	component.Details = fmt.Sprintf("уровень %s при требовании %s", resume.Seniority, vacancy.Seniority)
+	component.Evidence = []models.Evidence{{Requirement: vacancy.Seniority, Source: SourceResume, Snippet: resume.Seniority}}
+	return component
+}
+
+// SeniorityFactor возвращает множитель оценки за разницу уровней кандидата и вакансии.
+// Недостаток уровня штрафуется сильнее, чем избыток; неизвестный уровень не штрафуется.
+func SeniorityFactor(candidate, required string) float64 {
+	c, r := parser.SeniorityRank(candidate), parser.SeniorityRank(required)
+	if c < 0 || r < 0 {
+		return 1
+	}
+	switch diff := c - r; {
+	case diff == 0:
+		return 1
+	case diff == -1:
+		return 0.8
+	case diff == -2:
+		return 0.55
+	case diff < -2:
+		return 0.3
+	case diff == 1:
+		return 0.95
+	default:
+		return 0.85
+	}
+}
+
+func normalizeSkill(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+func nonEmpty(values ...string) []string {
+	var result []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func round1(v float64) float64 {
+	return math.Round(v*10) / 10
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,216 @@
+package matching
+
+import (
+	"CVMatch/internal/models"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculate_SkillsAndSeniority(t *testing.T) {
+	resume := &models.Resume{
+		Seniority: "junior",
+		Skills:    []models.Skill{{Name: "Go"}, {Name: "postgresql"}},
+	}
+	vacancy := &models.Vacancy{
+		Seniority: "middle",
+		Skills:    []models.Skill{{Name: "Go"}, {Name: "PostgreSQL"}, {Name: "Kafka"}, {Name: "Docker"}},
+	}
+
+	result := Calculate(resume, vacancy)
+	require.Equal(t, []string{"Go", "PostgreSQL"}, result.Breakdown.MatchedSkills())
+	require.Equal(t, []string{"Kafka", "Docker"}, result.Breakdown.UnmatchedSkills())
+	require.Equal(t, 50.0, component(t, result, models.ComponentSkills).Score)
+	require.Equal(t, 80.0, component(t, result, models.ComponentSeniority).Score)
+	require.False(t, component(t, result, models.ComponentExperience).Applicable)
+	require.False(t, component(t, result, models.ComponentLocation).Applicable)
+	require.False(t, component(t, result, models.ComponentEducation).Applicable)
+	// Навыки 0.45 и уровень 0.1 нормируются на сумму применимых весов
+	require.Equal(t, 0.82, component(t, result, models.ComponentSkills).Weight)
+	require.Equal(t, 40.9, component(t, result, models.ComponentSkills).Contribution)
+	require.Equal(t, 55.5, result.Score)
+}
+
+func TestCalculateWithProfile(t *testing.T) {
+	resume := &models.Resume{
+		Seniority: "junior",
+		Skills:    []models.Skill{{Name: "Go"}, {Name: "postgresql"}},
+	}
+	vacancy := &models.Vacancy{
+		Seniority: "middle",
+		Skills:    []models.Skill{{Name: "Go"}, {Name: "PostgreSQL"}, {Name: "Kafka"}, {Name: "Docker"}},
+	}
+
+	// Навыки 50 и уровень 80 с равными весами, образование не весит ничего
+	profile := models.ProfileSnapshot{Weights: models.ComponentWeights{Skills: 1, Seniority: 1}, Normalization: models.NormalizationRenormalize}
+	result := CalculateWithProfile(resume, vacancy, profile)
+	require.Equal(t, 65.0, result.Score)
+	require.Equal(t, 0.5, component(t, result, models.ComponentSkills).Weight)
+
+	// Стаж, образование, локация и близость текстов без данных получают 50 баллов с весами по умолчанию
+	profile = DefaultProfile()
+	profile.Normalization = models.NormalizationNeutral
+	result = CalculateWithProfile(resume, vacancy, profile)
+	require.Equal(t, 53.0, result.Score)
+	require.Equal(t, 0.2, component(t, result, models.ComponentExperience).Weight)
+	require.Equal(t, 10.0, component(t, result, models.ComponentExperience).Contribution)
+
+	profile.Normalization = models.NormalizationStrict
+	require.Equal(t, 30.5, CalculateWithProfile(resume, vacancy, profile).Score)
+
+	minYears := 2.0
+	vacancy.MinExperienceYears = &minYears
+	profile = DefaultProfile()
+	require.False(t, CalculateWithProfile(resume, vacancy, profile).Knockout)
+	profile.Knockout = models.KnockoutRules{BelowMinExperience: true, Cap: 20}
+	result = CalculateWithProfile(resume, vacancy, profile)
+	require.True(t, result.Knockout)
+	require.Equal(t, 20.0, result.Score)
+}
+
+func TestCalculate_ExperienceLocationAndEvidence(t *testing.T) {
+	minYears := 4.0
+	relocate := true
+	resume := &models.Resume{
+		Location:         "г. Казань",
+		ReadyToRelocate:  &relocate,
+		ExperienceMonths: 36,
+		Skills:           []models.Skill{{Name: "Go"}, {Name: "Kafka"}},
+		Experience: []models.Experience{
+			{Company: "Ozon", Position: "Backend developer", Description: "Разработка сервисов заказов на Go, очереди на Kafka", Months: 36},
+		},
+		File: models.ResumeFile{RawText: "Backend developer. Go, Kafka, PostgreSQL. Сервисы заказов"},
+	}
+	vacancy := &models.Vacancy{
+		Title:              "Go разработчик",
+		Description:        "Разработка backend сервисов на Go, Kafka и PostgreSQL",
+		Location:           "Москва",
+		RemotePolicy:       "office",
+		MinExperienceYears: &minYears,
+		Skills:             []models.Skill{{Name: "Go"}, {Name: "Kafka"}},
+	}
+
+	result := Calculate(resume, vacancy)
+	experience := component(t, result, models.ComponentExperience)
+	require.True(t, experience.Applicable)
+	require.Equal(t, 75.0, experience.Score)
+	require.Equal(t, "Backend developer, Ozon — 36 мес.", experience.Evidence[0].Snippet)
+
+	location := component(t, result, models.ComponentLocation)
+	require.Equal(t, 70.0, location.Score)
+
+	skills := component(t, result, models.ComponentSkills)
+	require.Len(t, skills.Evidence, 2)
+	require.Equal(t, SourceExperience, skills.Evidence[0].Source)
+	require.Contains(t, skills.Evidence[0].Snippet, "на Go")
+	require.True(t, strings.HasPrefix(skills.Evidence[0].Snippet, "Ozon: "))
+
+	semantic := component(t, result, models.ComponentSemantic)
+	require.True(t, semantic.Applicable)
+	require.Greater(t, semantic.Score, 0.0)
+
+	vacancy.RemotePolicy = "remote"
+	require.Equal(t, 100.0, component(t, Calculate(resume, vacancy), models.ComponentLocation).Score)
+}
+
+func TestSnippetAround_WholeWord(t *testing.T) {
+	_, ok := snippetAround("Аналитика в Google Sheets", "go")
+	require.False(t, ok)
+
+	snippet, ok := snippetAround("Писал на Go микросервисы", "go")
+	require.True(t, ok)
+	require.Equal(t, "Писал на Go микросервисы", snippet)
+}
+
+func component(t *testing.T, result Result, name string) models.ScoreComponent {
+	t.Helper()
+	for _, c := range result.Breakdown.Components {
+		if c.Name == name {
+			return c
+		}
+	}
+	t.Fatalf("component %s not found", name)
+	return models.ScoreComponent{}
+}
+
+func TestCalculate_WeightsAndKnockout(t *testing.T) {
+	goID, kafkaID, dockerID := uuid.New(), uuid.New(), uuid.New()
+	vacancy := &models.Vacancy{
+		Seniority: "middle",
+		Skills:    []models.Skill{{ID: goID, Name: "Go"}, {ID: kafkaID, Name: "Kafka"}, {ID: dockerID, Name: "Docker"}},
+		Requirements: []models.VacancySkill{
+			{SkillID: goID, Requirement: models.SkillRequired, Weight: 2},
+			{SkillID: kafkaID, Requirement: models.SkillRequired, Weight: 2},
+			{SkillID: dockerID, Requirement: models.SkillPreferred, Weight: 1},
+		},
+	}
+
+	full := Calculate(&models.Resume{Seniority: "middle", Skills: []models.Skill{{Name: "go"}, {Name: "Kafka"}}}, vacancy)
+	require.False(t, full.Knockout)
+	require.Equal(t, 80.0, component(t, full, models.ComponentSkills).Score)
+	require.Equal(t, 83.6, full.Score)
+
+	missing := Calculate(&models.Resume{Seniority: "middle", Skills: []models.Skill{{Name: "Go"}, {Name: "Docker"}}}, vacancy)
+	require.True(t, missing.Knockout)
+	require.Equal(t, []string{"Kafka"}, missing.Breakdown.MissingRequired())
+	require.Equal(t, 60.0, component(t, missing, models.ComponentSkills).Score)
+	require.Equal(t, KnockoutCap, missing.Score)
+}
+
+func TestCalculate_MinYears(t *testing.T) {
+	goID := uuid.New()
+	minYears := 4.0
+	vacancy := &models.Vacancy{
+		Skills:       []models.Skill{{ID: goID, Name: "Go"}},
+		Requirements: []models.VacancySkill{{SkillID: goID, Requirement: models.SkillRequired, Weight: 2, MinYears: &minYears}},
+	}
+	resume := &models.Resume{
+		Skills:    []models.Skill{{Name: "Go"}},
+		Education: []models.Education{{Institution: "КФУ", Degree: "Бакалавр"}},
+		Experience: []models.Experience{
+			{Position: "Backend developer", Description: "Сервисы на Go и PostgreSQL", Months: 24},
+			{Position: "Analyst", Description: "Отчёты в Google Sheets", Months: 36},
+		},
+	}
+
+	result := Calculate(resume, vacancy)
+	require.False(t, result.Knockout)
+	require.Len(t, result.Breakdown.Skills, 1)
+	require.Equal(t, 2.0, *result.Breakdown.Skills[0].Years)
+	require.Equal(t, 0.5, result.Breakdown.Skills[0].Credit)
+	require.Equal(t, 100.0, component(t, result, models.ComponentEducation).Score)
+	require.Equal(t, 55.0, result.Score)
+
+	// Без образования компонент не учитывается и не снижает оценку
+	resume.Education = []models.Education{{}}
+	result = Calculate(resume, vacancy)
+	require.False(t, component(t, result, models.ComponentEducation).Applicable)
+	resume.Education = nil
+
+	// Стаж не оценить — навык засчитывается полностью
+	resume.Experience = nil
+	result = Calculate(resume, vacancy)
+	require.Nil(t, result.Breakdown.Skills[0].Years)
+	require.Equal(t, 100.0, result.Score)
+}
+
+func TestCalculate_NoData(t *testing.T) {
+	// Ни навыков, ни требований: оценить нечего, пустое резюме не должно получить высшую оценку
+	result := Calculate(&models.Resume{}, &models.Vacancy{})
+	require.True(t, result.Breakdown.NoData)
+	require.Equal(t, 0.0, result.Score)
+
+	result = Calculate(&models.Resume{Skills: []models.Skill{{Name: "Go"}}}, &models.Vacancy{Skills: []models.Skill{{Name: "Go"}}})
+	require.False(t, result.Breakdown.NoData)
+	require.Equal(t, 100.0, result.Score)
+}
+
+func TestSeniorityFactor(t *testing.T) {
+	require.Equal(t, 1.0, SeniorityFactor("senior", "senior"))
+	require.Equal(t, 1.0, SeniorityFactor("", "senior"))
+	require.Equal(t, 0.3, SeniorityFactor("intern", "senior"))
+	require.Equal(t, 0.95, SeniorityFactor("lead", "senior"))
+	require.Greater(t, SeniorityFactor("senior", "middle"), SeniorityFactor("junior", "middle"))
+}
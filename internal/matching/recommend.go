@@ -0,0 +1,365 @@
+package matching
+
+import (
+	"CVMatch/internal/config"
+	"CVMatch/internal/llm"
+	"CVMatch/internal/models"
+	"CVMatch/internal/parser"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// Ограничения на объём советов
+const (
+	maxMissingKeywords = 10
+	maxBulletRewrites  = 5
+	maxSectionsToAdd   = 3
+)
+
+// maxAdvicePromptRunes — сколько символов текста резюме отправлять в LLM
+const maxAdvicePromptRunes = 12000
+
+// RecommenderI составляет советы, как доработать резюме под вакансию
+type RecommenderI interface {
+	Recommend(ctx context.Context, resume *models.Resume, vacancy *models.Vacancy, breakdown models.ScoreBreakdown, cfg *config.Config) (*models.Recommendations, error)
+}
+
+// YandexRecommender просит YandexGPT сравнить резюме с вакансией; Prompts и Client
+// подставляются так же, как в парсерах
+type YandexRecommender struct {
+	Prompts *parser.PromptSet
+	Client  llm.Client
+}
+
+func (r YandexRecommender) Recommend(ctx context.Context, resume *models.Resume, vacancy *models.Vacancy, breakdown models.ScoreBreakdown, cfg *config.Config) (*models.Recommendations, error) {
+	prompts := r.Prompts
+	if prompts == nil {
+		prompts = parser.DefaultPrompts()
+	}
+	client := r.Client
+	if client == nil {
+		client = llm.NewYandexClient(cfg)
+	}
+
+	lang := adviceLanguage(resume)
+	prompt, err := prompts.Recommendations(resumeAdviceText(resume), vacancyAdviceText(vacancy, lang), breakdown.UnmatchedSkills(), lang)
+	if err != nil {
+		return nil, err
+	}
+	systemPrompt, err := prompts.RecommendationsSystem(lang)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Complete(ctx, llm.Request{
+		Model:         cfg.YandexGPTModel,
+		PromptVersion: prompts.RecommendationsVersion,
+		System:        systemPrompt,
+		Prompt:        prompt,
+		Temperature:   0.5,
+		MaxTokens:     2000,
+	})
+	if err != nil {
+		return nil, err
+	}
+	out, err := parser.DecodeRecommendationsOutput(resp.Text)
+	if err != nil {
+		return nil, err
+	}
+
+	rec := &models.Recommendations{
+		MissingKeywords: limit(uniqueStrings(out.MissingKeywords), maxMissingKeywords),
+		BulletRewrites:  []models.BulletRewrite{},
+		SectionsToAdd:   []models.SectionSuggestion{},
+		Source:          models.SourceLLM,
+		Language:        lang,
+		Model:           cfg.YandexGPTModel,
+		PromptVersion:   prompts.RecommendationsVersion,
+		GeneratedAt:     time.Now().UTC(),
+	}
+	for _, b := range out.BulletRewrites {
+		b.Original, b.Suggested = strings.TrimSpace(b.Original), strings.TrimSpace(b.Suggested)
+		if b.Suggested == "" || b.Suggested == b.Original || len(rec.BulletRewrites) == maxBulletRewrites {
+			continue
+		}
+		rec.BulletRewrites = append(rec.BulletRewrites, models.BulletRewrite{Original: b.Original, Suggested: b.Suggested, Reason: strings.TrimSpace(b.Reason)})
+	}
+	for _, s := range out.SectionsToAdd {
+		s.Section = strings.TrimSpace(s.Section)
+		if s.Section == "" || len(rec.SectionsToAdd) == maxSectionsToAdd {
+			continue
+		}
+		rec.SectionsToAdd = append(rec.SectionsToAdd, models.SectionSuggestion{Section: s.Section, Reason: strings.TrimSpace(s.Reason)})
+	}
+	return rec, nil
+}
+
+// FallbackRecommender составляет советы основным способом, а если провайдер LLM недоступен — запасным
+type FallbackRecommender struct {
+	Primary  RecommenderI
+	Fallback RecommenderI
+}
+
+func (r FallbackRecommender) Recommend(ctx context.Context, resume *models.Resume, vacancy *models.Vacancy, breakdown models.ScoreBreakdown, cfg *config.Config) (*models.Recommendations, error) {
+	rec, err := r.Primary.Recommend(ctx, resume, vacancy, breakdown, cfg)
+	if err == nil || !llm.IsUnavailable(err) || ctx.Err() != nil {
+		return rec, err
+	}
+	rec, fallbackErr := r.Fallback.Recommend(ctx, resume, vacancy, breakdown, cfg)
+	if fallbackErr != nil {
+		return nil, errors.Join(err, fallbackErr)
+	}
+	return rec, nil
+}
+
+// TemplateRecommender составляет советы по правилам, без LLM: недостающие навыки вакансии,
+// пункты опыта без упоминания навыков или измеримого результата и отсутствующие разделы.
+// Для одного и того же резюме и вакансии советы всегда одинаковые.
+type TemplateRecommender struct{}
+
+func (TemplateRecommender) Recommend(_ context.Context, resume *models.Resume, vacancy *models.Vacancy, breakdown models.ScoreBreakdown, _ *config.Config) (*models.Recommendations, error) {
+	phrases, lang := phrasesFor(adviceLanguage(resume))
+
+	rec := &models.Recommendations{
+		MissingKeywords: []string{},
+		BulletRewrites:  []models.BulletRewrite{},
+		SectionsToAdd:   []models.SectionSuggestion{},
+		Source:          models.SourceTemplate,
+		Language:        lang,
+		GeneratedAt:     time.Now().UTC(),
+	}
+
+	// Сначала обязательные навыки, потом желательные
+	for _, required := range []bool{true, false} {
+		for _, skill := range breakdown.Skills {
+			if !skill.Matched && (skill.Requirement == models.SkillRequired) == required {
+				rec.MissingKeywords = append(rec.MissingKeywords, skill.Name)
+			}
+		}
+	}
+	rec.MissingKeywords = limit(rec.MissingKeywords, maxMissingKeywords)
+
+	// Навыки, которые есть в списке, но не подтверждены опытом работы
+	var unproven []string
+	for _, skill := range breakdown.Skills {
+		if skill.Matched && skill.Years == nil {
+			unproven = append(unproven, skill.Name)
+		}
+	}
+	for _, exp := range resume.Experience {
+		if len(rec.BulletRewrites) == maxBulletRewrites {
+			break
+		}
+		original := firstSentence(exp.Description)
+		if original == "" {
+			continue
+		}
+		var additions, reasons []string
+		if len(unproven) > 0 {
+			additions = append(additions, fmt.Sprintf(phrases.usingSkills, strings.Join(limit(unproven, 3), ", ")))
+			reasons = append(reasons, phrases.skillsReason)
+			unproven = nil
+		}
+		if !strings.ContainsFunc(exp.Description, unicode.IsDigit) {
+			additions = append(additions, phrases.addMetric)
+			reasons = append(reasons, phrases.metricReason)
+		}
+		if len(additions) == 0 {
+			continue
+		}
+		rec.BulletRewrites = append(rec.BulletRewrites, models.BulletRewrite{
+			Original:  original,
+			Suggested: strings.TrimRight(original, ".!;") + ", " + strings.Join(additions, ", ") + ".",
+			Reason:    strings.Join(reasons, " "),
+		})
+	}
+
+	addSection := func(section, reason string) {
+		if len(rec.SectionsToAdd) < maxSectionsToAdd {
+			rec.SectionsToAdd = append(rec.SectionsToAdd, models.SectionSuggestion{Section: section, Reason: reason})
+		}
+	}
+	if len(resume.Skills) == 0 && len(vacancy.Skills) > 0 {
+		addSection(phrases.skillsSection, phrases.skillsSectionReason)
+	}
+	if len(resume.Projects) == 0 && (len(rec.MissingKeywords) > 0 || resume.ExperienceMonths < 24) {
+		addSection(phrases.projectsSection, phrases.projectsSectionReason)
+	}
+	if len(resume.Certifications) == 0 && len(breakdown.MissingRequired()) > 0 {
+		addSection(phrases.certificationsSection, phrases.certificationsSectionReason)
+	}
+	if len(resume.Education) == 0 {
+		addSection(phrases.educationSection, phrases.educationSectionReason)
+	}
+	if len(resume.Links) == 0 {
+		addSection(phrases.linksSection, phrases.linksSectionReason)
+	}
+	return rec, nil
+}
+
+// adviceTexts — формулировки шаблонных советов на одном языке
+type adviceTexts struct {
+	requiredLabel               string
+	preferredLabel              string
+	usingSkills                 string
+	skillsReason                string
+	addMetric                   string
+	metricReason                string
+	skillsSection               string
+	skillsSectionReason         string
+	projectsSection             string
+	projectsSectionReason       string
+	certificationsSection       string
+	certificationsSectionReason string
+	educationSection            string
+	educationSectionReason      string
+	linksSection                string
+	linksSectionReason          string
+}
+
+var advicePhrases = map[string]adviceTexts{
+	parser.LangRussian: {
+		requiredLabel:               "Обязательные навыки",
+		preferredLabel:              "Желательные навыки",
+		usingSkills:                 "используя %s",
+		skillsReason:                "Навыки из списка не подтверждены опытом работы — покажите, где вы их применяли.",
+		addMetric:                   "что дало измеримый результат (например, «сократил время ответа на 30%»)",
+		metricReason:                "Цифры показывают масштаб и результат работы.",
+		skillsSection:               "Навыки",
+		skillsSectionReason:         "Перечислите технологии и инструменты, с которыми работали: по ним резюме находят и сравнивают с вакансией.",
+		projectsSection:             "Проекты",
+		projectsSectionReason:       "Опишите проекты, где вы применяли навыки из вакансии, со ссылками на код или результат.",
+		certificationsSection:       "Сертификаты и курсы",
+		certificationsSectionReason: "Курсы по обязательным навыкам вакансии покажут, что вы их осваиваете.",
+		educationSection:            "Образование",
+		educationSectionReason:      "Укажите образование, даже если оно не профильное.",
+		linksSection:                "Ссылки",
+		linksSectionReason:          "Добавьте GitHub, портфолио или профиль, где можно посмотреть вашу работу.",
+	},
+	parser.LangEnglish: {
+		requiredLabel:               "Required skills",
+		preferredLabel:              "Nice to have",
+		usingSkills:                 "using %s",
+		skillsReason:                "Listed skills are not backed by work experience — show where you applied them.",
+		addMetric:                   "with a measurable result (e.g. \"cut response time by 30%\")",
+		metricReason:                "Numbers show the scale and impact of your work.",
+		skillsSection:               "Skills",
+		skillsSectionReason:         "List the technologies and tools you have worked with: resumes are found and matched by them.",
+		projectsSection:             "Projects",
+		projectsSectionReason:       "Describe projects where you applied the skills from the job posting, with links to code or results.",
+		certificationsSection:       "Certifications and courses",
+		certificationsSectionReason: "Courses on the required skills show that you are learning them.",
+		educationSection:            "Education",
+		educationSectionReason:      "Add your education, even if it is not in the field.",
+		linksSection:                "Links",
+		linksSectionReason:          "Add GitHub, a portfolio or a profile where your work can be seen.",
+	},
+}
+
+// phrasesFor возвращает формулировки на языке lang, а если их нет — на русском,
+// вместе с языком, на котором они на самом деле написаны
+func phrasesFor(lang string) (adviceTexts, string) {
+	if phrases, ok := advicePhrases[lang]; ok {
+		return phrases, lang
+	}
+	return advicePhrases[parser.LangRussian], parser.LangRussian
+}
+
+// adviceLanguage — язык советов: язык сохранённых значений резюме, иначе язык документа
+func adviceLanguage(resume *models.Resume) string {
+	for _, lang := range []string{resume.ContentLanguage, resume.Language} {
+		if parser.IsSupportedLanguage(lang) {
+			return lang
+		}
+	}
+	return parser.LangRussian
+}
+
+// resumeAdviceText — текст резюме для промпта: текст документа, а если его нет — опыт и навыки
+func resumeAdviceText(resume *models.Resume) string {
+	text := strings.TrimSpace(resume.File.RawText)
+	if text == "" {
+		var b strings.Builder
+		for _, exp := range resume.Experience {
+			fmt.Fprintf(&b, "%s\n%s\n\n", strings.Join(nonEmpty(exp.Position, exp.Company), ", "), exp.Description)
+		}
+		var skills []string
+		for _, skill := range resume.Skills {
+			skills = append(skills, skill.Name)
+		}
+		b.WriteString(strings.Join(skills, ", "))
+		text = b.String()
+	}
+	if runes := []rune(text); len(runes) > maxAdvicePromptRunes {
+		text = string(runes[:maxAdvicePromptRunes])
+	}
+	return text
+}
+
+// vacancyAdviceText — описание вакансии для промпта с навыками по типу требования
+func vacancyAdviceText(vacancy *models.Vacancy, lang string) string {
+	requirements := make(map[uuid.UUID]string, len(vacancy.Requirements))
+	for _, req := range vacancy.Requirements {
+		requirements[req.SkillID] = req.Requirement
+	}
+	var required, preferred []string
+	for _, skill := range vacancy.Skills {
+		if requirements[skill.ID] == models.SkillRequired {
+			required = append(required, skill.Name)
+		} else {
+			preferred = append(preferred, skill.Name)
+		}
+	}
+
+	phrases, _ := phrasesFor(lang)
+	lines := nonEmpty(vacancy.Title, vacancy.Description)
+	if len(required) > 0 {
+		lines = append(lines, phrases.requiredLabel+": "+strings.Join(required, ", "))
+	}
+	if len(preferred) > 0 {
+		lines = append(lines, phrases.preferredLabel+": "+strings.Join(preferred, ", "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// firstSentence возвращает первую строку или предложение описания
+func firstSentence(text string) string {
+	text = strings.TrimSpace(text)
+	if i := strings.IndexAny(text, "\n"); i >= 0 {
+		text = text[:i]
+	}
+	if i := strings.Index(text, ". "); i >= 0 {
+		text = text[:i+1]
+	}
+	return strings.TrimSpace(strings.TrimLeft(text, "-•* "))
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]struct{}, len(values))
+	result := []string{}
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		key := strings.ToLower(v)
+		if v == "" {
+			continue
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		result = append(result, v)
+	}
+	return result
+}
+
+func limit(values []string, n int) []string {
+	if len(values) > n {
+		return values[:n]
+	}
+	return values
+}
@@ -0,0 +1,123 @@
+package matching
+
+import (
+	"CVMatch/internal/config"
+	"CVMatch/internal/llm"
+	"CVMatch/internal/models"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClient struct {
+	text string
+	err  error
+	req  llm.Request
+}
+
+func (f *fakeClient) Complete(_ context.Context, req llm.Request) (*llm.Response, error) {
+	f.req = req
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &llm.Response{Text: f.text, Model: req.Model, PromptVersion: req.PromptVersion}, nil
+}
+
+func adviceFixture() (*models.Resume, *models.Vacancy, models.ScoreBreakdown) {
+	resume := &models.Resume{
+		Language:         "en",
+		ExperienceMonths: 36,
+		Skills:           []models.Skill{{Name: "Go"}},
+		Experience: []models.Experience{
+			{Company: "Acme", Position: "Backend developer", Description: "Built the billing service in Go. Reduced costs by 20%."},
+			{Company: "Initech", Position: "Developer", Description: "- Maintained internal tools"},
+		},
+	}
+	vacancy := &models.Vacancy{Title: "Go developer", Description: "Billing platform", Skills: []models.Skill{{Name: "Go"}, {Name: "Kafka"}}}
+	years := 3.0
+	breakdown := models.ScoreBreakdown{Skills: []models.SkillMatch{
+		{Name: "Go", Requirement: models.SkillRequired, Matched: true, Years: &years, Credit: 1},
+		{Name: "Kafka", Requirement: models.SkillRequired},
+	}}
+	return resume, vacancy, breakdown
+}
+
+func TestTemplateRecommender(t *testing.T) {
+	resume, vacancy, breakdown := adviceFixture()
+
+	rec, err := TemplateRecommender{}.Recommend(context.Background(), resume, vacancy, breakdown, &config.Config{})
+	require.NoError(t, err)
+	require.Equal(t, models.SourceTemplate, rec.Source)
+	require.Equal(t, "en", rec.Language)
+	require.Equal(t, []string{"Kafka"}, rec.MissingKeywords)
+	// В первом месте работы уже есть цифры и навык, переписывать нечего
+	require.Len(t, rec.BulletRewrites, 1)
+	require.Equal(t, "Maintained internal tools", rec.BulletRewrites[0].Original)
+	require.Contains(t, rec.BulletRewrites[0].Suggested, "measurable result")
+	require.Equal(t, []string{"Projects", "Certifications and courses", "Education"}, sectionNames(rec.SectionsToAdd))
+
+	again, err := TemplateRecommender{}.Recommend(context.Background(), resume, vacancy, breakdown, &config.Config{})
+	require.NoError(t, err)
+	again.GeneratedAt = rec.GeneratedAt
+	require.Equal(t, rec, again)
+}
+
+func TestTemplateRecommender_RecordsFallbackLanguage(t *testing.T) {
+	resume, vacancy, breakdown := adviceFixture()
+	resume.Language, resume.ContentLanguage = "uz", "uz"
+
+	// Готовых формулировок на узбекском нет: советы пишутся по-русски и так и помечаются
+	rec, err := TemplateRecommender{}.Recommend(context.Background(), resume, vacancy, breakdown, &config.Config{})
+	require.NoError(t, err)
+	require.Equal(t, "ru", rec.Language)
+	require.Equal(t, "Проекты", rec.SectionsToAdd[0].Section)
+}
+
+func TestYandexRecommender(t *testing.T) {
+	resume, vacancy, breakdown := adviceFixture()
+	client := &fakeClient{text: "```json\n" + `{
+		"match_id": "injected",
+		"source": "template",
+		"language": "ru",
+		"missing_keywords": ["Kafka", "kafka", " "],
+		"bullet_rewrites": [{"original": "Maintained internal tools", "suggested": "Maintained 5 internal Go tools used by 40 engineers", "reason": "Shows scale"}],
+		"sections_to_add": [{"section": "Projects", "reason": "Show Kafka experience"}, {"section": ""}]
+	}` + "\n```"}
+
+	rec, err := YandexRecommender{Client: client}.Recommend(context.Background(), resume, vacancy, breakdown, &config.Config{YandexGPTModel: "yandexgpt-lite"})
+	require.NoError(t, err)
+	// Служебные поля задаёт сервис, а не модель
+	require.Equal(t, models.SourceLLM, rec.Source)
+	require.Equal(t, "en", rec.Language)
+	require.Equal(t, "yandexgpt-lite", rec.Model)
+	require.Equal(t, []string{"Kafka"}, rec.MissingKeywords)
+	require.Len(t, rec.BulletRewrites, 1)
+	require.Equal(t, []string{"Projects"}, sectionNames(rec.SectionsToAdd))
+	require.Contains(t, client.req.Prompt, "Skill comparison did not find in the resume: Kafka.")
+	require.Contains(t, client.req.Prompt, "Built the billing service in Go")
+}
+
+func TestFallbackRecommender_UsesTemplateWhenUnavailable(t *testing.T) {
+	resume, vacancy, breakdown := adviceFixture()
+	recommender := FallbackRecommender{
+		Primary:  YandexRecommender{Client: &fakeClient{err: llm.ErrCircuitOpen}},
+		Fallback: TemplateRecommender{},
+	}
+
+	rec, err := recommender.Recommend(context.Background(), resume, vacancy, breakdown, &config.Config{})
+	require.NoError(t, err)
+	require.Equal(t, models.SourceTemplate, rec.Source)
+
+	recommender.Primary = YandexRecommender{Client: &fakeClient{err: llm.ErrQuotaExceeded}}
+	_, err = recommender.Recommend(context.Background(), resume, vacancy, breakdown, &config.Config{})
+	require.ErrorIs(t, err, llm.ErrQuotaExceeded)
+}
+
+func sectionNames(sections []models.SectionSuggestion) []string {
+	names := []string{}
+	for _, s := range sections {
+		names = append(names, s.Section)
+	}
+	return names
+}
@@ -0,0 +1,117 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// Counter — монотонно растущий счётчик
+type Counter struct {
+	name  string
+	help  string
+	value atomic.Int64
+}
+
+func (c *Counter) Inc() {
+	c.value.Add(1)
+}
+
+func (c *Counter) Add(n int64) {
+	c.value.Add(n)
+}
+
+func (c *Counter) Value() int64 {
+	return c.value.Load()
+}
+
+type gaugeFunc struct {
+	name string
+	help string
+	fn   func() float64
+}
+
+// Registry хранит метрики процесса и отдаёт их в текстовом формате Prometheus
+type Registry struct {
+	mu       sync.Mutex
+	counters map[string]*Counter
+	gauges   map[string]*gaugeFunc
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		counters: make(map[string]*Counter),
+		gauges:   make(map[string]*gaugeFunc),
+	}
+}
+
+// Default — реестр, который отдаётся на /metrics
+var Default = NewRegistry()
+
+// NewCounter регистрирует счётчик в Default. Повторная регистрация возвращает уже существующий счётчик.
+func NewCounter(name, help string) *Counter {
+	return Default.Counter(name, help)
+}
+
+// NewGaugeFunc регистрирует в Default показатель, значение которого вычисляется при отдаче метрик
+func NewGaugeFunc(name, help string, fn func() float64) {
+	Default.GaugeFunc(name, help, fn)
+}
+
+func (r *Registry) Counter(name, help string) *Counter {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if c, ok := r.counters[name]; ok {
+		return c
+	}
+	c := &Counter{name: name, help: help}
+	r.counters[name] = c
+	return c
+}
+
+// GaugeFunc регистрирует показатель; повторная регистрация заменяет функцию
+func (r *Registry) GaugeFunc(name, help string, fn func() float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.gauges[name] = &gaugeFunc{name: name, help: help, fn: fn}
+}
+
+// Write выводит метрики в текстовом формате Prometheus, отсортированными по имени
+func (r *Registry) Write(w io.Writer) error {
+	r.mu.Lock()
+	counters := make([]*Counter, 0, len(r.counters))
+	for _, c := range r.counters {
+		counters = append(counters, c)
+	}
+	gauges := make([]*gaugeFunc, 0, len(r.gauges))
+	for _, g := range r.gauges {
+		gauges = append(gauges, g)
+	}
+	r.mu.Unlock()
+
+	sort.Slice(counters, func(i, j int) bool { return counters[i].name < counters[j].name })
+	sort.Slice(gauges, func(i, j int) bool { return gauges[i].name < gauges[j].name })
+
+	for _, c := range counters {
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n%s %d\n", c.name, c.help, c.name, c.name, c.Value()); err != nil {
+			return err
+		}
+	}
+	for _, g := range gauges {
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n%s %g\n", g.name, g.help, g.name, g.name, g.fn()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Handler отдаёт метрики реестра Default
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_ = Default.Write(w)
+	})
+}
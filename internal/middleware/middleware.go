@@ -1,28 +1,72 @@
-package middleware
-
-import (
-	"CVMatch/internal/config"
-	"CVMatch/internal/jwt"
-	"net/http"
-	"strings"
-
-	"github.com/gin-gonic/gin"
-)
-
-func JWTAuth(cfg *config.JWTConfig) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Missing or invalid token"})
-			return
-		}
-		tokenStr := strings.TrimPrefix(authHeader, "Bearer ")
-		claims, err := jwt.ParseAccessToken(tokenStr, cfg.Access)
-		if err != nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
-			return
-		}
-		c.Set("user_id", claims.UserID)
-		c.Next()
-	}
-}
+package middleware
+
+import (
+	"CVMatch/internal/config"
+	"CVMatch/internal/jwt"
+	"CVMatch/internal/repository"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func JWTAuth(cfg *config.JWTConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader("Authorization")
+		if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Missing or invalid token"})
+			return
+		}
+		tokenStr := strings.TrimPrefix(authHeader, "Bearer ")
+		claims, err := jwt.ParseAccessToken(tokenStr, cfg.Access)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+			return
+		}
+		c.Set("user_id", claims.UserID)
+		c.Next()
+	}
+}
+
+// RequireRole пропускает только пользователей с указанной ролью.
+// Роль читается из базы, а не из токена, чтобы отзыв прав действовал сразу.
+func RequireRole(users repository.UserRepositoryI, role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := c.Get("user_id")
+		if !exists {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+			return
+		}
+		user, err := users.FindByID(userID.(string))
+		if err != nil || user.Role != role {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Forbidden"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireWorkspaceRole пропускает только участников с одной из ролей в текущем рабочем
+// пространстве пользователя. Как и RequireRole, роль читается из базы на каждый запрос.
+func RequireWorkspaceRole(organizations repository.OrganizationRepositoryI, roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := c.Get("user_id")
+		if !exists {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+			return
+		}
+		userUUID, err := uuid.Parse(userID.(string))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+			return
+		}
+		member, err := organizations.ActiveMember(userUUID)
+		if err != nil || !slices.Contains(roles, member.Role) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Forbidden"})
+			return
+		}
+		c.Next()
+	}
+}
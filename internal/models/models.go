@@ -1,156 +1,805 @@
-package models
-
-import (
-	"time"
-
-	"github.com/google/uuid"
-	"gorm.io/gorm"
-)
-
-// User — пользователь системы
-type User struct {
-	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
-	Email     string    `gorm:"type:varchar(255);unique;not null"`
-	Nickname  string    `gorm:"type:varchar(255)"`
-	Password  string    `gorm:"type:varchar(255);not null"`
-	Role      string    `gorm:"type:varchar(50);default:user"`
-	Resumes   []Resume  `gorm:"foreignKey:UserID"`
-	Vacancies []Vacancy `gorm:"foreignKey:UserID"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	DeletedAt gorm.DeletedAt `gorm:"index"`
-}
-
-func (u *User) BeforeCreate(tx *gorm.DB) (err error) {
-	u.ID = uuid.New()
-	return
-}
-
-// Resume — информация о загруженном резюме
-type Resume struct {
-	ID         uuid.UUID    `gorm:"type:uuid;primaryKey"`
-	UserID     uuid.UUID    `gorm:"type:uuid;not null;index"`
-	User       User         `gorm:"foreignKey:UserID"`
-	FullName   string       `gorm:"type:varchar(255);not null"`
-	Email      string       `gorm:"type:varchar(255)"`
-	Phone      string       `gorm:"type:varchar(50)"`
-	Location   string       `gorm:"type:varchar(255)"`
-	Skills     []Skill      `gorm:"many2many:resume_skills;"`
-	Experience []Experience `gorm:"foreignKey:ResumeID;constraint:OnDelete:CASCADE"`
-	Education  []Education  `gorm:"foreignKey:ResumeID;constraint:OnDelete:CASCADE"`
-	File       ResumeFile   `gorm:"foreignKey:ResumeID;constraint:OnDelete:CASCADE"`
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
-	DeletedAt  gorm.DeletedAt `gorm:"index"`
-}
-
-func (m *Resume) BeforeCreate(tx *gorm.DB) (err error) {
-	m.ID = uuid.New()
-	return
-}
-
-// ResumeFile — файл с резюме
-type ResumeFile struct {
-	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
-	ResumeID  uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
-	Path      string    `gorm:"type:varchar(512);not null"`
-	MimeType  string    `gorm:"type:varchar(100)"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	DeletedAt gorm.DeletedAt `gorm:"index"`
-}
-
-func (m *ResumeFile) BeforeCreate(tx *gorm.DB) (err error) {
-	m.ID = uuid.New()
-	return
-}
-
-// Skill — отдельный навык (используется для резюме и вакансии)
-type Skill struct {
-	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
-	Name      string    `gorm:"type:varchar(100);unique;not null"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	DeletedAt gorm.DeletedAt `gorm:"index"`
-}
-
-func (m *Skill) BeforeCreate(tx *gorm.DB) (err error) {
-	m.ID = uuid.New()
-	return
-}
-
-// Experience — опыт работы
-type Experience struct {
-	ID          uuid.UUID `gorm:"type:uuid;primaryKey"`
-	ResumeID    uuid.UUID `gorm:"type:uuid;not null;index"`
-	Company     string    `gorm:"type:varchar(255)"`
-	Position    string    `gorm:"type:varchar(255)"`
-	StartDate   string    `gorm:"type:varchar(32)"`
-	EndDate     string    `gorm:"type:varchar(32)"`
-	Description string    `gorm:"type:text"`
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	DeletedAt   gorm.DeletedAt `gorm:"index"`
-}
-
-func (m *Experience) BeforeCreate(tx *gorm.DB) (err error) {
-	m.ID = uuid.New()
-	return
-}
-
-// Education — образование
-type Education struct {
-	ID          uuid.UUID `gorm:"type:uuid;primaryKey"`
-	ResumeID    uuid.UUID `gorm:"type:uuid;not null;index"`
-	Institution string    `gorm:"type:varchar(255)"`
-	Degree      string    `gorm:"type:varchar(255)"`
-	Field       string    `gorm:"type:varchar(255)"`
-	StartDate   string    `gorm:"type:varchar(32)"`
-	EndDate     string    `gorm:"type:varchar(32)"`
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	DeletedAt   gorm.DeletedAt `gorm:"index"`
-}
-
-func (m *Education) BeforeCreate(tx *gorm.DB) (err error) {
-	m.ID = uuid.New()
-	return
-}
-
-// Vacancy — вакансия (Job Description)
-type Vacancy struct {
-	ID          uuid.UUID `gorm:"type:uuid;primaryKey"`
-	UserID      uuid.UUID `gorm:"type:uuid;not null;index"`
-	User        User      `gorm:"foreignKey:UserID"`
-	Title       string    `gorm:"type:varchar(255);not null"`
-	Description string    `gorm:"type:text"`
-	Location    string    `gorm:"type:varchar(255)"`
-	Skills      []Skill   `gorm:"many2many:vacancy_skills;"`
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	DeletedAt   gorm.DeletedAt `gorm:"index"`
-}
-
-func (m *Vacancy) BeforeCreate(tx *gorm.DB) (err error) {
-	m.ID = uuid.New()
-	return
-}
-
-// MatchingResult — результат сравнения резюме и вакансии
-type MatchingResult struct {
-	ID              uuid.UUID `gorm:"type:uuid;primaryKey"`
-	ResumeID        uuid.UUID `gorm:"type:uuid;not null;index"`
-	VacancyID       uuid.UUID `gorm:"type:uuid;not null;index"`
-	Score           float64
-	MatchedSkills   string `gorm:"type:text"` // JSON-строка с совпавшими навыками
-	UnmatchedSkills string `gorm:"type:text"` // JSON-строка с несовпавшими
-	Recommendations string `gorm:"type:text"` // JSON-строка с советами
-	CreatedAt       time.Time
-	UpdatedAt       time.Time
-	DeletedAt       gorm.DeletedAt `gorm:"index"`
-}
-
-func (m *MatchingResult) BeforeCreate(tx *gorm.DB) (err error) {
-	m.ID = uuid.New()
-	return
-}
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Роли пользователей
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+// Типы требований к навыку вакансии
+const (
+	SkillRequired  = "required"  // обязательный: отсутствие ограничивает итоговую оценку
+	SkillPreferred = "preferred" // желательный: только добавляет баллы
+)
+
+// User — пользователь системы
+type User struct {
+	ID                   uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	Email                string     `gorm:"type:varchar(255);unique;not null"`
+	Nickname             string     `gorm:"type:varchar(255)"`
+	Password             string     `gorm:"type:varchar(255);not null"`
+	Role                 string     `gorm:"type:varchar(50);default:user"`
+	MonthlyTokenQuota    *int64     `gorm:"type:bigint"`                  // nil — квота по умолчанию, 0 — без ограничений
+	CalendarToken        *string    `gorm:"type:varchar(64);uniqueIndex"` // секрет ссылки на календарь интервью, nil — ссылка не выдана
+	ActiveOrganizationID *uuid.UUID `gorm:"type:uuid;index"`              // текущее рабочее пространство: резюме и вакансии видны и создаются только в нём
+	Resumes              []Resume   `gorm:"foreignKey:UserID"`
+	Vacancies            []Vacancy  `gorm:"foreignKey:UserID"`
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+	DeletedAt            gorm.DeletedAt `gorm:"index"`
+}
+
+func (u *User) BeforeCreate(tx *gorm.DB) (err error) {
+	u.ID = uuid.New()
+	return
+}
+
+// Resume — информация о загруженном резюме
+type Resume struct {
+	ID               uuid.UUID        `gorm:"type:uuid;primaryKey"`
+	OrganizationID   uuid.UUID        `gorm:"type:uuid;index"`          // рабочее пространство, которому принадлежит резюме
+	UserID           uuid.UUID        `gorm:"type:uuid;not null;index"` // кто загрузил резюме
+	User             User             `gorm:"foreignKey:UserID"`
+	FullName         string           `gorm:"type:varchar(255);not null"`
+	Email            string           `gorm:"type:varchar(255)"`
+	Phone            string           `gorm:"type:varchar(50)"`
+	Location         string           `gorm:"type:varchar(255)"`
+	ExperienceMonths int              `gorm:"not null;default:0;index"` // суммарный стаж в месяцах без учёта пересечений
+	TitleFamily      string           `gorm:"type:varchar(32);index"`   // backend, frontend, qa, data, pm...
+	Seniority        string           `gorm:"type:varchar(16);index"`   // intern, junior, middle, senior, lead
+	DesiredSalary    *int             `gorm:"type:integer"`             // желаемая зарплата в месяц
+	SalaryCurrency   string           `gorm:"type:varchar(8)"`          // RUB, USD, EUR, KZT, UZS...
+	ReadyToRelocate  *bool            `gorm:"type:boolean"`             // nil — в резюме не указано
+	RemotePreference string           `gorm:"type:varchar(16);index"`   // remote, hybrid, office
+	Confidence       string           `gorm:"type:text"`                // JSON-строка с уверенностью по полям
+	NeedsReview      bool             `gorm:"not null;default:false;index"`
+	Language         string           `gorm:"type:varchar(8);index"`  // язык документа: ru, en, kk, uz
+	ContentLanguage  string           `gorm:"type:varchar(8);index"`  // язык сохранённых значений, отличается от Language при переводе
+	PromptVersion    string           `gorm:"type:varchar(64);index"` // версия шаблонов промпта, которой разобрано резюме
+	LLMModel         string           `gorm:"type:varchar(64)"`
+	Version          int              `gorm:"not null;default:1"`  // растёт при каждой правке, по нему видно устаревшие сравнения
+	Rating           *int             `gorm:"type:smallint;index"` // оценка рекрутера 1–5, nil — не оценено
+	Skills           []Skill          `gorm:"many2many:resume_skills;"`
+	Tags             []Tag            `gorm:"many2many:resume_tags;"`
+	Experience       []Experience     `gorm:"foreignKey:ResumeID;constraint:OnDelete:CASCADE"`
+	Education        []Education      `gorm:"foreignKey:ResumeID;constraint:OnDelete:CASCADE"`
+	Languages        []ResumeLanguage `gorm:"foreignKey:ResumeID;constraint:OnDelete:CASCADE"`
+	Certifications   []Certification  `gorm:"foreignKey:ResumeID;constraint:OnDelete:CASCADE"`
+	Projects         []Project        `gorm:"foreignKey:ResumeID;constraint:OnDelete:CASCADE"`
+	Links            []ResumeLink     `gorm:"foreignKey:ResumeID;constraint:OnDelete:CASCADE"`
+	File             ResumeFile       `gorm:"foreignKey:ResumeID;constraint:OnDelete:CASCADE"`
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	DeletedAt        gorm.DeletedAt `gorm:"index"`
+}
+
+// BeforeCreate сохраняет заранее выданный ID: под ним резюме уже учтено в расходе токенов на разбор
+func (m *Resume) BeforeCreate(tx *gorm.DB) (err error) {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return
+}
+
+// ResumeFile — файл с резюме
+type ResumeFile struct {
+	ID               uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ResumeID         uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
+	Path             string    `gorm:"type:varchar(512);not null"`
+	MimeType         string    `gorm:"type:varchar(100)"`
+	RawText          string    `gorm:"type:text"` // текст, извлечённый из документа
+	PageCount        int       `gorm:"not null;default:0"`
+	Language         string    `gorm:"type:varchar(8)"`  // ru, en, kk, uz
+	ExtractionMethod string    `gorm:"type:varchar(32)"` // pdf_text
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	DeletedAt        gorm.DeletedAt `gorm:"index"`
+}
+
+func (m *ResumeFile) BeforeCreate(tx *gorm.DB) (err error) {
+	m.ID = uuid.New()
+	return
+}
+
+// Skill — отдельный навык (используется для резюме и вакансии)
+type Skill struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Name      string    `gorm:"type:varchar(100);unique;not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+func (m *Skill) BeforeCreate(tx *gorm.DB) (err error) {
+	m.ID = uuid.New()
+	return
+}
+
+// Experience — опыт работы
+type Experience struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	ResumeID       uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Company        string     `gorm:"type:varchar(255)"`
+	Position       string     `gorm:"type:varchar(255)"`
+	StartDate      string     `gorm:"type:varchar(32)"`
+	EndDate        string     `gorm:"type:varchar(32)"`
+	StartOn        *time.Time `gorm:"type:date"`       // нормализованная StartDate
+	StartPrecision string     `gorm:"type:varchar(8)"` // year, month или day
+	EndOn          *time.Time `gorm:"type:date"`       // нормализованная EndDate
+	EndPrecision   string     `gorm:"type:varchar(8)"`
+	IsCurrent      bool       `gorm:"not null;default:false"`
+	Months         int        `gorm:"not null;default:0"`
+	Description    string     `gorm:"type:text"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	DeletedAt      gorm.DeletedAt `gorm:"index"`
+}
+
+func (m *Experience) BeforeCreate(tx *gorm.DB) (err error) {
+	m.ID = uuid.New()
+	return
+}
+
+// Education — образование
+type Education struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	ResumeID       uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Institution    string     `gorm:"type:varchar(255)"`
+	Degree         string     `gorm:"type:varchar(255)"`
+	Field          string     `gorm:"type:varchar(255)"`
+	StartDate      string     `gorm:"type:varchar(32)"`
+	EndDate        string     `gorm:"type:varchar(32)"`
+	StartOn        *time.Time `gorm:"type:date"`
+	StartPrecision string     `gorm:"type:varchar(8)"`
+	EndOn          *time.Time `gorm:"type:date"`
+	EndPrecision   string     `gorm:"type:varchar(8)"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	DeletedAt      gorm.DeletedAt `gorm:"index"`
+}
+
+func (m *Education) BeforeCreate(tx *gorm.DB) (err error) {
+	m.ID = uuid.New()
+	return
+}
+
+// ResumeLanguage — владение иностранным языком
+type ResumeLanguage struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ResumeID  uuid.UUID `gorm:"type:uuid;not null;index"`
+	Name      string    `gorm:"type:varchar(100)"`
+	Level     string    `gorm:"type:varchar(50)"` // A1..C2, native или как указано в резюме
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+func (m *ResumeLanguage) BeforeCreate(tx *gorm.DB) (err error) {
+	m.ID = uuid.New()
+	return
+}
+
+// Certification — сертификат или курс
+type Certification struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ResumeID  uuid.UUID `gorm:"type:uuid;not null;index"`
+	Name      string    `gorm:"type:varchar(255)"`
+	Issuer    string    `gorm:"type:varchar(255)"`
+	Date      string    `gorm:"type:varchar(32)"`
+	URL       string    `gorm:"type:varchar(512)"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+func (m *Certification) BeforeCreate(tx *gorm.DB) (err error) {
+	m.ID = uuid.New()
+	return
+}
+
+// Project — личный или учебный проект
+type Project struct {
+	ID           uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ResumeID     uuid.UUID `gorm:"type:uuid;not null;index"`
+	Name         string    `gorm:"type:varchar(255)"`
+	Description  string    `gorm:"type:text"`
+	URL          string    `gorm:"type:varchar(512)"`
+	Technologies string    `gorm:"type:text"` // JSON-строка со списком технологий
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	DeletedAt    gorm.DeletedAt `gorm:"index"`
+}
+
+func (m *Project) BeforeCreate(tx *gorm.DB) (err error) {
+	m.ID = uuid.New()
+	return
+}
+
+// ResumeLink — ссылка на профиль кандидата
+type ResumeLink struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ResumeID  uuid.UUID `gorm:"type:uuid;not null;index"`
+	Type      string    `gorm:"type:varchar(32)"` // github, linkedin, telegram, website
+	URL       string    `gorm:"type:varchar(512)"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+func (m *ResumeLink) BeforeCreate(tx *gorm.DB) (err error) {
+	m.ID = uuid.New()
+	return
+}
+
+// Vacancy — вакансия (Job Description)
+type Vacancy struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey"`
+	OrganizationID uuid.UUID `gorm:"type:uuid;index"`          // рабочее пространство, которому принадлежит вакансия
+	UserID         uuid.UUID `gorm:"type:uuid;not null;index"` // кто создал вакансию
+	User           User      `gorm:"foreignKey:UserID"`
+	Title          string    `gorm:"type:varchar(255);not null"`
+	Description    string    `gorm:"type:text"`
+	Location       string    `gorm:"type:varchar(255)"`
+	TitleFamily    string    `gorm:"type:varchar(32);index"`
+	Seniority      string    `gorm:"type:varchar(16);index"`
+	Skills         []Skill   `gorm:"many2many:vacancy_skills;"`
+	// Requirements — те же строки vacancy_skills с типом требования, весом и минимальным стажем
+	Requirements []VacancySkill `gorm:"foreignKey:VacancyID"`
+
+	MinExperienceYears *float64 `gorm:"type:numeric(4,1)"`
+	MaxExperienceYears *float64 `gorm:"type:numeric(4,1)"`
+	SalaryFrom         *int     `gorm:"type:integer"` // вилка зарплаты в месяц
+	SalaryTo           *int     `gorm:"type:integer"`
+	SalaryCurrency     string   `gorm:"type:varchar(8)"`        // RUB, USD, EUR, KZT, UZS...
+	EmploymentType     string   `gorm:"type:varchar(16);index"` // full_time, part_time, contract, internship, project
+	RemotePolicy       string   `gorm:"type:varchar(16);index"` // remote, hybrid, office
+
+	MatchingProfileID *uuid.UUID `gorm:"type:uuid;index"`            // nil — профиль пользователя по умолчанию
+	Version           int        `gorm:"not null;default:1"`         // растёт при изменении навыков, требований и профиля
+	PipelineStages    []string   `gorm:"type:jsonb;serializer:json"` // этапы отбора по порядку, пусто — DefaultPipelineStages
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+func (m *Vacancy) BeforeCreate(tx *gorm.DB) (err error) {
+	m.ID = uuid.New()
+	return
+}
+
+// Stages возвращает этапы отбора кандидатов на вакансию; первый этап получают новые отклики
+func (m *Vacancy) Stages() []string {
+	if len(m.PipelineStages) == 0 {
+		return DefaultPipelineStages
+	}
+	return m.PipelineStages
+}
+
+// VacancySkill — навык вакансии: обязательный или желательный, с весом и минимальным стажем.
+// Навыки, добавленные до появления требований, считаются желательными с весом 1.
+type VacancySkill struct {
+	VacancyID   uuid.UUID `gorm:"type:uuid;primaryKey"`
+	SkillID     uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Requirement string    `gorm:"type:varchar(16);not null;default:preferred"`
+	Weight      float64   `gorm:"not null;default:1"`
+	MinYears    *float64  `gorm:"type:numeric(4,1)"` // минимальный стаж работы с навыком
+}
+
+// DefaultSkillWeight — вес навыка, если он не задан явно: обязательные навыки весят вдвое больше
+func DefaultSkillWeight(requirement string) float64 {
+	if requirement == SkillRequired {
+		return 2
+	}
+	return 1
+}
+
+// Способы учёта компонентов, для которых не хватает данных
+const (
+	// NormalizationRenormalize — вес компонента без данных делится между остальными пропорционально
+	NormalizationRenormalize = "renormalize"
+	// NormalizationNeutral — компонент без данных получает 50 баллов и сохраняет свой вес
+	NormalizationNeutral = "neutral"
+	// NormalizationStrict — компонент без данных получает 0 баллов и сохраняет свой вес
+	NormalizationStrict = "strict"
+)
+
+// ComponentWeights — веса компонентов в итоговой оценке; в сумме не обязаны давать 1,
+// при расчёте делятся на сумму
+type ComponentWeights struct {
+	Skills     float64 `gorm:"not null;default:0" json:"skills"`
+	Experience float64 `gorm:"not null;default:0" json:"experience"`
+	Seniority  float64 `gorm:"not null;default:0" json:"seniority"`
+	Education  float64 `gorm:"not null;default:0" json:"education"`
+	Location   float64 `gorm:"not null;default:0" json:"location"`
+	Semantic   float64 `gorm:"not null;default:0" json:"semantic"`
+}
+
+// KnockoutRules — условия, при которых итоговая оценка не превышает Cap
+type KnockoutRules struct {
+	MissingRequired    bool    `gorm:"not null;default:false" json:"missing_required"`     // нет обязательного навыка
+	BelowMinExperience bool    `gorm:"not null;default:false" json:"below_min_experience"` // стаж меньше минимального по вакансии
+	Cap                float64 `gorm:"not null;default:0" json:"cap"`
+}
+
+// MatchingProfile — настройки сравнения: веса компонентов, правила отсечения и учёт компонентов без данных.
+// Профиль привязывается к вакансии; без привязки используется профиль пользователя по умолчанию.
+type MatchingProfile struct {
+	ID            uuid.UUID        `gorm:"type:uuid;primaryKey"`
+	UserID        uuid.UUID        `gorm:"type:uuid;not null;index"`
+	Name          string           `gorm:"type:varchar(255);not null"`
+	IsDefault     bool             `gorm:"not null;default:false"`
+	Weights       ComponentWeights `gorm:"embedded;embeddedPrefix:weight_"`
+	Knockout      KnockoutRules    `gorm:"embedded;embeddedPrefix:knockout_"`
+	Normalization string           `gorm:"type:varchar(16);not null;default:renormalize"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	DeletedAt     gorm.DeletedAt `gorm:"index"`
+}
+
+func (m *MatchingProfile) BeforeCreate(tx *gorm.DB) (err error) {
+	m.ID = uuid.New()
+	return
+}
+
+// ProfileSnapshot — профиль сравнения в том виде, в каком он применён к результату.
+// ID равен nil для встроенного профиля по умолчанию.
+type ProfileSnapshot struct {
+	ID            *uuid.UUID       `json:"id,omitempty"`
+	Name          string           `json:"name"`
+	Weights       ComponentWeights `json:"weights"`
+	Knockout      KnockoutRules    `json:"knockout"`
+	Normalization string           `json:"normalization"`
+}
+
+// MatchingResult — результат сравнения резюме и вакансии
+type MatchingResult struct {
+	ID              uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ResumeID        uuid.UUID `gorm:"type:uuid;not null;index"`
+	VacancyID       uuid.UUID `gorm:"type:uuid;not null;index"`
+	Score           float64
+	Knockout        bool             `gorm:"not null;default:false"`     // сработало правило отсечения, оценка ограничена
+	Breakdown       ScoreBreakdown   `gorm:"type:jsonb;serializer:json"` // из чего сложилась оценка
+	Recommendations *Recommendations `gorm:"type:text;serializer:json"`  // nil — советы ещё не составлены
+	ProfileID       *uuid.UUID       `gorm:"type:uuid;index"`            // nil — встроенный профиль по умолчанию
+	Profile         ProfileSnapshot  `gorm:"type:jsonb;serializer:json"` // профиль на момент сравнения
+	JobID           *uuid.UUID       `gorm:"type:uuid;index"`            // задача расчёта матрицы, nil — одиночное сравнение
+	ResumeVersion   int              `gorm:"not null;default:1"`         // версия резюме, по которой посчитан результат
+	VacancyVersion  int              `gorm:"not null;default:1"`         // версия вакансии, по которой посчитан результат
+	Stale           bool             `gorm:"->;-:migration"`             // версии устарели, результат ждёт пересчёта; вычисляется при чтении
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	DeletedAt       gorm.DeletedAt `gorm:"index"`
+}
+
+// Компоненты оценки соответствия
+const (
+	ComponentSkills     = "skills"
+	ComponentExperience = "experience"
+	ComponentSeniority  = "seniority"
+	ComponentEducation  = "education"
+	ComponentLocation   = "location"
+	ComponentSemantic   = "semantic"
+)
+
+// ScoreBreakdown — разбор оценки: вклад компонентов и каждого навыка вакансии
+type ScoreBreakdown struct {
+	Components []ScoreComponent `json:"components"`
+	Skills     []SkillMatch     `json:"skills"`
+	NoData     bool             `json:"no_data,omitempty"` // ни один компонент не удалось оценить, оценка 0
+}
+
+// ScoreComponent — оценка по одному аспекту от 0 до 100. Weight — доля в итоговой оценке
+// после исключения неприменимых компонентов, Contribution = Score * Weight.
+type ScoreComponent struct {
+	Name         string     `json:"name"`
+	Score        float64    `json:"score"`
+	Weight       float64    `json:"weight"`
+	Contribution float64    `json:"contribution"`
+	Applicable   bool       `json:"applicable"` // false — данных для оценки нет, компонент не учитывается
+	Details      string     `json:"details"`
+	Evidence     []Evidence `json:"evidence"`
+}
+
+// Evidence — фрагмент резюме, подтверждающий требование вакансии
+type Evidence struct {
+	Requirement string `json:"requirement"`
+	Source      string `json:"source"` // experience, education, skills, resume_text
+	Snippet     string `json:"snippet"`
+}
+
+// SkillMatch — вклад одного навыка вакансии в оценку навыков
+type SkillMatch struct {
+	Name        string   `json:"name"`
+	Requirement string   `json:"requirement"`
+	Weight      float64  `json:"weight"`
+	Matched     bool     `json:"matched"`
+	MinYears    *float64 `json:"min_years,omitempty"`
+	// Years — стаж с навыком по описаниям мест работы; nil, если навык в опыте не упоминается
+	Years *float64 `json:"years,omitempty"`
+	// Credit — доля веса, засчитанная кандидату: 0, 1 или меньше 1 при недостатке стажа
+	Credit float64 `json:"credit"`
+}
+
+// Источники сгенерированного текста: ответ LLM или готовые шаблоны
+const (
+	SourceLLM      = "llm"
+	SourceTemplate = "template"
+)
+
+// Recommendations — советы, как доработать резюме под вакансию
+type Recommendations struct {
+	MissingKeywords []string            `json:"missing_keywords"`
+	BulletRewrites  []BulletRewrite     `json:"bullet_rewrites"`
+	SectionsToAdd   []SectionSuggestion `json:"sections_to_add"`
+	Source          string              `json:"source"` // llm или template
+	Language        string              `json:"language"`
+	Model           string              `json:"model,omitempty"`
+	PromptVersion   string              `json:"prompt_version,omitempty"`
+	GeneratedAt     time.Time           `json:"generated_at"`
+}
+
+// BulletRewrite — предлагаемая формулировка пункта опыта работы
+type BulletRewrite struct {
+	Original  string `json:"original"`
+	Suggested string `json:"suggested"`
+	Reason    string `json:"reason"`
+}
+
+// SectionSuggestion — раздел, которого не хватает в резюме
+type SectionSuggestion struct {
+	Section string `json:"section"`
+	Reason  string `json:"reason"`
+}
+
+// MatchedSkills возвращает навыки вакансии, найденные в резюме
+func (b ScoreBreakdown) MatchedSkills() []string {
+	result := []string{}
+	for _, skill := range b.Skills {
+		if skill.Matched {
+			result = append(result, skill.Name)
+		}
+	}
+	return result
+}
+
+// UnmatchedSkills возвращает навыки вакансии, которых нет в резюме
+func (b ScoreBreakdown) UnmatchedSkills() []string {
+	result := []string{}
+	for _, skill := range b.Skills {
+		if !skill.Matched {
+			result = append(result, skill.Name)
+		}
+	}
+	return result
+}
+
+// MissingRequired возвращает обязательные навыки, которых нет в резюме
+func (b ScoreBreakdown) MissingRequired() []string {
+	result := []string{}
+	for _, skill := range b.Skills {
+		if !skill.Matched && skill.Requirement == SkillRequired {
+			result = append(result, skill.Name)
+		}
+	}
+	return result
+}
+
+func (m *MatchingResult) BeforeCreate(tx *gorm.DB) (err error) {
+	m.ID = uuid.New()
+	return
+}
+
+// Тон сопроводительного письма
+const (
+	ToneFormal       = "formal"
+	ToneFriendly     = "friendly"
+	ToneEnthusiastic = "enthusiastic"
+)
+
+// Длина сопроводительного письма
+const (
+	LengthShort  = "short"
+	LengthMedium = "medium"
+	LengthLong   = "long"
+)
+
+// CoverLetter — сопроводительное письмо к вакансии по результату сравнения.
+// Письма не перезаписываются: каждая генерация добавляет новую запись в историю.
+type CoverLetter struct {
+	ID            uuid.UUID `gorm:"type:uuid;primaryKey"`
+	UserID        uuid.UUID `gorm:"type:uuid;not null;index"`
+	MatchID       uuid.UUID `gorm:"type:uuid;not null;index"`
+	ResumeID      uuid.UUID `gorm:"type:uuid;not null;index"`
+	VacancyID     uuid.UUID `gorm:"type:uuid;not null;index"`
+	Language      string    `gorm:"type:varchar(8)"`
+	Tone          string    `gorm:"type:varchar(16)"` // formal, friendly, enthusiastic
+	Length        string    `gorm:"type:varchar(16)"` // short, medium, long
+	Content       string    `gorm:"type:text;not null"`
+	Source        string    `gorm:"type:varchar(16)"` // llm или template
+	Model         string    `gorm:"type:varchar(64)"`
+	PromptVersion string    `gorm:"type:varchar(64)"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	DeletedAt     gorm.DeletedAt `gorm:"index"`
+}
+
+func (m *CoverLetter) BeforeCreate(tx *gorm.DB) (err error) {
+	m.ID = uuid.New()
+	return
+}
+
+// LLMCacheEntry — закешированный ответ LLM. Ключ — хеш запроса, версии промпта, модели и параметров.
+type LLMCacheEntry struct {
+	Key              string     `gorm:"type:varchar(64);primaryKey"`
+	Model            string     `gorm:"type:varchar(64)"`
+	PromptVersion    string     `gorm:"type:varchar(64);index"`
+	Response         string     `gorm:"type:text;not null"`
+	InputTokens      int        `gorm:"not null;default:0"`
+	CompletionTokens int        `gorm:"not null;default:0"`
+	ExpiresAt        *time.Time `gorm:"index"` // nil — без срока
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// LLMUsage — один вызов LLM: расход токенов, задержка и оценка стоимости
+type LLMUsage struct {
+	ID               uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	UserID           uuid.UUID  `gorm:"type:uuid;not null;index:idx_llm_usages_user_created"`
+	ResumeID         *uuid.UUID `gorm:"type:uuid;index"`
+	JobID            *uuid.UUID `gorm:"type:uuid;index"`
+	Operation        string     `gorm:"type:varchar(32)"` // resume_parse, vacancy_parse...
+	Model            string     `gorm:"type:varchar(64);index"`
+	PromptVersion    string     `gorm:"type:varchar(64)"`
+	InputTokens      int        `gorm:"not null;default:0"`
+	CompletionTokens int        `gorm:"not null;default:0"`
+	TotalTokens      int        `gorm:"not null;default:0"`
+	LatencyMs        int64      `gorm:"not null;default:0"`
+	Cost             float64    `gorm:"type:numeric(12,4);not null;default:0"` // в валюте LLM_CURRENCY
+	Cached           bool       `gorm:"not null;default:false"`
+	Success          bool       `gorm:"not null;default:false"`
+	CreatedAt        time.Time  `gorm:"index:idx_llm_usages_user_created"`
+}
+
+func (m *LLMUsage) BeforeCreate(tx *gorm.DB) (err error) {
+	m.ID = uuid.New()
+	return
+}
+
+// Статусы фоновой задачи
+const (
+	JobPending = "pending"
+	JobRunning = "running"
+	JobDone    = "done"
+	JobFailed  = "failed"
+)
+
+// MatchJob — фоновый расчёт матрицы сравнений: каждое резюме с каждой вакансией.
+// Результаты сохраняются как MatchingResult с JobID задачи.
+type MatchJob struct {
+	ID         uuid.UUID   `gorm:"type:uuid;primaryKey"`
+	UserID     uuid.UUID   `gorm:"type:uuid;not null;index"`
+	Status     string      `gorm:"type:varchar(16);not null"`
+	ResumeIDs  []uuid.UUID `gorm:"type:jsonb;serializer:json"`
+	VacancyIDs []uuid.UUID `gorm:"type:jsonb;serializer:json"`
+	Total      int         // пар резюме и вакансии
+	Completed  int         // посчитано и сохранено
+	Failed     int         // не удалось посчитать
+	Error      string      `gorm:"type:text"` // почему задача прервана
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (m *MatchJob) BeforeCreate(tx *gorm.DB) (err error) {
+	m.ID = uuid.New()
+	return
+}
+
+// Этапы отбора по умолчанию
+const (
+	StageNew       = "new"
+	StageScreening = "screening"
+	StageInterview = "interview"
+	StageOffer     = "offer"
+	StageHired     = "hired"
+	StageRejected  = "rejected"
+)
+
+// DefaultPipelineStages — этапы отбора вакансии, для которой они не настроены
+var DefaultPipelineStages = []string{StageNew, StageScreening, StageInterview, StageOffer, StageHired, StageRejected}
+
+// Application — кандидат (резюме) в отборе на вакансию. Stage — текущий этап из этапов вакансии,
+// история переходов хранится в ApplicationTransition.
+type Application struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index"` // кто добавил кандидата в отбор
+	ResumeID  uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_application_resume_vacancy"`
+	VacancyID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_application_resume_vacancy;index"`
+	Stage     string    `gorm:"type:varchar(32);not null;index"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (m *Application) BeforeCreate(tx *gorm.DB) (err error) {
+	m.ID = uuid.New()
+	return
+}
+
+// ApplicationTransition — переход кандидата между этапами. У первой записи FromStage пуст:
+// это попадание в отбор.
+type ApplicationTransition struct {
+	ID            uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ApplicationID uuid.UUID `gorm:"type:uuid;not null;index"`
+	UserID        uuid.UUID `gorm:"type:uuid;not null"` // кто перевёл кандидата
+	FromStage     string    `gorm:"type:varchar(32)"`
+	ToStage       string    `gorm:"type:varchar(32);not null"`
+	Reason        string    `gorm:"type:text"`
+	CreatedAt     time.Time
+}
+
+func (m *ApplicationTransition) BeforeCreate(tx *gorm.DB) (err error) {
+	m.ID = uuid.New()
+	return
+}
+
+// ResumeNote — заметка рекрутера к резюме. Править и удалять заметку может только её автор.
+type ResumeNote struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ResumeID  uuid.UUID `gorm:"type:uuid;not null;index"`
+	AuthorID  uuid.UUID `gorm:"type:uuid;not null"`
+	Author    User      `gorm:"foreignKey:AuthorID"`
+	Text      string    `gorm:"type:text;not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+func (m *ResumeNote) BeforeCreate(tx *gorm.DB) (err error) {
+	m.ID = uuid.New()
+	return
+}
+
+// Tag — метка рабочего пространства для резюме. Имя хранится в нижнем регистре и уникально
+// в пределах пространства, так что вся команда пользуется одними метками.
+type Tag struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey"`
+	OrganizationID uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_tag_organization_name"`
+	UserID         uuid.UUID `gorm:"type:uuid;not null;index"` // кто создал метку
+	Name           string    `gorm:"type:varchar(64);not null;uniqueIndex:idx_tag_organization_name"`
+	CreatedAt      time.Time
+}
+
+func (m *Tag) BeforeCreate(tx *gorm.DB) (err error) {
+	m.ID = uuid.New()
+	return
+}
+
+// Статусы интервью
+const (
+	InterviewScheduled = "scheduled"
+	InterviewCancelled = "cancelled"
+)
+
+// Рекомендации интервьюера по итогам интервью
+const (
+	RecommendStrongYes = "strong_yes"
+	RecommendYes       = "yes"
+	RecommendNo        = "no"
+	RecommendStrongNo  = "strong_no"
+)
+
+// Interview — интервью с кандидатом по отклику. Sequence растёт при каждом изменении:
+// по нему календари понимают, что событие обновилось.
+type Interview struct {
+	ID            uuid.UUID              `gorm:"type:uuid;primaryKey"`
+	UserID        uuid.UUID              `gorm:"type:uuid;not null;index"` // кто назначил интервью
+	ApplicationID uuid.UUID              `gorm:"type:uuid;not null;index"`
+	Title         string                 `gorm:"type:varchar(255);not null"`
+	StartsAt      time.Time              `gorm:"not null;index"`
+	EndsAt        time.Time              `gorm:"not null"`
+	Location      string                 `gorm:"type:varchar(255)"` // адрес или переговорная
+	VideoURL      string                 `gorm:"type:varchar(512)"`
+	Description   string                 `gorm:"type:text"`
+	Status        string                 `gorm:"type:varchar(16);not null;index"` // scheduled, cancelled
+	Sequence      int                    `gorm:"not null;default:0"`
+	Participants  []InterviewParticipant `gorm:"foreignKey:InterviewID"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (m *Interview) BeforeCreate(tx *gorm.DB) (err error) {
+	m.ID = uuid.New()
+	return
+}
+
+// InterviewParticipant — участник интервью. UserID заполнен, если участник зарегистрирован:
+// тогда интервью попадает в его календарь и он может оставить отзыв.
+type InterviewParticipant struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	InterviewID uuid.UUID  `gorm:"type:uuid;not null;index"`
+	UserID      *uuid.UUID `gorm:"type:uuid;index"`
+	Name        string     `gorm:"type:varchar(255)"`
+	Email       string     `gorm:"type:varchar(255);not null"`
+}
+
+func (m *InterviewParticipant) BeforeCreate(tx *gorm.DB) (err error) {
+	m.ID = uuid.New()
+	return
+}
+
+// Scorecard — оценка кандидата по одному критерию от 1 до 5
+type Scorecard struct {
+	Criterion string `json:"criterion"`
+	Score     int    `json:"score"`
+	Comment   string `json:"comment,omitempty"`
+}
+
+// InterviewFeedback — отзыв интервьюера. У каждого участника один отзыв на интервью,
+// повторная отправка его перезаписывает.
+type InterviewFeedback struct {
+	ID             uuid.UUID   `gorm:"type:uuid;primaryKey"`
+	InterviewID    uuid.UUID   `gorm:"type:uuid;not null;uniqueIndex:idx_feedback_interview_author"`
+	AuthorID       uuid.UUID   `gorm:"type:uuid;not null;uniqueIndex:idx_feedback_interview_author"`
+	Recommendation string      `gorm:"type:varchar(16);not null"`
+	Scores         []Scorecard `gorm:"type:jsonb;serializer:json"`
+	Comment        string      `gorm:"type:text"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (m *InterviewFeedback) BeforeCreate(tx *gorm.DB) (err error) {
+	m.ID = uuid.New()
+	return
+}
+
+// Роли участников организации
+const (
+	OrgRoleOwner         = "owner"          // управляет участниками и всеми данными
+	OrgRoleRecruiter     = "recruiter"      // ведёт резюме, вакансии и отбор
+	OrgRoleHiringManager = "hiring_manager" // оценивает кандидатов: сравнения, заметки, интервью
+	OrgRoleViewer        = "viewer"         // только просмотр
+)
+
+// OrgRoles — роли участников организации по убыванию прав
+var OrgRoles = []string{OrgRoleOwner, OrgRoleRecruiter, OrgRoleHiringManager, OrgRoleViewer}
+
+// Organization — рабочее пространство команды. Личное пространство (Personal) создаётся
+// при регистрации, в него нельзя приглашать других участников.
+type Organization struct {
+	ID        uuid.UUID            `gorm:"type:uuid;primaryKey"`
+	Name      string               `gorm:"type:varchar(255);not null"`
+	Personal  bool                 `gorm:"not null;default:false"`
+	Members   []OrganizationMember `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (m *Organization) BeforeCreate(tx *gorm.DB) (err error) {
+	m.ID = uuid.New()
+	return
+}
+
+// OrganizationMember — участие пользователя в организации с ролью из OrgRoles
+type OrganizationMember struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey"`
+	OrganizationID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_member_organization_user"`
+	UserID         uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_member_organization_user;index"`
+	User           User      `gorm:"foreignKey:UserID"`
+	Role           string    `gorm:"type:varchar(16);not null"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (m *OrganizationMember) BeforeCreate(tx *gorm.DB) (err error) {
+	m.ID = uuid.New()
+	return
+}
@@ -0,0 +1,229 @@
+package parser
+
+import (
+	"math"
+	"net/mail"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Поля резюме, для которых считается уверенность
+const (
+	FieldFullName   = "full_name"
+	FieldEmail      = "email"
+	FieldPhone      = "phone"
+	FieldLocation   = "location"
+	FieldSkills     = "skills"
+	FieldExperience = "experience"
+	FieldEducation  = "education"
+)
+
+// KeyFields — поля, низкая уверенность в которых отправляет резюме на ручную проверку.
+// Контакты сюда не входят: резюме без телефона или email — не ошибка разбора.
+var KeyFields = []string{FieldFullName, FieldSkills}
+
+const (
+	// defaultSelfConfidence — уверенность LLM, если она не сообщила свою оценку
+	defaultSelfConfidence = 0.7
+	// selfConfidenceWeight — вес самооценки LLM, остальное приходится на проверки
+	selfConfidenceWeight = 0.3
+	// absentCap — максимальная уверенность в значении, которого нет в тексте документа
+	absentCap = 0.4
+)
+
+var reEmail = regexp.MustCompile(`^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`)
+
+// ConfidenceInput — распознанные значения, которые сверяются с текстом документа
+type ConfidenceInput struct {
+	FullName     string
+	Email        string
+	Phone        string
+	Location     string
+	Skills       []string
+	Companies    []string
+	Institutions []string
+	Dates        []string
+}
+
+// ScoreConfidence считает уверенность в каждом поле от 0 до 1, комбинируя самооценку LLM,
+// наличие значения в исходном тексте и валидаторы формата. Пустое поле получает 0.
+// Если текст документа пуст, сверка с текстом пропускается.
+func ScoreConfidence(in ConfidenceInput, text string, selfReport map[string]float64) map[string]float64 {
+	t := newTextIndex(text)
+	result := make(map[string]float64, 7)
+
+	// validators — проверки формата, presence — сверка с текстом документа.
+	// Значение, которого нет в тексте вовсе, скорее всего выдумано: оценка ограничивается absentCap.
+	score := func(field string, empty bool, validators []float64, presence []float64) {
+		if empty {
+			result[field] = 0
+			return
+		}
+		self := defaultSelfConfidence
+		if v, ok := selfReport[field]; ok {
+			self = math.Max(0, math.Min(1, v))
+		}
+		checks := append(append([]float64{}, validators...), presence...)
+		if len(checks) == 0 {
+			result[field] = round2(self)
+			return
+		}
+		var sum float64
+		for _, c := range checks {
+			sum += c
+		}
+		value := selfConfidenceWeight*self + (1-selfConfidenceWeight)*sum/float64(len(checks))
+		for _, p := range presence {
+			if p == 0 {
+				value = math.Min(value, absentCap)
+			}
+		}
+		result[field] = round2(value)
+	}
+
+	email := strings.TrimSpace(in.Email)
+	score(FieldEmail, email == "", []float64{boolScore(isValidEmail(email))}, t.presence(email))
+
+	phone := digitsOnly(in.Phone)
+	score(FieldPhone, phone == "", []float64{boolScore(len(phone) >= 10 && len(phone) <= 15)}, t.digitsPresence(phone))
+
+	name := strings.TrimSpace(in.FullName)
+	score(FieldFullName, name == "", []float64{nameShapeScore(name)}, t.tokensPresence(name))
+
+	location := strings.TrimSpace(in.Location)
+	score(FieldLocation, location == "", nil, t.presence(location))
+
+	score(FieldSkills, len(in.Skills) == 0, nil, t.fractionPresent(in.Skills))
+
+	var dateChecks []float64
+	if len(in.Dates) > 0 {
+		parsed := 0
+		for _, d := range in.Dates {
+			if !NormalizeDate(d).IsZero() {
+				parsed++
+			}
+		}
+		dateChecks = append(dateChecks, float64(parsed)/float64(len(in.Dates)))
+	}
+	score(FieldExperience, len(in.Companies) == 0, dateChecks, t.fractionPresent(in.Companies))
+
+	score(FieldEducation, len(in.Institutions) == 0, nil, t.fractionPresent(in.Institutions))
+
+	return result
+}
+
+// NeedsReview сообщает, что хотя бы одно ключевое поле ниже порога
+func NeedsReview(confidence map[string]float64, threshold float64) bool {
+	for _, field := range KeyFields {
+		if confidence[field] < threshold {
+			return true
+		}
+	}
+	return false
+}
+
+func isValidEmail(email string) bool {
+	if !reEmail.MatchString(email) {
+		return false
+	}
+	_, err := mail.ParseAddress(email)
+	return err == nil
+}
+
+// nameShapeScore — ФИО из двух-трёх слов без цифр выглядит правдоподобно
+func nameShapeScore(name string) float64 {
+	words := strings.Fields(name)
+	for _, r := range name {
+		if unicode.IsDigit(r) || r == '@' {
+			return 0
+		}
+	}
+	if len(words) >= 2 && len(words) <= 4 {
+		return 1
+	}
+	return 0.5
+}
+
+type textIndex struct {
+	lower  string
+	digits string
+}
+
+func newTextIndex(text string) textIndex {
+	return textIndex{
+		lower:  strings.Join(strings.Fields(strings.ToLower(text)), " "),
+		digits: digitsOnly(text),
+	}
+}
+
+func (t textIndex) empty() bool {
+	return t.lower == ""
+}
+
+// presence возвращает одну проверку "значение встречается в тексте" или ничего, если текста нет
+func (t textIndex) presence(value string) []float64 {
+	if t.empty() || value == "" {
+		return nil
+	}
+	return []float64{boolScore(t.contains(value))}
+}
+
+func (t textIndex) digitsPresence(phone string) []float64 {
+	if t.empty() || phone == "" {
+		return nil
+	}
+	// Код страны в тексте может быть записан как +7, 8 или опущен — сверяем последние 10 цифр
+	tail := phone
+	if len(tail) > 10 {
+		tail = tail[len(tail)-10:]
+	}
+	return []float64{boolScore(strings.Contains(t.digits, tail))}
+}
+
+func (t textIndex) tokensPresence(name string) []float64 {
+	words := strings.Fields(name)
+	if t.empty() || len(words) == 0 {
+		return nil
+	}
+	return t.fractionPresent(words)
+}
+
+func (t textIndex) fractionPresent(values []string) []float64 {
+	if t.empty() || len(values) == 0 {
+		return nil
+	}
+	found := 0
+	for _, v := range values {
+		if t.contains(v) {
+			found++
+		}
+	}
+	return []float64{float64(found) / float64(len(values))}
+}
+
+func (t textIndex) contains(value string) bool {
+	v := strings.Join(strings.Fields(strings.ToLower(value)), " ")
+	return v != "" && strings.Contains(t.lower, v)
+}
+
+func digitsOnly(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func boolScore(ok bool) float64 {
+	if ok {
+		return 1
+	}
+	return 0
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
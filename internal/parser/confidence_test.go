@@ -0,0 +1,77 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const confidenceText = `Иванов Иван Иванович
+Москва, +7 (999) 123-45-67, ivan@example.com
+Навыки: Go, PostgreSQL, Docker
+ООО Ромашка, Backend-разработчик, сентябрь 2020 — по настоящее время
+МГУ им. Ломоносова, 2016-2020`
+
+func TestScoreConfidence_CrossChecksText(t *testing.T) {
+	in := ConfidenceInput{
+		FullName:     "Иванов Иван",
+		Email:        "ivan@example.com",
+		Phone:        "89991234567",
+		Location:     "Москва",
+		Skills:       []string{"Go", "PostgreSQL", "Kubernetes"},
+		Companies:    []string{"ООО Ромашка"},
+		Institutions: []string{"МГУ им. Ломоносова"},
+		Dates:        []string{"сентябрь 2020", "по настоящее время"},
+	}
+	conf := ScoreConfidence(in, confidenceText, map[string]float64{FieldEmail: 1})
+
+	require.Equal(t, 1.0, conf[FieldEmail])
+	require.Equal(t, 0.91, conf[FieldPhone])
+	require.Equal(t, 0.91, conf[FieldLocation])
+	require.InDelta(t, 0.3*0.7+0.7*2.0/3.0, conf[FieldSkills], 0.01)
+	require.Equal(t, 0.91, conf[FieldExperience])
+	require.False(t, NeedsReview(conf, 0.6))
+}
+
+func TestScoreConfidence_HallucinatedAndMissingFields(t *testing.T) {
+	in := ConfidenceInput{
+		FullName: "Петров Пётр",
+		Email:    "petr@example.com",
+		Skills:   []string{"Go"},
+	}
+	conf := ScoreConfidence(in, confidenceText, map[string]float64{FieldFullName: 0.99, FieldEmail: 0.99})
+
+	require.Less(t, conf[FieldFullName], 0.6)
+	require.Less(t, conf[FieldEmail], 0.6)
+	require.Equal(t, 0.0, conf[FieldPhone])
+	require.Equal(t, 0.0, conf[FieldEducation])
+	require.True(t, NeedsReview(conf, 0.6))
+}
+
+func TestScoreConfidence_NoText(t *testing.T) {
+	conf := ScoreConfidence(ConfidenceInput{Email: "not-an-email", Phone: "+7 999 123 45 67"}, "", nil)
+	require.Equal(t, 0.21, conf[FieldEmail])
+	require.Equal(t, 0.91, conf[FieldPhone])
+}
+
+func TestNeedsReview_MissingContacts(t *testing.T) {
+	in := ConfidenceInput{
+		FullName: "Иванов Иван",
+		Skills:   []string{"Go", "PostgreSQL", "Docker"},
+	}
+	conf := ScoreConfidence(in, confidenceText, nil)
+
+	require.Equal(t, 0.0, conf[FieldEmail])
+	require.Equal(t, 0.0, conf[FieldPhone])
+	require.False(t, NeedsReview(conf, 0.6))
+}
+
+func TestDecodeOutput_Confidence(t *testing.T) {
+	dto, err := DecodeOutput(`{"full_name": "Иванов Иван", "confidence": {"full_name": "0.9", "email": "high"}}`)
+	require.NoError(t, err)
+	require.Equal(t, 0.9, dto.Confidence[FieldFullName])
+	require.NotContains(t, dto.Confidence, FieldEmail)
+
+	_, err = DecodeOutput(`{"full_name": "Иванов Иван", "confidence": "high"}`)
+	require.Error(t, err)
+}
@@ -0,0 +1,213 @@
+package parser
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DatePrecision — с какой точностью удалось распознать дату
+type DatePrecision string
+
+const (
+	PrecisionNone  DatePrecision = ""
+	PrecisionYear  DatePrecision = "year"
+	PrecisionMonth DatePrecision = "month"
+	PrecisionDay   DatePrecision = "day"
+)
+
+// NormalizedDate — дата из резюме, приведённая к типизированному виду.
+// Для "по настоящее время" Present = true, а Time остаётся пустым.
+type NormalizedDate struct {
+	Time      *time.Time
+	Precision DatePrecision
+	Present   bool
+}
+
+// IsZero сообщает, что дату распознать не удалось
+func (d NormalizedDate) IsZero() bool {
+	return d.Time == nil && !d.Present
+}
+
+var presentMarkers = []string{
+	"по настоящее время",
+	"настоящее время",
+	"текущее время",
+	"по н.в.",
+	"по н.в",
+	"н.в.",
+	"н.в",
+	"по сей день",
+	"до сих пор",
+	"сейчас",
+	"present",
+	"current",
+	"currently",
+	"now",
+	"till now",
+	"to date",
+	"ongoing",
+}
+
+// Префиксы названий месяцев, по ним же распознаются сокращения и падежи.
+// Порядок важен: "март" должен проверяться раньше, чем "ма" (май).
+var monthPrefixes = []struct {
+	prefix string
+	month  time.Month
+}{
+	{"январ", time.January}, {"янв", time.January},
+	{"феврал", time.February}, {"фев", time.February},
+	{"март", time.March}, {"мар", time.March},
+	{"апрел", time.April}, {"апр", time.April},
+	{"ма", time.May},
+	{"июн", time.June},
+	{"июл", time.July},
+	{"август", time.August}, {"авг", time.August},
+	{"сентябр", time.September}, {"сен", time.September},
+	{"октябр", time.October}, {"окт", time.October},
+	{"ноябр", time.November}, {"ноя", time.November},
+	{"декабр", time.December}, {"дек", time.December},
+	{"jan", time.January},
+	{"feb", time.February},
+	{"mar", time.March},
+	{"apr", time.April},
+	{"may", time.May},
+	{"jun", time.June},
+	{"jul", time.July},
+	{"aug", time.August},
+	{"sep", time.September},
+	{"oct", time.October},
+	{"nov", time.November},
+	{"dec", time.December},
+}
+
+var (
+	reISODay     = regexp.MustCompile(`^(\d{4})-(\d{1,2})-(\d{1,2})`)
+	reISOMonth   = regexp.MustCompile(`^(\d{4})[-/.](\d{1,2})$`)
+	reDotDay     = regexp.MustCompile(`^(\d{1,2})[./](\d{1,2})[./](\d{4})$`)
+	reDotMonth   = regexp.MustCompile(`^(\d{1,2})[./](\d{4})$`)
+	reYear       = regexp.MustCompile(`^(\d{4})(?:\s*г\.?|\s*год)?$`)
+	reNamedMonth = regexp.MustCompile(`^(\p{L}+)\.?,?\s+(\d{4})(?:\s*г\.?|\s*года?)?$`)
+)
+
+// NormalizeDate приводит дату из резюме ("2023", "Sep 2020", "сентябрь 2020",
+// "09.2020", "2020-09-01", "по настоящее время") к типизированному виду.
+// Нераспознанная строка возвращает пустую дату.
+func NormalizeDate(raw string) NormalizedDate {
+	s := strings.ToLower(strings.TrimSpace(raw))
+	s = strings.Join(strings.Fields(s), " ")
+	if s == "" {
+		return NormalizedDate{}
+	}
+
+	for _, marker := range presentMarkers {
+		if s == marker || strings.HasPrefix(s, "по "+marker) || (len(marker) > 4 && strings.Contains(s, marker)) {
+			return NormalizedDate{Present: true}
+		}
+	}
+
+	if m := reISODay.FindStringSubmatch(s); m != nil {
+		return makeDate(atoi(m[1]), atoi(m[2]), atoi(m[3]), PrecisionDay)
+	}
+	if m := reISOMonth.FindStringSubmatch(s); m != nil {
+		return makeDate(atoi(m[1]), atoi(m[2]), 1, PrecisionMonth)
+	}
+	if m := reDotDay.FindStringSubmatch(s); m != nil {
+		return makeDate(atoi(m[3]), atoi(m[2]), atoi(m[1]), PrecisionDay)
+	}
+	if m := reDotMonth.FindStringSubmatch(s); m != nil {
+		return makeDate(atoi(m[2]), atoi(m[1]), 1, PrecisionMonth)
+	}
+	if m := reYear.FindStringSubmatch(s); m != nil {
+		return makeDate(atoi(m[1]), 1, 1, PrecisionYear)
+	}
+	if m := reNamedMonth.FindStringSubmatch(s); m != nil {
+		if month, ok := parseMonthName(m[1]); ok {
+			return makeDate(atoi(m[2]), int(month), 1, PrecisionMonth)
+		}
+	}
+	return NormalizedDate{}
+}
+
+func parseMonthName(name string) (time.Month, bool) {
+	for _, mp := range monthPrefixes {
+		if strings.HasPrefix(name, mp.prefix) {
+			return mp.month, true
+		}
+	}
+	return 0, false
+}
+
+func makeDate(year, month, day int, precision DatePrecision) NormalizedDate {
+	if year < 1900 || year > 2200 || month < 1 || month > 12 || day < 1 || day > 31 {
+		return NormalizedDate{}
+	}
+	t := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if t.Month() != time.Month(month) {
+		return NormalizedDate{}
+	}
+	return NormalizedDate{Time: &t, Precision: precision}
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+// Period — промежуток работы или учёбы
+type Period struct {
+	Start NormalizedDate
+	End   NormalizedDate
+}
+
+// bounds возвращает номер первого и последнего месяца периода (включительно).
+// Дата окончания с точностью до года считается декабрём, пустая — текущим месяцем.
+func (p Period) bounds(now time.Time) (int, int, bool) {
+	if p.Start.Time == nil {
+		return 0, 0, false
+	}
+	start := monthIndex(*p.Start.Time)
+
+	var end int
+	switch {
+	case p.End.Present || p.End.IsZero():
+		end = monthIndex(now)
+	case p.End.Precision == PrecisionYear:
+		end = p.End.Time.Year()*12 + 11
+	default:
+		end = monthIndex(*p.End.Time)
+	}
+	if end < start {
+		return 0, 0, false
+	}
+	return start, end, true
+}
+
+// Months — длительность периода в месяцах (неполные месяцы считаются целыми)
+func (p Period) Months(now time.Time) int {
+	start, end, ok := p.bounds(now)
+	if !ok {
+		return 0
+	}
+	return end - start + 1
+}
+
+// TotalMonths — суммарный стаж в месяцах без двойного учёта пересекающихся периодов
+func TotalMonths(periods []Period, now time.Time) int {
+	covered := make(map[int]struct{})
+	for _, p := range periods {
+		start, end, ok := p.bounds(now)
+		if !ok {
+			continue
+		}
+		for m := start; m <= end; m++ {
+			covered[m] = struct{}{}
+		}
+	}
+	return len(covered)
+}
+
+func monthIndex(t time.Time) int {
+	return t.Year()*12 + int(t.Month()) - 1
+}
@@ -0,0 +1,65 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeDate(t *testing.T) {
+	cases := []struct {
+		raw       string
+		year      int
+		month     time.Month
+		day       int
+		precision DatePrecision
+	}{
+		{"2023", 2023, time.January, 1, PrecisionYear},
+		{"2023 г.", 2023, time.January, 1, PrecisionYear},
+		{"2020-09", 2020, time.September, 1, PrecisionMonth},
+		{"2020-06-30", 2020, time.June, 30, PrecisionDay},
+		{"09.2020", 2020, time.September, 1, PrecisionMonth},
+		{"01.09.2016", 2016, time.September, 1, PrecisionDay},
+		{"Sep 2020", 2020, time.September, 1, PrecisionMonth},
+		{"September 2020", 2020, time.September, 1, PrecisionMonth},
+		{"сентябрь 2020", 2020, time.September, 1, PrecisionMonth},
+		{"марта 2019", 2019, time.March, 1, PrecisionMonth},
+		{"май 2018", 2018, time.May, 1, PrecisionMonth},
+		{"Дек. 2021", 2021, time.December, 1, PrecisionMonth},
+	}
+	for _, tc := range cases {
+		t.Run(tc.raw, func(t *testing.T) {
+			d := NormalizeDate(tc.raw)
+			require.NotNil(t, d.Time)
+			require.Equal(t, time.Date(tc.year, tc.month, tc.day, 0, 0, 0, 0, time.UTC), *d.Time)
+			require.Equal(t, tc.precision, d.Precision)
+			require.False(t, d.Present)
+		})
+	}
+}
+
+func TestNormalizeDate_PresentAndInvalid(t *testing.T) {
+	for _, raw := range []string{"по настоящее время", "по н.в.", "Present", "currently"} {
+		d := NormalizeDate(raw)
+		require.True(t, d.Present, raw)
+		require.Nil(t, d.Time, raw)
+	}
+	for _, raw := range []string{"", "когда-то", "13.2020", "2020-02-31"} {
+		require.True(t, NormalizeDate(raw).IsZero(), raw)
+	}
+}
+
+func TestTotalMonths(t *testing.T) {
+	now := time.Date(2024, time.June, 15, 0, 0, 0, 0, time.UTC)
+	first := Period{Start: NormalizeDate("Jan 2020"), End: NormalizeDate("Dec 2021")}
+	overlapping := Period{Start: NormalizeDate("2021-06"), End: NormalizeDate("2022-05")}
+	current := Period{Start: NormalizeDate("2024-01"), End: NormalizeDate("по настоящее время")}
+	yearOnly := Period{Start: NormalizeDate("2019"), End: NormalizeDate("2019")}
+
+	require.Equal(t, 24, first.Months(now))
+	require.Equal(t, 6, current.Months(now))
+	require.Equal(t, 12, yearOnly.Months(now))
+	require.Equal(t, 12+29+6, TotalMonths([]Period{yearOnly, first, overlapping, current}, now))
+	require.Equal(t, 0, Period{End: NormalizeDate("2020")}.Months(now))
+}
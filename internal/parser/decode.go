@@ -0,0 +1,64 @@
+package parser
+
+import (
+	"CVMatch/internal/response"
+	"encoding/json"
+	"strings"
+)
+
+// DecodeOutput разбирает ответ LLM в DTO резюме. Модели иногда оборачивают JSON
+// в markdown-блок кода, такие ограждения вместе с меткой языка отбрасываются.
+func DecodeOutput(output string) (*response.ParsedResumeDTO, error) {
+	var dto response.ParsedResumeDTO
+	if err := json.Unmarshal([]byte(stripCodeFence(output)), &dto); err != nil {
+		return nil, err
+	}
+	return &dto, nil
+}
+
+// DecodeVacancyOutput разбирает ответ LLM в черновик вакансии
+func DecodeVacancyOutput(output string) (*response.VacancyDraftDTO, error) {
+	var dto response.VacancyDraftDTO
+	if err := json.Unmarshal([]byte(stripCodeFence(output)), &dto); err != nil {
+		return nil, err
+	}
+	return &dto, nil
+}
+
+// RecommendationsOutput — советы по резюме в ответе LLM. Содержит только поля, которые заполняет модель;
+// идентификаторы, источник и язык проставляет сервис.
+type RecommendationsOutput struct {
+	MissingKeywords []string `json:"missing_keywords"`
+	BulletRewrites  []struct {
+		Original  string `json:"original"`
+		Suggested string `json:"suggested"`
+		Reason    string `json:"reason"`
+	} `json:"bullet_rewrites"`
+	SectionsToAdd []struct {
+		Section string `json:"section"`
+		Reason  string `json:"reason"`
+	} `json:"sections_to_add"`
+}
+
+// DecodeRecommendationsOutput разбирает ответ LLM с советами по резюме
+func DecodeRecommendationsOutput(output string) (*RecommendationsOutput, error) {
+	var out RecommendationsOutput
+	if err := json.Unmarshal([]byte(stripCodeFence(output)), &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func stripCodeFence(output string) string {
+	output = strings.TrimSpace(output)
+	if strings.HasPrefix(output, "```") {
+		output = strings.TrimPrefix(output, "```")
+		output = strings.TrimPrefix(output, "json")
+		output = strings.TrimSpace(output)
+	}
+	if strings.HasSuffix(output, "```") {
+		output = strings.TrimSuffix(output, "```")
+		output = strings.TrimSpace(output)
+	}
+	return output
+}
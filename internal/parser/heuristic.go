@@ -0,0 +1,226 @@
+package parser
+
+import (
+	"CVMatch/internal/config"
+	"CVMatch/internal/llm"
+	"CVMatch/internal/response"
+	"context"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"go.uber.org/zap"
+)
+
+// ModelHeuristic — вместо LLM резюме разобрано эвристиками
+const ModelHeuristic = "heuristic"
+
+// heuristicConfidence — самооценка эвристического разбора: резюме всё равно уйдёт на ручную проверку
+const heuristicConfidence = 0.3
+
+var (
+	reEmailInText = regexp.MustCompile(`[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}`)
+	rePhoneInText = regexp.MustCompile(`\+?\d[\d\-\s()]{8,}\d`)
+	reURLInText   = regexp.MustCompile(`(?i)(https?://|www\.|t\.me/)[^\s,;)]+`)
+)
+
+// knownSkill — навык и варианты его написания в нижнем регистре
+type knownSkill struct {
+	name string
+	keys []string
+}
+
+var knownSkills = []knownSkill{
+	{"Go", []string{"golang"}},
+	{"Python", []string{"python"}},
+	{"Java", []string{"java"}},
+	{"JavaScript", []string{"javascript"}},
+	{"TypeScript", []string{"typescript"}},
+	{"PHP", []string{"php"}},
+	{"C++", []string{"c++"}},
+	{"C#", []string{"c#"}},
+	{"Kotlin", []string{"kotlin"}},
+	{"Swift", []string{"swift"}},
+	{"SQL", []string{"sql"}},
+	{"PostgreSQL", []string{"postgresql", "postgres"}},
+	{"MySQL", []string{"mysql"}},
+	{"MongoDB", []string{"mongodb"}},
+	{"Redis", []string{"redis"}},
+	{"ClickHouse", []string{"clickhouse"}},
+	{"Elasticsearch", []string{"elasticsearch"}},
+	{"Kafka", []string{"kafka"}},
+	{"RabbitMQ", []string{"rabbitmq"}},
+	{"gRPC", []string{"grpc"}},
+	{"REST", []string{"rest", "rest api"}},
+	{"Docker", []string{"docker"}},
+	{"Kubernetes", []string{"kubernetes", "k8s"}},
+	{"Terraform", []string{"terraform"}},
+	{"Ansible", []string{"ansible"}},
+	{"Linux", []string{"linux"}},
+	{"Git", []string{"git"}},
+	{"CI/CD", []string{"ci/cd"}},
+	{"AWS", []string{"aws"}},
+	{"React", []string{"react"}},
+	{"Vue", []string{"vue"}},
+	{"Angular", []string{"angular"}},
+	{"Node.js", []string{"node.js", "nodejs"}},
+	{"Django", []string{"django"}},
+	{"Spring", []string{"spring"}},
+	{"HTML", []string{"html"}},
+	{"CSS", []string{"css"}},
+	{"Figma", []string{"figma"}},
+}
+
+// HeuristicResumeParser разбирает резюме без LLM: контакты, ссылки и навыки из словаря.
+// Используется как запасной вариант, пока провайдер LLM недоступен.
+type HeuristicResumeParser struct{}
+
+func (HeuristicResumeParser) ParseResume(_ context.Context, path string, _ *config.Config) (*ParseResult, error) {
+	extracted, err := ExtractPDF(path)
+	if err != nil {
+		return nil, err
+	}
+	output, err := json.Marshal(ParseTextHeuristically(extracted.Text))
+	if err != nil {
+		return nil, err
+	}
+	return &ParseResult{
+		Text:             extracted.Text,
+		Pages:            extracted.Pages,
+		Language:         extracted.Language,
+		ExtractionMethod: extracted.Method,
+		ContentLanguage:  extracted.Language,
+		Model:            ModelHeuristic,
+		Output:           string(output),
+	}, nil
+}
+
+// ParseTextHeuristically достаёт из текста то, что находится без понимания структуры резюме
+func ParseTextHeuristically(text string) *response.ParsedResumeDTO {
+	dto := &response.ParsedResumeDTO{
+		FullName:   guessFullName(text),
+		Email:      reEmailInText.FindString(text),
+		Skills:     []string{},
+		Experience: []response.ExperienceDTO{},
+		Education:  []response.EducationDTO{},
+		Links:      []response.LinkDTO{},
+		Confidence: response.ConfidenceMap{},
+	}
+	for _, candidate := range rePhoneInText.FindAllString(text, -1) {
+		if digits := digitsOnly(candidate); len(digits) >= 10 && len(digits) <= 15 {
+			dto.Phone = strings.TrimSpace(candidate)
+			break
+		}
+	}
+
+	lower := strings.ToLower(text)
+	for _, skill := range knownSkills {
+		for _, key := range skill.keys {
+			if containsWord(lower, key) {
+				dto.Skills = append(dto.Skills, skill.name)
+				break
+			}
+		}
+	}
+	// "go" в нижнем регистре — обычное английское слово, поэтому Go ищем с учётом регистра
+	if !containsSkill(dto.Skills, "Go") && containsWord(text, "Go") {
+		dto.Skills = append([]string{"Go"}, dto.Skills...)
+	}
+
+	for _, url := range reURLInText.FindAllString(text, -1) {
+		url = strings.TrimRight(url, ".")
+		dto.Links = append(dto.Links, response.LinkDTO{Type: DetectLinkType(url, ""), URL: url})
+	}
+
+	for _, field := range []string{FieldFullName, FieldEmail, FieldPhone, FieldLocation, FieldSkills, FieldExperience, FieldEducation} {
+		dto.Confidence[field] = heuristicConfidence
+	}
+	return dto
+}
+
+// SkillMention — навык из словаря и число его упоминаний в тексте
+type SkillMention struct {
+	Name  string
+	Count int
+}
+
+// SkillMentions считает упоминания навыков из словаря в тексте, в порядке словаря
+func SkillMentions(text string) []SkillMention {
+	lower := strings.ToLower(text)
+	var mentions []SkillMention
+	for _, skill := range knownSkills {
+		count := 0
+		for _, key := range skill.keys {
+			count += countWord(lower, key)
+		}
+		if skill.name == "Go" {
+			count += countWord(text, "Go")
+		}
+		if count > 0 {
+			mentions = append(mentions, SkillMention{Name: skill.name, Count: count})
+		}
+	}
+	return mentions
+}
+
+// guessFullName — первая строка из двух-трёх слов, каждое с заглавной буквы
+func guessFullName(text string) string {
+	for _, line := range strings.Split(text, "\n") {
+		words := strings.Fields(line)
+		if len(words) < 2 || len(words) > 3 {
+			continue
+		}
+		ok := true
+		for _, w := range words {
+			runes := []rune(w)
+			if !unicode.IsUpper(runes[0]) {
+				ok = false
+				break
+			}
+			for _, r := range runes {
+				if !unicode.IsLetter(r) && r != '-' {
+					ok = false
+					break
+				}
+			}
+		}
+		if ok {
+			return strings.Join(words, " ")
+		}
+	}
+	return ""
+}
+
+func containsSkill(skills []string, name string) bool {
+	for _, s := range skills {
+		if s == name {
+			return true
+		}
+	}
+	return false
+}
+
+// FallbackResumeParser разбирает резюме основным парсером, а если провайдер LLM недоступен
+// (сработал предохранитель или закончились повторы) — запасным. Если не задан Log, логи не пишутся.
+type FallbackResumeParser struct {
+	Primary  ResumeParserI
+	Fallback ResumeParserI
+	Log      *zap.Logger
+}
+
+func (p FallbackResumeParser) ParseResume(ctx context.Context, path string, cfg *config.Config) (*ParseResult, error) {
+	result, err := p.Primary.ParseResume(ctx, path, cfg)
+	if err == nil || !llm.IsUnavailable(err) || ctx.Err() != nil {
+		return result, err
+	}
+	if p.Log != nil {
+		p.Log.Warn("LLM provider unavailable, using fallback parser", zap.Error(err))
+	}
+	result, fallbackErr := p.Fallback.ParseResume(ctx, path, cfg)
+	if fallbackErr != nil {
+		return nil, errors.Join(err, fallbackErr)
+	}
+	return result, nil
+}
@@ -0,0 +1,49 @@
+package parser
+
+import (
+	"CVMatch/internal/config"
+	"CVMatch/internal/llm"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type failingParser struct {
+	err error
+}
+
+func (p failingParser) ParseResume(context.Context, string, *config.Config) (*ParseResult, error) {
+	return nil, p.err
+}
+
+func TestParseTextHeuristically(t *testing.T) {
+	text := "Резюме\nИван Петров\nivan@test.com, +7 (999) 123-45-67\nGo, PostgreSQL, Docker, k8s\nhttps://github.com/ivan"
+	dto := ParseTextHeuristically(text)
+	require.Equal(t, "Иван Петров", dto.FullName)
+	require.Equal(t, "ivan@test.com", dto.Email)
+	require.Equal(t, "+7 (999) 123-45-67", dto.Phone)
+	require.Equal(t, []string{"Go", "PostgreSQL", "Docker", "Kubernetes"}, dto.Skills)
+	require.Equal(t, LinkGitHub, dto.Links[0].Type)
+
+	// "go" в обычном тексте навыком не считается
+	require.Empty(t, ParseTextHeuristically("ready to go abroad").Skills)
+}
+
+func TestFallbackResumeParser(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	p := FallbackResumeParser{Primary: failingParser{err: llm.ErrCircuitOpen}, Fallback: HeuristicResumeParser{}, Log: zap.New(core)}
+	result, err := p.ParseResume(context.Background(), "../../uploads/test.pdf", &config.Config{})
+	require.NoError(t, err)
+	require.Equal(t, ModelHeuristic, result.Model)
+	require.Contains(t, result.Output, "ivan@test.com")
+	require.Equal(t, 1, logs.FilterField(zap.Error(llm.ErrCircuitOpen)).Len())
+
+	// Ошибки, не связанные с доступностью провайдера, не маскируются
+	p.Primary = failingParser{err: errors.New("broken pdf")}
+	_, err = p.ParseResume(context.Background(), "../../uploads/test.pdf", &config.Config{})
+	require.EqualError(t, err, "broken pdf")
+}
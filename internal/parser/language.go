@@ -0,0 +1,110 @@
+package parser
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Языки документов
+const (
+	LangRussian = "ru"
+	LangEnglish = "en"
+	LangKazakh  = "kk"
+	LangUzbek   = "uz"
+)
+
+// Буквы, которых нет в русском алфавите
+const (
+	kazakhLetters      = "әөұүіңғқһ"
+	uzbekCyrillicExtra = "ўҳ"
+)
+
+// Частые слова узбекского на латинице, по которым он отличается от английского: служебные
+// слова сравниваются целиком, основы — с начала слова
+var (
+	uzbekLatinWords = []string{"va", "bilan", "uchun", "yil", "yili", "yilda"}
+	uzbekLatinStems = []string{"dasturchi", "tajriba", "ishla", "universitet", "yillar", "yillik"}
+)
+
+// Минимальная доля узбекских маркеров среди слов латинского текста: отдельные «O'Reilly»
+// или «g'day» в английском резюме не делают его узбекским
+const uzbekMarkerRatio = 0.05
+
+// DetectLanguage определяет язык текста по алфавиту и характерным буквам и словам.
+// Возвращает ISO 639-1 код (ru, en, kk, uz) или пустую строку, если букв в тексте нет.
+func DetectLanguage(text string) string {
+	var cyrillic, latin, kazakh, uzbekCyr int
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.Is(unicode.Cyrillic, r):
+			cyrillic++
+			if strings.ContainsRune(uzbekCyrillicExtra, r) {
+				uzbekCyr++
+			} else if strings.ContainsRune(kazakhLetters, r) {
+				kazakh++
+			}
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		}
+	}
+	if cyrillic+latin == 0 {
+		return ""
+	}
+
+	if cyrillic >= latin {
+		switch {
+		case uzbekCyr*200 > cyrillic:
+			return LangUzbek
+		case kazakh*100 > cyrillic:
+			return LangKazakh
+		default:
+			return LangRussian
+		}
+	}
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !isApostrophe(r)
+	})
+	markers := 0
+	for _, word := range words {
+		if isUzbekLatinMarker(word) {
+			markers++
+		}
+	}
+	if markers >= 2 && float64(markers) >= uzbekMarkerRatio*float64(len(words)) {
+		return LangUzbek
+	}
+	return LangEnglish
+}
+
+// isUzbekLatinMarker сообщает, похоже ли слово на узбекское: буквы oʻ и gʻ или частое слово.
+// В начале слова обычный апостроф не учитывается — так пишутся английские O'Reilly и g'day.
+func isUzbekLatinMarker(word string) bool {
+	runes := []rune(word)
+	for i := 1; i+1 < len(runes); i++ {
+		if (runes[i-1] == 'o' || runes[i-1] == 'g') && isApostrophe(runes[i]) && unicode.IsLetter(runes[i+1]) &&
+			(i > 1 || runes[i] == 'ʻ') {
+			return true
+		}
+	}
+	for _, w := range uzbekLatinWords {
+		if word == w {
+			return true
+		}
+	}
+	for _, stem := range uzbekLatinStems {
+		if strings.HasPrefix(word, stem) {
+			return true
+		}
+	}
+	return false
+}
+
+// isApostrophe — апостроф и знаки, которыми на письме передают узбекские oʻ и gʻ
+func isApostrophe(r rune) bool {
+	switch r {
+	case '\'', 'ʻ', 'ʼ', '‘', '’':
+		return true
+	}
+	return false
+}
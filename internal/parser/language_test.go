@@ -0,0 +1,39 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	cases := []struct {
+		text string
+		want string
+	}{
+		{"Иванов Иван, backend-разработчик на Go", LangRussian},
+		{"John Smith, senior software engineer with Go and Kubernetes", LangEnglish},
+		{"Мен бағдарламашымын, Алматы қаласында тұрамын", LangKazakh},
+		{"Men dasturchiman va Toshkentda ishlayman", LangUzbek},
+		{"Toshkent axborot texnologiyalari universiteti, 2019 yil. Oʻzbek va rus tillari", LangUzbek},
+		{"To'g'ri bo'lim: ma'lumotlar bazasi bilan ishlash tajribasi 3 yil", LangUzbek},
+		// Отдельные апострофы и сочетания букв в английском тексте не делают его узбекским
+		{"Read O'Reilly books daily. Moody's analyst, g'day mate, yields and vanilla builds in a Go team of engineers " +
+			"working on payments, billing and reporting services for five years", LangEnglish},
+		{"", ""},
+		{"12345 +7 (999)", ""},
+	}
+	for _, c := range cases {
+		require.Equal(t, c.want, DetectLanguage(c.text), c.text)
+	}
+}
+
+func TestExtractPDF(t *testing.T) {
+	extracted, err := ExtractPDF("../../uploads/test.pdf")
+	require.NoError(t, err)
+	require.Equal(t, 1, extracted.Pages)
+	require.Equal(t, LangRussian, extracted.Language)
+	require.Equal(t, ExtractionMethodPDFText, extracted.Method)
+	require.True(t, strings.Contains(extracted.Text, "ivan@test.com"))
+}
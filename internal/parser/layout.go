@@ -0,0 +1,127 @@
+package parser
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// Layout — признаки вёрстки PDF, из-за которых ATS извлекают текст не по порядку или не извлекают вовсе
+type Layout struct {
+	Pages            int
+	Images           int // изображения на страницах, включая скан всего резюме
+	Rects            int // нарисованные прямоугольники: рамки, заливки и ячейки таблиц
+	Lines            int // строки текста
+	TableRows        int // строки из трёх и более фрагментов, разнесённых по горизонтали
+	MultiColumnPages int // страницы, свёрстанные в две колонки
+}
+
+const (
+	// rowTolerance — разница координаты Y в пунктах, при которой символы считаются одной строкой
+	rowTolerance = 2.0
+	// minColumnGap — расстояние между фрагментами строки в пунктах, начиная с которого это разные ячейки или колонки
+	minColumnGap = 24.0
+	// multiColumnShare — доля строк страницы из двух фрагментов, при которой страница считается двухколоночной
+	multiColumnShare = 0.3
+	// minColumnRows — на страницах с меньшим числом строк колонки не определяются
+	minColumnRows = 8
+)
+
+// AnalyzeLayout читает структуру страниц PDF: изображения, прямоугольники и расположение текста
+func AnalyzeLayout(path string) (*Layout, error) {
+	f, r, err := pdf.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	layout := &Layout{Pages: r.NumPage()}
+	for i := 1; i <= layout.Pages; i++ {
+		page := r.Page(i)
+		if page.V.IsNull() {
+			continue
+		}
+		content, err := pageContent(page)
+		if err != nil {
+			return nil, err
+		}
+		layout.Images += pageImages(page)
+		layout.Rects += len(content.Rect)
+
+		stats := analyzeRows(content.Text)
+		layout.Lines += stats.rows
+		layout.TableRows += stats.tableRows
+		if stats.rows >= minColumnRows && float64(stats.twoColumnRows) >= multiColumnShare*float64(stats.rows) {
+			layout.MultiColumnPages++
+		}
+	}
+	return layout, nil
+}
+
+// pageContent разбирает содержимое страницы; библиотека паникует на повреждённых потоках
+func pageContent(page pdf.Page) (content pdf.Content, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("не удалось разобрать страницу PDF: %v", r)
+		}
+	}()
+	return page.Content(), nil
+}
+
+// pageImages считает изображения среди внешних объектов страницы
+func pageImages(page pdf.Page) int {
+	xobjects := page.Resources().Key("XObject")
+	images := 0
+	for _, name := range xobjects.Keys() {
+		if xobjects.Key(name).Key("Subtype").Name() == "Image" {
+			images++
+		}
+	}
+	return images
+}
+
+// rowStats — строки страницы по числу фрагментов
+type rowStats struct {
+	rows          int
+	twoColumnRows int
+	tableRows     int
+}
+
+// analyzeRows собирает символы в строки по координате Y и делит каждую строку на фрагменты
+// по большим горизонтальным промежуткам: два фрагмента — признак колонок, три и больше — таблицы
+func analyzeRows(texts []pdf.Text) rowStats {
+	var glyphs []pdf.Text
+	for _, t := range texts {
+		if t.S != "" && t.S != " " {
+			glyphs = append(glyphs, t)
+		}
+	}
+	sort.SliceStable(glyphs, func(i, j int) bool { return glyphs[i].Y > glyphs[j].Y })
+
+	var stats rowStats
+	for start := 0; start < len(glyphs); {
+		end := start + 1
+		for end < len(glyphs) && math.Abs(glyphs[end].Y-glyphs[start].Y) <= rowTolerance {
+			end++
+		}
+		row := glyphs[start:end]
+		sort.SliceStable(row, func(i, j int) bool { return row[i].X < row[j].X })
+		segments := 1
+		for i := 1; i < len(row); i++ {
+			if row[i].X-(row[i-1].X+row[i-1].W) > math.Max(minColumnGap, 2*row[i-1].FontSize) {
+				segments++
+			}
+		}
+		stats.rows++
+		switch {
+		case segments == 2:
+			stats.twoColumnRows++
+		case segments >= 3:
+			stats.tableRows++
+		}
+		start = end
+	}
+	return stats
+}
@@ -0,0 +1,48 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/ledongthuc/pdf"
+	"github.com/stretchr/testify/require"
+)
+
+// line раскладывает фрагменты по строке на высоте y: символы шириной 5 пунктов, фрагмент начинается с x
+func line(y float64, fragments map[float64]string) []pdf.Text {
+	var texts []pdf.Text
+	for x, s := range fragments {
+		for i, r := range []rune(s) {
+			texts = append(texts, pdf.Text{FontSize: 10, X: x + float64(i)*5, Y: y, W: 5, S: string(r)})
+		}
+	}
+	return texts
+}
+
+func TestAnalyzeRows(t *testing.T) {
+	var texts []pdf.Text
+	texts = append(texts, line(700, map[float64]string{50: "Ivan Ivanov"})...)
+	texts = append(texts, line(680, map[float64]string{50: "Experience", 320: "Skills"})...)
+	texts = append(texts, line(680.5, map[float64]string{400: "Go"})...)
+	texts = append(texts, line(660, map[float64]string{50: "2020", 150: "Acme", 300: "Developer"})...)
+	texts = append(texts, line(640, map[float64]string{50: "Built services in Go with Kafka"})...)
+
+	stats := analyzeRows(texts)
+	require.Equal(t, 4, stats.rows)
+	require.Equal(t, 2, stats.tableRows)
+	require.Equal(t, 0, stats.twoColumnRows)
+
+	stats = analyzeRows(line(600, map[float64]string{50: "Experience", 320: "Skills"}))
+	require.Equal(t, rowStats{rows: 1, twoColumnRows: 1}, stats)
+}
+
+func TestAnalyzeLayout(t *testing.T) {
+	layout, err := AnalyzeLayout(testResumePDF)
+	require.NoError(t, err)
+	require.Equal(t, 1, layout.Pages)
+	require.Positive(t, layout.Lines)
+	require.Zero(t, layout.Images)
+	require.Zero(t, layout.MultiColumnPages)
+
+	_, err = AnalyzeLayout("testdata/missing.pdf")
+	require.Error(t, err)
+}
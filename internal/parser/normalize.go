@@ -0,0 +1,83 @@
+package parser
+
+import (
+	"strings"
+)
+
+// Формат работы
+const (
+	RemoteOnly   = "remote"
+	RemoteHybrid = "hybrid"
+	RemoteOffice = "office"
+)
+
+// Типы ссылок на профили кандидата
+const (
+	LinkGitHub   = "github"
+	LinkGitLab   = "gitlab"
+	LinkLinkedIn = "linkedin"
+	LinkTelegram = "telegram"
+	LinkHH       = "hh"
+	LinkWebsite  = "website"
+)
+
+var currencyAliases = map[string]string{
+	"rub": "RUB", "rur": "RUB", "руб": "RUB", "руб.": "RUB", "рубль": "RUB", "рублей": "RUB", "₽": "RUB", "р": "RUB", "р.": "RUB",
+	"usd": "USD", "$": "USD", "долл": "USD", "долларов": "USD", "dollar": "USD",
+	"eur": "EUR", "€": "EUR", "евро": "EUR", "euro": "EUR",
+	"kzt": "KZT", "₸": "KZT", "тенге": "KZT", "тг": "KZT",
+	"uzs": "UZS", "сум": "UZS", "сумов": "UZS", "so'm": "UZS", "sum": "UZS",
+	"byn": "BYN", "бел. руб": "BYN",
+	"gbp": "GBP", "£": "GBP",
+}
+
+// NormalizeCurrency приводит валюту к коду ISO 4217, неизвестное значение возвращается в верхнем регистре
+func NormalizeCurrency(raw string) string {
+	s := strings.ToLower(strings.TrimSpace(raw))
+	if s == "" {
+		return ""
+	}
+	if code, ok := currencyAliases[s]; ok {
+		return code
+	}
+	return strings.ToUpper(s)
+}
+
+// NormalizeRemotePreference приводит формат работы к remote, hybrid или office
+func NormalizeRemotePreference(raw string) string {
+	s := strings.ToLower(strings.TrimSpace(raw))
+	switch {
+	case s == "":
+		return ""
+	case strings.Contains(s, "гибрид") || strings.Contains(s, "hybrid"):
+		return RemoteHybrid
+	case strings.Contains(s, "удал") || strings.Contains(s, "remote") || strings.Contains(s, "дистанц"):
+		return RemoteOnly
+	case strings.Contains(s, "офис") || strings.Contains(s, "office") || strings.Contains(s, "on-site") || strings.Contains(s, "onsite"):
+		return RemoteOffice
+	default:
+		return ""
+	}
+}
+
+// DetectLinkType определяет тип ссылки по адресу; тип от LLM используется, если адрес ничего не говорит
+func DetectLinkType(url, hint string) string {
+	u := strings.ToLower(url)
+	switch {
+	case strings.Contains(u, "github.com"):
+		return LinkGitHub
+	case strings.Contains(u, "gitlab.com"):
+		return LinkGitLab
+	case strings.Contains(u, "linkedin.com"):
+		return LinkLinkedIn
+	case strings.Contains(u, "t.me/") || strings.HasPrefix(u, "@"):
+		return LinkTelegram
+	case strings.Contains(u, "hh.ru") || strings.Contains(u, "headhunter"):
+		return LinkHH
+	}
+	switch h := strings.ToLower(strings.TrimSpace(hint)); h {
+	case LinkGitHub, LinkGitLab, LinkLinkedIn, LinkTelegram, LinkHH:
+		return h
+	}
+	return LinkWebsite
+}
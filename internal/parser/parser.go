@@ -1,119 +1,158 @@
-package parser
-
-import (
-	"CVMatch/internal/config"
-	"bytes"
-	"context"
-	"fmt"
-	"time"
-
-	"github.com/ledongthuc/pdf"
-	"github.com/sheeiavellie/go-yandexgpt"
-)
-
-type ResumeParserI interface {
-	ParseResume(path string, cfg *config.Config) (string, error)
-}
-
-type YandexResumeParser struct{}
-
-func (YandexResumeParser) ParseResume(path string, cfg *config.Config) (string, error) {
-	return ParseResumeWithYandex(path, cfg)
-}
-
-func ExtractTextFromPDF(path string) (string, error) {
-	f, r, err := pdf.Open(path)
-	if err != nil {
-		return "", err
-	}
-	defer f.Close()
-
-	var textBuilder bytes.Buffer
-	b, err := r.GetPlainText()
-	if err != nil {
-		return "", err
-	}
-	_, err = textBuilder.ReadFrom(b)
-	if err != nil {
-		return "", err
-	}
-	return textBuilder.String(), nil
-}
-
-func BuildPrompt(text string) string {
-	return fmt.Sprintf(`
-Ты — помощник по анализу резюме. Проанализируй текст и верни результат в формате JSON со следующей структурой:
-
-{
-  "full_name": "ФИО",
-  "email": "email@example.com",
-  "phone": "+7 000 000-00-00",
-  "location": "Город",
-  "skills": ["Go", "PostgreSQL"],
-  "experience": [
-	{
-	  "company": "Компания",
-	  "position": "Должность",
-	  "start_date": "2023",
-	  "end_date": "2027",
-	  "description": "Описание работы"
-	}
-  ],
-  "education": [
-	{
-	  "institution": "Университет",
-	  "degree": "Степень",
-	  "field": "Специальность",
-	  "start_date": "2016-09-01" // дата начала обучения (если не указано, остваить пустым),
-	  "end_date": "2020-06-30 // дата окончания обучения (обычно указана только дата окончания)"
-	}
-  ]
-}
-
-Текст резюме:
-%s
-`, text)
-}
-
-// Парсинг резюме через Yandex (Ollama)
-func ParseResumeWithYandex(pdfPath string, cfg *config.Config) (string, error) {
-	start := time.Now()
-	fmt.Println("[Yandex] Начинаем парсинг резюме через Yandex...")
-	resumeText, err := ExtractTextFromPDF(pdfPath)
-	if err != nil {
-		fmt.Println("[Yandex] Ошибка извлечения текста из PDF:", err)
-		return "", err
-	}
-	fmt.Println("[Yandex] Текст резюме успешно извлечён, длина:", len(resumeText))
-	prompt := BuildPrompt(resumeText)
-	fmt.Println("[Yandex] Prompt сформирован, длина:", len(prompt))
-
-	client := yandexgpt.NewYandexGPTClientWithAPIKey(cfg.YandexGPTIAM)
-	request := yandexgpt.YandexGPTRequest{
-		ModelURI: yandexgpt.MakeModelURI(cfg.YandexGPTCatalog, yandexgpt.YandexGPTModelLite),
-		CompletionOptions: yandexgpt.YandexGPTCompletionOptions{
-			Stream:      false,
-			Temperature: 0.7,
-			MaxTokens:   2000,
-		},
-		Messages: []yandexgpt.YandexGPTMessage{
-			{
-				Role: yandexgpt.YandexGPTMessageRoleSystem,
-				Text: "Ты — парсер резюме. Возвращай только JSON в указанной структуре.",
-			},
-			{
-				Role: yandexgpt.YandexGPTMessageRoleUser,
-				Text: prompt,
-			},
-		},
-	}
-	response, err := client.GetCompletion(context.Background(), request)
-	if err != nil {
-		fmt.Println("Request error")
-		return "", err
-	}
-	fmt.Println("[Yandex] Ответ Yandex получен, длина:", len(response.Result.Alternatives[0].Message.Text))
-	elapsed := time.Since(start)
-	fmt.Printf("[Yandex] Время парсинга резюме: %s\n", elapsed)
-	return response.Result.Alternatives[0].Message.Text, nil
-}
+package parser
+
+import (
+	"CVMatch/internal/config"
+	"CVMatch/internal/llm"
+	"bytes"
+	"context"
+	"strings"
+	"time"
+
+	"github.com/ledongthuc/pdf"
+	"go.uber.org/zap"
+)
+
+// ParseResult — результат разбора документа
+type ParseResult struct {
+	Text             string // текст, извлечённый из документа
+	Pages            int
+	Language         string // язык документа (ru, en, kk, uz)
+	ExtractionMethod string
+	ContentLanguage  string // язык значений в ответе: язык документа или язык перевода
+	PromptVersion    string
+	Model            string
+	Cached           bool   // ответ LLM взят из кеша
+	Output           string // ответ LLM (JSON)
+}
+
+// ExtractionMethodPDFText — текст взят из текстового слоя PDF
+const ExtractionMethodPDFText = "pdf_text"
+
+// ExtractedText — текст документа с метаданными извлечения
+type ExtractedText struct {
+	Text     string
+	Pages    int
+	Method   string
+	Language string
+}
+
+type ResumeParserI interface {
+	ParseResume(ctx context.Context, path string, cfg *config.Config) (*ParseResult, error)
+}
+
+// YandexResumeParser разбирает резюме через YandexGPT. Если Prompts не задан, используются встроенные шаблоны,
+// если не задан Client — клиент YandexGPT без кеша, если не задан Log — логи не пишутся.
+type YandexResumeParser struct {
+	Prompts *PromptSet
+	Client  llm.Client
+	Log     *zap.Logger
+}
+
+func (p YandexResumeParser) ParseResume(ctx context.Context, path string, cfg *config.Config) (*ParseResult, error) {
+	prompts := p.Prompts
+	if prompts == nil {
+		prompts = DefaultPrompts()
+	}
+	client := p.Client
+	if client == nil {
+		client = llm.NewYandexClient(cfg)
+	}
+	log := p.Log
+	if log == nil {
+		log = zap.NewNop()
+	}
+	return ParseResumeWithYandex(ctx, path, cfg, prompts, client, log)
+}
+
+func ExtractTextFromPDF(path string) (string, error) {
+	extracted, err := ExtractPDF(path)
+	if err != nil {
+		return "", err
+	}
+	return extracted.Text, nil
+}
+
+// ExtractPDF извлекает текст PDF вместе с числом страниц и языком документа
+func ExtractPDF(path string) (*ExtractedText, error) {
+	f, r, err := pdf.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var textBuilder bytes.Buffer
+	b, err := r.GetPlainText()
+	if err != nil {
+		return nil, err
+	}
+	_, err = textBuilder.ReadFrom(b)
+	if err != nil {
+		return nil, err
+	}
+	// PostgreSQL не принимает нулевые байты в text
+	text := strings.ReplaceAll(textBuilder.String(), "\x00", "")
+	return &ExtractedText{
+		Text:     text,
+		Pages:    r.NumPage(),
+		Method:   ExtractionMethodPDFText,
+		Language: DetectLanguage(text),
+	}, nil
+}
+
+// BuildPrompt формирует промпт встроенной версии для резюме на русском языке
+func BuildPrompt(text string) string {
+	prompt, _ := DefaultPrompts().Resume(text, LangRussian, "")
+	return prompt
+}
+
+// Парсинг резюме через Yandex (Ollama)
+func ParseResumeWithYandex(ctx context.Context, pdfPath string, cfg *config.Config, prompts *PromptSet, client llm.Client, log *zap.Logger) (*ParseResult, error) {
+	start := time.Now()
+	log.Debug("Parsing resume with Yandex")
+	extracted, err := ExtractPDF(pdfPath)
+	if err != nil {
+		log.Error("Failed to extract text from PDF", zap.Error(err))
+		return nil, err
+	}
+	log.Debug("Resume text extracted", zap.Int("length", len(extracted.Text)))
+	contentLanguage := ContentLanguage(extracted.Language, cfg.ResumeTargetLanguage)
+	prompt, err := prompts.Resume(extracted.Text, extracted.Language, contentLanguage)
+	if err != nil {
+		log.Error("Failed to build resume prompt", zap.Error(err))
+		return nil, err
+	}
+	systemPrompt, err := prompts.System(extracted.Language)
+	if err != nil {
+		log.Error("Failed to build system prompt", zap.Error(err))
+		return nil, err
+	}
+	log.Debug("Resume prompt built", zap.String("prompt_version", prompts.Version), zap.String("language", extracted.Language), zap.Int("length", len(prompt)))
+
+	response, err := client.Complete(ctx, llm.Request{
+		Model:         cfg.YandexGPTModel,
+		PromptVersion: prompts.Version,
+		System:        systemPrompt,
+		Prompt:        prompt,
+		Temperature:   0.7,
+		MaxTokens:     2000,
+	})
+	if err != nil {
+		log.Error("Failed to parse resume with Yandex", zap.Error(err))
+		return nil, err
+	}
+	log.Debug("Yandex response received",
+		zap.Int("length", len(response.Text)),
+		zap.Bool("cached", response.Cached),
+		zap.Duration("elapsed", time.Since(start)))
+	return &ParseResult{
+		Text:             extracted.Text,
+		Pages:            extracted.Pages,
+		Language:         extracted.Language,
+		ExtractionMethod: extracted.Method,
+		ContentLanguage:  contentLanguage,
+		PromptVersion:    prompts.Version,
+		Model:            cfg.YandexGPTModel,
+		Cached:           response.Cached,
+		Output:           response.Text,
+	}, nil
+}
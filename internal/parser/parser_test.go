@@ -0,0 +1,56 @@
+package parser
+
+import (
+	"CVMatch/internal/config"
+	"CVMatch/internal/llm"
+	"context"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const (
+	testResumePDF = "testdata/resume.pdf"
+	fixturesDir   = "testdata/fixtures"
+)
+
+// yandexFixtureConfig — конфигурация для обменов с YandexGPT через фикстуры.
+// С LLM_FIXTURES_MODE=record запросы уходят настоящему провайдеру с ключами из окружения
+// и перезаписывают фикстуры; по умолчанию ответы воспроизводятся без сети.
+func yandexFixtureConfig(t *testing.T) (*config.Config, http.RoundTripper) {
+	t.Helper()
+	cfg := &config.Config{
+		YandexGPTIAM:     "test-api-key",
+		YandexGPTCatalog: "test-folder",
+		YandexGPTModel:   "yandexgpt-lite",
+	}
+	mode := llm.FixtureModeReplay
+	if os.Getenv("LLM_FIXTURES_MODE") == llm.FixtureModeRecord {
+		mode = llm.FixtureModeRecord
+		cfg.YandexGPTIAM = os.Getenv("YANDEXGPT_IAM")
+		cfg.YandexGPTCatalog = os.Getenv("YANDEXGPT_CATALOG_ID")
+		cfg.LLM.BaseURL = os.Getenv("YANDEXGPT_BASE_URL")
+	}
+	return cfg, llm.NewFixtureTransport(mode, fixturesDir, nil, llm.YandexSecrets(cfg))
+}
+
+func TestParseResumeWithYandex_Fixture(t *testing.T) {
+	cfg, transport := yandexFixtureConfig(t)
+	client := llm.NewYandexClientWithHTTP(cfg, &http.Client{Transport: transport})
+
+	result, err := ParseResumeWithYandex(context.Background(), testResumePDF, cfg, DefaultPrompts(), client, zap.NewNop())
+	require.NoError(t, err)
+	require.Equal(t, LangRussian, result.Language)
+	require.Equal(t, DefaultPrompts().Version, result.PromptVersion)
+	require.Equal(t, "yandexgpt-lite", result.Model)
+	require.Contains(t, result.Text, "ivan@test.com")
+
+	dto, err := DecodeOutput(result.Output)
+	require.NoError(t, err)
+	require.Equal(t, "Иван Иванов", dto.FullName)
+	require.Equal(t, "ivan@test.com", dto.Email)
+	require.Contains(t, dto.Skills, "Go")
+}
@@ -0,0 +1,367 @@
+package parser
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// Встроенные шаблоны промптов. Каждая группа шаблонов версионируется своим файлом (см. promptFamilies)
+// и меняется при правке шаблонов группы, чтобы по результату можно было понять, каким промптом он получен.
+// Версия входит в ключ кеша LLM, поэтому правка одной группы не сбрасывает закешированные ответы других.
+//
+//go:embed prompts/*.tmpl prompts/*VERSION
+var embeddedPrompts embed.FS
+
+const (
+	resumeTemplate        = "resume"
+	systemTemplate        = "system"
+	vacancyTemplate       = "vacancy"
+	vacancySystemTemplate = "system_vacancy"
+	adviceTemplate        = "recommendations"
+	adviceSystemTemplate  = "system_recommendations"
+	letterTemplate        = "cover_letter"
+	letterSystemTemplate  = "system_cover_letter"
+)
+
+// promptFamily — группа шаблонов с общей версией
+type promptFamily struct {
+	versionFile string
+	kinds       []string
+}
+
+var promptFamilies = map[string]promptFamily{
+	resumeTemplate:  {versionFile: "VERSION", kinds: []string{resumeTemplate, systemTemplate}},
+	vacancyTemplate: {versionFile: "VACANCY_VERSION", kinds: []string{vacancyTemplate, vacancySystemTemplate}},
+	adviceTemplate:  {versionFile: "RECOMMENDATIONS_VERSION", kinds: []string{adviceTemplate, adviceSystemTemplate}},
+	letterTemplate:  {versionFile: "COVER_LETTER_VERSION", kinds: []string{letterTemplate, letterSystemTemplate}},
+}
+
+// languageNames — название языка в формах, нужных для инструкций промпта
+type languageNames struct {
+	ruIn string // "на английском языке"
+	ruTo string // "на английский язык"
+	en   string
+}
+
+var supportedLanguages = map[string]languageNames{
+	LangRussian: {ruIn: "на русском языке", ruTo: "на русский язык", en: "Russian"},
+	LangEnglish: {ruIn: "на английском языке", ruTo: "на английский язык", en: "English"},
+	LangKazakh:  {ruIn: "на казахском языке", ruTo: "на казахский язык", en: "Kazakh"},
+	LangUzbek:   {ruIn: "на узбекском языке", ruTo: "на узбекский язык", en: "Uzbek"},
+}
+
+// IsSupportedLanguage сообщает, есть ли для языка инструкции промпта
+func IsSupportedLanguage(lang string) bool {
+	_, ok := supportedLanguages[lang]
+	return ok
+}
+
+// ContentLanguage возвращает язык, на котором LLM должна вернуть значения полей:
+// язык перевода, если он задан и поддерживается, иначе язык документа
+func ContentLanguage(docLang, targetLang string) string {
+	if IsSupportedLanguage(targetLang) {
+		return targetLang
+	}
+	return docLang
+}
+
+// PromptData — данные, доступные в шаблонах промптов
+type PromptData struct {
+	Text                string
+	Language            string // язык документа
+	ContentLanguage     string // язык, на котором нужно вернуть значения
+	Translate           bool
+	LanguageIn          string // "на английском языке"
+	ContentLanguageTo   string // "на русский язык"
+	LanguageName        string // "English"
+	ContentLanguageName string // "Russian"
+	Vacancy             string // описание вакансии для рекомендаций по резюме
+	MissingSkills       string // навыки вакансии, не найденные в резюме, через запятую
+	MatchedSkills       string // навыки вакансии, найденные в резюме, через запятую
+	Tone                string // тон сопроводительного письма: formal, friendly, enthusiastic
+	Length              string // длина сопроводительного письма: short, medium, long
+}
+
+// PromptSet — набор шаблонов промптов одной версии.
+// Шаблон resume_<lang>.tmpl выбирается по языку документа, при его отсутствии берётся resume_ru.tmpl;
+// так же выбираются системное сообщение system_<lang>.tmpl, шаблоны вакансий vacancy_<lang>.tmpl
+// и system_vacancy_<lang>.tmpl, рекомендаций recommendations_<lang>.tmpl и system_recommendations_<lang>.tmpl,
+// сопроводительных писем cover_letter_<lang>.tmpl и system_cover_letter_<lang>.tmpl.
+type PromptSet struct {
+	Version                string // версия шаблонов разбора резюме
+	VacancyVersion         string
+	RecommendationsVersion string
+	CoverLetterVersion     string
+	templates              *template.Template
+}
+
+var (
+	defaultPrompts     *PromptSet
+	defaultPromptsOnce sync.Once
+)
+
+// DefaultPrompts возвращает встроенный набор шаблонов
+func DefaultPrompts() *PromptSet {
+	defaultPromptsOnce.Do(func() {
+		set, err := newPromptSet(embeddedPrompts, "prompts", nil)
+		if err != nil {
+			panic(fmt.Sprintf("встроенные шаблоны промптов повреждены: %v", err))
+		}
+		defaultPrompts = set
+	})
+	return defaultPrompts
+}
+
+// LoadPrompts загружает шаблоны из каталога dir поверх встроенных: файлы каталога
+// заменяют одноимённые встроенные, недостающие берутся из встроенного набора.
+// Версия группы шаблонов читается из её файла версии в каталоге, без него — строится из хеша
+// переопределённых файлов группы; версии непереопределённых групп не меняются.
+// Пустой dir возвращает встроенный набор.
+func LoadPrompts(dir string) (*PromptSet, error) {
+	if dir == "" {
+		return DefaultPrompts(), nil
+	}
+	override := os.DirFS(dir)
+	if _, err := fs.Stat(override, "."); err != nil {
+		return nil, fmt.Errorf("каталог шаблонов промптов недоступен: %w", err)
+	}
+	return newPromptSet(embeddedPrompts, "prompts", override)
+}
+
+func newPromptSet(base fs.FS, baseDir string, override fs.FS) (*PromptSet, error) {
+	files := make(map[string][]byte)
+	collect := func(fsys fs.FS, dir string) ([]string, error) {
+		names, err := fs.Glob(fsys, filepath.ToSlash(filepath.Join(dir, "*.tmpl")))
+		if err != nil {
+			return nil, err
+		}
+		var collected []string
+		for _, name := range names {
+			content, err := fs.ReadFile(fsys, name)
+			if err != nil {
+				return nil, err
+			}
+			files[filepath.Base(name)] = content
+			collected = append(collected, filepath.Base(name))
+		}
+		return collected, nil
+	}
+
+	if _, err := collect(base, baseDir); err != nil {
+		return nil, err
+	}
+	versions := make(map[string]string, len(promptFamilies))
+	for name, family := range promptFamilies {
+		version, err := readVersion(base, filepath.ToSlash(filepath.Join(baseDir, family.versionFile)))
+		if err != nil {
+			return nil, err
+		}
+		versions[name] = version
+	}
+
+	if override != nil {
+		overridden, err := collect(override, ".")
+		if err != nil {
+			return nil, err
+		}
+		for name, family := range promptFamilies {
+			customVersion, err := readVersion(override, family.versionFile)
+			switch {
+			case err == nil:
+				versions[name] = customVersion
+			case errors.Is(err, fs.ErrNotExist):
+				if changed := family.filter(overridden); len(changed) > 0 {
+					versions[name] = "custom-" + hashFiles(files, changed)
+				}
+			default:
+				return nil, err
+			}
+		}
+	}
+
+	if _, ok := files[resumeTemplate+"_"+LangRussian+".tmpl"]; !ok {
+		return nil, fmt.Errorf("нет шаблона %s_%s.tmpl", resumeTemplate, LangRussian)
+	}
+
+	root := template.New("prompts").Option("missingkey=error")
+	for name, content := range files {
+		if _, err := root.New(name).Parse(string(content)); err != nil {
+			return nil, fmt.Errorf("шаблон %s: %w", name, err)
+		}
+	}
+	return &PromptSet{
+		Version:                versions[resumeTemplate],
+		VacancyVersion:         versions[vacancyTemplate],
+		RecommendationsVersion: versions[adviceTemplate],
+		CoverLetterVersion:     versions[letterTemplate],
+		templates:              root,
+	}, nil
+}
+
+// filter оставляет файлы шаблонов группы: <kind>_<lang>.tmpl
+func (f promptFamily) filter(names []string) []string {
+	var result []string
+	for _, name := range names {
+		base := strings.TrimSuffix(name, ".tmpl")
+		if i := strings.LastIndex(base, "_"); i > 0 && slices.Contains(f.kinds, base[:i]) {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+func readVersion(fsys fs.FS, name string) (string, error) {
+	content, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return "", err
+	}
+	version := strings.TrimSpace(string(content))
+	if version == "" {
+		return "", fmt.Errorf("пустой файл версии %s", name)
+	}
+	return version, nil
+}
+
+// hashFiles — короткий хеш содержимого переопределённых шаблонов
+func hashFiles(files map[string][]byte, names []string) string {
+	sort.Strings(names)
+	h := sha256.New()
+	for _, name := range names {
+		h.Write([]byte(name))
+		h.Write(files[name])
+	}
+	return hex.EncodeToString(h.Sum(nil))[:8]
+}
+
+// Resume формирует промпт разбора резюме под язык документа.
+// Если contentLang отличается от языка документа, LLM просят перевести содержимое разделов.
+func (p *PromptSet) Resume(text, docLang, contentLang string) (string, error) {
+	if !IsSupportedLanguage(docLang) {
+		docLang = LangRussian
+	}
+	if !IsSupportedLanguage(contentLang) {
+		contentLang = docLang
+	}
+	doc, content := supportedLanguages[docLang], supportedLanguages[contentLang]
+	return p.execute(resumeTemplate, docLang, PromptData{
+		Text:                text,
+		Language:            docLang,
+		ContentLanguage:     contentLang,
+		Translate:           docLang != contentLang,
+		LanguageIn:          doc.ruIn,
+		ContentLanguageTo:   content.ruTo,
+		LanguageName:        doc.en,
+		ContentLanguageName: content.en,
+	})
+}
+
+// System возвращает системное сообщение для языка документа
+func (p *PromptSet) System(docLang string) (string, error) {
+	out, err := p.execute(systemTemplate, docLang, PromptData{Language: docLang})
+	return strings.TrimSpace(out), err
+}
+
+// Vacancy формирует промпт разбора текста вакансии под язык документа
+func (p *PromptSet) Vacancy(text, docLang string) (string, error) {
+	if !IsSupportedLanguage(docLang) {
+		docLang = LangRussian
+	}
+	names := supportedLanguages[docLang]
+	return p.execute(vacancyTemplate, docLang, PromptData{
+		Text:                text,
+		Language:            docLang,
+		ContentLanguage:     docLang,
+		LanguageIn:          names.ruIn,
+		ContentLanguageTo:   names.ruTo,
+		LanguageName:        names.en,
+		ContentLanguageName: names.en,
+	})
+}
+
+// VacancySystem возвращает системное сообщение для разбора вакансии
+func (p *PromptSet) VacancySystem(docLang string) (string, error) {
+	out, err := p.execute(vacancySystemTemplate, docLang, PromptData{Language: docLang})
+	return strings.TrimSpace(out), err
+}
+
+// Recommendations формирует промпт рекомендаций по доработке резюме под вакансию.
+// lang — язык, на котором нужно вернуть советы.
+func (p *PromptSet) Recommendations(resumeText, vacancyText string, missingSkills []string, lang string) (string, error) {
+	if !IsSupportedLanguage(lang) {
+		lang = LangRussian
+	}
+	names := supportedLanguages[lang]
+	return p.execute(adviceTemplate, lang, PromptData{
+		Text:                resumeText,
+		Language:            lang,
+		ContentLanguage:     lang,
+		LanguageIn:          names.ruIn,
+		ContentLanguageTo:   names.ruTo,
+		LanguageName:        names.en,
+		ContentLanguageName: names.en,
+		Vacancy:             vacancyText,
+		MissingSkills:       strings.Join(missingSkills, ", "),
+	})
+}
+
+// RecommendationsSystem возвращает системное сообщение для рекомендаций
+func (p *PromptSet) RecommendationsSystem(lang string) (string, error) {
+	out, err := p.execute(adviceSystemTemplate, lang, PromptData{Language: lang})
+	return strings.TrimSpace(out), err
+}
+
+// CoverLetter формирует промпт сопроводительного письма к вакансии.
+// lang — язык письма, tone и length — тон и длина из models.Tone* и models.Length*.
+func (p *PromptSet) CoverLetter(resumeText, vacancyText string, matchedSkills, missingSkills []string, tone, length, lang string) (string, error) {
+	if !IsSupportedLanguage(lang) {
+		lang = LangRussian
+	}
+	names := supportedLanguages[lang]
+	return p.execute(letterTemplate, lang, PromptData{
+		Text:                resumeText,
+		Language:            lang,
+		ContentLanguage:     lang,
+		LanguageIn:          names.ruIn,
+		ContentLanguageTo:   names.ruTo,
+		LanguageName:        names.en,
+		ContentLanguageName: names.en,
+		Vacancy:             vacancyText,
+		MissingSkills:       strings.Join(missingSkills, ", "),
+		MatchedSkills:       strings.Join(matchedSkills, ", "),
+		Tone:                tone,
+		Length:              length,
+	})
+}
+
+// CoverLetterSystem возвращает системное сообщение для сопроводительного письма
+func (p *PromptSet) CoverLetterSystem(lang string) (string, error) {
+	out, err := p.execute(letterSystemTemplate, lang, PromptData{Language: lang})
+	return strings.TrimSpace(out), err
+}
+
+func (p *PromptSet) execute(kind, lang string, data PromptData) (string, error) {
+	tmpl := p.templates.Lookup(kind + "_" + lang + ".tmpl")
+	if tmpl == nil {
+		tmpl = p.templates.Lookup(kind + "_" + LangRussian + ".tmpl")
+	}
+	if tmpl == nil {
+		return "", fmt.Errorf("нет шаблона %s для языка %s", kind, lang)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
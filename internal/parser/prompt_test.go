@@ -0,0 +1,147 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromptSet_Resume(t *testing.T) {
+	prompts := DefaultPrompts()
+	require.Equal(t, "v1", prompts.Version)
+	require.Equal(t, "v1", prompts.VacancyVersion)
+	require.Equal(t, "v1", prompts.RecommendationsVersion)
+	require.Equal(t, "v1", prompts.CoverLetterVersion)
+
+	ru, err := prompts.Resume("текст", LangRussian, "")
+	require.NoError(t, err)
+	require.Contains(t, ru, "Текст резюме:\nтекст")
+	require.Contains(t, ru, "Резюме написано на русском языке")
+
+	en, err := prompts.Resume("text", LangEnglish, "")
+	require.NoError(t, err)
+	require.Contains(t, en, "Resume text:\ntext")
+	require.Contains(t, en, "Return all text values in English")
+
+	kk, err := prompts.Resume("мәтін", LangKazakh, LangRussian)
+	require.NoError(t, err)
+	require.Contains(t, kk, "Резюме написано на казахском языке")
+	require.Contains(t, kk, "на русский язык")
+
+	// Неизвестный язык обрабатывается русским шаблоном
+	unknown, err := prompts.Resume("x", "", "")
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(unknown, "Ты — помощник"))
+	require.Equal(t, BuildPrompt("x"), unknown)
+
+	system, err := prompts.System(LangEnglish)
+	require.NoError(t, err)
+	require.Equal(t, "You are a resume parser. Return only JSON in the specified structure.", system)
+}
+
+func TestLoadPrompts_Override(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "resume_en.tmpl"), []byte("EN {{.Language}}: {{.Text}}"), 0o644))
+
+	prompts, err := LoadPrompts(dir)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(prompts.Version, "custom-"))
+
+	en, err := prompts.Resume("text", LangEnglish, "")
+	require.NoError(t, err)
+	require.Equal(t, "EN en: text", en)
+
+	// Непереопределённые шаблоны берутся из встроенного набора
+	ru, err := prompts.Resume("текст", LangRussian, "")
+	require.NoError(t, err)
+	require.Equal(t, BuildPrompt("текст"), ru)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "VERSION"), []byte("v2-experiment\n"), 0o644))
+	prompts, err = LoadPrompts(dir)
+	require.NoError(t, err)
+	require.Equal(t, "v2-experiment", prompts.Version)
+}
+
+func TestLoadPrompts_FamilyVersions(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cover_letter_ru.tmpl"), []byte("Письмо: {{.Text}}"), 0o644))
+
+	// Правка шаблонов писем не меняет версию разбора резюме и вакансий, а значит и их ключи кеша
+	prompts, err := LoadPrompts(dir)
+	require.NoError(t, err)
+	require.Equal(t, DefaultPrompts().Version, prompts.Version)
+	require.Equal(t, DefaultPrompts().VacancyVersion, prompts.VacancyVersion)
+	require.Equal(t, DefaultPrompts().RecommendationsVersion, prompts.RecommendationsVersion)
+	require.True(t, strings.HasPrefix(prompts.CoverLetterVersion, "custom-"))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "COVER_LETTER_VERSION"), []byte("letters-v2\n"), 0o644))
+	prompts, err = LoadPrompts(dir)
+	require.NoError(t, err)
+	require.Equal(t, "letters-v2", prompts.CoverLetterVersion)
+	require.Equal(t, DefaultPrompts().Version, prompts.Version)
+}
+
+func TestLoadPrompts_Errors(t *testing.T) {
+	_, err := LoadPrompts(filepath.Join(t.TempDir(), "missing"))
+	require.Error(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "resume_ru.tmpl"), []byte("{{.Text"), 0o644))
+	_, err = LoadPrompts(dir)
+	require.Error(t, err)
+
+	prompts, err := LoadPrompts("")
+	require.NoError(t, err)
+	require.Same(t, DefaultPrompts(), prompts)
+}
+
+func TestContentLanguage(t *testing.T) {
+	require.Equal(t, LangEnglish, ContentLanguage(LangEnglish, ""))
+	require.Equal(t, LangRussian, ContentLanguage(LangEnglish, LangRussian))
+	require.Equal(t, LangKazakh, ContentLanguage(LangKazakh, "de"))
+}
+
+func TestPromptSet_Recommendations(t *testing.T) {
+	prompts := DefaultPrompts()
+
+	ru, err := prompts.Recommendations("резюме", "вакансия", []string{"Kafka", "gRPC"}, LangRussian)
+	require.NoError(t, err)
+	require.Contains(t, ru, "По сравнению навыков в резюме не найдены: Kafka, gRPC.")
+	require.Contains(t, ru, "Вакансия:\nвакансия")
+	require.Contains(t, ru, "Резюме:\nрезюме")
+
+	en, err := prompts.Recommendations("resume", "job", nil, LangEnglish)
+	require.NoError(t, err)
+	require.NotContains(t, en, "Skill comparison")
+	require.Contains(t, en, "Return all text values in English.")
+
+	system, err := prompts.RecommendationsSystem(LangKazakh)
+	require.NoError(t, err)
+	require.Equal(t, "Ты — карьерный консультант. Возвращай только JSON в указанной структуре.", system)
+}
+
+func TestPromptSet_CoverLetter(t *testing.T) {
+	prompts := DefaultPrompts()
+
+	ru, err := prompts.CoverLetter("резюме", "вакансия", []string{"Go"}, []string{"Kafka"}, "friendly", "short", LangRussian)
+	require.NoError(t, err)
+	require.Contains(t, ru, "тон дружелюбный")
+	require.Contains(t, ru, "до 120 слов")
+	require.Contains(t, ru, "подчеркни совпадающие с вакансией навыки: Go;")
+	require.Contains(t, ru, "не утверждай, что кандидат владеет навыками Kafka")
+	require.Contains(t, ru, "Пиши на русском языке.")
+
+	en, err := prompts.CoverLetter("resume", "job", nil, nil, "", "", LangEnglish)
+	require.NoError(t, err)
+	require.Contains(t, en, "formal, restrained business tone")
+	require.Contains(t, en, "up to 220 words")
+	require.NotContains(t, en, "highlight the skills")
+	require.Contains(t, en, "Write in English.")
+
+	system, err := prompts.CoverLetterSystem(LangUzbek)
+	require.NoError(t, err)
+	require.Equal(t, "Ты — карьерный консультант. Возвращай только текст сопроводительного письма.", system)
+}
@@ -0,0 +1,42 @@
+package parser
+
+import (
+	"CVMatch/internal/config"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ModelRecorded — ответ взят из записанных ранее ответов LLM
+const ModelRecorded = "recorded"
+
+// RecordedResumeParser возвращает записанный ранее ответ LLM вместо обращения к провайдеру:
+// для документа cv.pdf ответ читается из файла Dir/cv.json. Нужен, чтобы гонять оценку
+// качества разбора и тесты без сети и без ключей.
+type RecordedResumeParser struct {
+	Dir           string
+	PromptVersion string // версия промпта, которым были получены ответы
+}
+
+func (p RecordedResumeParser) ParseResume(_ context.Context, path string, _ *config.Config) (*ParseResult, error) {
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	output, err := os.ReadFile(filepath.Join(p.Dir, name+".json"))
+	if err != nil {
+		return nil, err
+	}
+	result := &ParseResult{
+		PromptVersion: p.PromptVersion,
+		Model:         ModelRecorded,
+		Output:        string(output),
+	}
+	// Текст нужен только для метаданных, документ без текстового слоя не мешает прогону
+	if extracted, err := ExtractPDF(path); err == nil {
+		result.Text = extracted.Text
+		result.Pages = extracted.Pages
+		result.Language = extracted.Language
+		result.ExtractionMethod = extracted.Method
+		result.ContentLanguage = extracted.Language
+	}
+	return result, nil
+}
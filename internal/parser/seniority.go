@@ -0,0 +1,189 @@
+package parser
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Семейства должностей
+const (
+	FamilyBackend   = "backend"
+	FamilyFrontend  = "frontend"
+	FamilyFullstack = "fullstack"
+	FamilyMobile    = "mobile"
+	FamilyQA        = "qa"
+	FamilyDevOps    = "devops"
+	FamilyData      = "data"
+	FamilyPM        = "pm"
+	FamilyDesign    = "design"
+	FamilyAnalyst   = "analyst"
+	FamilyOther     = "other"
+)
+
+// Уровни квалификации
+const (
+	SeniorityIntern = "intern"
+	SeniorityJunior = "junior"
+	SeniorityMiddle = "middle"
+	SenioritySenior = "senior"
+	SeniorityLead   = "lead"
+)
+
+// Порядок проверки важен: более специфичные семейства идут первыми,
+// чтобы "Data Engineer (Python)" попал в data, а "QA Automation (Java)" — в qa, а не в backend.
+var titleFamilies = []struct {
+	family   string
+	keywords []string
+}{
+	{FamilyQA, []string{"qa", "qc", "sdet", "test", "тестировщ", "тестирован"}},
+	{FamilyDevOps, []string{"devops", "sre", "site reliability", "infrastructure", "инфраструктур", "системный администратор", "sysadmin", "platform engineer"}},
+	{FamilyData, []string{"data", "ml", "machine learning", "ai engineer", "данных", "дата", "аналитик данных", "bi", "etl", "nlp", "computer vision"}},
+	{FamilyPM, []string{"product manager", "project manager", "product owner", "продакт", "проджект", "менеджер проект", "менеджер продукт", "руководитель проект", "scrum master", "delivery manager"}},
+	{FamilyDesign, []string{"design", "дизайн", "ux", "ui/ux"}},
+	{FamilyAnalyst, []string{"analyst", "аналитик"}},
+	{FamilyMobile, []string{"ios", "android", "mobile", "мобильн", "flutter", "react native", "kotlin", "swift"}},
+	{FamilyFullstack, []string{"fullstack", "full-stack", "full stack", "фулстек", "фуллстек"}},
+	{FamilyFrontend, []string{"frontend", "front-end", "front end", "фронтенд", "фронт", "react", "vue", "angular", "верстальщик", "javascript", "typescript"}},
+	{FamilyBackend, []string{"backend", "back-end", "back end", "бэкенд", "бекенд", "серверн", "golang", "go developer", "go-разработчик", "java", "python", "php", "c#", ".net", "node", "ruby", "rust", "c++", "scala", "erlang", "elixir"}},
+}
+
+var seniorityKeywords = []struct {
+	level    string
+	keywords []string
+}{
+	{SeniorityIntern, []string{"intern", "trainee", "стажер", "стажёр", "практикант"}},
+	{SeniorityLead, []string{"lead", "head", "principal", "architect", "chief", "cto", "тимлид", "техлид", "лид", "руководитель", "архитектор", "начальник"}},
+	{SenioritySenior, []string{"senior", "sr", "старший", "ведущий"}},
+	{SeniorityMiddle, []string{"middle", "mid", "regular"}},
+	{SeniorityJunior, []string{"junior", "jr", "младший", "начинающий"}},
+}
+
+var seniorityRanks = map[string]int{
+	SeniorityIntern: 0,
+	SeniorityJunior: 1,
+	SeniorityMiddle: 2,
+	SenioritySenior: 3,
+	SeniorityLead:   4,
+}
+
+// NormalizeTitleFamily определяет семейство должности по её названию.
+// Нераспознанная непустая должность относится к "other", пустая — к "".
+func NormalizeTitleFamily(position string) string {
+	title := " " + strings.ToLower(strings.TrimSpace(position)) + " "
+	if strings.TrimSpace(title) == "" {
+		return ""
+	}
+	for _, tf := range titleFamilies {
+		for _, kw := range tf.keywords {
+			if containsWord(title, kw) {
+				return tf.family
+			}
+		}
+	}
+	return FamilyOther
+}
+
+// SeniorityFromTitle ищет уровень в названии должности ("Senior Go Developer", "Ведущий разработчик")
+func SeniorityFromTitle(position string) string {
+	title := strings.ToLower(position)
+	for _, sk := range seniorityKeywords {
+		for _, kw := range sk.keywords {
+			if containsWord(title, kw) {
+				return sk.level
+			}
+		}
+	}
+	return ""
+}
+
+// SeniorityFromTenure оценивает уровень по суммарному стажу в месяцах
+func SeniorityFromTenure(months int) string {
+	switch {
+	case months <= 0:
+		return ""
+	case months < 24:
+		return SeniorityJunior
+	case months < 60:
+		return SeniorityMiddle
+	default:
+		return SenioritySenior
+	}
+}
+
+// InferSeniority определяет уровень: явное указание в должности важнее стажа
+func InferSeniority(position string, months int) string {
+	if level := SeniorityFromTitle(position); level != "" {
+		return level
+	}
+	return SeniorityFromTenure(months)
+}
+
+// SeniorityRank возвращает порядковый номер уровня, -1 для неизвестного
+func SeniorityRank(level string) int {
+	if rank, ok := seniorityRanks[level]; ok {
+		return rank
+	}
+	return -1
+}
+
+// IsValidSeniority проверяет, что уровень входит в известный список
+func IsValidSeniority(level string) bool {
+	return SeniorityRank(level) >= 0
+}
+
+// IsValidTitleFamily проверяет, что семейство должности входит в известный список
+func IsValidTitleFamily(family string) bool {
+	if family == FamilyOther {
+		return true
+	}
+	for _, tf := range titleFamilies {
+		if tf.family == family {
+			return true
+		}
+	}
+	return false
+}
+
+// containsWord ищет ключевое слово так, чтобы "go" не совпадало с "google",
+// а "лид" — с "валидация". Длинные ключи ("тестировщ", "design") совпадают по префиксу слова.
+func containsWord(text, keyword string) bool {
+	return countWord(text, keyword) > 0
+}
+
+// countWord считает вхождения ключевого слова по тем же правилам, что и containsWord
+func countWord(text, keyword string) int {
+	if keyword == "" {
+		return 0
+	}
+	count := 0
+	for start := 0; start < len(text); {
+		idx := strings.Index(text[start:], keyword)
+		if idx < 0 {
+			break
+		}
+		idx += start
+		end := idx + len(keyword)
+		if isBoundary(text, idx-1, true) && (isBoundary(text, end, false) || len([]rune(keyword)) >= 5) {
+			count++
+			start = end
+			continue
+		}
+		start = idx + 1
+	}
+	return count
+}
+
+// isBoundary проверяет символ перед (before) или после ключевого слова
+func isBoundary(text string, pos int, before bool) bool {
+	if pos < 0 || pos >= len(text) {
+		return true
+	}
+	var r rune
+	if before {
+		r, _ = utf8.DecodeLastRuneInString(text[:pos+1])
+	} else {
+		r, _ = utf8.DecodeRuneInString(text[pos:])
+	}
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '#' && r != '+'
+}
@@ -0,0 +1,38 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeTitleFamily(t *testing.T) {
+	cases := map[string]string{
+		"Senior Go Developer":          FamilyBackend,
+		"Backend-разработчик":          FamilyBackend,
+		"Frontend Developer (React)":   FamilyFrontend,
+		"QA Automation Engineer, Java": FamilyQA,
+		"Тестировщик ПО":               FamilyQA,
+		"Data Engineer (Python)":       FamilyData,
+		"Product Manager":              FamilyPM,
+		"iOS разработчик":              FamilyMobile,
+		"UX/UI Designer":               FamilyDesign,
+		"Google Ads специалист":        FamilyOther,
+		"":                             "",
+	}
+	for title, family := range cases {
+		require.Equal(t, family, NormalizeTitleFamily(title), title)
+	}
+}
+
+func TestInferSeniority(t *testing.T) {
+	require.Equal(t, SenioritySenior, InferSeniority("Senior Go Developer", 10))
+	require.Equal(t, SeniorityLead, InferSeniority("Team Lead", 10))
+	require.Equal(t, SeniorityLead, InferSeniority("Тимлид backend", 10))
+	require.Equal(t, SenioritySenior, InferSeniority("Ведущий разработчик", 10))
+	require.Equal(t, SeniorityIntern, InferSeniority("Стажёр-разработчик", 60))
+	require.Equal(t, SeniorityJunior, InferSeniority("Go Developer", 12))
+	require.Equal(t, SeniorityMiddle, InferSeniority("Go Developer", 36))
+	require.Equal(t, SenioritySenior, InferSeniority("Go Developer", 72))
+	require.Equal(t, "", InferSeniority("Специалист по валидации", 0))
+}
@@ -0,0 +1,137 @@
+package parser
+
+import (
+	"CVMatch/internal/config"
+	"CVMatch/internal/llm"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// Тип занятости в вакансии
+const (
+	EmploymentFullTime   = "full_time"
+	EmploymentPartTime   = "part_time"
+	EmploymentContract   = "contract"
+	EmploymentInternship = "internship"
+	EmploymentProject    = "project"
+)
+
+// ExtractionMethodPlainText — документ и так был текстом
+const ExtractionMethodPlainText = "plain_text"
+
+// ErrUnsupportedDocument — формат файла не поддерживается извлечением текста
+var ErrUnsupportedDocument = errors.New("unsupported document format")
+
+type VacancyParserI interface {
+	ParseVacancy(ctx context.Context, text string, cfg *config.Config) (*ParseResult, error)
+}
+
+// YandexVacancyParser разбирает текст вакансии через YandexGPT; Prompts и Client
+// подставляются так же, как в YandexResumeParser
+type YandexVacancyParser struct {
+	Prompts *PromptSet
+	Client  llm.Client
+}
+
+func (p YandexVacancyParser) ParseVacancy(ctx context.Context, text string, cfg *config.Config) (*ParseResult, error) {
+	prompts := p.Prompts
+	if prompts == nil {
+		prompts = DefaultPrompts()
+	}
+	client := p.Client
+	if client == nil {
+		client = llm.NewYandexClient(cfg)
+	}
+
+	language := DetectLanguage(text)
+	prompt, err := prompts.Vacancy(text, language)
+	if err != nil {
+		return nil, err
+	}
+	systemPrompt, err := prompts.VacancySystem(language)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Complete(ctx, llm.Request{
+		Model:         cfg.YandexGPTModel,
+		PromptVersion: prompts.VacancyVersion,
+		System:        systemPrompt,
+		Prompt:        prompt,
+		Temperature:   0.3,
+		MaxTokens:     2000,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ParseResult{
+		Text:             text,
+		Language:         language,
+		ExtractionMethod: ExtractionMethodPlainText,
+		ContentLanguage:  language,
+		PromptVersion:    prompts.VacancyVersion,
+		Model:            cfg.YandexGPTModel,
+		Cached:           resp.Cached,
+		Output:           resp.Text,
+	}, nil
+}
+
+// ExtractDocument извлекает текст из PDF или текстового файла
+func ExtractDocument(path string) (*ExtractedText, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".pdf":
+		return ExtractPDF(path)
+	case ".txt", ".md":
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if !utf8.Valid(content) {
+			return nil, ErrUnsupportedDocument
+		}
+		text := strings.ReplaceAll(string(content), "\x00", "")
+		return &ExtractedText{
+			Text:     text,
+			Pages:    1,
+			Method:   ExtractionMethodPlainText,
+			Language: DetectLanguage(text),
+		}, nil
+	default:
+		return nil, ErrUnsupportedDocument
+	}
+}
+
+// NormalizeEmploymentType приводит тип занятости к одному из Employment*; неизвестное значение даёт пустую строку
+func NormalizeEmploymentType(raw string) string {
+	s := strings.ToLower(strings.TrimSpace(raw))
+	s = strings.NewReplacer("-", "_", " ", "_").Replace(s)
+	switch {
+	case s == "":
+		return ""
+	case s == EmploymentFullTime || strings.Contains(s, "полная") || strings.Contains(s, "full"):
+		return EmploymentFullTime
+	case s == EmploymentPartTime || strings.Contains(s, "частичная") || strings.Contains(s, "part"):
+		return EmploymentPartTime
+	case s == EmploymentInternship || strings.Contains(s, "стажир") || strings.Contains(s, "intern"):
+		return EmploymentInternship
+	case s == EmploymentProject || strings.Contains(s, "проект") || strings.Contains(s, "project"):
+		return EmploymentProject
+	case s == EmploymentContract || strings.Contains(s, "договор") || strings.Contains(s, "контракт") ||
+		strings.Contains(s, "contract") || strings.Contains(s, "freelance"):
+		return EmploymentContract
+	default:
+		return ""
+	}
+}
+
+// IsValidEmploymentType сообщает, что значение — один из Employment*
+func IsValidEmploymentType(value string) bool {
+	switch value {
+	case EmploymentFullTime, EmploymentPartTime, EmploymentContract, EmploymentInternship, EmploymentProject:
+		return true
+	}
+	return false
+}
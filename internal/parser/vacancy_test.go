@@ -0,0 +1,66 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeEmploymentType(t *testing.T) {
+	cases := map[string]string{
+		"full_time":        EmploymentFullTime,
+		"Полная занятость": EmploymentFullTime,
+		"part-time":        EmploymentPartTime,
+		"Стажировка":       EmploymentInternship,
+		"Проектная работа": EmploymentProject,
+		"Договор ГПХ":      EmploymentContract,
+		"freelance":        EmploymentContract,
+		"вахта":            "",
+		"":                 "",
+	}
+	for raw, want := range cases {
+		require.Equal(t, want, NormalizeEmploymentType(raw), raw)
+	}
+}
+
+func TestDecodeVacancyOutput(t *testing.T) {
+	draft, err := DecodeVacancyOutput("```json\n{\"title\":\"Go Developer\",\"salary\":{\"from\":\"200 000\",\"to\":250000,\"currency\":\"RUB\"}}\n```")
+	require.NoError(t, err)
+	require.Equal(t, "Go Developer", draft.Title)
+	require.Equal(t, 200000, *draft.Salary.From)
+	require.Equal(t, 250000, *draft.Salary.To)
+
+	_, err = DecodeVacancyOutput("not json")
+	require.Error(t, err)
+}
+
+func TestPromptSet_Vacancy(t *testing.T) {
+	ru, err := DefaultPrompts().Vacancy("Ищем Go-разработчика", LangRussian)
+	require.NoError(t, err)
+	require.Contains(t, ru, "required_skills")
+	require.Contains(t, ru, "Текст вакансии:\nИщем Go-разработчика")
+
+	system, err := DefaultPrompts().VacancySystem(LangEnglish)
+	require.NoError(t, err)
+	require.Equal(t, "You are a job posting parser. Return only JSON in the specified structure.", system)
+}
+
+func TestExtractDocument(t *testing.T) {
+	dir := t.TempDir()
+	txt := filepath.Join(dir, "vacancy.txt")
+	require.NoError(t, os.WriteFile(txt, []byte("We are hiring a Go developer"), 0o644))
+
+	extracted, err := ExtractDocument(txt)
+	require.NoError(t, err)
+	require.Equal(t, LangEnglish, extracted.Language)
+	require.Equal(t, ExtractionMethodPlainText, extracted.Method)
+
+	extracted, err = ExtractDocument(testResumePDF)
+	require.NoError(t, err)
+	require.Equal(t, ExtractionMethodPDFText, extracted.Method)
+
+	_, err = ExtractDocument(filepath.Join(dir, "vacancy.docx"))
+	require.ErrorIs(t, err, ErrUnsupportedDocument)
+}
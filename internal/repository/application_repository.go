@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"CVMatch/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ApplicationRepository struct {
+	db *gorm.DB
+}
+
+type ApplicationRepositoryI interface {
+	DB() *gorm.DB
+	WithTx(tx *gorm.DB) ApplicationRepositoryI
+	Create(application *models.Application) error
+	GetByID(userID, applicationID uuid.UUID) (*models.Application, error)
+	GetByPair(resumeID, vacancyID uuid.UUID) (*models.Application, error)
+	List(userID uuid.UUID, filter ApplicationFilter) ([]models.Application, error)
+	UpdateStage(applicationID uuid.UUID, stage string) error
+	CreateTransition(transition *models.ApplicationTransition) error
+	ListTransitions(applicationID uuid.UUID) ([]models.ApplicationTransition, error)
+	CountByStage(vacancyID uuid.UUID) (map[string]int64, error)
+	Delete(applicationID uuid.UUID) error
+}
+
+// ApplicationFilter — параметры фильтрации откликов, nil и пустая строка означают отсутствие фильтра
+type ApplicationFilter struct {
+	VacancyID *uuid.UUID
+	ResumeID  *uuid.UUID
+	Stage     string
+}
+
+func NewApplicationRepository(db *gorm.DB) *ApplicationRepository {
+	return &ApplicationRepository{
+		db: db,
+	}
+}
+
+func (r *ApplicationRepository) DB() *gorm.DB {
+	return r.db
+}
+
+func (r *ApplicationRepository) WithTx(tx *gorm.DB) ApplicationRepositoryI {
+	return &ApplicationRepository{db: tx}
+}
+
+func (r *ApplicationRepository) Create(application *models.Application) error {
+	return r.db.Create(application).Error
+}
+
+// active оставляет отклики, резюме и вакансия которых не удалены
+func (r *ApplicationRepository) active() *gorm.DB {
+	return r.db.Model(&models.Application{}).Select("applications.*").
+		Joins("join resumes on resumes.id = applications.resume_id AND resumes.deleted_at IS NULL").
+		Joins("join vacancies on vacancies.id = applications.vacancy_id AND vacancies.deleted_at IS NULL")
+}
+
+// GetByID возвращает отклик, если резюме лежит в текущем рабочем пространстве пользователя
+func (r *ApplicationRepository) GetByID(userID, applicationID uuid.UUID) (*models.Application, error) {
+	var application models.Application
+	if err := r.active().Where("applications.id = ? AND resumes.organization_id IN (?)", applicationID, workspaceOf(r.db, userID)).
+		First(&application).Error; err != nil {
+		return nil, err
+	}
+	return &application, nil
+}
+
+// GetByPair возвращает отклик резюме на вакансию или gorm.ErrRecordNotFound
+func (r *ApplicationRepository) GetByPair(resumeID, vacancyID uuid.UUID) (*models.Application, error) {
+	var application models.Application
+	if err := r.db.Where("resume_id = ? AND vacancy_id = ?", resumeID, vacancyID).First(&application).Error; err != nil {
+		return nil, err
+	}
+	return &application, nil
+}
+
+func (r *ApplicationRepository) List(userID uuid.UUID, filter ApplicationFilter) ([]models.Application, error) {
+	var applications []models.Application
+	query := r.active().Where("resumes.organization_id IN (?)", workspaceOf(r.db, userID))
+	if filter.VacancyID != nil {
+		query = query.Where("applications.vacancy_id = ?", *filter.VacancyID)
+	}
+	if filter.ResumeID != nil {
+		query = query.Where("applications.resume_id = ?", *filter.ResumeID)
+	}
+	if filter.Stage != "" {
+		query = query.Where("applications.stage = ?", filter.Stage)
+	}
+	if err := query.Order("applications.updated_at DESC").Find(&applications).Error; err != nil {
+		return nil, err
+	}
+	return applications, nil
+}
+
+func (r *ApplicationRepository) UpdateStage(applicationID uuid.UUID, stage string) error {
+	return r.db.Model(&models.Application{ID: applicationID}).Update("stage", stage).Error
+}
+
+func (r *ApplicationRepository) CreateTransition(transition *models.ApplicationTransition) error {
+	return r.db.Create(transition).Error
+}
+
+// ListTransitions возвращает историю переходов отклика от первого к последнему
+func (r *ApplicationRepository) ListTransitions(applicationID uuid.UUID) ([]models.ApplicationTransition, error) {
+	var transitions []models.ApplicationTransition
+	if err := r.db.Where("application_id = ?", applicationID).Order("created_at, id").Find(&transitions).Error; err != nil {
+		return nil, err
+	}
+	return transitions, nil
+}
+
+// CountByStage считает отклики на вакансию по этапам; этапы без откликов в результат не попадают
+func (r *ApplicationRepository) CountByStage(vacancyID uuid.UUID) (map[string]int64, error) {
+	var rows []struct {
+		Stage string
+		Count int64
+	}
+	if err := r.active().Select("applications.stage AS stage, COUNT(*) AS count").
+		Where("applications.vacancy_id = ?", vacancyID).
+		Group("applications.stage").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Stage] = row.Count
+	}
+	return counts, nil
+}
+
+// Delete удаляет отклик вместе с историей переходов
+func (r *ApplicationRepository) Delete(applicationID uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.ApplicationTransition{}, "application_id = ?", applicationID).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Application{}, "id = ?", applicationID).Error
+	})
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"CVMatch/internal/models"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestApplicationRepository_ListCountAndDelete(t *testing.T) {
+	db, _ := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, db.AutoMigrate(&models.User{}, &models.Resume{}, &models.Vacancy{}, &models.Application{}, &models.ApplicationTransition{}))
+	repo := NewApplicationRepository(db)
+
+	userID, organizationID := newWorkspaceUser(t, db)
+	first := &models.Resume{OrganizationID: organizationID, UserID: userID, FullName: "Иван"}
+	second := &models.Resume{OrganizationID: organizationID, UserID: userID, FullName: "Пётр"}
+	require.NoError(t, db.Create(first).Error)
+	require.NoError(t, db.Create(second).Error)
+	vacancy := &models.Vacancy{OrganizationID: organizationID, UserID: userID, Title: "Go Developer"}
+	require.NoError(t, db.Create(vacancy).Error)
+
+	screening := &models.Application{UserID: userID, ResumeID: first.ID, VacancyID: vacancy.ID, Stage: models.StageScreening}
+	fresh := &models.Application{UserID: userID, ResumeID: second.ID, VacancyID: vacancy.ID, Stage: models.StageNew}
+	require.NoError(t, repo.Create(screening))
+	require.NoError(t, repo.Create(fresh))
+	require.NoError(t, repo.CreateTransition(&models.ApplicationTransition{ApplicationID: screening.ID, UserID: userID, ToStage: models.StageNew}))
+	require.NoError(t, repo.CreateTransition(&models.ApplicationTransition{ApplicationID: screening.ID, UserID: userID, FromStage: models.StageNew, ToStage: models.StageScreening, Reason: "Подходит по стеку"}))
+
+	got, err := repo.GetByPair(first.ID, vacancy.ID)
+	require.NoError(t, err)
+	require.Equal(t, screening.ID, got.ID)
+	_, err = repo.GetByPair(first.ID, uuid.New())
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	list, err := repo.List(userID, ApplicationFilter{VacancyID: &vacancy.ID, Stage: models.StageScreening})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, screening.ID, list[0].ID)
+
+	list, err = repo.List(userID, ApplicationFilter{ResumeID: &second.ID})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, fresh.ID, list[0].ID)
+
+	counts, err := repo.CountByStage(vacancy.ID)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int64{models.StageNew: 1, models.StageScreening: 1}, counts)
+
+	transitions, err := repo.ListTransitions(screening.ID)
+	require.NoError(t, err)
+	require.Len(t, transitions, 2)
+	require.Equal(t, models.StageScreening, transitions[1].ToStage)
+
+	// Отклики удалённых резюме не показываются
+	require.NoError(t, db.Delete(second).Error)
+	_, err = repo.GetByID(userID, fresh.ID)
+	require.Error(t, err)
+	list, err = repo.List(userID, ApplicationFilter{})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+
+	_, err = repo.GetByID(uuid.New(), screening.ID)
+	require.Error(t, err)
+
+	require.NoError(t, repo.Delete(screening.ID))
+	_, err = repo.GetByID(userID, screening.ID)
+	require.Error(t, err)
+	transitions, err = repo.ListTransitions(screening.ID)
+	require.NoError(t, err)
+	require.Empty(t, transitions)
+}
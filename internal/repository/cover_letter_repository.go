@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"CVMatch/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CoverLetterRepository struct {
+	db *gorm.DB
+}
+
+type CoverLetterRepositoryI interface {
+	Create(letter *models.CoverLetter) error
+	ListByMatch(userID, matchID uuid.UUID) ([]models.CoverLetter, error)
+	GetByID(userID, matchID, letterID uuid.UUID) (*models.CoverLetter, error)
+}
+
+func NewCoverLetterRepository(db *gorm.DB) *CoverLetterRepository {
+	return &CoverLetterRepository{
+		db: db,
+	}
+}
+
+func (r *CoverLetterRepository) Create(letter *models.CoverLetter) error {
+	return r.db.Create(letter).Error
+}
+
+// ListByMatch возвращает историю писем по результату сравнения, новые первыми
+func (r *CoverLetterRepository) ListByMatch(userID, matchID uuid.UUID) ([]models.CoverLetter, error) {
+	var letters []models.CoverLetter
+	if err := r.db.Where("user_id = ? AND match_id = ?", userID, matchID).
+		Order("created_at DESC").
+		Find(&letters).Error; err != nil {
+		return nil, err
+	}
+	return letters, nil
+}
+
+func (r *CoverLetterRepository) GetByID(userID, matchID, letterID uuid.UUID) (*models.CoverLetter, error) {
+	var letter models.CoverLetter
+	if err := r.db.Where("id = ? AND user_id = ? AND match_id = ?", letterID, userID, matchID).
+		First(&letter).Error; err != nil {
+		return nil, err
+	}
+	return &letter, nil
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"CVMatch/internal/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestCoverLetterRepository_History(t *testing.T) {
+	db, _ := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, db.AutoMigrate(&models.CoverLetter{}))
+	repo := NewCoverLetterRepository(db)
+
+	userID, matchID := uuid.New(), uuid.New()
+	first := &models.CoverLetter{UserID: userID, MatchID: matchID, Tone: models.ToneFormal, Content: "Первое", CreatedAt: time.Now().Add(-time.Hour)}
+	second := &models.CoverLetter{UserID: userID, MatchID: matchID, Tone: models.ToneFriendly, Content: "Второе"}
+	other := &models.CoverLetter{UserID: uuid.New(), MatchID: matchID, Content: "Чужое"}
+	for _, letter := range []*models.CoverLetter{first, second, other} {
+		require.NoError(t, repo.Create(letter))
+	}
+
+	letters, err := repo.ListByMatch(userID, matchID)
+	require.NoError(t, err)
+	require.Len(t, letters, 2)
+	require.Equal(t, "Второе", letters[0].Content)
+	require.Equal(t, "Первое", letters[1].Content)
+
+	got, err := repo.GetByID(userID, matchID, first.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.ToneFormal, got.Tone)
+
+	_, err = repo.GetByID(userID, matchID, other.ID)
+	require.Error(t, err)
+	_, err = repo.GetByID(userID, uuid.New(), first.ID)
+	require.Error(t, err)
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"CVMatch/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type InterviewRepository struct {
+	db *gorm.DB
+}
+
+type InterviewRepositoryI interface {
+	DB() *gorm.DB
+	WithTx(tx *gorm.DB) InterviewRepositoryI
+	Create(interview *models.Interview) error
+	Update(interview *models.Interview) error
+	ReplaceParticipants(interviewID uuid.UUID, participants []models.InterviewParticipant) error
+	GetByID(userID, interviewID uuid.UUID) (*models.Interview, error)
+	List(userID uuid.UUID, filter InterviewFilter) ([]models.Interview, error)
+	SaveFeedback(feedback *models.InterviewFeedback) error
+	ListFeedback(interviewID uuid.UUID) ([]models.InterviewFeedback, error)
+}
+
+// InterviewFilter — параметры фильтрации интервью, nil и пустая строка означают отсутствие фильтра
+type InterviewFilter struct {
+	ApplicationID *uuid.UUID
+	From          *time.Time // интервью, которые заканчиваются не раньше
+	To            *time.Time // интервью, которые начинаются раньше
+	Status        string
+}
+
+func NewInterviewRepository(db *gorm.DB) *InterviewRepository {
+	return &InterviewRepository{
+		db: db,
+	}
+}
+
+func (r *InterviewRepository) DB() *gorm.DB {
+	return r.db
+}
+
+func (r *InterviewRepository) WithTx(tx *gorm.DB) InterviewRepositoryI {
+	return &InterviewRepository{db: tx}
+}
+
+// Create сохраняет интервью вместе с участниками
+func (r *InterviewRepository) Create(interview *models.Interview) error {
+	return r.db.Create(interview).Error
+}
+
+func (r *InterviewRepository) Update(interview *models.Interview) error {
+	return r.db.Omit(clause.Associations).Save(interview).Error
+}
+
+func (r *InterviewRepository) ReplaceParticipants(interviewID uuid.UUID, participants []models.InterviewParticipant) error {
+	if err := r.db.Delete(&models.InterviewParticipant{}, "interview_id = ?", interviewID).Error; err != nil {
+		return err
+	}
+	if len(participants) == 0 {
+		return nil
+	}
+	for i := range participants {
+		participants[i].InterviewID = interviewID
+	}
+	return r.db.Create(&participants).Error
+}
+
+// visible оставляет интервью, которые пользователь назначил или в которых участвует,
+// если отклик, резюме и вакансия ещё существуют
+func (r *InterviewRepository) visible(userID uuid.UUID) *gorm.DB {
+	return r.db.Model(&models.Interview{}).Select("interviews.*").Preload("Participants").
+		Joins("join applications on applications.id = interviews.application_id").
+		Joins("join resumes on resumes.id = applications.resume_id AND resumes.deleted_at IS NULL").
+		Joins("join vacancies on vacancies.id = applications.vacancy_id AND vacancies.deleted_at IS NULL").
+		Where("interviews.user_id = ? OR interviews.id IN (?)", userID,
+			r.db.Model(&models.InterviewParticipant{}).Select("interview_id").Where("user_id = ?", userID))
+}
+
+func (r *InterviewRepository) GetByID(userID, interviewID uuid.UUID) (*models.Interview, error) {
+	var interview models.Interview
+	if err := r.visible(userID).Where("interviews.id = ?", interviewID).First(&interview).Error; err != nil {
+		return nil, err
+	}
+	return &interview, nil
+}
+
+// List возвращает интервью по времени начала
+func (r *InterviewRepository) List(userID uuid.UUID, filter InterviewFilter) ([]models.Interview, error) {
+	var interviews []models.Interview
+	query := r.visible(userID)
+	if filter.ApplicationID != nil {
+		query = query.Where("interviews.application_id = ?", *filter.ApplicationID)
+	}
+	if filter.From != nil {
+		query = query.Where("interviews.ends_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("interviews.starts_at < ?", *filter.To)
+	}
+	if filter.Status != "" {
+		query = query.Where("interviews.status = ?", filter.Status)
+	}
+	if err := query.Order("interviews.starts_at").Find(&interviews).Error; err != nil {
+		return nil, err
+	}
+	return interviews, nil
+}
+
+// SaveFeedback сохраняет отзыв; отзыв того же автора на то же интервью перезаписывается
+func (r *InterviewRepository) SaveFeedback(feedback *models.InterviewFeedback) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "interview_id"}, {Name: "author_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"recommendation", "scores", "comment", "updated_at"}),
+	}).Create(feedback).Error
+}
+
+func (r *InterviewRepository) ListFeedback(interviewID uuid.UUID) ([]models.InterviewFeedback, error) {
+	var feedback []models.InterviewFeedback
+	if err := r.db.Where("interview_id = ?", interviewID).Order("created_at, id").Find(&feedback).Error; err != nil {
+		return nil, err
+	}
+	return feedback, nil
+}
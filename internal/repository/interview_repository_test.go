@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"CVMatch/internal/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestInterviewRepository_VisibilityAndFeedback(t *testing.T) {
+	db, _ := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, db.AutoMigrate(&models.User{}, &models.Resume{}, &models.Vacancy{}, &models.Application{},
+		&models.Interview{}, &models.InterviewParticipant{}, &models.InterviewFeedback{}))
+	repo := NewInterviewRepository(db)
+
+	organizerID, interviewerID := uuid.New(), uuid.New()
+	resume := &models.Resume{UserID: organizerID, FullName: "Иван"}
+	require.NoError(t, db.Create(resume).Error)
+	vacancy := &models.Vacancy{UserID: organizerID, Title: "Go Developer"}
+	require.NoError(t, db.Create(vacancy).Error)
+	application := &models.Application{UserID: organizerID, ResumeID: resume.ID, VacancyID: vacancy.ID, Stage: models.StageInterview}
+	require.NoError(t, db.Create(application).Error)
+
+	start := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	tech := &models.Interview{
+		UserID: organizerID, ApplicationID: application.ID, Title: "Техническое", Status: models.InterviewScheduled,
+		StartsAt: start, EndsAt: start.Add(time.Hour),
+		Participants: []models.InterviewParticipant{{UserID: &interviewerID, Email: "anna@example.com"}, {Email: "guest@example.com"}},
+	}
+	final := &models.Interview{
+		UserID: organizerID, ApplicationID: application.ID, Title: "Финальное", Status: models.InterviewScheduled,
+		StartsAt: start.Add(48 * time.Hour), EndsAt: start.Add(49 * time.Hour),
+	}
+	require.NoError(t, repo.Create(tech))
+	require.NoError(t, repo.Create(final))
+
+	got, err := repo.GetByID(interviewerID, tech.ID)
+	require.NoError(t, err)
+	require.Len(t, got.Participants, 2)
+	// Участник видит только интервью, в которые приглашён
+	_, err = repo.GetByID(interviewerID, final.ID)
+	require.Error(t, err)
+	_, err = repo.GetByID(uuid.New(), tech.ID)
+	require.Error(t, err)
+
+	list, err := repo.List(organizerID, InterviewFilter{ApplicationID: &application.ID})
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.Equal(t, tech.ID, list[0].ID)
+	from := start.Add(24 * time.Hour)
+	list, err = repo.List(organizerID, InterviewFilter{From: &from})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, final.ID, list[0].ID)
+
+	final.Status = models.InterviewCancelled
+	final.Sequence++
+	require.NoError(t, repo.Update(final))
+	list, err = repo.List(organizerID, InterviewFilter{Status: models.InterviewCancelled})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, 1, list[0].Sequence)
+
+	require.NoError(t, repo.ReplaceParticipants(tech.ID, []models.InterviewParticipant{{Email: "oleg@example.com"}}))
+	_, err = repo.GetByID(interviewerID, tech.ID)
+	require.Error(t, err)
+
+	require.NoError(t, repo.SaveFeedback(&models.InterviewFeedback{InterviewID: tech.ID, AuthorID: organizerID, Recommendation: models.RecommendYes,
+		Scores: []models.Scorecard{{Criterion: "Go", Score: 4}}}))
+	require.NoError(t, repo.SaveFeedback(&models.InterviewFeedback{InterviewID: tech.ID, AuthorID: organizerID, Recommendation: models.RecommendStrongYes,
+		Scores: []models.Scorecard{{Criterion: "Go", Score: 5}}, Comment: "Передумал"}))
+	feedback, err := repo.ListFeedback(tech.ID)
+	require.NoError(t, err)
+	require.Len(t, feedback, 1)
+	require.Equal(t, models.RecommendStrongYes, feedback[0].Recommendation)
+	require.Equal(t, 5, feedback[0].Scores[0].Score)
+
+	// Интервью удалённого резюме не показываются
+	require.NoError(t, db.Delete(resume).Error)
+	list, err = repo.List(organizerID, InterviewFilter{})
+	require.NoError(t, err)
+	require.Empty(t, list)
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"CVMatch/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LLMCacheRepository struct {
+	db *gorm.DB
+}
+
+type LLMCacheRepositoryI interface {
+	Get(key string, now time.Time) (*models.LLMCacheEntry, error)
+	Upsert(entry *models.LLMCacheEntry) error
+	DeleteExpired(now time.Time) (int64, error)
+}
+
+func NewLLMCacheRepository(db *gorm.DB) *LLMCacheRepository {
+	return &LLMCacheRepository{
+		db: db,
+	}
+}
+
+// Get возвращает непросроченную запись кеша
+func (r *LLMCacheRepository) Get(key string, now time.Time) (*models.LLMCacheEntry, error) {
+	var entry models.LLMCacheEntry
+	if err := r.db.Where("key = ? AND (expires_at IS NULL OR expires_at > ?)", key, now).First(&entry).Error; err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (r *LLMCacheRepository) Upsert(entry *models.LLMCacheEntry) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"model", "prompt_version", "response", "input_tokens", "completion_tokens", "expires_at", "updated_at"}),
+	}).Create(entry).Error
+}
+
+func (r *LLMCacheRepository) DeleteExpired(now time.Time) (int64, error) {
+	res := r.db.Where("expires_at IS NOT NULL AND expires_at <= ?", now).Delete(&models.LLMCacheEntry{})
+	return res.RowsAffected, res.Error
+}
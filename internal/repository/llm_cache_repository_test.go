@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"CVMatch/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupLLMCacheTestDB() *gorm.DB {
+	db, _ := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	db.AutoMigrate(&models.LLMCacheEntry{})
+	return db
+}
+
+func TestLLMCacheRepository_UpsertAndGet(t *testing.T) {
+	repo := NewLLMCacheRepository(setupLLMCacheTestDB())
+	now := time.Now()
+	expiresAt := now.Add(time.Hour)
+
+	require.NoError(t, repo.Upsert(&models.LLMCacheEntry{Key: "k", Model: "yandexgpt-lite", Response: "first", ExpiresAt: &expiresAt}))
+	require.NoError(t, repo.Upsert(&models.LLMCacheEntry{Key: "k", Model: "yandexgpt-lite", Response: "second", ExpiresAt: &expiresAt}))
+
+	entry, err := repo.Get("k", now)
+	require.NoError(t, err)
+	require.Equal(t, "second", entry.Response)
+
+	_, err = repo.Get("k", now.Add(2*time.Hour))
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestLLMCacheRepository_DeleteExpired(t *testing.T) {
+	repo := NewLLMCacheRepository(setupLLMCacheTestDB())
+	now := time.Now()
+	past, future := now.Add(-time.Minute), now.Add(time.Hour)
+	require.NoError(t, repo.Upsert(&models.LLMCacheEntry{Key: "old", Response: "x", ExpiresAt: &past}))
+	require.NoError(t, repo.Upsert(&models.LLMCacheEntry{Key: "fresh", Response: "x", ExpiresAt: &future}))
+	require.NoError(t, repo.Upsert(&models.LLMCacheEntry{Key: "forever", Response: "x"}))
+
+	deleted, err := repo.DeleteExpired(now)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+
+	_, err = repo.Get("forever", now.Add(24*time.Hour))
+	require.NoError(t, err)
+}
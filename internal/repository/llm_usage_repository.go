@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"CVMatch/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type LLMUsageRepository struct {
+	db *gorm.DB
+}
+
+type LLMUsageRepositoryI interface {
+	Create(usage *models.LLMUsage) error
+	SumTokensSince(userID uuid.UUID, since time.Time) (int64, error)
+	GetUsageByModel(userID uuid.UUID, from, to time.Time) ([]UsageAggregate, error)
+	GetUsageByUser(from, to time.Time) ([]UsageAggregate, error)
+}
+
+// UsageAggregate — суммарный расход LLM за период, сгруппированный по модели или пользователю
+type UsageAggregate struct {
+	UserID           uuid.UUID
+	Email            string
+	Model            string
+	Requests         int64
+	CachedRequests   int64
+	InputTokens      int64
+	CompletionTokens int64
+	TotalTokens      int64
+	Cost             float64
+}
+
+const usageAggregateColumns = "COUNT(*) AS requests, " +
+	"COALESCE(SUM(CASE WHEN llm_usages.cached THEN 1 ELSE 0 END), 0) AS cached_requests, " +
+	"COALESCE(SUM(llm_usages.input_tokens), 0) AS input_tokens, " +
+	"COALESCE(SUM(llm_usages.completion_tokens), 0) AS completion_tokens, " +
+	"COALESCE(SUM(llm_usages.total_tokens), 0) AS total_tokens, " +
+	"COALESCE(SUM(llm_usages.cost), 0) AS cost"
+
+func NewLLMUsageRepository(db *gorm.DB) *LLMUsageRepository {
+	return &LLMUsageRepository{
+		db: db,
+	}
+}
+
+func (r *LLMUsageRepository) Create(usage *models.LLMUsage) error {
+	return r.db.Create(usage).Error
+}
+
+// SumTokensSince — сколько токенов пользователь потратил начиная с since
+func (r *LLMUsageRepository) SumTokensSince(userID uuid.UUID, since time.Time) (int64, error) {
+	var total int64
+	err := r.db.Model(&models.LLMUsage{}).
+		Select("COALESCE(SUM(total_tokens), 0)").
+		Where("user_id = ? AND created_at >= ?", userID, since).
+		Scan(&total).Error
+	return total, err
+}
+
+// GetUsageByModel — расход пользователя за [from, to) по моделям
+func (r *LLMUsageRepository) GetUsageByModel(userID uuid.UUID, from, to time.Time) ([]UsageAggregate, error) {
+	var rows []UsageAggregate
+	err := r.db.Model(&models.LLMUsage{}).
+		Select("llm_usages.model, "+usageAggregateColumns).
+		Where("llm_usages.user_id = ? AND llm_usages.created_at >= ? AND llm_usages.created_at < ?", userID, from, to).
+		Group("llm_usages.model").
+		Order("llm_usages.model").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// GetUsageByUser — расход всех пользователей за [from, to), самые затратные первыми
+func (r *LLMUsageRepository) GetUsageByUser(from, to time.Time) ([]UsageAggregate, error) {
+	var rows []UsageAggregate
+	err := r.db.Model(&models.LLMUsage{}).
+		Select("llm_usages.user_id, users.email, "+usageAggregateColumns).
+		Joins("left join users on users.id = llm_usages.user_id").
+		Where("llm_usages.created_at >= ? AND llm_usages.created_at < ?", from, to).
+		Group("llm_usages.user_id, users.email").
+		Order("cost DESC, total_tokens DESC").
+		Scan(&rows).Error
+	return rows, err
+}
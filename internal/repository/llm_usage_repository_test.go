@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"CVMatch/internal/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupLLMUsageTestDB() *gorm.DB {
+	db, _ := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	db.AutoMigrate(&models.User{}, &models.Organization{}, &models.OrganizationMember{}, &models.LLMUsage{})
+	return db
+}
+
+func TestLLMUsageRepository_Aggregates(t *testing.T) {
+	db := setupLLMUsageTestDB()
+	repo := NewLLMUsageRepository(db)
+
+	alice := &models.User{Email: "alice@example.com", Password: "x"}
+	bob := &models.User{Email: "bob@example.com", Password: "x"}
+	require.NoError(t, db.Create(alice).Error)
+	require.NoError(t, db.Create(bob).Error)
+
+	now := time.Now().UTC()
+	old := now.AddDate(0, -2, 0)
+	rows := []*models.LLMUsage{
+		{UserID: alice.ID, Model: "yandexgpt-lite", TotalTokens: 100, InputTokens: 80, CompletionTokens: 20, Cost: 0.02, Success: true, CreatedAt: now},
+		{UserID: alice.ID, Model: "yandexgpt-lite", Cached: true, Success: true, CreatedAt: now},
+		{UserID: alice.ID, Model: "yandexgpt", TotalTokens: 1000, Cost: 1.2, Success: true, CreatedAt: now},
+		{UserID: alice.ID, Model: "yandexgpt", TotalTokens: 5000, Cost: 6, Success: true, CreatedAt: old},
+		{UserID: bob.ID, Model: "yandexgpt-lite", TotalTokens: 50, Cost: 0.01, Success: true, CreatedAt: now},
+	}
+	for _, row := range rows {
+		require.NoError(t, repo.Create(row))
+	}
+
+	total, err := repo.SumTokensSince(alice.ID, now.Add(-time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, int64(1100), total)
+
+	from, to := now.Add(-time.Hour), now.Add(time.Hour)
+	byModel, err := repo.GetUsageByModel(alice.ID, from, to)
+	require.NoError(t, err)
+	require.Len(t, byModel, 2)
+	require.Equal(t, "yandexgpt", byModel[0].Model)
+	require.Equal(t, int64(1000), byModel[0].TotalTokens)
+	require.Equal(t, "yandexgpt-lite", byModel[1].Model)
+	require.Equal(t, int64(2), byModel[1].Requests)
+	require.Equal(t, int64(1), byModel[1].CachedRequests)
+	require.Equal(t, int64(80), byModel[1].InputTokens)
+
+	byUser, err := repo.GetUsageByUser(from, to)
+	require.NoError(t, err)
+	require.Len(t, byUser, 2)
+	require.Equal(t, alice.ID, byUser[0].UserID)
+	require.Equal(t, "alice@example.com", byUser[0].Email)
+	require.InDelta(t, 1.22, byUser[0].Cost, 1e-9)
+	require.Equal(t, "bob@example.com", byUser[1].Email)
+}
+
+func TestUserRepository_UpdateMonthlyTokenQuota(t *testing.T) {
+	db := setupLLMUsageTestDB()
+	repo := NewUserRepository(db)
+	user := &models.User{Email: "quota@example.com", Password: "x"}
+	require.NoError(t, repo.Create(user))
+
+	quota := int64(5000)
+	require.NoError(t, repo.UpdateMonthlyTokenQuota(user.ID, &quota))
+	found, err := repo.FindByID(user.ID.String())
+	require.NoError(t, err)
+	require.Equal(t, quota, *found.MonthlyTokenQuota)
+
+	require.NoError(t, repo.UpdateMonthlyTokenQuota(user.ID, nil))
+	found, err = repo.FindByID(user.ID.String())
+	require.NoError(t, err)
+	require.Nil(t, found.MonthlyTokenQuota)
+
+	require.ErrorIs(t, repo.UpdateMonthlyTokenQuota(uuid.New(), &quota), gorm.ErrRecordNotFound)
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"CVMatch/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type MatchJobRepository struct {
+	db *gorm.DB
+}
+
+type MatchJobRepositoryI interface {
+	Create(job *models.MatchJob) error
+	GetByID(userID, jobID uuid.UUID) (*models.MatchJob, error)
+	Start(jobID uuid.UUID) error
+	UpdateProgress(jobID uuid.UUID, completed, failed int) error
+	Finish(jobID uuid.UUID, status, errMsg string) error
+	FailUnfinished(errMsg string) (int64, error)
+}
+
+func NewMatchJobRepository(db *gorm.DB) *MatchJobRepository {
+	return &MatchJobRepository{
+		db: db,
+	}
+}
+
+func (r *MatchJobRepository) Create(job *models.MatchJob) error {
+	return r.db.Create(job).Error
+}
+
+func (r *MatchJobRepository) GetByID(userID, jobID uuid.UUID) (*models.MatchJob, error) {
+	var job models.MatchJob
+	if err := r.db.Where("id = ? AND user_id = ?", jobID, userID).First(&job).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *MatchJobRepository) Start(jobID uuid.UUID) error {
+	return r.db.Model(&models.MatchJob{ID: jobID}).Updates(map[string]interface{}{
+		"status":     models.JobRunning,
+		"started_at": time.Now(),
+	}).Error
+}
+
+func (r *MatchJobRepository) UpdateProgress(jobID uuid.UUID, completed, failed int) error {
+	return r.db.Model(&models.MatchJob{ID: jobID}).Updates(map[string]interface{}{
+		"completed": completed,
+		"failed":    failed,
+	}).Error
+}
+
+func (r *MatchJobRepository) Finish(jobID uuid.UUID, status, errMsg string) error {
+	return r.db.Model(&models.MatchJob{ID: jobID}).Updates(map[string]interface{}{
+		"status":      status,
+		"error":       errMsg,
+		"finished_at": time.Now(),
+	}).Error
+}
+
+// FailUnfinished помечает прерванными задачи, которые не успели завершиться до остановки сервера
+func (r *MatchJobRepository) FailUnfinished(errMsg string) (int64, error) {
+	result := r.db.Model(&models.MatchJob{}).
+		Where("status IN ?", []string{models.JobPending, models.JobRunning}).
+		Updates(map[string]interface{}{
+			"status":      models.JobFailed,
+			"error":       errMsg,
+			"finished_at": time.Now(),
+		})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"CVMatch/internal/models"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestMatchJobRepository_Lifecycle(t *testing.T) {
+	db, _ := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, db.AutoMigrate(&models.MatchJob{}))
+	repo := NewMatchJobRepository(db)
+
+	userID := uuid.New()
+	job := &models.MatchJob{UserID: userID, Status: models.JobPending, ResumeIDs: []uuid.UUID{uuid.New()}, VacancyIDs: []uuid.UUID{uuid.New(), uuid.New()}, Total: 2}
+	stale := &models.MatchJob{UserID: userID, Status: models.JobRunning, Total: 1}
+	require.NoError(t, repo.Create(job))
+	require.NoError(t, repo.Create(stale))
+
+	require.NoError(t, repo.Start(job.ID))
+	require.NoError(t, repo.UpdateProgress(job.ID, 1, 1))
+	require.NoError(t, repo.Finish(job.ID, models.JobDone, ""))
+
+	got, err := repo.GetByID(userID, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.JobDone, got.Status)
+	require.Equal(t, 1, got.Completed)
+	require.Equal(t, 1, got.Failed)
+	require.Len(t, got.VacancyIDs, 2)
+	require.NotNil(t, got.StartedAt)
+	require.NotNil(t, got.FinishedAt)
+
+	_, err = repo.GetByID(uuid.New(), job.ID)
+	require.Error(t, err)
+
+	n, err := repo.FailUnfinished("interrupted")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+	got, err = repo.GetByID(userID, stale.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.JobFailed, got.Status)
+	require.Equal(t, "interrupted", got.Error)
+}
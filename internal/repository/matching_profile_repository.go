@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"CVMatch/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type MatchingProfileRepository struct {
+	db *gorm.DB
+}
+
+type MatchingProfileRepositoryI interface {
+	Create(profile *models.MatchingProfile) error
+	Update(profile *models.MatchingProfile) error
+	GetByID(userID, profileID uuid.UUID) (*models.MatchingProfile, error)
+	LoadProfile(profileID uuid.UUID) (*models.MatchingProfile, error)
+	GetDefault(userID uuid.UUID) (*models.MatchingProfile, error)
+	List(userID uuid.UUID) ([]models.MatchingProfile, error)
+	Delete(userID, profileID uuid.UUID) error
+}
+
+func NewMatchingProfileRepository(db *gorm.DB) *MatchingProfileRepository {
+	return &MatchingProfileRepository{
+		db: db,
+	}
+}
+
+// Create сохраняет профиль; если он по умолчанию, снимает этот признак с остальных профилей пользователя
+// и увеличивает версию вакансий, которые теперь считаются по нему
+func (r *MatchingProfileRepository) Create(profile *models.MatchingProfile) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(profile).Error; err != nil {
+			return err
+		}
+		if err := clearOtherDefaults(tx, profile); err != nil {
+			return err
+		}
+		return touchVacancies(tx, profile, profile.IsDefault)
+	})
+}
+
+// Update сохраняет профиль и увеличивает версию вакансий, которые считались или теперь считаются по нему
+func (r *MatchingProfileRepository) Update(profile *models.MatchingProfile) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var wasDefault []bool
+		if err := tx.Model(&models.MatchingProfile{}).Where("id = ?", profile.ID).Pluck("is_default", &wasDefault).Error; err != nil {
+			return err
+		}
+		if err := tx.Save(profile).Error; err != nil {
+			return err
+		}
+		if err := clearOtherDefaults(tx, profile); err != nil {
+			return err
+		}
+		return touchVacancies(tx, profile, profile.IsDefault || (len(wasDefault) > 0 && wasDefault[0]))
+	})
+}
+
+func clearOtherDefaults(tx *gorm.DB, profile *models.MatchingProfile) error {
+	if !profile.IsDefault {
+		return nil
+	}
+	return tx.Model(&models.MatchingProfile{}).
+		Where("user_id = ? AND id <> ? AND is_default", profile.UserID, profile.ID).
+		Update("is_default", false).Error
+}
+
+// touchVacancies увеличивает версию вакансий, результаты сравнения с которыми зависят от профиля:
+// с привязанным профилем, а если он по умолчанию — и вакансий владельца без своего профиля
+func touchVacancies(tx *gorm.DB, profile *models.MatchingProfile, isDefault bool) error {
+	query := tx.Model(&models.Vacancy{}).Where("matching_profile_id = ?", profile.ID)
+	if isDefault {
+		query = query.Or("user_id = ? AND matching_profile_id IS NULL", profile.UserID)
+	}
+	return query.Update("version", gorm.Expr("version + 1")).Error
+}
+
+func (r *MatchingProfileRepository) GetByID(userID, profileID uuid.UUID) (*models.MatchingProfile, error) {
+	var profile models.MatchingProfile
+	if err := r.db.Where("id = ? AND user_id = ?", profileID, userID).First(&profile).Error; err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// LoadProfile загружает профиль без проверки владельца: профиль, привязанный к вакансии
+// рабочего пространства, применяется при сравнении любым участником
+func (r *MatchingProfileRepository) LoadProfile(profileID uuid.UUID) (*models.MatchingProfile, error) {
+	var profile models.MatchingProfile
+	if err := r.db.Where("id = ?", profileID).First(&profile).Error; err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// GetDefault возвращает профиль пользователя по умолчанию или gorm.ErrRecordNotFound
+func (r *MatchingProfileRepository) GetDefault(userID uuid.UUID) (*models.MatchingProfile, error) {
+	var profile models.MatchingProfile
+	if err := r.db.Where("user_id = ? AND is_default", userID).First(&profile).Error; err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+func (r *MatchingProfileRepository) List(userID uuid.UUID) ([]models.MatchingProfile, error) {
+	var profiles []models.MatchingProfile
+	if err := r.db.Where("user_id = ?", userID).Order("created_at").Find(&profiles).Error; err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+// Delete удаляет профиль и отвязывает его от всех вакансий, в том числе вакансий коллег по рабочему
+// пространству: они переходят на профиль по умолчанию.
+// Версия вакансий, которые считались по удалённому профилю, растёт.
+func (r *MatchingProfileRepository) Delete(userID, profileID uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var profile models.MatchingProfile
+		if err := tx.Where("id = ? AND user_id = ?", profileID, userID).First(&profile).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&profile).Error; err != nil {
+			return err
+		}
+		if err := touchVacancies(tx, &profile, profile.IsDefault); err != nil {
+			return err
+		}
+		return tx.Model(&models.Vacancy{}).
+			Where("matching_profile_id = ?", profileID).
+			Update("matching_profile_id", nil).Error
+	})
+}
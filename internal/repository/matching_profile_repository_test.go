@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"CVMatch/internal/models"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestMatchingProfileRepository_DefaultAndDelete(t *testing.T) {
+	db, _ := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, db.AutoMigrate(&models.User{}, &models.Vacancy{}, &models.MatchingProfile{}))
+	repo := NewMatchingProfileRepository(db)
+
+	userID := uuid.New()
+	first := &models.MatchingProfile{
+		UserID:        userID,
+		Name:          "Backend",
+		IsDefault:     true,
+		Weights:       models.ComponentWeights{Skills: 0.7, Experience: 0.3},
+		Knockout:      models.KnockoutRules{BelowMinExperience: true, Cap: 0},
+		Normalization: models.NormalizationStrict,
+	}
+	require.NoError(t, repo.Create(first))
+	second := &models.MatchingProfile{UserID: userID, Name: "Junior", Weights: models.ComponentWeights{Skills: 1}, Normalization: models.NormalizationNeutral}
+	require.NoError(t, repo.Create(second))
+
+	got, err := repo.GetDefault(userID)
+	require.NoError(t, err)
+	require.Equal(t, first.ID, got.ID)
+	require.Equal(t, first.Weights, got.Weights)
+	require.Equal(t, first.Knockout, got.Knockout)
+
+	// Новый профиль по умолчанию снимает признак с прежнего
+	second.IsDefault = true
+	require.NoError(t, repo.Update(second))
+	got, err = repo.GetDefault(userID)
+	require.NoError(t, err)
+	require.Equal(t, second.ID, got.ID)
+	profiles, err := repo.List(userID)
+	require.NoError(t, err)
+	require.Len(t, profiles, 2)
+	require.False(t, profiles[0].IsDefault)
+
+	_, err = repo.GetByID(uuid.New(), first.ID)
+	require.Error(t, err)
+	loaded, err := repo.LoadProfile(first.ID)
+	require.NoError(t, err)
+	require.Equal(t, first.ID, loaded.ID)
+
+	vacancy := &models.Vacancy{UserID: userID, Title: "Go developer"}
+	require.NoError(t, db.Create(vacancy).Error)
+	require.NoError(t, NewVacancyRepository(db).SetMatchingProfile(vacancy.ID, &first.ID))
+	// Коллега по рабочему пространству привязал тот же профиль к своей вакансии
+	teammate := &models.Vacancy{UserID: uuid.New(), Title: "Go team lead", MatchingProfileID: &first.ID}
+	require.NoError(t, db.Create(teammate).Error)
+
+	require.ErrorIs(t, repo.Delete(uuid.New(), first.ID), gorm.ErrRecordNotFound)
+	require.NoError(t, repo.Delete(userID, first.ID))
+	for _, id := range []uuid.UUID{vacancy.ID, teammate.ID} {
+		var reloaded models.Vacancy
+		require.NoError(t, db.First(&reloaded, "id = ?", id).Error)
+		require.Nil(t, reloaded.MatchingProfileID)
+	}
+	_, err = repo.LoadProfile(first.ID)
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestMatchingProfileRepository_TouchesVacancies(t *testing.T) {
+	db, _ := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, db.AutoMigrate(&models.User{}, &models.Vacancy{}, &models.MatchingProfile{}))
+	repo := NewMatchingProfileRepository(db)
+
+	userID := uuid.New()
+	profile := &models.MatchingProfile{UserID: userID, Name: "Backend", Weights: models.ComponentWeights{Skills: 1}}
+	require.NoError(t, repo.Create(profile))
+	attached := &models.Vacancy{UserID: userID, Title: "Go developer", MatchingProfileID: &profile.ID}
+	unassigned := &models.Vacancy{UserID: userID, Title: "Python developer"}
+	foreign := &models.Vacancy{UserID: uuid.New(), Title: "Java developer"}
+	for _, v := range []*models.Vacancy{attached, unassigned, foreign} {
+		require.NoError(t, db.Create(v).Error)
+	}
+	versions := func() []int {
+		var result []int
+		for _, v := range []*models.Vacancy{attached, unassigned, foreign} {
+			var reloaded models.Vacancy
+			require.NoError(t, db.First(&reloaded, "id = ?", v.ID).Error)
+			result = append(result, reloaded.Version)
+		}
+		return result
+	}
+
+	// Правка профиля устаревает только вакансии, привязанные к нему
+	profile.Weights.Experience = 1
+	require.NoError(t, repo.Update(profile))
+	require.Equal(t, []int{2, 1, 1}, versions())
+
+	// Профиль по умолчанию применяется и к вакансиям владельца без своего профиля
+	profile.IsDefault = true
+	require.NoError(t, repo.Update(profile))
+	require.Equal(t, []int{3, 2, 1}, versions())
+
+	require.NoError(t, repo.Delete(userID, profile.ID))
+	require.Equal(t, []int{4, 3, 1}, versions())
+}
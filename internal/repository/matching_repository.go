@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"CVMatch/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type MatchingRepository struct {
+	db *gorm.DB
+}
+
+type MatchingRepositoryI interface {
+	Create(result *models.MatchingResult) error
+	GetMatchByID(userID, matchID uuid.UUID) (*models.MatchingResult, error)
+	UpdateRecommendations(matchID uuid.UUID, recommendations *models.Recommendations) error
+	ListByJob(jobID uuid.UUID) ([]models.MatchingResult, error)
+	ListStale(after uuid.UUID, limit int) ([]StaleMatch, error)
+	UpdateComputed(result *models.MatchingResult) error
+}
+
+// StaleMatch — результат сравнения, посчитанный по прежней версии резюме или вакансии
+type StaleMatch struct {
+	ID        uuid.UUID
+	ResumeID  uuid.UUID
+	VacancyID uuid.UUID
+	UserID    uuid.UUID
+}
+
+// staleCondition — результат устарел, если резюме или вакансия изменились после расчёта
+const staleCondition = "(matching_results.resume_version <> resumes.version OR matching_results.vacancy_version <> vacancies.version)"
+
+func NewMatchingRepository(db *gorm.DB) *MatchingRepository {
+	return &MatchingRepository{
+		db: db,
+	}
+}
+
+func (r *MatchingRepository) Create(result *models.MatchingResult) error {
+	return r.db.Create(result).Error
+}
+
+// GetMatchByID возвращает результат сравнения, если резюме лежит в текущем рабочем пространстве пользователя,
+// а резюме и вакансия не удалены
+func (r *MatchingRepository) GetMatchByID(userID, matchID uuid.UUID) (*models.MatchingResult, error) {
+	var result models.MatchingResult
+	if err := r.db.Select("matching_results.*, "+staleCondition+" AS stale").
+		Joins("join resumes on resumes.id = matching_results.resume_id AND resumes.deleted_at IS NULL").
+		Joins("join vacancies on vacancies.id = matching_results.vacancy_id AND vacancies.deleted_at IS NULL").
+		Where("matching_results.id = ? AND resumes.organization_id IN (?)", matchID, workspaceOf(r.db, userID)).
+		First(&result).Error; err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (r *MatchingRepository) UpdateRecommendations(matchID uuid.UUID, recommendations *models.Recommendations) error {
+	return r.db.Model(&models.MatchingResult{ID: matchID}).Select("Recommendations").
+		Updates(&models.MatchingResult{Recommendations: recommendations}).Error
+}
+
+// ListByJob возвращает результаты задачи расчёта матрицы без разбора оценки
+func (r *MatchingRepository) ListByJob(jobID uuid.UUID) ([]models.MatchingResult, error) {
+	var results []models.MatchingResult
+	if err := r.db.Select("id", "resume_id", "vacancy_id", "score", "knockout", "job_id").
+		Where("job_id = ?", jobID).Find(&results).Error; err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// ListStale возвращает устаревшие результаты по возрастанию ID, начиная после after
+func (r *MatchingRepository) ListStale(after uuid.UUID, limit int) ([]StaleMatch, error) {
+	var stale []StaleMatch
+	if err := r.db.Model(&models.MatchingResult{}).
+		Select("matching_results.id, matching_results.resume_id, matching_results.vacancy_id, resumes.user_id").
+		Joins("join resumes on resumes.id = matching_results.resume_id AND resumes.deleted_at IS NULL").
+		Joins("join vacancies on vacancies.id = matching_results.vacancy_id AND vacancies.deleted_at IS NULL").
+		Where(staleCondition+" AND matching_results.id > ?", after).
+		Order("matching_results.id").Limit(limit).Scan(&stale).Error; err != nil {
+		return nil, err
+	}
+	return stale, nil
+}
+
+// UpdateComputed сохраняет пересчитанную оценку на месте, чтобы не терять ссылки на результат
+// (сопроводительные письма, задачи матрицы). Советы по резюме сбрасываются: они составлялись по старой оценке.
+func (r *MatchingRepository) UpdateComputed(result *models.MatchingResult) error {
+	result.Recommendations = nil
+	return r.db.Model(&models.MatchingResult{ID: result.ID}).
+		Select("Score", "Knockout", "Breakdown", "ProfileID", "Profile", "ResumeVersion", "VacancyVersion", "Recommendations").
+		Updates(result).Error
+}
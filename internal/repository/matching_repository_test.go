@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"CVMatch/internal/models"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestMatchingRepository_CreateAndGetMatchByID_Breakdown(t *testing.T) {
+	db, _ := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, db.AutoMigrate(&models.User{}, &models.Resume{}, &models.Vacancy{}, &models.MatchingResult{}))
+	repo := NewMatchingRepository(db)
+
+	userID, organizationID := newWorkspaceUser(t, db)
+	resume := &models.Resume{OrganizationID: organizationID, UserID: userID, FullName: "Test"}
+	require.NoError(t, db.Create(resume).Error)
+	vacancy := &models.Vacancy{OrganizationID: organizationID, UserID: userID, Title: "Go Developer"}
+	require.NoError(t, db.Create(vacancy).Error)
+
+	result := &models.MatchingResult{
+		ResumeID:  resume.ID,
+		VacancyID: vacancy.ID,
+		Score:     72.5,
+		Breakdown: models.ScoreBreakdown{
+			Components: []models.ScoreComponent{{
+				Name:       models.ComponentSkills,
+				Score:      80,
+				Weight:     0.5,
+				Applicable: true,
+				Evidence:   []models.Evidence{{Requirement: "Go", Source: "experience", Snippet: "Сервисы на Go"}},
+			}},
+			Skills: []models.SkillMatch{{Name: "Go", Requirement: models.SkillRequired, Weight: 2, Matched: true, Credit: 1}},
+		},
+	}
+	require.NoError(t, repo.Create(result))
+
+	got, err := repo.GetMatchByID(userID, result.ID)
+	require.NoError(t, err)
+	require.Equal(t, result.Breakdown, got.Breakdown)
+
+	require.Nil(t, got.Recommendations)
+
+	_, err = repo.GetMatchByID(uuid.New(), result.ID)
+	require.Error(t, err)
+
+	require.NoError(t, repo.UpdateRecommendations(result.ID, &models.Recommendations{
+		MissingKeywords: []string{"Kafka"},
+		Source:          models.SourceTemplate,
+	}))
+	got, err = repo.GetMatchByID(userID, result.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got.Recommendations)
+	require.Equal(t, []string{"Kafka"}, got.Recommendations.MissingKeywords)
+	require.Equal(t, result.Breakdown, got.Breakdown)
+}
+
+func TestMatchingRepository_ListByJob(t *testing.T) {
+	db, _ := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, db.AutoMigrate(&models.MatchingResult{}))
+	repo := NewMatchingRepository(db)
+
+	jobID := uuid.New()
+	require.NoError(t, repo.Create(&models.MatchingResult{ResumeID: uuid.New(), VacancyID: uuid.New(), Score: 55, JobID: &jobID}))
+	require.NoError(t, repo.Create(&models.MatchingResult{ResumeID: uuid.New(), VacancyID: uuid.New(), Score: 70}))
+
+	results, err := repo.ListByJob(jobID)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, 55.0, results[0].Score)
+}
+
+func TestMatchingRepository_StaleAndUpdateComputed(t *testing.T) {
+	db, _ := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, db.AutoMigrate(&models.User{}, &models.Resume{}, &models.Vacancy{}, &models.MatchingResult{}))
+	repo := NewMatchingRepository(db)
+
+	userID, organizationID := newWorkspaceUser(t, db)
+	resume := &models.Resume{OrganizationID: organizationID, UserID: userID, FullName: "Test", Version: 1}
+	require.NoError(t, db.Create(resume).Error)
+	vacancy := &models.Vacancy{OrganizationID: organizationID, UserID: userID, Title: "Go Developer", Version: 1}
+	require.NoError(t, db.Create(vacancy).Error)
+
+	current := &models.MatchingResult{ResumeID: resume.ID, VacancyID: vacancy.ID, Score: 40, ResumeVersion: 1, VacancyVersion: 1}
+	require.NoError(t, repo.Create(current))
+	got, err := repo.GetMatchByID(userID, current.ID)
+	require.NoError(t, err)
+	require.False(t, got.Stale)
+
+	stale, err := repo.ListStale(uuid.Nil, 10)
+	require.NoError(t, err)
+	require.Empty(t, stale)
+
+	require.NoError(t, db.Model(resume).Update("version", 2).Error)
+	require.NoError(t, repo.UpdateRecommendations(current.ID, &models.Recommendations{MissingKeywords: []string{"Kafka"}}))
+
+	got, err = repo.GetMatchByID(userID, current.ID)
+	require.NoError(t, err)
+	require.True(t, got.Stale)
+
+	stale, err = repo.ListStale(uuid.Nil, 10)
+	require.NoError(t, err)
+	require.Equal(t, []StaleMatch{{ID: current.ID, ResumeID: resume.ID, VacancyID: vacancy.ID, UserID: userID}}, stale)
+	stale, err = repo.ListStale(current.ID, 10)
+	require.NoError(t, err)
+	require.Empty(t, stale)
+
+	require.NoError(t, repo.UpdateComputed(&models.MatchingResult{ID: current.ID, Score: 65, ResumeVersion: 2, VacancyVersion: 1}))
+	got, err = repo.GetMatchByID(userID, current.ID)
+	require.NoError(t, err)
+	require.False(t, got.Stale)
+	require.Equal(t, 65.0, got.Score)
+	require.Equal(t, 2, got.ResumeVersion)
+	require.Nil(t, got.Recommendations)
+
+	// Результат по удалённой вакансии не возвращается
+	require.NoError(t, db.Delete(vacancy).Error)
+	_, err = repo.GetMatchByID(userID, current.ID)
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
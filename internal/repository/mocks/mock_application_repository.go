@@ -0,0 +1,203 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/application_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/application_repository.go -destination=internal/repository/mocks/mock_application_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "CVMatch/internal/models"
+	repository "CVMatch/internal/repository"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockApplicationRepositoryI is a mock of ApplicationRepositoryI interface.
+type MockApplicationRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockApplicationRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockApplicationRepositoryIMockRecorder is the mock recorder for MockApplicationRepositoryI.
+type MockApplicationRepositoryIMockRecorder struct {
+	mock *MockApplicationRepositoryI
+}
+
+// NewMockApplicationRepositoryI creates a new mock instance.
+func NewMockApplicationRepositoryI(ctrl *gomock.Controller) *MockApplicationRepositoryI {
+	mock := &MockApplicationRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockApplicationRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApplicationRepositoryI) EXPECT() *MockApplicationRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// CountByStage mocks base method.
+func (m *MockApplicationRepositoryI) CountByStage(vacancyID uuid.UUID) (map[string]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByStage", vacancyID)
+	ret0, _ := ret[0].(map[string]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByStage indicates an expected call of CountByStage.
+func (mr *MockApplicationRepositoryIMockRecorder) CountByStage(vacancyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByStage", reflect.TypeOf((*MockApplicationRepositoryI)(nil).CountByStage), vacancyID)
+}
+
+// Create mocks base method.
+func (m *MockApplicationRepositoryI) Create(application *models.Application) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", application)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockApplicationRepositoryIMockRecorder) Create(application any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockApplicationRepositoryI)(nil).Create), application)
+}
+
+// CreateTransition mocks base method.
+func (m *MockApplicationRepositoryI) CreateTransition(transition *models.ApplicationTransition) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransition", transition)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTransition indicates an expected call of CreateTransition.
+func (mr *MockApplicationRepositoryIMockRecorder) CreateTransition(transition any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransition", reflect.TypeOf((*MockApplicationRepositoryI)(nil).CreateTransition), transition)
+}
+
+// DB mocks base method.
+func (m *MockApplicationRepositoryI) DB() *gorm.DB {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DB")
+	ret0, _ := ret[0].(*gorm.DB)
+	return ret0
+}
+
+// DB indicates an expected call of DB.
+func (mr *MockApplicationRepositoryIMockRecorder) DB() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DB", reflect.TypeOf((*MockApplicationRepositoryI)(nil).DB))
+}
+
+// Delete mocks base method.
+func (m *MockApplicationRepositoryI) Delete(applicationID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", applicationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockApplicationRepositoryIMockRecorder) Delete(applicationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockApplicationRepositoryI)(nil).Delete), applicationID)
+}
+
+// GetByID mocks base method.
+func (m *MockApplicationRepositoryI) GetByID(userID, applicationID uuid.UUID) (*models.Application, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", userID, applicationID)
+	ret0, _ := ret[0].(*models.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockApplicationRepositoryIMockRecorder) GetByID(userID, applicationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockApplicationRepositoryI)(nil).GetByID), userID, applicationID)
+}
+
+// GetByPair mocks base method.
+func (m *MockApplicationRepositoryI) GetByPair(resumeID, vacancyID uuid.UUID) (*models.Application, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByPair", resumeID, vacancyID)
+	ret0, _ := ret[0].(*models.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByPair indicates an expected call of GetByPair.
+func (mr *MockApplicationRepositoryIMockRecorder) GetByPair(resumeID, vacancyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByPair", reflect.TypeOf((*MockApplicationRepositoryI)(nil).GetByPair), resumeID, vacancyID)
+}
+
+// List mocks base method.
+func (m *MockApplicationRepositoryI) List(userID uuid.UUID, filter repository.ApplicationFilter) ([]models.Application, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", userID, filter)
+	ret0, _ := ret[0].([]models.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockApplicationRepositoryIMockRecorder) List(userID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockApplicationRepositoryI)(nil).List), userID, filter)
+}
+
+// ListTransitions mocks base method.
+func (m *MockApplicationRepositoryI) ListTransitions(applicationID uuid.UUID) ([]models.ApplicationTransition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransitions", applicationID)
+	ret0, _ := ret[0].([]models.ApplicationTransition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransitions indicates an expected call of ListTransitions.
+func (mr *MockApplicationRepositoryIMockRecorder) ListTransitions(applicationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransitions", reflect.TypeOf((*MockApplicationRepositoryI)(nil).ListTransitions), applicationID)
+}
+
+// UpdateStage mocks base method.
+func (m *MockApplicationRepositoryI) UpdateStage(applicationID uuid.UUID, stage string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStage", applicationID, stage)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStage indicates an expected call of UpdateStage.
+func (mr *MockApplicationRepositoryIMockRecorder) UpdateStage(applicationID, stage any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStage", reflect.TypeOf((*MockApplicationRepositoryI)(nil).UpdateStage), applicationID, stage)
+}
+
+// WithTx mocks base method.
+func (m *MockApplicationRepositoryI) WithTx(tx *gorm.DB) repository.ApplicationRepositoryI {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", tx)
+	ret0, _ := ret[0].(repository.ApplicationRepositoryI)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockApplicationRepositoryIMockRecorder) WithTx(tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockApplicationRepositoryI)(nil).WithTx), tx)
+}
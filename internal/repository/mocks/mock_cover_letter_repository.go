@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/cover_letter_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/cover_letter_repository.go -destination=internal/repository/mocks/mock_cover_letter_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "CVMatch/internal/models"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCoverLetterRepositoryI is a mock of CoverLetterRepositoryI interface.
+type MockCoverLetterRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockCoverLetterRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockCoverLetterRepositoryIMockRecorder is the mock recorder for MockCoverLetterRepositoryI.
+type MockCoverLetterRepositoryIMockRecorder struct {
+	mock *MockCoverLetterRepositoryI
+}
+
+// NewMockCoverLetterRepositoryI creates a new mock instance.
+func NewMockCoverLetterRepositoryI(ctrl *gomock.Controller) *MockCoverLetterRepositoryI {
+	mock := &MockCoverLetterRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockCoverLetterRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCoverLetterRepositoryI) EXPECT() *MockCoverLetterRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCoverLetterRepositoryI) Create(letter *models.CoverLetter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", letter)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCoverLetterRepositoryIMockRecorder) Create(letter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCoverLetterRepositoryI)(nil).Create), letter)
+}
+
+// GetByID mocks base method.
+func (m *MockCoverLetterRepositoryI) GetByID(userID, matchID, letterID uuid.UUID) (*models.CoverLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", userID, matchID, letterID)
+	ret0, _ := ret[0].(*models.CoverLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockCoverLetterRepositoryIMockRecorder) GetByID(userID, matchID, letterID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockCoverLetterRepositoryI)(nil).GetByID), userID, matchID, letterID)
+}
+
+// ListByMatch mocks base method.
+func (m *MockCoverLetterRepositoryI) ListByMatch(userID, matchID uuid.UUID) ([]models.CoverLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByMatch", userID, matchID)
+	ret0, _ := ret[0].([]models.CoverLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByMatch indicates an expected call of ListByMatch.
+func (mr *MockCoverLetterRepositoryIMockRecorder) ListByMatch(userID, matchID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByMatch", reflect.TypeOf((*MockCoverLetterRepositoryI)(nil).ListByMatch), userID, matchID)
+}
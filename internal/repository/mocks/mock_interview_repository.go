@@ -0,0 +1,173 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/interview_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/interview_repository.go -destination=internal/repository/mocks/mock_interview_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "CVMatch/internal/models"
+	repository "CVMatch/internal/repository"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockInterviewRepositoryI is a mock of InterviewRepositoryI interface.
+type MockInterviewRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockInterviewRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockInterviewRepositoryIMockRecorder is the mock recorder for MockInterviewRepositoryI.
+type MockInterviewRepositoryIMockRecorder struct {
+	mock *MockInterviewRepositoryI
+}
+
+// NewMockInterviewRepositoryI creates a new mock instance.
+func NewMockInterviewRepositoryI(ctrl *gomock.Controller) *MockInterviewRepositoryI {
+	mock := &MockInterviewRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockInterviewRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInterviewRepositoryI) EXPECT() *MockInterviewRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockInterviewRepositoryI) Create(interview *models.Interview) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", interview)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockInterviewRepositoryIMockRecorder) Create(interview any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockInterviewRepositoryI)(nil).Create), interview)
+}
+
+// DB mocks base method.
+func (m *MockInterviewRepositoryI) DB() *gorm.DB {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DB")
+	ret0, _ := ret[0].(*gorm.DB)
+	return ret0
+}
+
+// DB indicates an expected call of DB.
+func (mr *MockInterviewRepositoryIMockRecorder) DB() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DB", reflect.TypeOf((*MockInterviewRepositoryI)(nil).DB))
+}
+
+// GetByID mocks base method.
+func (m *MockInterviewRepositoryI) GetByID(userID, interviewID uuid.UUID) (*models.Interview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", userID, interviewID)
+	ret0, _ := ret[0].(*models.Interview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockInterviewRepositoryIMockRecorder) GetByID(userID, interviewID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockInterviewRepositoryI)(nil).GetByID), userID, interviewID)
+}
+
+// List mocks base method.
+func (m *MockInterviewRepositoryI) List(userID uuid.UUID, filter repository.InterviewFilter) ([]models.Interview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", userID, filter)
+	ret0, _ := ret[0].([]models.Interview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockInterviewRepositoryIMockRecorder) List(userID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockInterviewRepositoryI)(nil).List), userID, filter)
+}
+
+// ListFeedback mocks base method.
+func (m *MockInterviewRepositoryI) ListFeedback(interviewID uuid.UUID) ([]models.InterviewFeedback, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFeedback", interviewID)
+	ret0, _ := ret[0].([]models.InterviewFeedback)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFeedback indicates an expected call of ListFeedback.
+func (mr *MockInterviewRepositoryIMockRecorder) ListFeedback(interviewID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFeedback", reflect.TypeOf((*MockInterviewRepositoryI)(nil).ListFeedback), interviewID)
+}
+
+// ReplaceParticipants mocks base method.
+func (m *MockInterviewRepositoryI) ReplaceParticipants(interviewID uuid.UUID, participants []models.InterviewParticipant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceParticipants", interviewID, participants)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceParticipants indicates an expected call of ReplaceParticipants.
+func (mr *MockInterviewRepositoryIMockRecorder) ReplaceParticipants(interviewID, participants any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceParticipants", reflect.TypeOf((*MockInterviewRepositoryI)(nil).ReplaceParticipants), interviewID, participants)
+}
+
+// SaveFeedback mocks base method.
+func (m *MockInterviewRepositoryI) SaveFeedback(feedback *models.InterviewFeedback) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveFeedback", feedback)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveFeedback indicates an expected call of SaveFeedback.
+func (mr *MockInterviewRepositoryIMockRecorder) SaveFeedback(feedback any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveFeedback", reflect.TypeOf((*MockInterviewRepositoryI)(nil).SaveFeedback), feedback)
+}
+
+// Update mocks base method.
+func (m *MockInterviewRepositoryI) Update(interview *models.Interview) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", interview)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockInterviewRepositoryIMockRecorder) Update(interview any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockInterviewRepositoryI)(nil).Update), interview)
+}
+
+// WithTx mocks base method.
+func (m *MockInterviewRepositoryI) WithTx(tx *gorm.DB) repository.InterviewRepositoryI {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", tx)
+	ret0, _ := ret[0].(repository.InterviewRepositoryI)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockInterviewRepositoryIMockRecorder) WithTx(tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockInterviewRepositoryI)(nil).WithTx), tx)
+}
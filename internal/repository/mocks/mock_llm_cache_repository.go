@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/llm_cache_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/llm_cache_repository.go -destination=internal/repository/mocks/mock_llm_cache_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "CVMatch/internal/models"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLLMCacheRepositoryI is a mock of LLMCacheRepositoryI interface.
+type MockLLMCacheRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockLLMCacheRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockLLMCacheRepositoryIMockRecorder is the mock recorder for MockLLMCacheRepositoryI.
+type MockLLMCacheRepositoryIMockRecorder struct {
+	mock *MockLLMCacheRepositoryI
+}
+
+// NewMockLLMCacheRepositoryI creates a new mock instance.
+func NewMockLLMCacheRepositoryI(ctrl *gomock.Controller) *MockLLMCacheRepositoryI {
+	mock := &MockLLMCacheRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockLLMCacheRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLLMCacheRepositoryI) EXPECT() *MockLLMCacheRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// DeleteExpired mocks base method.
+func (m *MockLLMCacheRepositoryI) DeleteExpired(now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpired", now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpired indicates an expected call of DeleteExpired.
+func (mr *MockLLMCacheRepositoryIMockRecorder) DeleteExpired(now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockLLMCacheRepositoryI)(nil).DeleteExpired), now)
+}
+
+// Get mocks base method.
+func (m *MockLLMCacheRepositoryI) Get(key string, now time.Time) (*models.LLMCacheEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", key, now)
+	ret0, _ := ret[0].(*models.LLMCacheEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockLLMCacheRepositoryIMockRecorder) Get(key, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockLLMCacheRepositoryI)(nil).Get), key, now)
+}
+
+// Upsert mocks base method.
+func (m *MockLLMCacheRepositoryI) Upsert(entry *models.LLMCacheEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockLLMCacheRepositoryIMockRecorder) Upsert(entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockLLMCacheRepositoryI)(nil).Upsert), entry)
+}
@@ -0,0 +1,103 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/llm_usage_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/llm_usage_repository.go -destination=internal/repository/mocks/mock_llm_usage_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "CVMatch/internal/models"
+	repository "CVMatch/internal/repository"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLLMUsageRepositoryI is a mock of LLMUsageRepositoryI interface.
+type MockLLMUsageRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockLLMUsageRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockLLMUsageRepositoryIMockRecorder is the mock recorder for MockLLMUsageRepositoryI.
+type MockLLMUsageRepositoryIMockRecorder struct {
+	mock *MockLLMUsageRepositoryI
+}
+
+// NewMockLLMUsageRepositoryI creates a new mock instance.
+func NewMockLLMUsageRepositoryI(ctrl *gomock.Controller) *MockLLMUsageRepositoryI {
+	mock := &MockLLMUsageRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockLLMUsageRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLLMUsageRepositoryI) EXPECT() *MockLLMUsageRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockLLMUsageRepositoryI) Create(usage *models.LLMUsage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", usage)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockLLMUsageRepositoryIMockRecorder) Create(usage any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockLLMUsageRepositoryI)(nil).Create), usage)
+}
+
+// GetUsageByModel mocks base method.
+func (m *MockLLMUsageRepositoryI) GetUsageByModel(userID uuid.UUID, from, to time.Time) ([]repository.UsageAggregate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsageByModel", userID, from, to)
+	ret0, _ := ret[0].([]repository.UsageAggregate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsageByModel indicates an expected call of GetUsageByModel.
+func (mr *MockLLMUsageRepositoryIMockRecorder) GetUsageByModel(userID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsageByModel", reflect.TypeOf((*MockLLMUsageRepositoryI)(nil).GetUsageByModel), userID, from, to)
+}
+
+// GetUsageByUser mocks base method.
+func (m *MockLLMUsageRepositoryI) GetUsageByUser(from, to time.Time) ([]repository.UsageAggregate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsageByUser", from, to)
+	ret0, _ := ret[0].([]repository.UsageAggregate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsageByUser indicates an expected call of GetUsageByUser.
+func (mr *MockLLMUsageRepositoryIMockRecorder) GetUsageByUser(from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsageByUser", reflect.TypeOf((*MockLLMUsageRepositoryI)(nil).GetUsageByUser), from, to)
+}
+
+// SumTokensSince mocks base method.
+func (m *MockLLMUsageRepositoryI) SumTokensSince(userID uuid.UUID, since time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumTokensSince", userID, since)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumTokensSince indicates an expected call of SumTokensSince.
+func (mr *MockLLMUsageRepositoryIMockRecorder) SumTokensSince(userID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumTokensSince", reflect.TypeOf((*MockLLMUsageRepositoryI)(nil).SumTokensSince), userID, since)
+}
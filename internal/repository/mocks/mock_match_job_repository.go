@@ -0,0 +1,128 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/match_job_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/match_job_repository.go -destination=internal/repository/mocks/mock_match_job_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "CVMatch/internal/models"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMatchJobRepositoryI is a mock of MatchJobRepositoryI interface.
+type MockMatchJobRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockMatchJobRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockMatchJobRepositoryIMockRecorder is the mock recorder for MockMatchJobRepositoryI.
+type MockMatchJobRepositoryIMockRecorder struct {
+	mock *MockMatchJobRepositoryI
+}
+
+// NewMockMatchJobRepositoryI creates a new mock instance.
+func NewMockMatchJobRepositoryI(ctrl *gomock.Controller) *MockMatchJobRepositoryI {
+	mock := &MockMatchJobRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockMatchJobRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMatchJobRepositoryI) EXPECT() *MockMatchJobRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockMatchJobRepositoryI) Create(job *models.MatchJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockMatchJobRepositoryIMockRecorder) Create(job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMatchJobRepositoryI)(nil).Create), job)
+}
+
+// FailUnfinished mocks base method.
+func (m *MockMatchJobRepositoryI) FailUnfinished(errMsg string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailUnfinished", errMsg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FailUnfinished indicates an expected call of FailUnfinished.
+func (mr *MockMatchJobRepositoryIMockRecorder) FailUnfinished(errMsg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailUnfinished", reflect.TypeOf((*MockMatchJobRepositoryI)(nil).FailUnfinished), errMsg)
+}
+
+// Finish mocks base method.
+func (m *MockMatchJobRepositoryI) Finish(jobID uuid.UUID, status, errMsg string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Finish", jobID, status, errMsg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Finish indicates an expected call of Finish.
+func (mr *MockMatchJobRepositoryIMockRecorder) Finish(jobID, status, errMsg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Finish", reflect.TypeOf((*MockMatchJobRepositoryI)(nil).Finish), jobID, status, errMsg)
+}
+
+// GetByID mocks base method.
+func (m *MockMatchJobRepositoryI) GetByID(userID, jobID uuid.UUID) (*models.MatchJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", userID, jobID)
+	ret0, _ := ret[0].(*models.MatchJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockMatchJobRepositoryIMockRecorder) GetByID(userID, jobID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockMatchJobRepositoryI)(nil).GetByID), userID, jobID)
+}
+
+// Start mocks base method.
+func (m *MockMatchJobRepositoryI) Start(jobID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", jobID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockMatchJobRepositoryIMockRecorder) Start(jobID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockMatchJobRepositoryI)(nil).Start), jobID)
+}
+
+// UpdateProgress mocks base method.
+func (m *MockMatchJobRepositoryI) UpdateProgress(jobID uuid.UUID, completed, failed int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProgress", jobID, completed, failed)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProgress indicates an expected call of UpdateProgress.
+func (mr *MockMatchJobRepositoryIMockRecorder) UpdateProgress(jobID, completed, failed any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProgress", reflect.TypeOf((*MockMatchJobRepositoryI)(nil).UpdateProgress), jobID, completed, failed)
+}
@@ -0,0 +1,144 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/matching_profile_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/matching_profile_repository.go -destination=internal/repository/mocks/mock_matching_profile_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "CVMatch/internal/models"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMatchingProfileRepositoryI is a mock of MatchingProfileRepositoryI interface.
+type MockMatchingProfileRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockMatchingProfileRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockMatchingProfileRepositoryIMockRecorder is the mock recorder for MockMatchingProfileRepositoryI.
+type MockMatchingProfileRepositoryIMockRecorder struct {
+	mock *MockMatchingProfileRepositoryI
+}
+
+// NewMockMatchingProfileRepositoryI creates a new mock instance.
+func NewMockMatchingProfileRepositoryI(ctrl *gomock.Controller) *MockMatchingProfileRepositoryI {
+	mock := &MockMatchingProfileRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockMatchingProfileRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMatchingProfileRepositoryI) EXPECT() *MockMatchingProfileRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockMatchingProfileRepositoryI) Create(profile *models.MatchingProfile) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", profile)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockMatchingProfileRepositoryIMockRecorder) Create(profile any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMatchingProfileRepositoryI)(nil).Create), profile)
+}
+
+// Delete mocks base method.
+func (m *MockMatchingProfileRepositoryI) Delete(userID, profileID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userID, profileID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockMatchingProfileRepositoryIMockRecorder) Delete(userID, profileID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMatchingProfileRepositoryI)(nil).Delete), userID, profileID)
+}
+
+// GetByID mocks base method.
+func (m *MockMatchingProfileRepositoryI) GetByID(userID, profileID uuid.UUID) (*models.MatchingProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", userID, profileID)
+	ret0, _ := ret[0].(*models.MatchingProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockMatchingProfileRepositoryIMockRecorder) GetByID(userID, profileID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockMatchingProfileRepositoryI)(nil).GetByID), userID, profileID)
+}
+
+// GetDefault mocks base method.
+func (m *MockMatchingProfileRepositoryI) GetDefault(userID uuid.UUID) (*models.MatchingProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDefault", userID)
+	ret0, _ := ret[0].(*models.MatchingProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDefault indicates an expected call of GetDefault.
+func (mr *MockMatchingProfileRepositoryIMockRecorder) GetDefault(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefault", reflect.TypeOf((*MockMatchingProfileRepositoryI)(nil).GetDefault), userID)
+}
+
+// List mocks base method.
+func (m *MockMatchingProfileRepositoryI) List(userID uuid.UUID) ([]models.MatchingProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", userID)
+	ret0, _ := ret[0].([]models.MatchingProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockMatchingProfileRepositoryIMockRecorder) List(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockMatchingProfileRepositoryI)(nil).List), userID)
+}
+
+// LoadProfile mocks base method.
+func (m *MockMatchingProfileRepositoryI) LoadProfile(profileID uuid.UUID) (*models.MatchingProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadProfile", profileID)
+	ret0, _ := ret[0].(*models.MatchingProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadProfile indicates an expected call of LoadProfile.
+func (mr *MockMatchingProfileRepositoryIMockRecorder) LoadProfile(profileID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadProfile", reflect.TypeOf((*MockMatchingProfileRepositoryI)(nil).LoadProfile), profileID)
+}
+
+// Update mocks base method.
+func (m *MockMatchingProfileRepositoryI) Update(profile *models.MatchingProfile) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", profile)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockMatchingProfileRepositoryIMockRecorder) Update(profile any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockMatchingProfileRepositoryI)(nil).Update), profile)
+}
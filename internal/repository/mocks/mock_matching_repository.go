@@ -0,0 +1,130 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/matching_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/matching_repository.go -destination=internal/repository/mocks/mock_matching_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "CVMatch/internal/models"
+	repository "CVMatch/internal/repository"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMatchingRepositoryI is a mock of MatchingRepositoryI interface.
+type MockMatchingRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockMatchingRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockMatchingRepositoryIMockRecorder is the mock recorder for MockMatchingRepositoryI.
+type MockMatchingRepositoryIMockRecorder struct {
+	mock *MockMatchingRepositoryI
+}
+
+// NewMockMatchingRepositoryI creates a new mock instance.
+func NewMockMatchingRepositoryI(ctrl *gomock.Controller) *MockMatchingRepositoryI {
+	mock := &MockMatchingRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockMatchingRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMatchingRepositoryI) EXPECT() *MockMatchingRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockMatchingRepositoryI) Create(result *models.MatchingResult) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", result)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockMatchingRepositoryIMockRecorder) Create(result any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMatchingRepositoryI)(nil).Create), result)
+}
+
+// GetMatchByID mocks base method.
+func (m *MockMatchingRepositoryI) GetMatchByID(userID, matchID uuid.UUID) (*models.MatchingResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMatchByID", userID, matchID)
+	ret0, _ := ret[0].(*models.MatchingResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMatchByID indicates an expected call of GetMatchByID.
+func (mr *MockMatchingRepositoryIMockRecorder) GetMatchByID(userID, matchID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMatchByID", reflect.TypeOf((*MockMatchingRepositoryI)(nil).GetMatchByID), userID, matchID)
+}
+
+// ListByJob mocks base method.
+func (m *MockMatchingRepositoryI) ListByJob(jobID uuid.UUID) ([]models.MatchingResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByJob", jobID)
+	ret0, _ := ret[0].([]models.MatchingResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByJob indicates an expected call of ListByJob.
+func (mr *MockMatchingRepositoryIMockRecorder) ListByJob(jobID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByJob", reflect.TypeOf((*MockMatchingRepositoryI)(nil).ListByJob), jobID)
+}
+
+// ListStale mocks base method.
+func (m *MockMatchingRepositoryI) ListStale(after uuid.UUID, limit int) ([]repository.StaleMatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStale", after, limit)
+	ret0, _ := ret[0].([]repository.StaleMatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStale indicates an expected call of ListStale.
+func (mr *MockMatchingRepositoryIMockRecorder) ListStale(after, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStale", reflect.TypeOf((*MockMatchingRepositoryI)(nil).ListStale), after, limit)
+}
+
+// UpdateComputed mocks base method.
+func (m *MockMatchingRepositoryI) UpdateComputed(result *models.MatchingResult) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateComputed", result)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateComputed indicates an expected call of UpdateComputed.
+func (mr *MockMatchingRepositoryIMockRecorder) UpdateComputed(result any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComputed", reflect.TypeOf((*MockMatchingRepositoryI)(nil).UpdateComputed), result)
+}
+
+// UpdateRecommendations mocks base method.
+func (m *MockMatchingRepositoryI) UpdateRecommendations(matchID uuid.UUID, recommendations *models.Recommendations) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRecommendations", matchID, recommendations)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRecommendations indicates an expected call of UpdateRecommendations.
+func (mr *MockMatchingRepositoryIMockRecorder) UpdateRecommendations(matchID, recommendations any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRecommendations", reflect.TypeOf((*MockMatchingRepositoryI)(nil).UpdateRecommendations), matchID, recommendations)
+}
@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/note_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/note_repository.go -destination=internal/repository/mocks/mock_note_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "CVMatch/internal/models"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockNoteRepositoryI is a mock of NoteRepositoryI interface.
+type MockNoteRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockNoteRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockNoteRepositoryIMockRecorder is the mock recorder for MockNoteRepositoryI.
+type MockNoteRepositoryIMockRecorder struct {
+	mock *MockNoteRepositoryI
+}
+
+// NewMockNoteRepositoryI creates a new mock instance.
+func NewMockNoteRepositoryI(ctrl *gomock.Controller) *MockNoteRepositoryI {
+	mock := &MockNoteRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockNoteRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNoteRepositoryI) EXPECT() *MockNoteRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockNoteRepositoryI) Create(note *models.ResumeNote) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", note)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockNoteRepositoryIMockRecorder) Create(note any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockNoteRepositoryI)(nil).Create), note)
+}
+
+// Delete mocks base method.
+func (m *MockNoteRepositoryI) Delete(noteID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", noteID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockNoteRepositoryIMockRecorder) Delete(noteID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockNoteRepositoryI)(nil).Delete), noteID)
+}
+
+// GetByID mocks base method.
+func (m *MockNoteRepositoryI) GetByID(resumeID, noteID uuid.UUID) (*models.ResumeNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", resumeID, noteID)
+	ret0, _ := ret[0].(*models.ResumeNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockNoteRepositoryIMockRecorder) GetByID(resumeID, noteID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockNoteRepositoryI)(nil).GetByID), resumeID, noteID)
+}
+
+// ListByResume mocks base method.
+func (m *MockNoteRepositoryI) ListByResume(resumeID uuid.UUID) ([]models.ResumeNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByResume", resumeID)
+	ret0, _ := ret[0].([]models.ResumeNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByResume indicates an expected call of ListByResume.
+func (mr *MockNoteRepositoryIMockRecorder) ListByResume(resumeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByResume", reflect.TypeOf((*MockNoteRepositoryI)(nil).ListByResume), resumeID)
+}
+
+// UpdateText mocks base method.
+func (m *MockNoteRepositoryI) UpdateText(noteID uuid.UUID, text string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateText", noteID, text)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateText indicates an expected call of UpdateText.
+func (mr *MockNoteRepositoryIMockRecorder) UpdateText(noteID, text any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateText", reflect.TypeOf((*MockNoteRepositoryI)(nil).UpdateText), noteID, text)
+}
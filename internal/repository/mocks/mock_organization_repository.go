@@ -0,0 +1,246 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/organization_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/organization_repository.go -destination=internal/repository/mocks/mock_organization_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "CVMatch/internal/models"
+	repository "CVMatch/internal/repository"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockOrganizationRepositoryI is a mock of OrganizationRepositoryI interface.
+type MockOrganizationRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrganizationRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockOrganizationRepositoryIMockRecorder is the mock recorder for MockOrganizationRepositoryI.
+type MockOrganizationRepositoryIMockRecorder struct {
+	mock *MockOrganizationRepositoryI
+}
+
+// NewMockOrganizationRepositoryI creates a new mock instance.
+func NewMockOrganizationRepositoryI(ctrl *gomock.Controller) *MockOrganizationRepositoryI {
+	mock := &MockOrganizationRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockOrganizationRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrganizationRepositoryI) EXPECT() *MockOrganizationRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// ActiveMember mocks base method.
+func (m *MockOrganizationRepositoryI) ActiveMember(userID uuid.UUID) (*models.OrganizationMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActiveMember", userID)
+	ret0, _ := ret[0].(*models.OrganizationMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActiveMember indicates an expected call of ActiveMember.
+func (mr *MockOrganizationRepositoryIMockRecorder) ActiveMember(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActiveMember", reflect.TypeOf((*MockOrganizationRepositoryI)(nil).ActiveMember), userID)
+}
+
+// AddMember mocks base method.
+func (m *MockOrganizationRepositoryI) AddMember(member *models.OrganizationMember) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMember", member)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *MockOrganizationRepositoryIMockRecorder) AddMember(member any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockOrganizationRepositoryI)(nil).AddMember), member)
+}
+
+// CountOwners mocks base method.
+func (m *MockOrganizationRepositoryI) CountOwners(organizationID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOwners", organizationID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOwners indicates an expected call of CountOwners.
+func (mr *MockOrganizationRepositoryIMockRecorder) CountOwners(organizationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOwners", reflect.TypeOf((*MockOrganizationRepositoryI)(nil).CountOwners), organizationID)
+}
+
+// Create mocks base method.
+func (m *MockOrganizationRepositoryI) Create(organization *models.Organization) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", organization)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOrganizationRepositoryIMockRecorder) Create(organization any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrganizationRepositoryI)(nil).Create), organization)
+}
+
+// DB mocks base method.
+func (m *MockOrganizationRepositoryI) DB() *gorm.DB {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DB")
+	ret0, _ := ret[0].(*gorm.DB)
+	return ret0
+}
+
+// DB indicates an expected call of DB.
+func (mr *MockOrganizationRepositoryIMockRecorder) DB() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DB", reflect.TypeOf((*MockOrganizationRepositoryI)(nil).DB))
+}
+
+// GetByID mocks base method.
+func (m *MockOrganizationRepositoryI) GetByID(organizationID uuid.UUID) (*models.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", organizationID)
+	ret0, _ := ret[0].(*models.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockOrganizationRepositoryIMockRecorder) GetByID(organizationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockOrganizationRepositoryI)(nil).GetByID), organizationID)
+}
+
+// GetMember mocks base method.
+func (m *MockOrganizationRepositoryI) GetMember(organizationID, userID uuid.UUID) (*models.OrganizationMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMember", organizationID, userID)
+	ret0, _ := ret[0].(*models.OrganizationMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMember indicates an expected call of GetMember.
+func (mr *MockOrganizationRepositoryIMockRecorder) GetMember(organizationID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMember", reflect.TypeOf((*MockOrganizationRepositoryI)(nil).GetMember), organizationID, userID)
+}
+
+// ListByUser mocks base method.
+func (m *MockOrganizationRepositoryI) ListByUser(userID uuid.UUID) ([]repository.OrganizationMembership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUser", userID)
+	ret0, _ := ret[0].([]repository.OrganizationMembership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUser indicates an expected call of ListByUser.
+func (mr *MockOrganizationRepositoryIMockRecorder) ListByUser(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*MockOrganizationRepositoryI)(nil).ListByUser), userID)
+}
+
+// ListMembers mocks base method.
+func (m *MockOrganizationRepositoryI) ListMembers(organizationID uuid.UUID) ([]models.OrganizationMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMembers", organizationID)
+	ret0, _ := ret[0].([]models.OrganizationMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMembers indicates an expected call of ListMembers.
+func (mr *MockOrganizationRepositoryIMockRecorder) ListMembers(organizationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMembers", reflect.TypeOf((*MockOrganizationRepositoryI)(nil).ListMembers), organizationID)
+}
+
+// RemoveMember mocks base method.
+func (m *MockOrganizationRepositoryI) RemoveMember(organizationID, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", organizationID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockOrganizationRepositoryIMockRecorder) RemoveMember(organizationID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockOrganizationRepositoryI)(nil).RemoveMember), organizationID, userID)
+}
+
+// ResetActive mocks base method.
+func (m *MockOrganizationRepositoryI) ResetActive(organizationID, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetActive", organizationID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetActive indicates an expected call of ResetActive.
+func (mr *MockOrganizationRepositoryIMockRecorder) ResetActive(organizationID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetActive", reflect.TypeOf((*MockOrganizationRepositoryI)(nil).ResetActive), organizationID, userID)
+}
+
+// SetActive mocks base method.
+func (m *MockOrganizationRepositoryI) SetActive(userID, organizationID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetActive", userID, organizationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetActive indicates an expected call of SetActive.
+func (mr *MockOrganizationRepositoryIMockRecorder) SetActive(userID, organizationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetActive", reflect.TypeOf((*MockOrganizationRepositoryI)(nil).SetActive), userID, organizationID)
+}
+
+// UpdateMemberRole mocks base method.
+func (m *MockOrganizationRepositoryI) UpdateMemberRole(organizationID, userID uuid.UUID, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMemberRole", organizationID, userID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMemberRole indicates an expected call of UpdateMemberRole.
+func (mr *MockOrganizationRepositoryIMockRecorder) UpdateMemberRole(organizationID, userID, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMemberRole", reflect.TypeOf((*MockOrganizationRepositoryI)(nil).UpdateMemberRole), organizationID, userID, role)
+}
+
+// WithTx mocks base method.
+func (m *MockOrganizationRepositoryI) WithTx(tx *gorm.DB) repository.OrganizationRepositoryI {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", tx)
+	ret0, _ := ret[0].(repository.OrganizationRepositoryI)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockOrganizationRepositoryIMockRecorder) WithTx(tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockOrganizationRepositoryI)(nil).WithTx), tx)
+}
@@ -11,6 +11,8 @@ package mocks
 
 import (
 	config "CVMatch/internal/config"
+	parser "CVMatch/internal/parser"
+	context "context"
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
@@ -41,16 +43,16 @@ func (m *MockResumeParserI) EXPECT() *MockResumeParserIMockRecorder {
 }
 
 // ParseResume mocks base method.
-func (m *MockResumeParserI) ParseResume(path string, cfg *config.Config) (string, error) {
+func (m *MockResumeParserI) ParseResume(ctx context.Context, path string, cfg *config.Config) (*parser.ParseResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ParseResume", path, cfg)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "ParseResume", ctx, path, cfg)
+	ret0, _ := ret[0].(*parser.ParseResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ParseResume indicates an expected call of ParseResume.
-func (mr *MockResumeParserIMockRecorder) ParseResume(path, cfg any) *gomock.Call {
+func (mr *MockResumeParserIMockRecorder) ParseResume(ctx, path, cfg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseResume", reflect.TypeOf((*MockResumeParserI)(nil).ParseResume), path, cfg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseResume", reflect.TypeOf((*MockResumeParserI)(nil).ParseResume), ctx, path, cfg)
 }
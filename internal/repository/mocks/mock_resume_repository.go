@@ -127,6 +127,20 @@ func (mr *MockResumeRepositoryIMockRecorder) DeleteResumeFile(resumeID any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteResumeFile", reflect.TypeOf((*MockResumeRepositoryI)(nil).DeleteResumeFile), resumeID)
 }
 
+// DeleteResumeSections mocks base method.
+func (m *MockResumeRepositoryI) DeleteResumeSections(resumeID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteResumeSections", resumeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteResumeSections indicates an expected call of DeleteResumeSections.
+func (mr *MockResumeRepositoryIMockRecorder) DeleteResumeSections(resumeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteResumeSections", reflect.TypeOf((*MockResumeRepositoryI)(nil).DeleteResumeSections), resumeID)
+}
+
 // DeleteSkillFromResume mocks base method.
 func (m *MockResumeRepositoryI) DeleteSkillFromResume(resumeID, skillID uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -199,18 +213,18 @@ func (mr *MockResumeRepositoryIMockRecorder) FirstOrCreateSkill(name any) *gomoc
 }
 
 // GetListRes mocks base method.
-func (m *MockResumeRepositoryI) GetListRes(userID uuid.UUID) (*[]models.Resume, error) {
+func (m *MockResumeRepositoryI) GetListRes(userID uuid.UUID, filter repository.ResumeFilter) (*[]models.Resume, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetListRes", userID)
+	ret := m.ctrl.Call(m, "GetListRes", userID, filter)
 	ret0, _ := ret[0].(*[]models.Resume)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetListRes indicates an expected call of GetListRes.
-func (mr *MockResumeRepositoryIMockRecorder) GetListRes(userID any) *gomock.Call {
+func (mr *MockResumeRepositoryIMockRecorder) GetListRes(userID, filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListRes", reflect.TypeOf((*MockResumeRepositoryI)(nil).GetListRes), userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListRes", reflect.TypeOf((*MockResumeRepositoryI)(nil).GetListRes), userID, filter)
 }
 
 // GetResFileURL mocks base method.
@@ -243,6 +257,21 @@ func (mr *MockResumeRepositoryIMockRecorder) GetResumeByID(userID, resumeID any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResumeByID", reflect.TypeOf((*MockResumeRepositoryI)(nil).GetResumeByID), userID, resumeID)
 }
 
+// GetResumeFile mocks base method.
+func (m *MockResumeRepositoryI) GetResumeFile(resumeID uuid.UUID) (*models.ResumeFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResumeFile", resumeID)
+	ret0, _ := ret[0].(*models.ResumeFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResumeFile indicates an expected call of GetResumeFile.
+func (mr *MockResumeRepositoryIMockRecorder) GetResumeFile(resumeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResumeFile", reflect.TypeOf((*MockResumeRepositoryI)(nil).GetResumeFile), resumeID)
+}
+
 // GetSkillsByResumeID mocks base method.
 func (m *MockResumeRepositoryI) GetSkillsByResumeID(resumeID uuid.UUID) ([]*models.Skill, error) {
 	m.ctrl.T.Helper()
@@ -258,6 +287,49 @@ func (mr *MockResumeRepositoryIMockRecorder) GetSkillsByResumeID(resumeID any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSkillsByResumeID", reflect.TypeOf((*MockResumeRepositoryI)(nil).GetSkillsByResumeID), resumeID)
 }
 
+// LoadResume mocks base method.
+func (m *MockResumeRepositoryI) LoadResume(resumeID uuid.UUID) (*models.Resume, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadResume", resumeID)
+	ret0, _ := ret[0].(*models.Resume)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadResume indicates an expected call of LoadResume.
+func (mr *MockResumeRepositoryIMockRecorder) LoadResume(resumeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadResume", reflect.TypeOf((*MockResumeRepositoryI)(nil).LoadResume), resumeID)
+}
+
+// SetRating mocks base method.
+func (m *MockResumeRepositoryI) SetRating(resumeID uuid.UUID, rating *int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRating", resumeID, rating)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRating indicates an expected call of SetRating.
+func (mr *MockResumeRepositoryIMockRecorder) SetRating(resumeID, rating any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRating", reflect.TypeOf((*MockResumeRepositoryI)(nil).SetRating), resumeID, rating)
+}
+
+// Update mocks base method.
+func (m *MockResumeRepositoryI) Update(resume *models.Resume) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", resume)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockResumeRepositoryIMockRecorder) Update(resume any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockResumeRepositoryI)(nil).Update), resume)
+}
+
 // WithTx mocks base method.
 func (m *MockResumeRepositoryI) WithTx(tx *gorm.DB) repository.ResumeRepositoryI {
 	m.ctrl.T.Helper()
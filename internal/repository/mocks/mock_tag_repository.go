@@ -0,0 +1,159 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/tag_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/tag_repository.go -destination=internal/repository/mocks/mock_tag_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "CVMatch/internal/models"
+	repository "CVMatch/internal/repository"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockTagRepositoryI is a mock of TagRepositoryI interface.
+type MockTagRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockTagRepositoryIMockRecorder is the mock recorder for MockTagRepositoryI.
+type MockTagRepositoryIMockRecorder struct {
+	mock *MockTagRepositoryI
+}
+
+// NewMockTagRepositoryI creates a new mock instance.
+func NewMockTagRepositoryI(ctrl *gomock.Controller) *MockTagRepositoryI {
+	mock := &MockTagRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockTagRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTagRepositoryI) EXPECT() *MockTagRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// AddToResumes mocks base method.
+func (m *MockTagRepositoryI) AddToResumes(resumeIDs, tagIDs []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddToResumes", resumeIDs, tagIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddToResumes indicates an expected call of AddToResumes.
+func (mr *MockTagRepositoryIMockRecorder) AddToResumes(resumeIDs, tagIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToResumes", reflect.TypeOf((*MockTagRepositoryI)(nil).AddToResumes), resumeIDs, tagIDs)
+}
+
+// ClearResume mocks base method.
+func (m *MockTagRepositoryI) ClearResume(resumeID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearResume", resumeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearResume indicates an expected call of ClearResume.
+func (mr *MockTagRepositoryIMockRecorder) ClearResume(resumeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearResume", reflect.TypeOf((*MockTagRepositoryI)(nil).ClearResume), resumeID)
+}
+
+// DB mocks base method.
+func (m *MockTagRepositoryI) DB() *gorm.DB {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DB")
+	ret0, _ := ret[0].(*gorm.DB)
+	return ret0
+}
+
+// DB indicates an expected call of DB.
+func (mr *MockTagRepositoryIMockRecorder) DB() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DB", reflect.TypeOf((*MockTagRepositoryI)(nil).DB))
+}
+
+// FirstOrCreate mocks base method.
+func (m *MockTagRepositoryI) FirstOrCreate(userID uuid.UUID, name string) (*models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FirstOrCreate", userID, name)
+	ret0, _ := ret[0].(*models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FirstOrCreate indicates an expected call of FirstOrCreate.
+func (mr *MockTagRepositoryIMockRecorder) FirstOrCreate(userID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FirstOrCreate", reflect.TypeOf((*MockTagRepositoryI)(nil).FirstOrCreate), userID, name)
+}
+
+// GetByNames mocks base method.
+func (m *MockTagRepositoryI) GetByNames(userID uuid.UUID, names []string) ([]models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByNames", userID, names)
+	ret0, _ := ret[0].([]models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByNames indicates an expected call of GetByNames.
+func (mr *MockTagRepositoryIMockRecorder) GetByNames(userID, names any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByNames", reflect.TypeOf((*MockTagRepositoryI)(nil).GetByNames), userID, names)
+}
+
+// List mocks base method.
+func (m *MockTagRepositoryI) List(userID uuid.UUID) ([]repository.TagCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", userID)
+	ret0, _ := ret[0].([]repository.TagCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockTagRepositoryIMockRecorder) List(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTagRepositoryI)(nil).List), userID)
+}
+
+// RemoveFromResumes mocks base method.
+func (m *MockTagRepositoryI) RemoveFromResumes(resumeIDs, tagIDs []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFromResumes", resumeIDs, tagIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveFromResumes indicates an expected call of RemoveFromResumes.
+func (mr *MockTagRepositoryIMockRecorder) RemoveFromResumes(resumeIDs, tagIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFromResumes", reflect.TypeOf((*MockTagRepositoryI)(nil).RemoveFromResumes), resumeIDs, tagIDs)
+}
+
+// WithTx mocks base method.
+func (m *MockTagRepositoryI) WithTx(tx *gorm.DB) repository.TagRepositoryI {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", tx)
+	ret0, _ := ret[0].(repository.TagRepositoryI)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockTagRepositoryIMockRecorder) WithTx(tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockTagRepositoryI)(nil).WithTx), tx)
+}
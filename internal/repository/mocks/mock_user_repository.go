@@ -13,6 +13,7 @@ import (
 	models "CVMatch/internal/models"
 	reflect "reflect"
 
+	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -54,6 +55,21 @@ func (mr *MockUserRepositoryIMockRecorder) Create(user any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserRepositoryI)(nil).Create), user)
 }
 
+// FindByCalendarToken mocks base method.
+func (m *MockUserRepositoryI) FindByCalendarToken(token string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByCalendarToken", token)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByCalendarToken indicates an expected call of FindByCalendarToken.
+func (mr *MockUserRepositoryIMockRecorder) FindByCalendarToken(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCalendarToken", reflect.TypeOf((*MockUserRepositoryI)(nil).FindByCalendarToken), token)
+}
+
 // FindByEmail mocks base method.
 func (m *MockUserRepositoryI) FindByEmail(email string) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -69,6 +85,21 @@ func (mr *MockUserRepositoryIMockRecorder) FindByEmail(email any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByEmail", reflect.TypeOf((*MockUserRepositoryI)(nil).FindByEmail), email)
 }
 
+// FindByEmails mocks base method.
+func (m *MockUserRepositoryI) FindByEmails(emails []string) ([]models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByEmails", emails)
+	ret0, _ := ret[0].([]models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByEmails indicates an expected call of FindByEmails.
+func (mr *MockUserRepositoryIMockRecorder) FindByEmails(emails any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByEmails", reflect.TypeOf((*MockUserRepositoryI)(nil).FindByEmails), emails)
+}
+
 // FindByID mocks base method.
 func (m *MockUserRepositoryI) FindByID(id string) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -83,3 +114,31 @@ func (mr *MockUserRepositoryIMockRecorder) FindByID(id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockUserRepositoryI)(nil).FindByID), id)
 }
+
+// SetCalendarToken mocks base method.
+func (m *MockUserRepositoryI) SetCalendarToken(id uuid.UUID, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCalendarToken", id, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCalendarToken indicates an expected call of SetCalendarToken.
+func (mr *MockUserRepositoryIMockRecorder) SetCalendarToken(id, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCalendarToken", reflect.TypeOf((*MockUserRepositoryI)(nil).SetCalendarToken), id, token)
+}
+
+// UpdateMonthlyTokenQuota mocks base method.
+func (m *MockUserRepositoryI) UpdateMonthlyTokenQuota(id uuid.UUID, quota *int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMonthlyTokenQuota", id, quota)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMonthlyTokenQuota indicates an expected call of UpdateMonthlyTokenQuota.
+func (mr *MockUserRepositoryIMockRecorder) UpdateMonthlyTokenQuota(id, quota any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMonthlyTokenQuota", reflect.TypeOf((*MockUserRepositoryI)(nil).UpdateMonthlyTokenQuota), id, quota)
+}
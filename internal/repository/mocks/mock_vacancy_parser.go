@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/parser/vacancy.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/parser/vacancy.go -destination=internal/parser/mocks/mock_vacancy_parser.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	config "CVMatch/internal/config"
+	parser "CVMatch/internal/parser"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockVacancyParserI is a mock of VacancyParserI interface.
+type MockVacancyParserI struct {
+	ctrl     *gomock.Controller
+	recorder *MockVacancyParserIMockRecorder
+	isgomock struct{}
+}
+
+// MockVacancyParserIMockRecorder is the mock recorder for MockVacancyParserI.
+type MockVacancyParserIMockRecorder struct {
+	mock *MockVacancyParserI
+}
+
+// NewMockVacancyParserI creates a new mock instance.
+func NewMockVacancyParserI(ctrl *gomock.Controller) *MockVacancyParserI {
+	mock := &MockVacancyParserI{ctrl: ctrl}
+	mock.recorder = &MockVacancyParserIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVacancyParserI) EXPECT() *MockVacancyParserIMockRecorder {
+	return m.recorder
+}
+
+// ParseVacancy mocks base method.
+func (m *MockVacancyParserI) ParseVacancy(ctx context.Context, text string, cfg *config.Config) (*parser.ParseResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseVacancy", ctx, text, cfg)
+	ret0, _ := ret[0].(*parser.ParseResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseVacancy indicates an expected call of ParseVacancy.
+func (mr *MockVacancyParserIMockRecorder) ParseVacancy(ctx, text, cfg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseVacancy", reflect.TypeOf((*MockVacancyParserI)(nil).ParseVacancy), ctx, text, cfg)
+}
@@ -0,0 +1,273 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/vacancy_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/vacancy_repository.go -destination=internal/repository/mocks/mock_vacancy_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "CVMatch/internal/models"
+	repository "CVMatch/internal/repository"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockVacancyRepositoryI is a mock of VacancyRepositoryI interface.
+type MockVacancyRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockVacancyRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockVacancyRepositoryIMockRecorder is the mock recorder for MockVacancyRepositoryI.
+type MockVacancyRepositoryIMockRecorder struct {
+	mock *MockVacancyRepositoryI
+}
+
+// NewMockVacancyRepositoryI creates a new mock instance.
+func NewMockVacancyRepositoryI(ctrl *gomock.Controller) *MockVacancyRepositoryI {
+	mock := &MockVacancyRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockVacancyRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVacancyRepositoryI) EXPECT() *MockVacancyRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// AssociateSkills mocks base method.
+func (m *MockVacancyRepositoryI) AssociateSkills(vacancy *models.Vacancy, requirements []models.VacancySkill) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssociateSkills", vacancy, requirements)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssociateSkills indicates an expected call of AssociateSkills.
+func (mr *MockVacancyRepositoryIMockRecorder) AssociateSkills(vacancy, requirements any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssociateSkills", reflect.TypeOf((*MockVacancyRepositoryI)(nil).AssociateSkills), vacancy, requirements)
+}
+
+// Create mocks base method.
+func (m *MockVacancyRepositoryI) Create(vacancy *models.Vacancy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", vacancy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockVacancyRepositoryIMockRecorder) Create(vacancy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockVacancyRepositoryI)(nil).Create), vacancy)
+}
+
+// DB mocks base method.
+func (m *MockVacancyRepositoryI) DB() *gorm.DB {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DB")
+	ret0, _ := ret[0].(*gorm.DB)
+	return ret0
+}
+
+// DB indicates an expected call of DB.
+func (mr *MockVacancyRepositoryIMockRecorder) DB() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DB", reflect.TypeOf((*MockVacancyRepositoryI)(nil).DB))
+}
+
+// DeleteSkillFromVacancy mocks base method.
+func (m *MockVacancyRepositoryI) DeleteSkillFromVacancy(vacancyID, skillID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSkillFromVacancy", vacancyID, skillID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSkillFromVacancy indicates an expected call of DeleteSkillFromVacancy.
+func (mr *MockVacancyRepositoryIMockRecorder) DeleteSkillFromVacancy(vacancyID, skillID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSkillFromVacancy", reflect.TypeOf((*MockVacancyRepositoryI)(nil).DeleteSkillFromVacancy), vacancyID, skillID)
+}
+
+// DeleteUnusedMatching mocks base method.
+func (m *MockVacancyRepositoryI) DeleteUnusedMatching(vacancyID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUnusedMatching", vacancyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUnusedMatching indicates an expected call of DeleteUnusedMatching.
+func (mr *MockVacancyRepositoryIMockRecorder) DeleteUnusedMatching(vacancyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUnusedMatching", reflect.TypeOf((*MockVacancyRepositoryI)(nil).DeleteUnusedMatching), vacancyID)
+}
+
+// DeleteUnusedSkill mocks base method.
+func (m *MockVacancyRepositoryI) DeleteUnusedSkill(skillID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUnusedSkill", skillID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUnusedSkill indicates an expected call of DeleteUnusedSkill.
+func (mr *MockVacancyRepositoryIMockRecorder) DeleteUnusedSkill(skillID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUnusedSkill", reflect.TypeOf((*MockVacancyRepositoryI)(nil).DeleteUnusedSkill), skillID)
+}
+
+// DeleteVacancy mocks base method.
+func (m *MockVacancyRepositoryI) DeleteVacancy(vacancyID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVacancy", vacancyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVacancy indicates an expected call of DeleteVacancy.
+func (mr *MockVacancyRepositoryIMockRecorder) DeleteVacancy(vacancyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVacancy", reflect.TypeOf((*MockVacancyRepositoryI)(nil).DeleteVacancy), vacancyID)
+}
+
+// FirstOrCreateSkill mocks base method.
+func (m *MockVacancyRepositoryI) FirstOrCreateSkill(name string) (*models.Skill, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FirstOrCreateSkill", name)
+	ret0, _ := ret[0].(*models.Skill)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FirstOrCreateSkill indicates an expected call of FirstOrCreateSkill.
+func (mr *MockVacancyRepositoryIMockRecorder) FirstOrCreateSkill(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FirstOrCreateSkill", reflect.TypeOf((*MockVacancyRepositoryI)(nil).FirstOrCreateSkill), name)
+}
+
+// GetListVacancy mocks base method.
+func (m *MockVacancyRepositoryI) GetListVacancy(userID uuid.UUID, filter repository.VacancyFilter) (*[]models.Vacancy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListVacancy", userID, filter)
+	ret0, _ := ret[0].(*[]models.Vacancy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListVacancy indicates an expected call of GetListVacancy.
+func (mr *MockVacancyRepositoryIMockRecorder) GetListVacancy(userID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListVacancy", reflect.TypeOf((*MockVacancyRepositoryI)(nil).GetListVacancy), userID, filter)
+}
+
+// GetSkillsByVacancyID mocks base method.
+func (m *MockVacancyRepositoryI) GetSkillsByVacancyID(vacancyID uuid.UUID) ([]*models.Skill, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSkillsByVacancyID", vacancyID)
+	ret0, _ := ret[0].([]*models.Skill)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSkillsByVacancyID indicates an expected call of GetSkillsByVacancyID.
+func (mr *MockVacancyRepositoryIMockRecorder) GetSkillsByVacancyID(vacancyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSkillsByVacancyID", reflect.TypeOf((*MockVacancyRepositoryI)(nil).GetSkillsByVacancyID), vacancyID)
+}
+
+// GetVacancyByID mocks base method.
+func (m *MockVacancyRepositoryI) GetVacancyByID(userID, vacancyID uuid.UUID) (*models.Vacancy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVacancyByID", userID, vacancyID)
+	ret0, _ := ret[0].(*models.Vacancy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVacancyByID indicates an expected call of GetVacancyByID.
+func (mr *MockVacancyRepositoryIMockRecorder) GetVacancyByID(userID, vacancyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVacancyByID", reflect.TypeOf((*MockVacancyRepositoryI)(nil).GetVacancyByID), userID, vacancyID)
+}
+
+// LoadVacancy mocks base method.
+func (m *MockVacancyRepositoryI) LoadVacancy(vacancyID uuid.UUID) (*models.Vacancy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadVacancy", vacancyID)
+	ret0, _ := ret[0].(*models.Vacancy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadVacancy indicates an expected call of LoadVacancy.
+func (mr *MockVacancyRepositoryIMockRecorder) LoadVacancy(vacancyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadVacancy", reflect.TypeOf((*MockVacancyRepositoryI)(nil).LoadVacancy), vacancyID)
+}
+
+// SetMatchingProfile mocks base method.
+func (m *MockVacancyRepositoryI) SetMatchingProfile(vacancyID uuid.UUID, profileID *uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMatchingProfile", vacancyID, profileID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMatchingProfile indicates an expected call of SetMatchingProfile.
+func (mr *MockVacancyRepositoryIMockRecorder) SetMatchingProfile(vacancyID, profileID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMatchingProfile", reflect.TypeOf((*MockVacancyRepositoryI)(nil).SetMatchingProfile), vacancyID, profileID)
+}
+
+// SetPipelineStages mocks base method.
+func (m *MockVacancyRepositoryI) SetPipelineStages(vacancyID uuid.UUID, stages []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPipelineStages", vacancyID, stages)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPipelineStages indicates an expected call of SetPipelineStages.
+func (mr *MockVacancyRepositoryIMockRecorder) SetPipelineStages(vacancyID, stages any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPipelineStages", reflect.TypeOf((*MockVacancyRepositoryI)(nil).SetPipelineStages), vacancyID, stages)
+}
+
+// Update mocks base method.
+func (m *MockVacancyRepositoryI) Update(vacancy *models.Vacancy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", vacancy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockVacancyRepositoryIMockRecorder) Update(vacancy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockVacancyRepositoryI)(nil).Update), vacancy)
+}
+
+// WithTx mocks base method.
+func (m *MockVacancyRepositoryI) WithTx(tx *gorm.DB) repository.VacancyRepositoryI {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", tx)
+	ret0, _ := ret[0].(repository.VacancyRepositoryI)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockVacancyRepositoryIMockRecorder) WithTx(tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockVacancyRepositoryI)(nil).WithTx), tx)
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"CVMatch/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type NoteRepository struct {
+	db *gorm.DB
+}
+
+type NoteRepositoryI interface {
+	Create(note *models.ResumeNote) error
+	GetByID(resumeID, noteID uuid.UUID) (*models.ResumeNote, error)
+	ListByResume(resumeID uuid.UUID) ([]models.ResumeNote, error)
+	UpdateText(noteID uuid.UUID, text string) error
+	Delete(noteID uuid.UUID) error
+}
+
+func NewNoteRepository(db *gorm.DB) *NoteRepository {
+	return &NoteRepository{
+		db: db,
+	}
+}
+
+func (r *NoteRepository) Create(note *models.ResumeNote) error {
+	return r.db.Create(note).Error
+}
+
+func (r *NoteRepository) GetByID(resumeID, noteID uuid.UUID) (*models.ResumeNote, error) {
+	var note models.ResumeNote
+	if err := r.db.Preload("Author").Where("id = ? AND resume_id = ?", noteID, resumeID).First(&note).Error; err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+// ListByResume возвращает заметки резюме от старых к новым вместе с авторами
+func (r *NoteRepository) ListByResume(resumeID uuid.UUID) ([]models.ResumeNote, error) {
+	var notes []models.ResumeNote
+	if err := r.db.Preload("Author").Where("resume_id = ?", resumeID).Order("created_at, id").Find(&notes).Error; err != nil {
+		return nil, err
+	}
+	return notes, nil
+}
+
+func (r *NoteRepository) UpdateText(noteID uuid.UUID, text string) error {
+	return r.db.Model(&models.ResumeNote{ID: noteID}).Update("text", text).Error
+}
+
+func (r *NoteRepository) Delete(noteID uuid.UUID) error {
+	return r.db.Delete(&models.ResumeNote{}, "id = ?", noteID).Error
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"CVMatch/internal/models"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNoteRepository_CRUD(t *testing.T) {
+	db := setupResumeTestDB()
+	require.NoError(t, db.AutoMigrate(&models.ResumeNote{}))
+	repo := NewNoteRepository(db)
+
+	author := &models.User{Email: "hr@example.com", Nickname: "Анна", Password: "x"}
+	require.NoError(t, db.Create(author).Error)
+	resumeID := uuid.New()
+
+	first := &models.ResumeNote{ResumeID: resumeID, AuthorID: author.ID, Text: "Созвонились, ждёт оффер"}
+	second := &models.ResumeNote{ResumeID: resumeID, AuthorID: author.ID, Text: "Прислал тестовое"}
+	require.NoError(t, repo.Create(first))
+	require.NoError(t, repo.Create(second))
+
+	notes, err := repo.ListByResume(resumeID)
+	require.NoError(t, err)
+	require.Len(t, notes, 2)
+	require.Equal(t, "Анна", notes[0].Author.Nickname)
+
+	require.NoError(t, repo.UpdateText(first.ID, "Созвонились, оффер отправлен"))
+	got, err := repo.GetByID(resumeID, first.ID)
+	require.NoError(t, err)
+	require.Equal(t, "Созвонились, оффер отправлен", got.Text)
+
+	_, err = repo.GetByID(uuid.New(), first.ID)
+	require.Error(t, err)
+
+	require.NoError(t, repo.Delete(second.ID))
+	notes, err = repo.ListByResume(resumeID)
+	require.NoError(t, err)
+	require.Len(t, notes, 1)
+}
@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"CVMatch/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type OrganizationRepository struct {
+	db *gorm.DB
+}
+
+type OrganizationRepositoryI interface {
+	DB() *gorm.DB
+	WithTx(tx *gorm.DB) OrganizationRepositoryI
+	Create(organization *models.Organization) error
+	GetByID(organizationID uuid.UUID) (*models.Organization, error)
+	ListByUser(userID uuid.UUID) ([]OrganizationMembership, error)
+	AddMember(member *models.OrganizationMember) error
+	GetMember(organizationID, userID uuid.UUID) (*models.OrganizationMember, error)
+	ListMembers(organizationID uuid.UUID) ([]models.OrganizationMember, error)
+	UpdateMemberRole(organizationID, userID uuid.UUID, role string) error
+	RemoveMember(organizationID, userID uuid.UUID) error
+	CountOwners(organizationID uuid.UUID) (int64, error)
+	ActiveMember(userID uuid.UUID) (*models.OrganizationMember, error)
+	SetActive(userID, organizationID uuid.UUID) error
+	ResetActive(organizationID, userID uuid.UUID) error
+}
+
+// OrganizationMembership — организация пользователя с его ролью в ней
+type OrganizationMembership struct {
+	ID       uuid.UUID
+	Name     string
+	Personal bool
+	Role     string
+}
+
+func NewOrganizationRepository(db *gorm.DB) *OrganizationRepository {
+	return &OrganizationRepository{
+		db: db,
+	}
+}
+
+func (r *OrganizationRepository) DB() *gorm.DB {
+	return r.db
+}
+
+func (r *OrganizationRepository) WithTx(tx *gorm.DB) OrganizationRepositoryI {
+	return &OrganizationRepository{db: tx}
+}
+
+func (r *OrganizationRepository) Create(organization *models.Organization) error {
+	return r.db.Create(organization).Error
+}
+
+func (r *OrganizationRepository) GetByID(organizationID uuid.UUID) (*models.Organization, error) {
+	var organization models.Organization
+	if err := r.db.Where("id = ?", organizationID).First(&organization).Error; err != nil {
+		return nil, err
+	}
+	return &organization, nil
+}
+
+// ListByUser возвращает организации пользователя: сначала личное пространство, затем по названию
+func (r *OrganizationRepository) ListByUser(userID uuid.UUID) ([]OrganizationMembership, error) {
+	var memberships []OrganizationMembership
+	if err := r.db.Model(&models.Organization{}).
+		Select("organizations.id, organizations.name, organizations.personal, organization_members.role").
+		Joins("join organization_members on organization_members.organization_id = organizations.id").
+		Where("organization_members.user_id = ?", userID).
+		Order("organizations.personal DESC, organizations.name").Scan(&memberships).Error; err != nil {
+		return nil, err
+	}
+	return memberships, nil
+}
+
+func (r *OrganizationRepository) AddMember(member *models.OrganizationMember) error {
+	return r.db.Create(member).Error
+}
+
+func (r *OrganizationRepository) GetMember(organizationID, userID uuid.UUID) (*models.OrganizationMember, error) {
+	var member models.OrganizationMember
+	if err := r.db.Where("organization_id = ? AND user_id = ?", organizationID, userID).First(&member).Error; err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (r *OrganizationRepository) ListMembers(organizationID uuid.UUID) ([]models.OrganizationMember, error) {
+	var members []models.OrganizationMember
+	if err := r.db.Preload("User").Where("organization_id = ?", organizationID).
+		Order("created_at").Find(&members).Error; err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func (r *OrganizationRepository) UpdateMemberRole(organizationID, userID uuid.UUID, role string) error {
+	res := r.db.Model(&models.OrganizationMember{}).
+		Where("organization_id = ? AND user_id = ?", organizationID, userID).Update("role", role)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *OrganizationRepository) RemoveMember(organizationID, userID uuid.UUID) error {
+	res := r.db.Where("organization_id = ? AND user_id = ?", organizationID, userID).Delete(&models.OrganizationMember{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *OrganizationRepository) CountOwners(organizationID uuid.UUID) (int64, error) {
+	var count int64
+	if err := r.db.Model(&models.OrganizationMember{}).
+		Where("organization_id = ? AND role = ?", organizationID, models.OrgRoleOwner).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// ActiveMember возвращает участие пользователя в его текущем рабочем пространстве
+func (r *OrganizationRepository) ActiveMember(userID uuid.UUID) (*models.OrganizationMember, error) {
+	var member models.OrganizationMember
+	if err := r.db.Where("organization_members.organization_id IN (?)", workspaceOf(r.db, userID)).
+		Where("organization_members.user_id = ?", userID).First(&member).Error; err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (r *OrganizationRepository) SetActive(userID, organizationID uuid.UUID) error {
+	return r.db.Model(&models.User{}).Where("id = ?", userID).Update("active_organization_id", organizationID).Error
+}
+
+// ResetActive возвращает в личное пространство пользователя, который работал в organizationID
+func (r *OrganizationRepository) ResetActive(organizationID, userID uuid.UUID) error {
+	personal := r.db.Model(&models.Organization{}).Select("organizations.id").
+		Joins("join organization_members on organization_members.organization_id = organizations.id").
+		Where("organizations.personal AND organization_members.user_id = ?", userID).Limit(1)
+	return r.db.Model(&models.User{}).Where("id = ? AND active_organization_id = ?", userID, organizationID).
+		Update("active_organization_id", personal).Error
+}
+
+// workspaceOf — подзапрос с текущим рабочим пространством пользователя. Пространство попадает
+// в него, только пока пользователь остаётся его участником, поэтому исключение из организации
+// сразу закрывает доступ к её данным.
+func workspaceOf(db *gorm.DB, userID uuid.UUID) *gorm.DB {
+	return db.Model(&models.OrganizationMember{}).Select("organization_members.organization_id").
+		Joins("join users on users.id = organization_members.user_id AND users.active_organization_id = organization_members.organization_id").
+		Where("organization_members.user_id = ?", userID)
+}
+
+// activeWorkspace возвращает ID текущего рабочего пространства пользователя,
+// gorm.ErrRecordNotFound — если пространство не выбрано или пользователь из него исключён
+func activeWorkspace(db *gorm.DB, userID uuid.UUID) (uuid.UUID, error) {
+	var ids []uuid.UUID
+	if err := workspaceOf(db, userID).Limit(1).Pluck("organization_members.organization_id", &ids).Error; err != nil {
+		return uuid.Nil, err
+	}
+	if len(ids) == 0 {
+		return uuid.Nil, gorm.ErrRecordNotFound
+	}
+	return ids[0], nil
+}
+
+// CreatePersonalWorkspace создаёт пользователю личное пространство, делает его владельцем
+// и выбирает пространство текущим
+func CreatePersonalWorkspace(db *gorm.DB, user *models.User) (*models.Organization, error) {
+	name := user.Nickname
+	if name == "" {
+		name = user.Email
+	}
+	organization := &models.Organization{Name: name, Personal: true}
+	if err := db.Create(organization).Error; err != nil {
+		return nil, err
+	}
+	member := &models.OrganizationMember{OrganizationID: organization.ID, UserID: user.ID, Role: models.OrgRoleOwner}
+	if err := db.Create(member).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Model(&models.User{}).Where("id = ?", user.ID).Update("active_organization_id", organization.ID).Error; err != nil {
+		return nil, err
+	}
+	user.ActiveOrganizationID = &organization.ID
+	return organization, nil
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"CVMatch/internal/models"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// newWorkspaceUser регистрирует пользователя и возвращает его ID и ID личного пространства
+func newWorkspaceUser(t *testing.T, db *gorm.DB) (uuid.UUID, uuid.UUID) {
+	t.Helper()
+	require.NoError(t, db.AutoMigrate(&models.User{}, &models.Organization{}, &models.OrganizationMember{}))
+	user := &models.User{Email: uuid.NewString() + "@example.com", Password: "x"}
+	require.NoError(t, NewUserRepository(db).Create(user))
+	require.NotNil(t, user.ActiveOrganizationID)
+	return user.ID, *user.ActiveOrganizationID
+}
+
+func TestOrganizationRepository_PersonalWorkspace(t *testing.T) {
+	db, _ := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	repo := NewOrganizationRepository(db)
+
+	userID, personalID := newWorkspaceUser(t, db)
+	organization, err := repo.GetByID(personalID)
+	require.NoError(t, err)
+	require.True(t, organization.Personal)
+
+	member, err := repo.ActiveMember(userID)
+	require.NoError(t, err)
+	require.Equal(t, personalID, member.OrganizationID)
+	require.Equal(t, models.OrgRoleOwner, member.Role)
+}
+
+func TestOrganizationRepository_TenantIsolation(t *testing.T) {
+	db := setupResumeTestDB()
+	require.NoError(t, db.AutoMigrate(&models.Vacancy{}, &models.VacancySkill{}))
+	repo := NewOrganizationRepository(db)
+	resumeRepo := NewResumeRepository(db)
+	vacancyRepo := NewVacancyRepository(db)
+
+	ownerID, ownerPersonalID := newWorkspaceUser(t, db)
+	recruiterID, _ := newWorkspaceUser(t, db)
+
+	team := &models.Organization{Name: "Команда"}
+	require.NoError(t, repo.Create(team))
+	require.NoError(t, repo.AddMember(&models.OrganizationMember{OrganizationID: team.ID, UserID: ownerID, Role: models.OrgRoleOwner}))
+	require.NoError(t, repo.AddMember(&models.OrganizationMember{OrganizationID: team.ID, UserID: recruiterID, Role: models.OrgRoleRecruiter}))
+
+	private := &models.Resume{UserID: ownerID, FullName: "Личное"}
+	require.NoError(t, resumeRepo.Create(private))
+	require.Equal(t, ownerPersonalID, private.OrganizationID)
+
+	require.NoError(t, repo.SetActive(ownerID, team.ID))
+	shared := &models.Resume{UserID: ownerID, FullName: "Общее"}
+	require.NoError(t, resumeRepo.Create(shared))
+	require.Equal(t, team.ID, shared.OrganizationID)
+	vacancy := &models.Vacancy{UserID: ownerID, Title: "Go Developer"}
+	require.NoError(t, vacancyRepo.Create(vacancy))
+
+	// Пока коллега работает в личном пространстве, данные команды ему не видны
+	_, err := resumeRepo.GetResumeByID(recruiterID, shared.ID)
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	require.NoError(t, repo.SetActive(recruiterID, team.ID))
+	got, err := resumeRepo.GetResumeByID(recruiterID, shared.ID)
+	require.NoError(t, err)
+	require.Equal(t, "Общее", got.FullName)
+	_, err = vacancyRepo.GetVacancyByID(recruiterID, vacancy.ID)
+	require.NoError(t, err)
+	list, err := resumeRepo.GetListRes(recruiterID, ResumeFilter{})
+	require.NoError(t, err)
+	require.Len(t, *list, 1)
+	// Личное резюме владельца в команду не попадает
+	_, err = resumeRepo.GetResumeByID(recruiterID, private.ID)
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	memberships, err := repo.ListByUser(recruiterID)
+	require.NoError(t, err)
+	require.Len(t, memberships, 2)
+	require.True(t, memberships[0].Personal)
+	require.Equal(t, models.OrgRoleRecruiter, memberships[1].Role)
+
+	owners, err := repo.CountOwners(team.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), owners)
+	require.NoError(t, repo.UpdateMemberRole(team.ID, recruiterID, models.OrgRoleViewer))
+	member, err := repo.ActiveMember(recruiterID)
+	require.NoError(t, err)
+	require.Equal(t, models.OrgRoleViewer, member.Role)
+
+	// Исключённый участник теряет доступ и возвращается в личное пространство
+	require.NoError(t, repo.RemoveMember(team.ID, recruiterID))
+	require.NoError(t, repo.ResetActive(team.ID, recruiterID))
+	_, err = resumeRepo.GetResumeByID(recruiterID, shared.ID)
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	member, err = repo.ActiveMember(recruiterID)
+	require.NoError(t, err)
+	require.NotEqual(t, team.ID, member.OrganizationID)
+
+	members, err := repo.ListMembers(team.ID)
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+	require.Equal(t, ownerID, members[0].UserID)
+	require.ErrorIs(t, repo.RemoveMember(team.ID, recruiterID), gorm.ErrRecordNotFound)
+}
@@ -1,162 +1,282 @@
-package repository
-
-import (
-	"CVMatch/internal/models"
-
-	"github.com/google/uuid"
-	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
-)
-
-type ResumeRepository struct {
-	db *gorm.DB
-}
-
-type ResumeRepositoryI interface {
-	DB() *gorm.DB
-	Create(resume *models.Resume) error
-	CreateFile(file *models.ResumeFile) error
-	GetResumeByID(userID, resumeID uuid.UUID) (*models.Resume, error)
-	GetListRes(userID uuid.UUID) (*[]models.Resume, error)
-	GetResFileURL(id uuid.UUID) (string, error)
-	FirstOrCreateSkill(name string) (*models.Skill, error)
-	WithTx(tx *gorm.DB) ResumeRepositoryI
-	GetSkillsByResumeID(resumeID uuid.UUID) ([]*models.Skill, error)
-	DeleteSkillFromResume(resumeID, skillID uuid.UUID) error
-	DeleteUnusedSkill(skillID uuid.UUID) error
-	AssociateSkills(resume *models.Resume, skills []*models.Skill) error
-	DeleteUnusedEdAndEx(resumeID uuid.UUID) error
-	DeleteUnusedMatching(resumeID uuid.UUID) error
-	DeleteResumeFile(resumeID uuid.UUID) error
-	DeleteResume(resumeID uuid.UUID) error
-}
-
-// Возвращает *gorm.DB для прямого доступа (например, для select по именам)
-func (r *ResumeRepository) DB() *gorm.DB {
-	return r.db
-}
-
-// Ассоциация резюме и скиллов через many2many
-func (r *ResumeRepository) AssociateSkills(resume *models.Resume, skills []*models.Skill) error {
-	var rows []map[string]interface{}
-	for _, skill := range skills {
-		rows = append(rows, map[string]interface{}{
-			"resume_id": resume.ID,
-			"skill_id":  skill.ID,
-		})
-	}
-	return r.db.Table("resume_skills").Clauses(clause.OnConflict{DoNothing: true}).Create(rows).Error
-}
-
-func NewResumeRepository(db *gorm.DB) *ResumeRepository {
-	return &ResumeRepository{
-		db: db,
-	}
-}
-
-func (r *ResumeRepository) Create(resume *models.Resume) error {
-	return r.db.Create(resume).Error
-}
-
-func (r *ResumeRepository) CreateFile(file *models.ResumeFile) error {
-	return r.db.Create(file).Error
-}
-
-func (r *ResumeRepository) GetResumeByID(userID, resumeID uuid.UUID) (*models.Resume, error) {
-	var resume models.Resume
-	if err := r.db.Preload("Skills").Preload("Experience").Preload("Education").Where("id = ? AND user_id = ?", resumeID, userID).First(&resume).Error; err != nil {
-		return nil, err
-	}
-	return &resume, nil
-}
-
-func (r *ResumeRepository) GetListRes(userID uuid.UUID) (*[]models.Resume, error) {
-	var resumes []models.Resume
-	if err := r.db.Where("user_id = ?", userID).Find(&resumes).Error; err != nil {
-		return nil, err
-	}
-	return &resumes, nil
-}
-
-func (r *ResumeRepository) GetResFileURL(id uuid.UUID) (string, error) {
-	var fileURL string
-	if err := r.db.Model(&models.ResumeFile{}).Where("resume_id = ?", id).Select("path").Scan(&fileURL).Error; err != nil {
-		return "", err
-	}
-	return fileURL, nil
-}
-
-func (r *ResumeRepository) FirstOrCreateSkill(name string) (*models.Skill, error) {
-	var skill models.Skill
-	// Ищем скилл среди всех, включая soft-deleted
-	err := r.db.Unscoped().Where("name = ?", name).First(&skill).Error
-	if err == nil {
-		// Если найден и был soft-deleted, восстанавливаем
-		if skill.DeletedAt.Valid {
-			if err := r.db.Unscoped().Model(&skill).Update("deleted_at", nil).Error; err != nil {
-				return nil, err
-			}
-			skill.DeletedAt.Valid = false
-		}
-		return &skill, nil
-	}
-	if err == gorm.ErrRecordNotFound {
-		skill.Name = name
-		if err := r.db.Create(&skill).Error; err != nil {
-			return nil, err
-		}
-		// После создания обязательно получить объект из базы по имени (гарантия ID)
-		if err := r.db.Where("name = ?", name).First(&skill).Error; err != nil {
-			return nil, err
-		}
-		return &skill, nil
-	}
-	return nil, err
-}
-
-func (r *ResumeRepository) WithTx(tx *gorm.DB) ResumeRepositoryI {
-	return &ResumeRepository{db: tx}
-}
-
-func (r *ResumeRepository) GetSkillsByResumeID(resumeID uuid.UUID) ([]*models.Skill, error) {
-	var skills []*models.Skill
-	if err := r.db.Table("resume_skills").Select("skills.*").
-		Joins("join skills on skills.id = resume_skills.skill_id").
-		Where("resume_skills.resume_id = ?", resumeID).Scan(&skills).Error; err != nil {
-		return nil, err
-	}
-	return skills, nil
-}
-
-func (r *ResumeRepository) DeleteSkillFromResume(resumeID, skillID uuid.UUID) error {
-	return r.db.Table("resume_skills").Where("resume_id = ? AND skill_id = ?", resumeID, skillID).Delete(nil).Error
-}
-
-func (r *ResumeRepository) DeleteUnusedSkill(skillID uuid.UUID) error {
-	var count int64
-	if err := r.db.Table("resume_skills").Where("skill_id = ?", skillID).Count(&count).Error; err != nil {
-		return err
-	}
-	if count > 0 {
-		return nil
-	}
-	return r.db.Delete(&models.Skill{}, "id = ?", skillID).Error
-}
-
-func (r *ResumeRepository) DeleteUnusedEdAndEx(resumeID uuid.UUID) error {
-	if err := r.db.Delete(&models.Education{}, "resume_id = ?", resumeID).Error; err != nil {
-		return err
-	}
-	return r.db.Delete(&models.Experience{}, "resume_id = ?", resumeID).Error
-}
-func (r *ResumeRepository) DeleteUnusedMatching(resumeID uuid.UUID) error {
-	return r.db.Delete(&models.MatchingResult{}, "resume_id = ?", resumeID).Error
-}
-
-func (r *ResumeRepository) DeleteResumeFile(resumeID uuid.UUID) error {
-	return r.db.Delete(&models.ResumeFile{}, "resume_id = ?", resumeID).Error
-}
-
-func (r *ResumeRepository) DeleteResume(resumeID uuid.UUID) error {
-	return r.db.Delete(&models.Resume{}, "id = ?", resumeID).Error
-}
+package repository
+
+import (
+	"CVMatch/internal/models"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ResumeRepository struct {
+	db *gorm.DB
+}
+
+type ResumeRepositoryI interface {
+	DB() *gorm.DB
+	Create(resume *models.Resume) error
+	Update(resume *models.Resume) error
+	CreateFile(file *models.ResumeFile) error
+	GetResumeByID(userID, resumeID uuid.UUID) (*models.Resume, error)
+	LoadResume(resumeID uuid.UUID) (*models.Resume, error)
+	GetListRes(userID uuid.UUID, filter ResumeFilter) (*[]models.Resume, error)
+	GetResFileURL(id uuid.UUID) (string, error)
+	GetResumeFile(resumeID uuid.UUID) (*models.ResumeFile, error)
+	FirstOrCreateSkill(name string) (*models.Skill, error)
+	WithTx(tx *gorm.DB) ResumeRepositoryI
+	GetSkillsByResumeID(resumeID uuid.UUID) ([]*models.Skill, error)
+	DeleteSkillFromResume(resumeID, skillID uuid.UUID) error
+	DeleteUnusedSkill(skillID uuid.UUID) error
+	AssociateSkills(resume *models.Resume, skills []*models.Skill) error
+	DeleteUnusedEdAndEx(resumeID uuid.UUID) error
+	DeleteResumeSections(resumeID uuid.UUID) error
+	DeleteUnusedMatching(resumeID uuid.UUID) error
+	DeleteResumeFile(resumeID uuid.UUID) error
+	DeleteResume(resumeID uuid.UUID) error
+	SetRating(resumeID uuid.UUID, rating *int) error
+}
+
+// likeEscaper экранирует спецсимволы LIKE в пользовательском поиске
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// ResumeFilter — параметры фильтрации списка резюме, nil означает отсутствие фильтра
+type ResumeFilter struct {
+	IDs                 []uuid.UUID
+	MinExperienceMonths *int
+	MaxExperienceMonths *int
+	TitleFamily         string
+	Seniority           string
+	NeedsReview         *bool
+	Language            string   // язык документа
+	Query               string   // поиск по извлечённому тексту резюме
+	Tags                []string // резюме должно иметь все перечисленные метки
+	MinRating           *int
+}
+
+// Возвращает *gorm.DB для прямого доступа (например, для select по именам)
+func (r *ResumeRepository) DB() *gorm.DB {
+	return r.db
+}
+
+// Ассоциация резюме и скиллов через many2many
+func (r *ResumeRepository) AssociateSkills(resume *models.Resume, skills []*models.Skill) error {
+	var rows []map[string]interface{}
+	for _, skill := range skills {
+		rows = append(rows, map[string]interface{}{
+			"resume_id": resume.ID,
+			"skill_id":  skill.ID,
+		})
+	}
+	return r.db.Table("resume_skills").Clauses(clause.OnConflict{DoNothing: true}).Create(rows).Error
+}
+
+func NewResumeRepository(db *gorm.DB) *ResumeRepository {
+	return &ResumeRepository{
+		db: db,
+	}
+}
+
+// Create сохраняет резюме в текущем рабочем пространстве загрузившего его пользователя
+func (r *ResumeRepository) Create(resume *models.Resume) error {
+	organizationID, err := activeWorkspace(r.db, resume.UserID)
+	if err != nil {
+		return err
+	}
+	resume.OrganizationID = organizationID
+	return r.db.Create(resume).Error
+}
+
+// Update сохраняет резюме вместе с опытом, образованием и разделами; навыки обновляются отдельно.
+// Прежние строки разделов перед этим удаляются через DeleteUnusedEdAndEx и DeleteResumeSections.
+func (r *ResumeRepository) Update(resume *models.Resume) error {
+	return r.db.Omit("User", "Skills", "Tags", "File").Save(resume).Error
+}
+
+func (r *ResumeRepository) CreateFile(file *models.ResumeFile) error {
+	return r.db.Create(file).Error
+}
+
+// GetResumeByID возвращает резюме из текущего рабочего пространства пользователя
+func (r *ResumeRepository) GetResumeByID(userID, resumeID uuid.UUID) (*models.Resume, error) {
+	return r.getResume(r.db.Where("id = ? AND organization_id IN (?)", resumeID, workspaceOf(r.db, userID)))
+}
+
+// LoadResume возвращает резюме без проверки рабочего пространства. Только для фоновых задач,
+// которые проверили доступ, когда их ставили в очередь.
+func (r *ResumeRepository) LoadResume(resumeID uuid.UUID) (*models.Resume, error) {
+	return r.getResume(r.db.Where("id = ?", resumeID))
+}
+
+func (r *ResumeRepository) getResume(query *gorm.DB) (*models.Resume, error) {
+	var resume models.Resume
+	if err := query.Preload("Skills").Preload("Experience").Preload("Education").
+		Preload("Languages").Preload("Certifications").Preload("Projects").Preload("Links").Preload("Tags").First(&resume).Error; err != nil {
+		return nil, err
+	}
+	return &resume, nil
+}
+
+func (r *ResumeRepository) GetListRes(userID uuid.UUID, filter ResumeFilter) (*[]models.Resume, error) {
+	var resumes []models.Resume
+	query := r.db.Preload("Tags").Where("organization_id IN (?)", workspaceOf(r.db, userID))
+	if len(filter.IDs) > 0 {
+		query = query.Where("id IN ?", filter.IDs)
+	}
+	if filter.MinExperienceMonths != nil {
+		query = query.Where("experience_months >= ?", *filter.MinExperienceMonths)
+	}
+	if filter.MaxExperienceMonths != nil {
+		query = query.Where("experience_months <= ?", *filter.MaxExperienceMonths)
+	}
+	if filter.TitleFamily != "" {
+		query = query.Where("title_family = ?", filter.TitleFamily)
+	}
+	if filter.Seniority != "" {
+		query = query.Where("seniority = ?", filter.Seniority)
+	}
+	if filter.NeedsReview != nil {
+		query = query.Where("needs_review = ?", *filter.NeedsReview)
+	}
+	if filter.Language != "" {
+		query = query.Where("language = ?", filter.Language)
+	}
+	if q := strings.TrimSpace(filter.Query); q != "" {
+		pattern := "%" + likeEscaper.Replace(strings.ToLower(q)) + "%"
+		query = query.Where("id IN (?)", r.db.Model(&models.ResumeFile{}).Select("resume_id").
+			Where(`LOWER(raw_text) LIKE ? ESCAPE '\'`, pattern))
+	}
+	if len(filter.Tags) > 0 {
+		query = query.Where("id IN (?)", r.db.Table("resume_tags").Select("resume_tags.resume_id").
+			Joins("join tags on tags.id = resume_tags.tag_id").
+			Where("tags.organization_id IN (?) AND tags.name IN ?", workspaceOf(r.db, userID), filter.Tags).
+			Group("resume_tags.resume_id").Having("COUNT(*) = ?", len(filter.Tags)))
+	}
+	if filter.MinRating != nil {
+		query = query.Where("rating >= ?", *filter.MinRating)
+	}
+	if err := query.Find(&resumes).Error; err != nil {
+		return nil, err
+	}
+	return &resumes, nil
+}
+
+func (r *ResumeRepository) GetResFileURL(id uuid.UUID) (string, error) {
+	var fileURL string
+	if err := r.db.Model(&models.ResumeFile{}).Where("resume_id = ?", id).Select("path").Scan(&fileURL).Error; err != nil {
+		return "", err
+	}
+	return fileURL, nil
+}
+
+// GetResumeFile возвращает файл резюме вместе с извлечённым текстом
+func (r *ResumeRepository) GetResumeFile(resumeID uuid.UUID) (*models.ResumeFile, error) {
+	var file models.ResumeFile
+	if err := r.db.Where("resume_id = ?", resumeID).First(&file).Error; err != nil {
+		return nil, err
+	}
+	return &file, nil
+}
+
+func (r *ResumeRepository) FirstOrCreateSkill(name string) (*models.Skill, error) {
+	return firstOrCreateSkill(r.db, name)
+}
+
+// firstOrCreateSkill — общий для резюме и вакансий поиск навыка по имени
+func firstOrCreateSkill(db *gorm.DB, name string) (*models.Skill, error) {
+	var skill models.Skill
+	// Ищем скилл среди всех, включая soft-deleted
+	err := db.Unscoped().Where("name = ?", name).First(&skill).Error
+	if err == nil {
+		// Если найден и был soft-deleted, восстанавливаем
+		if skill.DeletedAt.Valid {
+			if err := db.Unscoped().Model(&skill).Update("deleted_at", nil).Error; err != nil {
+				return nil, err
+			}
+			skill.DeletedAt.Valid = false
+		}
+		return &skill, nil
+	}
+	if err == gorm.ErrRecordNotFound {
+		skill.Name = name
+		if err := db.Create(&skill).Error; err != nil {
+			return nil, err
+		}
+		// После создания обязательно получить объект из базы по имени (гарантия ID)
+		if err := db.Where("name = ?", name).First(&skill).Error; err != nil {
+			return nil, err
+		}
+		return &skill, nil
+	}
+	return nil, err
+}
+
+func (r *ResumeRepository) WithTx(tx *gorm.DB) ResumeRepositoryI {
+	return &ResumeRepository{db: tx}
+}
+
+func (r *ResumeRepository) GetSkillsByResumeID(resumeID uuid.UUID) ([]*models.Skill, error) {
+	var skills []*models.Skill
+	if err := r.db.Table("resume_skills").Select("skills.*").
+		Joins("join skills on skills.id = resume_skills.skill_id").
+		Where("resume_skills.resume_id = ?", resumeID).Scan(&skills).Error; err != nil {
+		return nil, err
+	}
+	return skills, nil
+}
+
+func (r *ResumeRepository) DeleteSkillFromResume(resumeID, skillID uuid.UUID) error {
+	return r.db.Table("resume_skills").Where("resume_id = ? AND skill_id = ?", resumeID, skillID).Delete(nil).Error
+}
+
+func (r *ResumeRepository) DeleteUnusedSkill(skillID uuid.UUID) error {
+	return deleteUnusedSkill(r.db, skillID)
+}
+
+// deleteUnusedSkill удаляет навык, если на него не ссылаются ни резюме, ни вакансии
+func deleteUnusedSkill(db *gorm.DB, skillID uuid.UUID) error {
+	for _, table := range []string{"resume_skills", "vacancy_skills"} {
+		var count int64
+		if err := db.Table(table).Where("skill_id = ?", skillID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+	}
+	return db.Delete(&models.Skill{}, "id = ?", skillID).Error
+}
+
+func (r *ResumeRepository) DeleteUnusedEdAndEx(resumeID uuid.UUID) error {
+	if err := r.db.Delete(&models.Education{}, "resume_id = ?", resumeID).Error; err != nil {
+		return err
+	}
+	return r.db.Delete(&models.Experience{}, "resume_id = ?", resumeID).Error
+}
+
+// DeleteResumeSections удаляет языки, сертификаты, проекты и ссылки резюме
+func (r *ResumeRepository) DeleteResumeSections(resumeID uuid.UUID) error {
+	for _, section := range []interface{}{&models.ResumeLanguage{}, &models.Certification{}, &models.Project{}, &models.ResumeLink{}} {
+		if err := r.db.Delete(section, "resume_id = ?", resumeID).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ResumeRepository) DeleteUnusedMatching(resumeID uuid.UUID) error {
+	return r.db.Delete(&models.MatchingResult{}, "resume_id = ?", resumeID).Error
+}
+
+func (r *ResumeRepository) DeleteResumeFile(resumeID uuid.UUID) error {
+	return r.db.Delete(&models.ResumeFile{}, "resume_id = ?", resumeID).Error
+}
+
+func (r *ResumeRepository) DeleteResume(resumeID uuid.UUID) error {
+	return r.db.Delete(&models.Resume{}, "id = ?", resumeID).Error
+}
+
+// SetRating выставляет оценку резюме, nil снимает её
+func (r *ResumeRepository) SetRating(resumeID uuid.UUID, rating *int) error {
+	return r.db.Model(&models.Resume{ID: resumeID}).Update("rating", rating).Error
+}
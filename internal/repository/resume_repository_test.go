@@ -14,14 +14,15 @@ import (
 
 func setupResumeTestDB() *gorm.DB {
 	db, _ := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
-	db.AutoMigrate(&models.User{}, &models.Resume{}, &models.Skill{}, &models.ResumeFile{}, &models.Experience{}, &models.Education{})
+	db.AutoMigrate(&models.User{}, &models.Resume{}, &models.Skill{}, &models.ResumeFile{}, &models.Experience{}, &models.Education{},
+		&models.ResumeLanguage{}, &models.Certification{}, &models.Project{}, &models.ResumeLink{})
 	return db
 }
 
 func TestResumeRepository_CreateAndGetResumeByID(t *testing.T) {
 	db := setupResumeTestDB()
 	repo := NewResumeRepository(db)
-	userID := uuid.New()
+	userID, _ := newWorkspaceUser(t, db)
 	resume := &models.Resume{
 		ID:        uuid.New(),
 		UserID:    userID,
@@ -40,20 +41,104 @@ func TestResumeRepository_CreateAndGetResumeByID(t *testing.T) {
 	require.Equal(t, resume.Email, got.Email)
 }
 
+func TestResumeRepository_ExtendedSections(t *testing.T) {
+	db := setupResumeTestDB()
+	repo := NewResumeRepository(db)
+	userID, _ := newWorkspaceUser(t, db)
+	salary := 200000
+	resume := &models.Resume{
+		UserID:         userID,
+		FullName:       "Test User",
+		DesiredSalary:  &salary,
+		SalaryCurrency: "RUB",
+		Languages:      []models.ResumeLanguage{{Name: "English", Level: "B2"}},
+		Links:          []models.ResumeLink{{Type: "github", URL: "https://github.com/test"}},
+	}
+	require.NoError(t, repo.Create(resume))
+
+	got, err := repo.GetResumeByID(userID, resume.ID)
+	require.NoError(t, err)
+	require.Len(t, got.Languages, 1)
+	require.Len(t, got.Links, 1)
+	require.Equal(t, 200000, *got.DesiredSalary)
+
+	require.NoError(t, repo.DeleteResumeSections(resume.ID))
+	got, err = repo.GetResumeByID(userID, resume.ID)
+	require.NoError(t, err)
+	require.Empty(t, got.Languages)
+	require.Empty(t, got.Links)
+}
+
+func TestResumeRepository_Update(t *testing.T) {
+	db := setupResumeTestDB()
+	repo := NewResumeRepository(db)
+	userID, _ := newWorkspaceUser(t, db)
+	resume := &models.Resume{
+		UserID:     userID,
+		FullName:   "Test User",
+		Experience: []models.Experience{{Company: "Old", Position: "Developer"}},
+	}
+	require.NoError(t, repo.Create(resume))
+
+	require.NoError(t, repo.DeleteUnusedEdAndEx(resume.ID))
+	resume.FullName = "Updated User"
+	resume.Version = 2
+	resume.Experience = []models.Experience{{Company: "New", Position: "Senior Developer"}}
+	resume.Links = []models.ResumeLink{{Type: "github", URL: "https://github.com/test"}}
+	require.NoError(t, repo.Update(resume))
+
+	got, err := repo.GetResumeByID(userID, resume.ID)
+	require.NoError(t, err)
+	require.Equal(t, "Updated User", got.FullName)
+	require.Equal(t, 2, got.Version)
+	require.Len(t, got.Experience, 1)
+	require.Equal(t, "New", got.Experience[0].Company)
+	require.Len(t, got.Links, 1)
+}
+
 func TestResumeRepository_GetListRes(t *testing.T) {
 	db := setupResumeTestDB()
 	repo := NewResumeRepository(db)
-	userID := uuid.New()
+	userID, _ := newWorkspaceUser(t, db)
 	resume1 := &models.Resume{ID: uuid.New(), UserID: userID, FullName: "User1", CreatedAt: time.Now()}
 	resume2 := &models.Resume{ID: uuid.New(), UserID: userID, FullName: "User2", CreatedAt: time.Now()}
 	_ = repo.Create(resume1)
 	_ = repo.Create(resume2)
 
-	list, err := repo.GetListRes(userID)
+	list, err := repo.GetListRes(userID, ResumeFilter{})
 	require.NoError(t, err)
 	require.Len(t, *list, 2)
 }
 
+func TestResumeRepository_GetListRes_ExperienceFilter(t *testing.T) {
+	db := setupResumeTestDB()
+	repo := NewResumeRepository(db)
+	userID, _ := newWorkspaceUser(t, db)
+	_ = repo.Create(&models.Resume{UserID: userID, FullName: "Junior", ExperienceMonths: 6})
+	_ = repo.Create(&models.Resume{UserID: userID, FullName: "Middle", ExperienceMonths: 30})
+	_ = repo.Create(&models.Resume{UserID: userID, FullName: "Senior", ExperienceMonths: 80})
+
+	minMonths, maxMonths := 12, 60
+	list, err := repo.GetListRes(userID, ResumeFilter{MinExperienceMonths: &minMonths, MaxExperienceMonths: &maxMonths})
+	require.NoError(t, err)
+	require.Len(t, *list, 1)
+	require.Equal(t, "Middle", (*list)[0].FullName)
+}
+
+func TestResumeRepository_GetListRes_NeedsReviewFilter(t *testing.T) {
+	db := setupResumeTestDB()
+	repo := NewResumeRepository(db)
+	userID, _ := newWorkspaceUser(t, db)
+	_ = repo.Create(&models.Resume{UserID: userID, FullName: "Reviewed"})
+	_ = repo.Create(&models.Resume{UserID: userID, FullName: "Doubtful", NeedsReview: true})
+
+	needsReview := true
+	list, err := repo.GetListRes(userID, ResumeFilter{NeedsReview: &needsReview})
+	require.NoError(t, err)
+	require.Len(t, *list, 1)
+	require.Equal(t, "Doubtful", (*list)[0].FullName)
+}
+
 func TestResumeRepository_GetResFileURL(t *testing.T) {
 	db := setupResumeTestDB()
 	repo := NewResumeRepository(db)
@@ -67,6 +152,59 @@ func TestResumeRepository_GetResFileURL(t *testing.T) {
 	require.Equal(t, "./uploads/test.pdf", url)
 }
 
+func TestResumeRepository_GetListRes_LanguageFilter(t *testing.T) {
+	db := setupResumeTestDB()
+	repo := NewResumeRepository(db)
+	userID, _ := newWorkspaceUser(t, db)
+	_ = repo.Create(&models.Resume{UserID: userID, FullName: "Иван", Language: "ru"})
+	_ = repo.Create(&models.Resume{UserID: userID, FullName: "John", Language: "en"})
+
+	list, err := repo.GetListRes(userID, ResumeFilter{Language: "en"})
+	require.NoError(t, err)
+	require.Len(t, *list, 1)
+	require.Equal(t, "John", (*list)[0].FullName)
+}
+
+func TestResumeRepository_GetListRes_QueryFilter(t *testing.T) {
+	db := setupResumeTestDB()
+	repo := NewResumeRepository(db)
+	userID, _ := newWorkspaceUser(t, db)
+	goDev := &models.Resume{UserID: userID, FullName: "Go Dev"}
+	pyDev := &models.Resume{UserID: userID, FullName: "Python Dev"}
+	_ = repo.Create(goDev)
+	_ = repo.Create(pyDev)
+	_ = repo.CreateFile(&models.ResumeFile{ResumeID: goDev.ID, Path: "a.pdf", RawText: "Опыт: Golang, Kubernetes, 100% покрытие"})
+	_ = repo.CreateFile(&models.ResumeFile{ResumeID: pyDev.ID, Path: "b.pdf", RawText: "Опыт: Python, Django"})
+
+	list, err := repo.GetListRes(userID, ResumeFilter{Query: "kubernetes"})
+	require.NoError(t, err)
+	require.Len(t, *list, 1)
+	require.Equal(t, "Go Dev", (*list)[0].FullName)
+
+	// Спецсимволы LIKE ищутся буквально
+	list, err = repo.GetListRes(userID, ResumeFilter{Query: "100%"})
+	require.NoError(t, err)
+	require.Len(t, *list, 1)
+	list, err = repo.GetListRes(userID, ResumeFilter{Query: "%"})
+	require.NoError(t, err)
+	require.Len(t, *list, 1)
+}
+
+func TestResumeRepository_GetResumeFile(t *testing.T) {
+	db := setupResumeTestDB()
+	repo := NewResumeRepository(db)
+	resumeID := uuid.New()
+	_ = repo.CreateFile(&models.ResumeFile{ResumeID: resumeID, Path: "a.pdf", RawText: "text", PageCount: 2, Language: "ru"})
+
+	file, err := repo.GetResumeFile(resumeID)
+	require.NoError(t, err)
+	require.Equal(t, "text", file.RawText)
+	require.Equal(t, 2, file.PageCount)
+
+	_, err = repo.GetResumeFile(uuid.New())
+	require.Error(t, err)
+}
+
 func TestResumeRepository_FirstOrCreateSkill(t *testing.T) {
 	db := setupResumeTestDB()
 	repo := NewResumeRepository(db)
@@ -80,3 +218,51 @@ func TestResumeRepository_FirstOrCreateSkill(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, skill.ID, skill2.ID)
 }
+
+func TestResumeRepository_GetListRes_TagsAndRatingFilter(t *testing.T) {
+	db := setupResumeTestDB()
+	repo := NewResumeRepository(db)
+	tagRepo := NewTagRepository(db)
+	userID, _ := newWorkspaceUser(t, db)
+	strong := &models.Resume{UserID: userID, FullName: "Strong"}
+	weak := &models.Resume{UserID: userID, FullName: "Weak"}
+	_ = repo.Create(strong)
+	_ = repo.Create(weak)
+
+	golang, err := tagRepo.FirstOrCreate(userID, "golang")
+	require.NoError(t, err)
+	shortlist, err := tagRepo.FirstOrCreate(userID, "shortlist")
+	require.NoError(t, err)
+	require.NoError(t, tagRepo.AddToResumes([]uuid.UUID{strong.ID, weak.ID}, []uuid.UUID{golang.ID}))
+	require.NoError(t, tagRepo.AddToResumes([]uuid.UUID{strong.ID}, []uuid.UUID{shortlist.ID}))
+	five, two := 5, 2
+	require.NoError(t, repo.SetRating(strong.ID, &five))
+	require.NoError(t, repo.SetRating(weak.ID, &two))
+
+	list, err := repo.GetListRes(userID, ResumeFilter{Tags: []string{"golang"}})
+	require.NoError(t, err)
+	require.Len(t, *list, 2)
+
+	list, err = repo.GetListRes(userID, ResumeFilter{Tags: []string{"golang", "shortlist"}})
+	require.NoError(t, err)
+	require.Len(t, *list, 1)
+	require.Equal(t, "Strong", (*list)[0].FullName)
+	require.Len(t, (*list)[0].Tags, 2)
+
+	minRating := 3
+	list, err = repo.GetListRes(userID, ResumeFilter{MinRating: &minRating})
+	require.NoError(t, err)
+	require.Len(t, *list, 1)
+	require.Equal(t, "Strong", (*list)[0].FullName)
+
+	// Чужие метки с тем же именем не учитываются
+	list, err = repo.GetListRes(uuid.New(), ResumeFilter{Tags: []string{"golang"}})
+	require.NoError(t, err)
+	require.Empty(t, *list)
+
+	require.NoError(t, repo.SetRating(weak.ID, nil))
+	got, err := repo.GetResumeByID(userID, weak.ID)
+	require.NoError(t, err)
+	require.Nil(t, got.Rating)
+	require.Len(t, got.Tags, 1)
+}
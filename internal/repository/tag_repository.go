@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"CVMatch/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TagRepository struct {
+	db *gorm.DB
+}
+
+type TagRepositoryI interface {
+	DB() *gorm.DB
+	WithTx(tx *gorm.DB) TagRepositoryI
+	FirstOrCreate(userID uuid.UUID, name string) (*models.Tag, error)
+	GetByNames(userID uuid.UUID, names []string) ([]models.Tag, error)
+	List(userID uuid.UUID) ([]TagCount, error)
+	AddToResumes(resumeIDs, tagIDs []uuid.UUID) error
+	RemoveFromResumes(resumeIDs, tagIDs []uuid.UUID) error
+	ClearResume(resumeID uuid.UUID) error
+}
+
+// TagCount — метка и число неудалённых резюме с ней
+type TagCount struct {
+	Name    string
+	Resumes int64
+}
+
+func NewTagRepository(db *gorm.DB) *TagRepository {
+	return &TagRepository{
+		db: db,
+	}
+}
+
+func (r *TagRepository) DB() *gorm.DB {
+	return r.db
+}
+
+func (r *TagRepository) WithTx(tx *gorm.DB) TagRepositoryI {
+	return &TagRepository{db: tx}
+}
+
+// FirstOrCreate возвращает метку текущего рабочего пространства пользователя, создавая её при необходимости
+func (r *TagRepository) FirstOrCreate(userID uuid.UUID, name string) (*models.Tag, error) {
+	organizationID, err := activeWorkspace(r.db, userID)
+	if err != nil {
+		return nil, err
+	}
+	tag := models.Tag{OrganizationID: organizationID, UserID: userID, Name: name}
+	if err := r.db.Where("organization_id = ? AND name = ?", organizationID, name).FirstOrCreate(&tag).Error; err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+func (r *TagRepository) GetByNames(userID uuid.UUID, names []string) ([]models.Tag, error) {
+	var tags []models.Tag
+	if err := r.db.Where("organization_id IN (?) AND name IN ?", workspaceOf(r.db, userID), names).Find(&tags).Error; err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// List возвращает метки текущего рабочего пространства пользователя по алфавиту; метки без резюме тоже попадают в список
+func (r *TagRepository) List(userID uuid.UUID) ([]TagCount, error) {
+	var counts []TagCount
+	if err := r.db.Model(&models.Tag{}).Select("tags.name AS name, COUNT(resumes.id) AS resumes").
+		Joins("left join resume_tags on resume_tags.tag_id = tags.id").
+		Joins("left join resumes on resumes.id = resume_tags.resume_id AND resumes.deleted_at IS NULL").
+		Where("tags.organization_id IN (?)", workspaceOf(r.db, userID)).
+		Group("tags.name").Order("tags.name").Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// AddToResumes вешает каждую метку на каждое резюме, уже существующие связи пропускаются
+func (r *TagRepository) AddToResumes(resumeIDs, tagIDs []uuid.UUID) error {
+	var rows []map[string]interface{}
+	for _, resumeID := range resumeIDs {
+		for _, tagID := range tagIDs {
+			rows = append(rows, map[string]interface{}{
+				"resume_id": resumeID,
+				"tag_id":    tagID,
+			})
+		}
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	return r.db.Table("resume_tags").Clauses(clause.OnConflict{DoNothing: true}).Create(rows).Error
+}
+
+func (r *TagRepository) RemoveFromResumes(resumeIDs, tagIDs []uuid.UUID) error {
+	if len(resumeIDs) == 0 || len(tagIDs) == 0 {
+		return nil
+	}
+	return r.db.Table("resume_tags").Where("resume_id IN ? AND tag_id IN ?", resumeIDs, tagIDs).Delete(nil).Error
+}
+
+// ClearResume снимает с резюме все метки
+func (r *TagRepository) ClearResume(resumeID uuid.UUID) error {
+	return r.db.Table("resume_tags").Where("resume_id = ?", resumeID).Delete(nil).Error
+}
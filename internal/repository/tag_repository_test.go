@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"CVMatch/internal/models"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagRepository_AddRemoveAndList(t *testing.T) {
+	db := setupResumeTestDB()
+	require.NoError(t, db.AutoMigrate(&models.Tag{}))
+	repo := NewTagRepository(db)
+
+	userID, _ := newWorkspaceUser(t, db)
+	first := &models.Resume{UserID: userID, FullName: "Иван"}
+	second := &models.Resume{UserID: userID, FullName: "Пётр"}
+	require.NoError(t, db.Create(first).Error)
+	require.NoError(t, db.Create(second).Error)
+
+	backend, err := repo.FirstOrCreate(userID, "backend")
+	require.NoError(t, err)
+	again, err := repo.FirstOrCreate(userID, "backend")
+	require.NoError(t, err)
+	require.Equal(t, backend.ID, again.ID)
+	remote, err := repo.FirstOrCreate(userID, "remote")
+	require.NoError(t, err)
+	// Метка с тем же именем в другом пространстве — отдельная метка
+	otherID, _ := newWorkspaceUser(t, db)
+	_, err = repo.FirstOrCreate(otherID, "backend")
+	require.NoError(t, err)
+
+	both := []uuid.UUID{first.ID, second.ID}
+	require.NoError(t, repo.AddToResumes(both, []uuid.UUID{backend.ID, remote.ID}))
+	// Повторное добавление не дублирует связи
+	require.NoError(t, repo.AddToResumes(both, []uuid.UUID{backend.ID}))
+	require.NoError(t, repo.RemoveFromResumes([]uuid.UUID{second.ID}, []uuid.UUID{remote.ID}))
+
+	counts, err := repo.List(userID)
+	require.NoError(t, err)
+	require.Equal(t, []TagCount{{Name: "backend", Resumes: 2}, {Name: "remote", Resumes: 1}}, counts)
+
+	tags, err := repo.GetByNames(userID, []string{"remote", "missing"})
+	require.NoError(t, err)
+	require.Len(t, tags, 1)
+	require.Equal(t, remote.ID, tags[0].ID)
+
+	// Удалённые резюме не считаются, метка без резюме остаётся в списке
+	require.NoError(t, db.Delete(first).Error)
+	require.NoError(t, repo.ClearResume(second.ID))
+	counts, err = repo.List(userID)
+	require.NoError(t, err)
+	require.Equal(t, []TagCount{{Name: "backend", Resumes: 0}, {Name: "remote", Resumes: 0}}, counts)
+}
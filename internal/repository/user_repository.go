@@ -1,43 +1,105 @@
-package repository
-
-import (
-	"CVMatch/internal/models"
-
-	"gorm.io/gorm"
-)
-
-type UserRepository struct {
-	db *gorm.DB
-}
-
-func NewUserRepository(db *gorm.DB) *UserRepository {
-	return &UserRepository{
-		db: db,
-	}
-}
-
-type UserRepositoryI interface {
-	Create(user *models.User) error
-	FindByEmail(email string) (*models.User, error)
-	FindByID(id string) (*models.User, error)
-}
-
-func (r *UserRepository) Create(user *models.User) error {
-	return r.db.Create(user).Error
-}
-
-func (r *UserRepository) FindByEmail(email string) (*models.User, error) {
-	var user models.User
-	if err := r.db.Where("email = ?", email).First(&user).Error; err != nil {
-		return nil, err
-	}
-	return &user, nil
-}
-
-func (r *UserRepository) FindByID(id string) (*models.User, error) {
-	var user models.User
-	if err := r.db.Where("id = ?", id).First(&user).Error; err != nil {
-		return nil, err
-	}
-	return &user, nil
-}
+package repository
+
+import (
+	"CVMatch/internal/models"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type UserRepository struct {
+	db *gorm.DB
+}
+
+func NewUserRepository(db *gorm.DB) *UserRepository {
+	return &UserRepository{
+		db: db,
+	}
+}
+
+type UserRepositoryI interface {
+	Create(user *models.User) error
+	FindByEmail(email string) (*models.User, error)
+	FindByID(id string) (*models.User, error)
+	UpdateMonthlyTokenQuota(id uuid.UUID, quota *int64) error
+	FindByEmails(emails []string) ([]models.User, error)
+	FindByCalendarToken(token string) (*models.User, error)
+	SetCalendarToken(id uuid.UUID, token string) error
+}
+
+// Create сохраняет пользователя вместе с его личным рабочим пространством
+func (r *UserRepository) Create(user *models.User) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		_, err := CreatePersonalWorkspace(tx, user)
+		return err
+	})
+}
+
+func (r *UserRepository) FindByEmail(email string) (*models.User, error) {
+	var user models.User
+	if err := r.db.Where("email = ?", email).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *UserRepository) FindByID(id string) (*models.User, error) {
+	var user models.User
+	if err := r.db.Where("id = ?", id).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// UpdateMonthlyTokenQuota задаёт квоту пользователя; nil возвращает квоту по умолчанию
+func (r *UserRepository) UpdateMonthlyTokenQuota(id uuid.UUID, quota *int64) error {
+	res := r.db.Model(&models.User{}).Where("id = ?", id).Update("monthly_token_quota", quota)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// FindByEmails ищет пользователей по адресам без учёта регистра: при регистрации адрес
+// сохраняется как введён
+func (r *UserRepository) FindByEmails(emails []string) ([]models.User, error) {
+	var users []models.User
+	if len(emails) == 0 {
+		return users, nil
+	}
+	lower := make([]string, 0, len(emails))
+	for _, email := range emails {
+		lower = append(lower, strings.ToLower(email))
+	}
+	if err := r.db.Where("LOWER(email) IN ?", lower).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (r *UserRepository) FindByCalendarToken(token string) (*models.User, error) {
+	var user models.User
+	if err := r.db.Where("calendar_token = ?", token).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// SetCalendarToken выдаёт пользователю новую ссылку на календарь, старая перестаёт работать
+func (r *UserRepository) SetCalendarToken(id uuid.UUID, token string) error {
+	res := r.db.Model(&models.User{}).Where("id = ?", id).Update("calendar_token", token)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -11,7 +11,7 @@ import (
 
 func setupTestDB() *gorm.DB {
 	db, _ := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
-	db.AutoMigrate(&models.Resume{}, &models.Skill{}, &models.ResumeFile{})
+	db.AutoMigrate(&models.Resume{}, &models.Skill{}, &models.ResumeFile{}, &models.Organization{}, &models.OrganizationMember{})
 	return db
 }
 func TestUserRepository_CreateAndFind(t *testing.T) {
@@ -34,3 +34,34 @@ func TestUserRepository_CreateAndFind(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, user.ID, foundByID.ID)
 }
+
+func TestUserRepository_CalendarTokenAndEmails(t *testing.T) {
+	db := setupTestDB()
+	repo := NewUserRepository(db)
+
+	anna := &models.User{Email: "anna@example.com", Password: "password"}
+	oleg := &models.User{Email: "Oleg.Petrov@Example.com", Password: "password"}
+	require.NoError(t, repo.Create(anna))
+	require.NoError(t, repo.Create(oleg))
+
+	users, err := repo.FindByEmails([]string{"anna@example.com", "nobody@example.com"})
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	require.Equal(t, anna.ID, users[0].ID)
+
+	// Адрес с заглавными буквами находится по адресу в нижнем регистре
+	users, err = repo.FindByEmails([]string{"oleg.petrov@example.com"})
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	require.Equal(t, oleg.ID, users[0].ID)
+
+	require.NoError(t, repo.SetCalendarToken(anna.ID, "secret"))
+	found, err := repo.FindByCalendarToken("secret")
+	require.NoError(t, err)
+	require.Equal(t, anna.ID, found.ID)
+
+	// Новый токен заменяет прежний
+	require.NoError(t, repo.SetCalendarToken(anna.ID, "rotated"))
+	_, err = repo.FindByCalendarToken("secret")
+	require.Error(t, err)
+}
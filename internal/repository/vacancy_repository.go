@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"CVMatch/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type VacancyRepository struct {
+	db *gorm.DB
+}
+
+type VacancyRepositoryI interface {
+	DB() *gorm.DB
+	WithTx(tx *gorm.DB) VacancyRepositoryI
+	Create(vacancy *models.Vacancy) error
+	Update(vacancy *models.Vacancy) error
+	GetVacancyByID(userID, vacancyID uuid.UUID) (*models.Vacancy, error)
+	LoadVacancy(vacancyID uuid.UUID) (*models.Vacancy, error)
+	GetListVacancy(userID uuid.UUID, filter VacancyFilter) (*[]models.Vacancy, error)
+	FirstOrCreateSkill(name string) (*models.Skill, error)
+	AssociateSkills(vacancy *models.Vacancy, requirements []models.VacancySkill) error
+	GetSkillsByVacancyID(vacancyID uuid.UUID) ([]*models.Skill, error)
+	DeleteSkillFromVacancy(vacancyID, skillID uuid.UUID) error
+	DeleteUnusedSkill(skillID uuid.UUID) error
+	DeleteUnusedMatching(vacancyID uuid.UUID) error
+	DeleteVacancy(vacancyID uuid.UUID) error
+	SetMatchingProfile(vacancyID uuid.UUID, profileID *uuid.UUID) error
+	SetPipelineStages(vacancyID uuid.UUID, stages []string) error
+}
+
+// VacancyFilter — параметры фильтрации списка вакансий
+type VacancyFilter struct {
+	IDs         []uuid.UUID
+	TitleFamily string
+	Seniority   string
+}
+
+func NewVacancyRepository(db *gorm.DB) *VacancyRepository {
+	return &VacancyRepository{
+		db: db,
+	}
+}
+
+func (r *VacancyRepository) DB() *gorm.DB {
+	return r.db
+}
+
+func (r *VacancyRepository) WithTx(tx *gorm.DB) VacancyRepositoryI {
+	return &VacancyRepository{db: tx}
+}
+
+// Create сохраняет вакансию в текущем рабочем пространстве её автора
+func (r *VacancyRepository) Create(vacancy *models.Vacancy) error {
+	organizationID, err := activeWorkspace(r.db, vacancy.UserID)
+	if err != nil {
+		return err
+	}
+	vacancy.OrganizationID = organizationID
+	return r.db.Create(vacancy).Error
+}
+
+// Update сохраняет поля вакансии; навыки и требования обновляются отдельно
+func (r *VacancyRepository) Update(vacancy *models.Vacancy) error {
+	return r.db.Omit(clause.Associations).Save(vacancy).Error
+}
+
+// GetVacancyByID возвращает вакансию из текущего рабочего пространства пользователя
+func (r *VacancyRepository) GetVacancyByID(userID, vacancyID uuid.UUID) (*models.Vacancy, error) {
+	return r.getVacancy(r.db.Where("id = ? AND organization_id IN (?)", vacancyID, workspaceOf(r.db, userID)))
+}
+
+// LoadVacancy возвращает вакансию без проверки рабочего пространства. Только для фоновых задач,
+// которые проверили доступ, когда их ставили в очередь.
+func (r *VacancyRepository) LoadVacancy(vacancyID uuid.UUID) (*models.Vacancy, error) {
+	return r.getVacancy(r.db.Where("id = ?", vacancyID))
+}
+
+func (r *VacancyRepository) getVacancy(query *gorm.DB) (*models.Vacancy, error) {
+	var vacancy models.Vacancy
+	if err := query.Preload("Skills").Preload("Requirements").First(&vacancy).Error; err != nil {
+		return nil, err
+	}
+	return &vacancy, nil
+}
+
+func (r *VacancyRepository) GetListVacancy(userID uuid.UUID, filter VacancyFilter) (*[]models.Vacancy, error) {
+	var vacancies []models.Vacancy
+	query := r.db.Where("organization_id IN (?)", workspaceOf(r.db, userID))
+	if len(filter.IDs) > 0 {
+		query = query.Where("id IN ?", filter.IDs)
+	}
+	if filter.TitleFamily != "" {
+		query = query.Where("title_family = ?", filter.TitleFamily)
+	}
+	if filter.Seniority != "" {
+		query = query.Where("seniority = ?", filter.Seniority)
+	}
+	if err := query.Order("created_at DESC").Find(&vacancies).Error; err != nil {
+		return nil, err
+	}
+	return &vacancies, nil
+}
+
+func (r *VacancyRepository) FirstOrCreateSkill(name string) (*models.Skill, error) {
+	return firstOrCreateSkill(r.db, name)
+}
+
+// Ассоциация вакансии и скиллов через many2many; строки связи хранят тип требования, вес и минимальный стаж
+func (r *VacancyRepository) AssociateSkills(vacancy *models.Vacancy, requirements []models.VacancySkill) error {
+	for i := range requirements {
+		requirements[i].VacancyID = vacancy.ID
+	}
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&requirements).Error
+}
+
+func (r *VacancyRepository) GetSkillsByVacancyID(vacancyID uuid.UUID) ([]*models.Skill, error) {
+	var skills []*models.Skill
+	if err := r.db.Table("vacancy_skills").Select("skills.*").
+		Joins("join skills on skills.id = vacancy_skills.skill_id").
+		Where("vacancy_skills.vacancy_id = ?", vacancyID).Scan(&skills).Error; err != nil {
+		return nil, err
+	}
+	return skills, nil
+}
+
+func (r *VacancyRepository) DeleteSkillFromVacancy(vacancyID, skillID uuid.UUID) error {
+	return r.db.Table("vacancy_skills").Where("vacancy_id = ? AND skill_id = ?", vacancyID, skillID).Delete(nil).Error
+}
+
+func (r *VacancyRepository) DeleteUnusedSkill(skillID uuid.UUID) error {
+	return deleteUnusedSkill(r.db, skillID)
+}
+
+func (r *VacancyRepository) DeleteUnusedMatching(vacancyID uuid.UUID) error {
+	return r.db.Delete(&models.MatchingResult{}, "vacancy_id = ?", vacancyID).Error
+}
+
+func (r *VacancyRepository) DeleteVacancy(vacancyID uuid.UUID) error {
+	return r.db.Delete(&models.Vacancy{}, "id = ?", vacancyID).Error
+}
+
+// SetMatchingProfile привязывает к вакансии профиль сравнения; nil возвращает профиль по умолчанию.
+// Версия вакансии растёт: результаты сравнения с ней надо пересчитать по новому профилю.
+func (r *VacancyRepository) SetMatchingProfile(vacancyID uuid.UUID, profileID *uuid.UUID) error {
+	return r.db.Model(&models.Vacancy{}).Where("id = ?", vacancyID).Updates(map[string]interface{}{
+		"matching_profile_id": profileID,
+		"version":             gorm.Expr("version + 1"),
+	}).Error
+}
+
+// SetPipelineStages сохраняет этапы отбора вакансии; nil возвращает этапы по умолчанию
+func (r *VacancyRepository) SetPipelineStages(vacancyID uuid.UUID, stages []string) error {
+	return r.db.Model(&models.Vacancy{ID: vacancyID}).Select("PipelineStages").
+		Updates(&models.Vacancy{PipelineStages: stages}).Error
+}
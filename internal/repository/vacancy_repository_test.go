@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"CVMatch/internal/models"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupVacancyTestDB() *gorm.DB {
+	db, _ := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	db.AutoMigrate(&models.User{}, &models.Resume{}, &models.Skill{}, &models.Vacancy{}, &models.VacancySkill{}, &models.MatchingResult{})
+	return db
+}
+
+func TestVacancyRepository_CreateAndGetVacancyByID(t *testing.T) {
+	db := setupVacancyTestDB()
+	repo := NewVacancyRepository(db)
+	userID, _ := newWorkspaceUser(t, db)
+	vacancy := &models.Vacancy{UserID: userID, Title: "Go Developer", Seniority: "middle"}
+	require.NoError(t, repo.Create(vacancy))
+
+	skill, err := repo.FirstOrCreateSkill("Go")
+	require.NoError(t, err)
+	minYears := 3.0
+	require.NoError(t, repo.AssociateSkills(vacancy, []models.VacancySkill{
+		{SkillID: skill.ID, Requirement: models.SkillRequired, Weight: 2, MinYears: &minYears},
+	}))
+
+	got, err := repo.GetVacancyByID(userID, vacancy.ID)
+	require.NoError(t, err)
+	require.Equal(t, "Go Developer", got.Title)
+	require.Len(t, got.Skills, 1)
+	require.Len(t, got.Requirements, 1)
+	require.Equal(t, skill.ID, got.Requirements[0].SkillID)
+	require.Equal(t, models.SkillRequired, got.Requirements[0].Requirement)
+	require.Equal(t, 2.0, got.Requirements[0].Weight)
+	require.Equal(t, 3.0, *got.Requirements[0].MinYears)
+
+	skills, err := repo.GetSkillsByVacancyID(vacancy.ID)
+	require.NoError(t, err)
+	require.Len(t, skills, 1)
+
+	_, err = repo.GetVacancyByID(uuid.New(), vacancy.ID)
+	require.Error(t, err)
+}
+
+func TestVacancyRepository_GetListVacancy_Filter(t *testing.T) {
+	db := setupVacancyTestDB()
+	repo := NewVacancyRepository(db)
+	userID, _ := newWorkspaceUser(t, db)
+	_ = repo.Create(&models.Vacancy{UserID: userID, Title: "Go", TitleFamily: "backend", Seniority: "senior"})
+	_ = repo.Create(&models.Vacancy{UserID: userID, Title: "React", TitleFamily: "frontend", Seniority: "senior"})
+	_ = repo.Create(&models.Vacancy{UserID: userID, Title: "Java", TitleFamily: "backend", Seniority: "junior"})
+
+	list, err := repo.GetListVacancy(userID, VacancyFilter{TitleFamily: "backend", Seniority: "senior"})
+	require.NoError(t, err)
+	require.Len(t, *list, 1)
+	require.Equal(t, "Go", (*list)[0].Title)
+}
+
+func TestVacancyRepository_DeleteUnusedSkill_KeepsResumeSkill(t *testing.T) {
+	db := setupVacancyTestDB()
+	vacancyRepo := NewVacancyRepository(db)
+	resumeRepo := NewResumeRepository(db)
+
+	userID, _ := newWorkspaceUser(t, db)
+	resume := &models.Resume{UserID: userID, FullName: "Test"}
+	require.NoError(t, resumeRepo.Create(resume))
+	skill, err := resumeRepo.FirstOrCreateSkill("Go")
+	require.NoError(t, err)
+	require.NoError(t, resumeRepo.AssociateSkills(resume, []*models.Skill{skill}))
+
+	require.NoError(t, vacancyRepo.DeleteUnusedSkill(skill.ID))
+	var found models.Skill
+	require.NoError(t, db.First(&found, "id = ?", skill.ID).Error)
+}
+
+func TestVacancyRepository_UpdateAndSetMatchingProfile_BumpVersion(t *testing.T) {
+	db := setupVacancyTestDB()
+	repo := NewVacancyRepository(db)
+	userID, _ := newWorkspaceUser(t, db)
+	vacancy := &models.Vacancy{UserID: userID, Title: "Go Developer", Version: 1}
+	require.NoError(t, repo.Create(vacancy))
+
+	vacancy.Title = "Senior Go Developer"
+	vacancy.Version = 2
+	require.NoError(t, repo.Update(vacancy))
+
+	profileID := uuid.New()
+	require.NoError(t, repo.SetMatchingProfile(vacancy.ID, &profileID))
+
+	got, err := repo.GetVacancyByID(userID, vacancy.ID)
+	require.NoError(t, err)
+	require.Equal(t, "Senior Go Developer", got.Title)
+	require.Equal(t, profileID, *got.MatchingProfileID)
+	require.Equal(t, 3, got.Version)
+}
+
+func TestVacancyRepository_SetPipelineStages(t *testing.T) {
+	db := setupVacancyTestDB()
+	repo := NewVacancyRepository(db)
+	userID, _ := newWorkspaceUser(t, db)
+	vacancy := &models.Vacancy{UserID: userID, Title: "Go Developer"}
+	require.NoError(t, repo.Create(vacancy))
+
+	got, err := repo.GetVacancyByID(userID, vacancy.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.DefaultPipelineStages, got.Stages())
+
+	require.NoError(t, repo.SetPipelineStages(vacancy.ID, []string{"new", "test_task", "hired"}))
+	got, err = repo.GetVacancyByID(userID, vacancy.ID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"new", "test_task", "hired"}, got.Stages())
+
+	require.NoError(t, repo.SetPipelineStages(vacancy.ID, nil))
+	got, err = repo.GetVacancyByID(userID, vacancy.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.DefaultPipelineStages, got.Stages())
+}
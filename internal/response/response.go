@@ -1,67 +1,657 @@
-package response
-
-import "time"
-
-type ErrorResponse struct {
-	Error string `json:"error"`
-}
-
-type UserRegisterResponse struct {
-	ID       string `json:"id"`
-	Nickname string `json:"nickname"`
-	Email    string `json:"email"`
-}
-
-type SuccessResponse struct {
-	Message string `json:"message"`
-}
-
-type TokenResponse struct {
-	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
-}
-
-type UserProfileResponse struct {
-	ID       string `json:"id"`
-	Nickname string `json:"nickname"`
-	Email    string `json:"email"`
-}
-
-type ParsedResumeDTO struct {
-	ID         string          `json:"id"`
-	FullName   string          `json:"full_name"`
-	Email      string          `json:"email"`
-	Phone      string          `json:"phone"`
-	Location   string          `json:"location"`
-	Skills     []string        `json:"skills"`
-	Experience []ExperienceDTO `json:"experience"`
-	Education  []EducationDTO  `json:"education"`
-	FileURL    string          `json:"file_url"`
-}
-
-type ExperienceDTO struct {
-	Company     string `json:"company"`
-	Position    string `json:"position"`
-	StartDate   string `json:"start_date"`
-	EndDate     string `json:"end_date"`
-	Description string `json:"description"`
-}
-
-type EducationDTO struct {
-	Institution string `json:"institution"`
-	Degree      string `json:"degree"`
-	Field       string `json:"field"`
-	StartDate   string `json:"start_date"`
-	EndDate     string `json:"end_date"`
-}
-
-type ResumeListItemDTO struct {
-	ID        string    `json:"id"`
-	FullName  string    `json:"full_name"`
-	FileURL   string    `json:"file_url"`
-	CreatedAt time.Time `json:"created_at"`
-}
-
-type ResumeListDTO struct {
-	Resumes []*ResumeListItemDTO `json:"resumes"`
-}
+package response
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+type UserRegisterResponse struct {
+	ID       string `json:"id"`
+	Nickname string `json:"nickname"`
+	Email    string `json:"email"`
+}
+
+type SuccessResponse struct {
+	Message string `json:"message"`
+}
+
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+type UserProfileResponse struct {
+	ID       string `json:"id"`
+	Nickname string `json:"nickname"`
+	Email    string `json:"email"`
+}
+
+type ParsedResumeDTO struct {
+	ID         string          `json:"id"`
+	FullName   string          `json:"full_name"`
+	Email      string          `json:"email"`
+	Phone      string          `json:"phone"`
+	Location   string          `json:"location"`
+	Skills     []string        `json:"skills"`
+	Experience []ExperienceDTO `json:"experience"`
+	Education  []EducationDTO  `json:"education"`
+	FileURL    string          `json:"file_url"`
+
+	Languages      []LanguageDTO      `json:"languages"`
+	Certifications []CertificationDTO `json:"certifications"`
+	Projects       []ProjectDTO       `json:"projects"`
+	Links          []LinkDTO          `json:"links"`
+	Salary         *SalaryDTO         `json:"salary"`
+	Relocation     *bool              `json:"relocation"`
+	Remote         string             `json:"remote"`
+
+	Confidence  ConfidenceMap `json:"confidence"`
+	NeedsReview bool          `json:"needs_review"`
+
+	Language        string `json:"language"`
+	ContentLanguage string `json:"content_language"`
+	PromptVersion   string `json:"prompt_version"`
+	Model           string `json:"model"`
+
+	ExperienceMonths  int     `json:"experience_months"`
+	YearsOfExperience float64 `json:"years_of_experience"`
+	TitleFamily       string  `json:"title_family"`
+	Seniority         string  `json:"seniority"`
+	Version           int     `json:"version"`
+
+	Tags   []string `json:"tags"`
+	Rating *int     `json:"rating"`
+}
+
+type ExperienceDTO struct {
+	Company     string `json:"company"`
+	Position    string `json:"position"`
+	StartDate   string `json:"start_date"`
+	EndDate     string `json:"end_date"`
+	Description string `json:"description"`
+	Months      int    `json:"months"`
+	IsCurrent   bool   `json:"is_current"`
+}
+
+type EducationDTO struct {
+	Institution string `json:"institution"`
+	Degree      string `json:"degree"`
+	Field       string `json:"field"`
+	StartDate   string `json:"start_date"`
+	EndDate     string `json:"end_date"`
+}
+
+type LanguageDTO struct {
+	Name  string `json:"name"`
+	Level string `json:"level"`
+}
+
+type CertificationDTO struct {
+	Name   string `json:"name"`
+	Issuer string `json:"issuer"`
+	Date   string `json:"date"`
+	URL    string `json:"url"`
+}
+
+type ProjectDTO struct {
+	Name         string   `json:"name"`
+	Description  string   `json:"description"`
+	URL          string   `json:"url"`
+	Technologies []string `json:"technologies"`
+}
+
+type LinkDTO struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+type SalaryDTO struct {
+	Amount   *int   `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// UnmarshalJSON допускает сумму строкой ("200 000", "от 150000"), как её иногда возвращает LLM
+func (s *SalaryDTO) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Amount   json.RawMessage `json:"amount"`
+		Currency string          `json:"currency"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	s.Currency = raw.Currency
+	s.Amount = parseAmount(raw.Amount)
+	return nil
+}
+
+// parseAmount читает сумму числом или строкой; нераспознанное значение даёт nil
+func parseAmount(raw json.RawMessage) *int {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	var number float64
+	if err := json.Unmarshal(raw, &number); err == nil {
+		amount := int(number)
+		return &amount
+	}
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		digits := strings.Map(func(r rune) rune {
+			if unicode.IsDigit(r) {
+				return r
+			}
+			return -1
+		}, text)
+		if amount, err := strconv.Atoi(digits); err == nil {
+			return &amount
+		}
+	}
+	return nil
+}
+
+// ConfidenceMap — уверенность по полям резюме от 0 до 1
+type ConfidenceMap map[string]float64
+
+// UnmarshalJSON пропускает нечисловые значения отдельных полей в самооценке LLM;
+// самооценка не объектом — ошибка разбора
+func (m *ConfidenceMap) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	result := make(ConfidenceMap, len(raw))
+	for field, value := range raw {
+		var number float64
+		if err := json.Unmarshal(value, &number); err == nil {
+			result[field] = number
+			continue
+		}
+		var text string
+		if err := json.Unmarshal(value, &text); err == nil {
+			if number, err := strconv.ParseFloat(strings.TrimSpace(text), 64); err == nil {
+				result[field] = number
+			}
+		}
+	}
+	*m = result
+	return nil
+}
+
+type ResumeListItemDTO struct {
+	ID                string    `json:"id"`
+	FullName          string    `json:"full_name"`
+	FileURL           string    `json:"file_url"`
+	YearsOfExperience float64   `json:"years_of_experience"`
+	TitleFamily       string    `json:"title_family"`
+	Seniority         string    `json:"seniority"`
+	NeedsReview       bool      `json:"needs_review"`
+	Language          string    `json:"language"`
+	Tags              []string  `json:"tags"`
+	Rating            *int      `json:"rating"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+type ResumeTextDTO struct {
+	ResumeID         string `json:"resume_id"`
+	Text             string `json:"text"`
+	PageCount        int    `json:"page_count"`
+	Language         string `json:"language"`
+	ExtractionMethod string `json:"extraction_method"`
+}
+
+// ATSReportDTO — проверка резюме на удобство для ATS: оценка от 0 до 100 и замечания, самые серьёзные первыми.
+// layout_checked=false — файл резюме недоступен, и проверен только извлечённый текст.
+type ATSReportDTO struct {
+	ResumeID      string           `json:"resume_id"`
+	Score         int              `json:"score"`
+	Pages         int              `json:"pages"`
+	Words         int              `json:"words"`
+	LayoutChecked bool             `json:"layout_checked"`
+	Findings      []ATSFindingDTO  `json:"findings"`
+	Keywords      []KeywordStatDTO `json:"keywords"`
+}
+
+type ATSFindingDTO struct {
+	Code       string `json:"code"`
+	Severity   string `json:"severity"` // critical, warning, info
+	Message    string `json:"message"`
+	Suggestion string `json:"suggestion"`
+}
+
+type KeywordStatDTO struct {
+	Keyword string  `json:"keyword"`
+	Count   int     `json:"count"`
+	Density float64 `json:"density"` // доля среди слов резюме, %
+}
+
+type ResumeListDTO struct {
+	Resumes []*ResumeListItemDTO `json:"resumes"`
+}
+
+type VacancyDTO struct {
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Location    string   `json:"location"`
+	TitleFamily string   `json:"title_family"`
+	Seniority   string   `json:"seniority"`
+	Skills      []string `json:"skills"`
+	// SkillRequirements — навыки с типом требования; при создании дополняют Skills
+	SkillRequirements []SkillRequirementDTO `json:"skill_requirements"`
+
+	MinExperienceYears *float64        `json:"min_experience_years"`
+	MaxExperienceYears *float64        `json:"max_experience_years"`
+	Salary             *SalaryRangeDTO `json:"salary"`
+	EmploymentType     string          `json:"employment_type"`
+	Remote             string          `json:"remote"`
+	MatchingProfileID  *string         `json:"matching_profile_id"` // null — профиль пользователя по умолчанию
+	Version            int             `json:"version"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SkillRequirementDTO — навык вакансии: обязательный (required) или желательный (preferred),
+// с весом в оценке и минимальным стажем в годах
+type SkillRequirementDTO struct {
+	Name        string   `json:"name" binding:"required"`
+	Requirement string   `json:"requirement" binding:"omitempty,oneof=required preferred"`
+	Weight      float64  `json:"weight" binding:"omitempty,gt=0,lte=10"`
+	MinYears    *float64 `json:"min_years" binding:"omitempty,min=0"`
+}
+
+// SalaryRangeDTO — вилка зарплаты в месяц
+type SalaryRangeDTO struct {
+	From     *int   `json:"from"`
+	To       *int   `json:"to"`
+	Currency string `json:"currency"`
+}
+
+// UnmarshalJSON допускает границы строкой ("200 000", "от 150000"), как их иногда возвращает LLM
+func (s *SalaryRangeDTO) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		From     json.RawMessage `json:"from"`
+		To       json.RawMessage `json:"to"`
+		Currency string          `json:"currency"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	s.From = parseAmount(raw.From)
+	s.To = parseAmount(raw.To)
+	s.Currency = raw.Currency
+	return nil
+}
+
+// VacancyDraftDTO — черновик вакансии, извлечённый из текста объявления.
+// Поля совпадают с запросом создания вакансии, так что черновик можно сохранить как есть.
+type VacancyDraftDTO struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Location    string `json:"location"`
+	TitleFamily string `json:"title_family"`
+	Seniority   string `json:"seniority"`
+	// Skills — все навыки вакансии: обязательные и желательные
+	Skills          []string `json:"skills"`
+	RequiredSkills  []string `json:"required_skills"`
+	PreferredSkills []string `json:"preferred_skills"`
+
+	MinExperienceYears *float64        `json:"min_experience_years"`
+	MaxExperienceYears *float64        `json:"max_experience_years"`
+	Salary             *SalaryRangeDTO `json:"salary"`
+	EmploymentType     string          `json:"employment_type"`
+	Remote             string          `json:"remote"`
+
+	Language      string `json:"language"`
+	PromptVersion string `json:"prompt_version"`
+	Model         string `json:"model"`
+}
+
+type VacancyListDTO struct {
+	Vacancies []*VacancyDTO `json:"vacancies"`
+}
+
+type MatchResultDTO struct {
+	ID              string              `json:"id"`
+	ResumeID        string              `json:"resume_id"`
+	VacancyID       string              `json:"vacancy_id"`
+	Score           float64             `json:"score"`
+	Knockout        bool                `json:"knockout"` // сработало правило отсечения профиля, оценка ограничена
+	NoData          bool                `json:"no_data"`  // ни один компонент не удалось оценить, оценка 0
+	MatchedSkills   []string            `json:"matched_skills"`
+	UnmatchedSkills []string            `json:"unmatched_skills"`
+	MissingRequired []string            `json:"missing_required"`
+	Components      []ScoreComponentDTO `json:"components"`
+	SkillBreakdown  []SkillMatchDTO     `json:"skill_breakdown"`
+	Profile         *MatchingProfileDTO `json:"profile,omitempty"` // профиль сравнения на момент расчёта
+	ResumeVersion   int                 `json:"resume_version"`    // версия резюме, по которой посчитан результат
+	VacancyVersion  int                 `json:"vacancy_version"`   // версия вакансии, по которой посчитан результат
+	Stale           bool                `json:"stale"`             // резюме или вакансия изменились, результат пересчитывается
+	CreatedAt       time.Time           `json:"created_at"`
+}
+
+// MatchingProfileDTO — профиль сравнения: веса компонентов, правила отсечения и нормализация.
+// В результате сравнения id пуст, если применён встроенный профиль по умолчанию.
+type MatchingProfileDTO struct {
+	ID            string              `json:"id,omitempty"`
+	Name          string              `json:"name"`
+	IsDefault     bool                `json:"is_default"`
+	Weights       ComponentWeightsDTO `json:"weights"`
+	Knockout      KnockoutRulesDTO    `json:"knockout"`
+	Normalization string              `json:"normalization"` // renormalize, neutral или strict
+	CreatedAt     *time.Time          `json:"created_at,omitempty"`
+	UpdatedAt     *time.Time          `json:"updated_at,omitempty"`
+}
+
+type ComponentWeightsDTO struct {
+	Skills     float64 `json:"skills" binding:"gte=0,lte=100"`
+	Experience float64 `json:"experience" binding:"gte=0,lte=100"`
+	Seniority  float64 `json:"seniority" binding:"gte=0,lte=100"`
+	Education  float64 `json:"education" binding:"gte=0,lte=100"`
+	Location   float64 `json:"location" binding:"gte=0,lte=100"`
+	Semantic   float64 `json:"semantic" binding:"gte=0,lte=100"`
+}
+
+// KnockoutRulesDTO — при нехватке обязательного навыка или стажа оценка не превышает cap
+type KnockoutRulesDTO struct {
+	MissingRequired    bool    `json:"missing_required"`
+	BelowMinExperience bool    `json:"below_min_experience"`
+	Cap                float64 `json:"cap" binding:"gte=0,lte=100"`
+}
+
+type MatchingProfileListDTO struct {
+	Profiles []*MatchingProfileDTO `json:"profiles"`
+}
+
+// ScoreComponentDTO — оценка по одному аспекту: skills, experience, seniority, education, location, semantic.
+// Score от 0 до 100, Weight — доля в итоговой оценке, Contribution — вклад в баллах.
+type ScoreComponentDTO struct {
+	Name         string        `json:"name"`
+	Score        float64       `json:"score"`
+	Weight       float64       `json:"weight"`
+	Contribution float64       `json:"contribution"`
+	Applicable   bool          `json:"applicable"`
+	Details      string        `json:"details"`
+	Evidence     []EvidenceDTO `json:"evidence"`
+}
+
+// EvidenceDTO — фрагмент резюме, подтверждающий требование вакансии
+type EvidenceDTO struct {
+	Requirement string `json:"requirement"`
+	Source      string `json:"source"`
+	Snippet     string `json:"snippet"`
+}
+
+// SkillMatchDTO — вклад навыка вакансии в оценку: Credit — засчитанная доля веса
+type SkillMatchDTO struct {
+	Name        string   `json:"name"`
+	Requirement string   `json:"requirement"`
+	Weight      float64  `json:"weight"`
+	Matched     bool     `json:"matched"`
+	MinYears    *float64 `json:"min_years,omitempty"`
+	Years       *float64 `json:"years,omitempty"`
+	Credit      float64  `json:"credit"`
+}
+
+// RecommendationsDTO — советы по доработке резюме под вакансию.
+// Source — llm или template (шаблонные советы, когда LLM не настроена или недоступна).
+type RecommendationsDTO struct {
+	MatchID         string                 `json:"match_id"`
+	MissingKeywords []string               `json:"missing_keywords"`
+	BulletRewrites  []BulletRewriteDTO     `json:"bullet_rewrites"`
+	SectionsToAdd   []SectionSuggestionDTO `json:"sections_to_add"`
+	Source          string                 `json:"source"`
+	Language        string                 `json:"language"`
+	Model           string                 `json:"model,omitempty"`
+	PromptVersion   string                 `json:"prompt_version,omitempty"`
+	GeneratedAt     time.Time              `json:"generated_at"`
+}
+
+type BulletRewriteDTO struct {
+	Original  string `json:"original"`
+	Suggested string `json:"suggested"`
+	Reason    string `json:"reason"`
+}
+
+type SectionSuggestionDTO struct {
+	Section string `json:"section"`
+	Reason  string `json:"reason"`
+}
+
+// CoverLetterDTO — сопроводительное письмо к вакансии.
+// Source — llm или template (письмо по шаблону, когда LLM не настроена или недоступна).
+type CoverLetterDTO struct {
+	ID            string    `json:"id"`
+	MatchID       string    `json:"match_id"`
+	ResumeID      string    `json:"resume_id"`
+	VacancyID     string    `json:"vacancy_id"`
+	Language      string    `json:"language"`
+	Tone          string    `json:"tone"`
+	Length        string    `json:"length"`
+	Content       string    `json:"content"`
+	Source        string    `json:"source"`
+	Model         string    `json:"model,omitempty"`
+	PromptVersion string    `json:"prompt_version,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// CoverLetterListDTO — история писем по результату сравнения, новые первыми
+type CoverLetterListDTO struct {
+	Letters []*CoverLetterDTO `json:"letters"`
+}
+
+// MatchJobDTO — состояние фонового расчёта матрицы сравнений.
+// Status — pending, running, done или failed; Progress — доля обработанных пар в процентах.
+type MatchJobDTO struct {
+	ID         string     `json:"id"`
+	Status     string     `json:"status"`
+	Resumes    int        `json:"resumes"`
+	Vacancies  int        `json:"vacancies"`
+	Total      int        `json:"total"`
+	Completed  int        `json:"completed"`
+	Failed     int        `json:"failed"`
+	Progress   float64    `json:"progress"`
+	Error      string     `json:"error,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// ApplicationDTO — кандидат в отборе на вакансию. History заполняется только при запросе одного отклика.
+type ApplicationDTO struct {
+	ID        string                     `json:"id"`
+	ResumeID  string                     `json:"resume_id"`
+	VacancyID string                     `json:"vacancy_id"`
+	Stage     string                     `json:"stage"`
+	History   []ApplicationTransitionDTO `json:"history,omitempty"`
+	CreatedAt time.Time                  `json:"created_at"`
+	UpdatedAt time.Time                  `json:"updated_at"`
+}
+
+// ApplicationTransitionDTO — переход между этапами; from_stage пуст у попадания в отбор
+type ApplicationTransitionDTO struct {
+	FromStage string    `json:"from_stage"`
+	ToStage   string    `json:"to_stage"`
+	Reason    string    `json:"reason"`
+	UserID    string    `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ApplicationListDTO struct {
+	Applications []*ApplicationDTO `json:"applications"`
+}
+
+// PipelineDTO — этапы отбора вакансии по порядку с числом кандидатов на каждом
+type PipelineDTO struct {
+	VacancyID string          `json:"vacancy_id"`
+	Stages    []StageCountDTO `json:"stages"`
+}
+
+type StageCountDTO struct {
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+// NoteDTO — заметка рекрутера к резюме
+type NoteDTO struct {
+	ID        string    `json:"id"`
+	ResumeID  string    `json:"resume_id"`
+	AuthorID  string    `json:"author_id"`
+	Author    string    `json:"author"` // никнейм автора, а если его нет — email
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type NoteListDTO struct {
+	Notes []NoteDTO `json:"notes"`
+}
+
+// ResumeTagsDTO — метки и оценка одного резюме после изменения
+type ResumeTagsDTO struct {
+	ResumeID string   `json:"resume_id"`
+	Tags     []string `json:"tags"`
+	Rating   *int     `json:"rating"`
+}
+
+type TagDTO struct {
+	Name    string `json:"name"`
+	Resumes int64  `json:"resumes"`
+}
+
+type TagListDTO struct {
+	Tags []TagDTO `json:"tags"`
+}
+
+// BulkTagResultDTO — итог массовой расстановки меток
+type BulkTagResultDTO struct {
+	Resumes int      `json:"resumes"`
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+}
+
+// InterviewDTO — интервью по отклику. Feedback заполняется только при запросе одного интервью.
+type InterviewDTO struct {
+	ID            string                    `json:"id"`
+	ApplicationID string                    `json:"application_id"`
+	OrganizerID   string                    `json:"organizer_id"`
+	Title         string                    `json:"title"`
+	StartsAt      time.Time                 `json:"starts_at"`
+	EndsAt        time.Time                 `json:"ends_at"`
+	Location      string                    `json:"location"`
+	VideoURL      string                    `json:"video_url"`
+	Description   string                    `json:"description"`
+	Status        string                    `json:"status"` // scheduled, cancelled
+	Participants  []InterviewParticipantDTO `json:"participants"`
+	Feedback      []InterviewFeedbackDTO    `json:"feedback,omitempty"`
+	CreatedAt     time.Time                 `json:"created_at"`
+	UpdatedAt     time.Time                 `json:"updated_at"`
+}
+
+// InterviewParticipantDTO — участник интервью; user_id пуст у незарегистрированных участников
+type InterviewParticipantDTO struct {
+	Name   string `json:"name"`
+	Email  string `json:"email" binding:"required"`
+	UserID string `json:"user_id,omitempty"`
+}
+
+type InterviewFeedbackDTO struct {
+	AuthorID       string         `json:"author_id"`
+	Recommendation string         `json:"recommendation"` // strong_yes, yes, no, strong_no
+	Scores         []ScorecardDTO `json:"scores"`
+	AverageScore   float64        `json:"average_score"`
+	Comment        string         `json:"comment"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
+
+type ScorecardDTO struct {
+	Criterion string `json:"criterion"`
+	Score     int    `json:"score"`
+	Comment   string `json:"comment,omitempty"`
+}
+
+type InterviewListDTO struct {
+	Interviews []*InterviewDTO `json:"interviews"`
+}
+
+// CalendarLinkDTO — ссылка для подписки на календарь интервью
+type CalendarLinkDTO struct {
+	URL string `json:"url"`
+}
+
+type UsageByModelDTO struct {
+	Model            string  `json:"model"`
+	Requests         int64   `json:"requests"`
+	CachedRequests   int64   `json:"cached_requests"`
+	InputTokens      int64   `json:"input_tokens"`
+	CompletionTokens int64   `json:"completion_tokens"`
+	TotalTokens      int64   `json:"total_tokens"`
+	Cost             float64 `json:"cost"`
+}
+
+type UsageDTO struct {
+	From             time.Time         `json:"from"`
+	To               time.Time         `json:"to"`
+	Currency         string            `json:"currency"`
+	Requests         int64             `json:"requests"`
+	CachedRequests   int64             `json:"cached_requests"`
+	InputTokens      int64             `json:"input_tokens"`
+	CompletionTokens int64             `json:"completion_tokens"`
+	TotalTokens      int64             `json:"total_tokens"`
+	Cost             float64           `json:"cost"`
+	ByModel          []UsageByModelDTO `json:"by_model"`
+
+	MonthlyTokenQuota int64  `json:"monthly_token_quota"` // 0 — без ограничений
+	QuotaUsed         int64  `json:"quota_used"`          // токены за текущий месяц
+	QuotaRemaining    *int64 `json:"quota_remaining"`     // null, если квоты нет
+}
+
+type UserUsageDTO struct {
+	UserID           string  `json:"user_id"`
+	Email            string  `json:"email"`
+	Requests         int64   `json:"requests"`
+	CachedRequests   int64   `json:"cached_requests"`
+	InputTokens      int64   `json:"input_tokens"`
+	CompletionTokens int64   `json:"completion_tokens"`
+	TotalTokens      int64   `json:"total_tokens"`
+	Cost             float64 `json:"cost"`
+}
+
+type UsageReportDTO struct {
+	From        time.Time      `json:"from"`
+	To          time.Time      `json:"to"`
+	Currency    string         `json:"currency"`
+	TotalTokens int64          `json:"total_tokens"`
+	Cost        float64        `json:"cost"`
+	Users       []UserUsageDTO `json:"users"`
+}
+
+// OrganizationDTO — рабочее пространство с ролью пользователя в нём. Members заполняется
+// только при запросе одной организации.
+type OrganizationDTO struct {
+	ID       string                  `json:"id"`
+	Name     string                  `json:"name"`
+	Personal bool                    `json:"personal"`
+	Role     string                  `json:"role"`   // owner, recruiter, hiring_manager, viewer
+	Active   bool                    `json:"active"` // текущее рабочее пространство пользователя
+	Members  []OrganizationMemberDTO `json:"members,omitempty"`
+}
+
+type OrganizationMemberDTO struct {
+	UserID   string    `json:"user_id"`
+	Email    string    `json:"email"`
+	Nickname string    `json:"nickname"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+type OrganizationListDTO struct {
+	Organizations []OrganizationDTO `json:"organizations"`
+}
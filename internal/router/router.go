@@ -1,61 +1,173 @@
-package router
-
-import (
-	"CVMatch/internal/config"
-	"CVMatch/internal/handlers"
-	"CVMatch/internal/middleware"
-
-	"github.com/gin-contrib/cors"
-	swaggerFiles "github.com/swaggo/files"
-	ginSwagger "github.com/swaggo/gin-swagger"
-
-	"github.com/gin-gonic/gin"
-	"go.uber.org/zap"
-	"gorm.io/gorm"
-)
-
-type Handlers struct {
-	User   *handlers.UserHandler
-	Resume *handlers.ResumeHandler
-}
-
-func Router(db *gorm.DB, log *zap.Logger, cfg *config.Config, handlers *Handlers) *gin.Engine {
-	r := gin.Default()
-
-	r.Use(cors.New(cors.Config{
-		AllowOrigins:     []string{"*"},
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Authorization", "Content-Type"},
-		ExposeHeaders:    []string{"Content-Length"},
-		AllowCredentials: true,
-	}))
-
-	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
-
-	r.Static("/uploads", "./uploads")
-
-	r.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{
-			"status": "ok",
-		})
-	})
-
-	auth := r.Group("/auth")
-	{
-		auth.POST("/register", handlers.User.RegisterHandler)
-		auth.POST("/login", handlers.User.LoginHandler)
-		auth.POST("/refresh", handlers.User.RefreshHandler)
-	}
-
-	resume := r.Group("/resumes", middleware.JWTAuth(&cfg.JWT))
-	{
-		resume.POST("/upload", handlers.Resume.UploadResumeHandler)
-		resume.GET("/list", handlers.Resume.ListResumesHandler)
-		resume.GET("/:id", handlers.Resume.GetResumeHandler)
-		resume.DELETE("/:id", handlers.Resume.DeleteResumeHandler)
-	}
-
-	r.GET("/profile", middleware.JWTAuth(&cfg.JWT), handlers.User.ProfileHandler)
-
-	return r
-}
+package router
+
+import (
+	"CVMatch/internal/config"
+	"CVMatch/internal/handlers"
+	"CVMatch/internal/metrics"
+	"CVMatch/internal/middleware"
+	"CVMatch/internal/models"
+	"CVMatch/internal/repository"
+
+	"github.com/gin-contrib/cors"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Handlers struct {
+	User         *handlers.UserHandler
+	Resume       *handlers.ResumeHandler
+	Annotation   *handlers.AnnotationHandler
+	Vacancy      *handlers.VacancyHandler
+	Profile      *handlers.MatchingProfileHandler
+	Match        *handlers.MatchHandler
+	Matrix       *handlers.MatrixHandler
+	Application  *handlers.ApplicationHandler
+	Interview    *handlers.InterviewHandler
+	Organization *handlers.OrganizationHandler
+	CoverLetter  *handlers.CoverLetterHandler
+	Usage        *handlers.UsageHandler
+}
+
+func Router(db *gorm.DB, log *zap.Logger, cfg *config.Config, handlers *Handlers) *gin.Engine {
+	r := gin.Default()
+
+	r.Use(cors.New(cors.Config{
+		AllowOrigins:     []string{"*"},
+		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Authorization", "Content-Type"},
+		ExposeHeaders:    []string{"Content-Length", "Content-Disposition"},
+		AllowCredentials: true,
+	}))
+
+	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+
+	r.Static("/uploads", "./uploads")
+
+	r.GET("/metrics", gin.WrapH(metrics.Handler()))
+
+	r.GET("/health", func(c *gin.Context) {
+		c.JSON(200, gin.H{
+			"status": "ok",
+		})
+	})
+
+	auth := r.Group("/auth")
+	{
+		auth.POST("/register", handlers.User.RegisterHandler)
+		auth.POST("/login", handlers.User.LoginHandler)
+		auth.POST("/refresh", handlers.User.RefreshHandler)
+	}
+
+	// Наблюдатель в рабочем пространстве только читает; менеджер по найму оценивает кандидатов,
+	// но не меняет сами резюме, вакансии и состав отбора
+	organizations := repository.NewOrganizationRepository(db)
+	editors := middleware.RequireWorkspaceRole(organizations, models.OrgRoleOwner, models.OrgRoleRecruiter)
+	reviewers := middleware.RequireWorkspaceRole(organizations, models.OrgRoleOwner, models.OrgRoleRecruiter, models.OrgRoleHiringManager)
+
+	resume := r.Group("/resumes", middleware.JWTAuth(&cfg.JWT))
+	{
+		resume.POST("/upload", editors, handlers.Resume.UploadResumeHandler)
+		resume.GET("", handlers.Resume.ListResumesHandler)
+		resume.GET("/list", handlers.Resume.ListResumesHandler)
+		resume.GET("/tags", handlers.Annotation.ListTagsHandler)
+		resume.POST("/tags/bulk", reviewers, handlers.Annotation.BulkTagHandler)
+		resume.GET("/:id", handlers.Resume.GetResumeHandler)
+		resume.GET("/:id/text", handlers.Resume.GetResumeTextHandler)
+		resume.GET("/:id/ats-report", handlers.Resume.GetATSReportHandler)
+		resume.PUT("/:id", editors, handlers.Resume.UpdateResumeHandler)
+		resume.DELETE("/:id", editors, handlers.Resume.DeleteResumeHandler)
+		resume.GET("/:id/notes", handlers.Annotation.ListNotesHandler)
+		resume.POST("/:id/notes", reviewers, handlers.Annotation.CreateNoteHandler)
+		resume.PUT("/:id/notes/:note_id", reviewers, handlers.Annotation.UpdateNoteHandler)
+		resume.DELETE("/:id/notes/:note_id", reviewers, handlers.Annotation.DeleteNoteHandler)
+		resume.PUT("/:id/rating", reviewers, handlers.Annotation.SetRatingHandler)
+		resume.PUT("/:id/tags", reviewers, handlers.Annotation.SetResumeTagsHandler)
+	}
+
+	vacancy := r.Group("/vacancies", middleware.JWTAuth(&cfg.JWT))
+	{
+		vacancy.POST("", editors, handlers.Vacancy.CreateVacancyHandler)
+		vacancy.POST("/parse", editors, handlers.Vacancy.ParseVacancyHandler)
+		vacancy.GET("/list", handlers.Vacancy.ListVacanciesHandler)
+		vacancy.GET("/:id", handlers.Vacancy.GetVacancyHandler)
+		vacancy.PUT("/:id", editors, handlers.Vacancy.UpdateVacancyHandler)
+		vacancy.PUT("/:id/matching-profile", editors, handlers.Profile.AttachToVacancyHandler)
+		vacancy.GET("/:id/stages", handlers.Application.GetPipelineHandler)
+		vacancy.PUT("/:id/stages", editors, handlers.Application.SetPipelineStagesHandler)
+		vacancy.DELETE("/:id", editors, handlers.Vacancy.DeleteVacancyHandler)
+	}
+
+	profile := r.Group("/matching-profiles", middleware.JWTAuth(&cfg.JWT))
+	{
+		profile.POST("", editors, handlers.Profile.CreateProfileHandler)
+		profile.GET("", handlers.Profile.ListProfilesHandler)
+		profile.GET("/:id", handlers.Profile.GetProfileHandler)
+		profile.PUT("/:id", editors, handlers.Profile.UpdateProfileHandler)
+		profile.DELETE("/:id", editors, handlers.Profile.DeleteProfileHandler)
+	}
+
+	match := r.Group("/matches", middleware.JWTAuth(&cfg.JWT))
+	{
+		match.POST("", reviewers, handlers.Match.CreateMatchHandler)
+		match.POST("/matrix", reviewers, handlers.Matrix.CreateMatrixHandler)
+		match.GET("/matrix/:id", handlers.Matrix.GetMatrixJobHandler)
+		match.GET("/matrix/:id/export", handlers.Matrix.ExportMatrixHandler)
+		match.GET("/:id", handlers.Match.GetMatchHandler)
+		match.GET("/:id/recommendations", handlers.Match.GetRecommendationsHandler)
+		match.POST("/:id/recommendations", reviewers, handlers.Match.GenerateRecommendationsHandler)
+		match.POST("/:id/cover-letter", reviewers, handlers.CoverLetter.CreateCoverLetterHandler)
+		match.GET("/:id/cover-letters", handlers.CoverLetter.ListCoverLettersHandler)
+		match.GET("/:id/cover-letters/:letter_id/export", handlers.CoverLetter.ExportCoverLetterHandler)
+	}
+
+	application := r.Group("/applications", middleware.JWTAuth(&cfg.JWT))
+	{
+		application.POST("", editors, handlers.Application.CreateApplicationHandler)
+		application.GET("", handlers.Application.ListApplicationsHandler)
+		application.GET("/:id", handlers.Application.GetApplicationHandler)
+		application.POST("/:id/move", reviewers, handlers.Application.MoveApplicationHandler)
+		application.DELETE("/:id", editors, handlers.Application.DeleteApplicationHandler)
+	}
+
+	interview := r.Group("/interviews", middleware.JWTAuth(&cfg.JWT))
+	{
+		interview.POST("", reviewers, handlers.Interview.ScheduleInterviewHandler)
+		interview.GET("", handlers.Interview.ListInterviewsHandler)
+		interview.GET("/calendar", handlers.Interview.GetCalendarLinkHandler)
+		interview.POST("/calendar/reset", handlers.Interview.ResetCalendarLinkHandler)
+		interview.GET("/:id", handlers.Interview.GetInterviewHandler)
+		interview.PUT("/:id", reviewers, handlers.Interview.UpdateInterviewHandler)
+		interview.POST("/:id/cancel", reviewers, handlers.Interview.CancelInterviewHandler)
+		interview.POST("/:id/feedback", reviewers, handlers.Interview.SubmitFeedbackHandler)
+		interview.GET("/:id/ics", handlers.Interview.ExportInterviewHandler)
+	}
+
+	organization := r.Group("/organizations", middleware.JWTAuth(&cfg.JWT))
+	{
+		organization.POST("", handlers.Organization.CreateOrganizationHandler)
+		organization.GET("", handlers.Organization.ListOrganizationsHandler)
+		organization.GET("/:id", handlers.Organization.GetOrganizationHandler)
+		organization.POST("/:id/switch", handlers.Organization.SwitchWorkspaceHandler)
+		organization.POST("/:id/members", handlers.Organization.AddMemberHandler)
+		organization.PUT("/:id/members/:user_id", handlers.Organization.UpdateMemberRoleHandler)
+		organization.DELETE("/:id/members/:user_id", handlers.Organization.RemoveMemberHandler)
+	}
+
+	// Календарные приложения не передают токен, доступ — по секрету в ссылке
+	r.GET("/calendar/:token", handlers.Interview.CalendarFeedHandler)
+
+	r.GET("/profile", middleware.JWTAuth(&cfg.JWT), handlers.User.ProfileHandler)
+	r.GET("/usage", middleware.JWTAuth(&cfg.JWT), handlers.Usage.GetUsageHandler)
+
+	admin := r.Group("/admin", middleware.JWTAuth(&cfg.JWT), middleware.RequireRole(repository.NewUserRepository(db), models.RoleAdmin))
+	{
+		admin.GET("/usage", handlers.Usage.GetUsageReportHandler)
+		admin.PUT("/users/:id/quota", handlers.Usage.UpdateQuotaHandler)
+	}
+
+	return r
+}
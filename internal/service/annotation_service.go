@@ -0,0 +1,318 @@
+package service
+
+import (
+	"CVMatch/internal/models"
+	"CVMatch/internal/repository"
+	"CVMatch/internal/response"
+	"errors"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrNoteNotFound  = errors.New("note not found")
+	ErrNoteForbidden = errors.New("only the author can change the note")
+	ErrEmptyNote     = errors.New("note text is empty")
+	ErrInvalidRating = errors.New("rating must be between 1 and 5")
+	ErrInvalidTag    = errors.New("tags must be 1-64 characters without commas")
+)
+
+const (
+	minRating  = 1
+	maxRating  = 5
+	maxTagSize = 64
+)
+
+// AnnotationService — заметки, метки и оценки рекрутера на резюме
+type AnnotationService struct {
+	resumeRepo repository.ResumeRepositoryI
+	noteRepo   repository.NoteRepositoryI
+	tagRepo    repository.TagRepositoryI
+	log        *zap.Logger
+}
+
+func NewAnnotationService(resumeRepo repository.ResumeRepositoryI, noteRepo repository.NoteRepositoryI, tagRepo repository.TagRepositoryI, log *zap.Logger) *AnnotationService {
+	return &AnnotationService{
+		resumeRepo: resumeRepo,
+		noteRepo:   noteRepo,
+		tagRepo:    tagRepo,
+		log:        log,
+	}
+}
+
+func (s *AnnotationService) ListNotes(userID, resumeID uuid.UUID) (*response.NoteListDTO, error) {
+	if _, err := s.resumeRepo.GetResumeByID(userID, resumeID); err != nil {
+		s.log.Warn("Failed to get resume by ID", zap.Error(err))
+		return nil, ErrResumeNotFound
+	}
+	notes, err := s.noteRepo.ListByResume(resumeID)
+	if err != nil {
+		s.log.Error("Failed to get resume notes", zap.Error(err))
+		return nil, err
+	}
+	dto := &response.NoteListDTO{Notes: make([]response.NoteDTO, 0, len(notes))}
+	for i := range notes {
+		dto.Notes = append(dto.Notes, noteToDTO(&notes[i]))
+	}
+	return dto, nil
+}
+
+func (s *AnnotationService) AddNote(userID, resumeID uuid.UUID, text string) (*response.NoteDTO, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, ErrEmptyNote
+	}
+	if _, err := s.resumeRepo.GetResumeByID(userID, resumeID); err != nil {
+		s.log.Warn("Failed to get resume by ID", zap.Error(err))
+		return nil, ErrResumeNotFound
+	}
+	note := &models.ResumeNote{ResumeID: resumeID, AuthorID: userID, Text: text}
+	if err := s.noteRepo.Create(note); err != nil {
+		s.log.Error("Failed to create resume note", zap.Error(err))
+		return nil, err
+	}
+	// Перечитываем заметку, чтобы отдать имя автора
+	saved, err := s.noteRepo.GetByID(resumeID, note.ID)
+	if err != nil {
+		s.log.Error("Failed to get resume note", zap.Error(err))
+		return nil, err
+	}
+	dto := noteToDTO(saved)
+	return &dto, nil
+}
+
+func (s *AnnotationService) UpdateNote(userID, resumeID, noteID uuid.UUID, text string) (*response.NoteDTO, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, ErrEmptyNote
+	}
+	note, err := s.authoredNote(userID, resumeID, noteID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.noteRepo.UpdateText(note.ID, text); err != nil {
+		s.log.Error("Failed to update resume note", zap.Error(err))
+		return nil, err
+	}
+	saved, err := s.noteRepo.GetByID(resumeID, note.ID)
+	if err != nil {
+		s.log.Error("Failed to get resume note", zap.Error(err))
+		return nil, err
+	}
+	dto := noteToDTO(saved)
+	return &dto, nil
+}
+
+func (s *AnnotationService) DeleteNote(userID, resumeID, noteID uuid.UUID) error {
+	note, err := s.authoredNote(userID, resumeID, noteID)
+	if err != nil {
+		return err
+	}
+	if err := s.noteRepo.Delete(note.ID); err != nil {
+		s.log.Error("Failed to delete resume note", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// authoredNote возвращает заметку резюме, если её автор — userID
+func (s *AnnotationService) authoredNote(userID, resumeID, noteID uuid.UUID) (*models.ResumeNote, error) {
+	if _, err := s.resumeRepo.GetResumeByID(userID, resumeID); err != nil {
+		s.log.Warn("Failed to get resume by ID", zap.Error(err))
+		return nil, ErrResumeNotFound
+	}
+	note, err := s.noteRepo.GetByID(resumeID, noteID)
+	if err != nil {
+		s.log.Warn("Failed to get resume note", zap.Error(err))
+		return nil, ErrNoteNotFound
+	}
+	if note.AuthorID != userID {
+		return nil, ErrNoteForbidden
+	}
+	return note, nil
+}
+
+// SetRating выставляет оценку резюме от 1 до 5; nil снимает оценку
+func (s *AnnotationService) SetRating(userID, resumeID uuid.UUID, rating *int) (*response.ResumeTagsDTO, error) {
+	if rating != nil && (*rating < minRating || *rating > maxRating) {
+		return nil, ErrInvalidRating
+	}
+	resume, err := s.resumeRepo.GetResumeByID(userID, resumeID)
+	if err != nil {
+		s.log.Warn("Failed to get resume by ID", zap.Error(err))
+		return nil, ErrResumeNotFound
+	}
+	if err := s.resumeRepo.SetRating(resumeID, rating); err != nil {
+		s.log.Error("Failed to set resume rating", zap.Error(err))
+		return nil, err
+	}
+	return &response.ResumeTagsDTO{ResumeID: resumeID.String(), Tags: tagNames(resume.Tags), Rating: rating}, nil
+}
+
+// SetTags заменяет метки резюме переданными; новые метки создаются
+func (s *AnnotationService) SetTags(userID, resumeID uuid.UUID, names []string) (*response.ResumeTagsDTO, error) {
+	normalized, err := NormalizeTags(names)
+	if err != nil {
+		return nil, err
+	}
+	resume, err := s.resumeRepo.GetResumeByID(userID, resumeID)
+	if err != nil {
+		s.log.Warn("Failed to get resume by ID", zap.Error(err))
+		return nil, ErrResumeNotFound
+	}
+
+	txErr := s.tagRepo.DB().Transaction(func(tx *gorm.DB) error {
+		txRepo := s.tagRepo.WithTx(tx)
+		if err := txRepo.ClearResume(resumeID); err != nil {
+			s.log.Error("Failed to clear resume tags", zap.Error(err))
+			return err
+		}
+		tagIDs, err := s.ensureTags(txRepo, userID, normalized)
+		if err != nil {
+			return err
+		}
+		if err := txRepo.AddToResumes([]uuid.UUID{resumeID}, tagIDs); err != nil {
+			s.log.Error("Failed to add tags to resume", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+	slices.Sort(normalized)
+	return &response.ResumeTagsDTO{ResumeID: resumeID.String(), Tags: normalized, Rating: resume.Rating}, nil
+}
+
+// BulkTag добавляет и снимает метки сразу у нескольких резюме.
+// Если хотя бы одно резюме не найдено, ничего не меняется.
+func (s *AnnotationService) BulkTag(userID uuid.UUID, resumeIDs []uuid.UUID, add, remove []string) (*response.BulkTagResultDTO, error) {
+	added, err := NormalizeTags(add)
+	if err != nil {
+		return nil, err
+	}
+	removed, err := NormalizeTags(remove)
+	if err != nil {
+		return nil, err
+	}
+	var ids []uuid.UUID
+	for _, id := range resumeIDs {
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	resumes, err := s.resumeRepo.GetListRes(userID, repository.ResumeFilter{IDs: ids})
+	if err != nil {
+		s.log.Error("Failed to get list of resumes", zap.Error(err))
+		return nil, err
+	}
+	if len(ids) == 0 || len(*resumes) != len(ids) {
+		return nil, ErrResumeNotFound
+	}
+
+	txErr := s.tagRepo.DB().Transaction(func(tx *gorm.DB) error {
+		txRepo := s.tagRepo.WithTx(tx)
+		if len(removed) > 0 {
+			tags, err := txRepo.GetByNames(userID, removed)
+			if err != nil {
+				s.log.Error("Failed to get tags by names", zap.Error(err))
+				return err
+			}
+			tagIDs := make([]uuid.UUID, 0, len(tags))
+			for _, tag := range tags {
+				tagIDs = append(tagIDs, tag.ID)
+			}
+			if err := txRepo.RemoveFromResumes(ids, tagIDs); err != nil {
+				s.log.Error("Failed to remove tags from resumes", zap.Error(err))
+				return err
+			}
+		}
+		tagIDs, err := s.ensureTags(txRepo, userID, added)
+		if err != nil {
+			return err
+		}
+		if err := txRepo.AddToResumes(ids, tagIDs); err != nil {
+			s.log.Error("Failed to add tags to resumes", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+	return &response.BulkTagResultDTO{Resumes: len(ids), Added: added, Removed: removed}, nil
+}
+
+func (s *AnnotationService) ListTags(userID uuid.UUID) (*response.TagListDTO, error) {
+	counts, err := s.tagRepo.List(userID)
+	if err != nil {
+		s.log.Error("Failed to get list of tags", zap.Error(err))
+		return nil, err
+	}
+	dto := &response.TagListDTO{Tags: make([]response.TagDTO, 0, len(counts))}
+	for _, count := range counts {
+		dto.Tags = append(dto.Tags, response.TagDTO{Name: count.Name, Resumes: count.Resumes})
+	}
+	return dto, nil
+}
+
+// ensureTags возвращает ID меток текущего рабочего пространства, создавая недостающие
+func (s *AnnotationService) ensureTags(repo repository.TagRepositoryI, userID uuid.UUID, names []string) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, len(names))
+	for _, name := range names {
+		tag, err := repo.FirstOrCreate(userID, name)
+		if err != nil {
+			s.log.Error("Failed to create tag", zap.String("tag", name), zap.Error(err))
+			return nil, err
+		}
+		ids = append(ids, tag.ID)
+	}
+	return ids, nil
+}
+
+// NormalizeTags приводит метки к нижнему регистру, схлопывает пробелы и убирает повторы.
+// Запятая недопустима: в фильтре списка резюме метки перечисляются через неё.
+func NormalizeTags(names []string) ([]string, error) {
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.Join(strings.Fields(name), " "))
+		if name == "" || utf8.RuneCountInString(name) > maxTagSize || strings.Contains(name, ",") {
+			return nil, ErrInvalidTag
+		}
+		if !slices.Contains(normalized, name) {
+			normalized = append(normalized, name)
+		}
+	}
+	return normalized, nil
+}
+
+// tagNames возвращает имена меток по алфавиту
+func tagNames(tags []models.Tag) []string {
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func noteToDTO(note *models.ResumeNote) response.NoteDTO {
+	author := note.Author.Nickname
+	if author == "" {
+		author = note.Author.Email
+	}
+	return response.NoteDTO{
+		ID:        note.ID.String(),
+		ResumeID:  note.ResumeID.String(),
+		AuthorID:  note.AuthorID.String(),
+		Author:    author,
+		Text:      note.Text,
+		CreatedAt: note.CreatedAt,
+		UpdatedAt: note.UpdatedAt,
+	}
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"CVMatch/internal/models"
+	"CVMatch/internal/repository"
+	"CVMatch/internal/repository/mocks"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	tags, err := NormalizeTags([]string{" Go ", "go", "Senior  Backend"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"go", "senior backend"}, tags)
+
+	_, err = NormalizeTags([]string{"  "})
+	require.ErrorIs(t, err, ErrInvalidTag)
+	_, err = NormalizeTags([]string{"a,b"})
+	require.ErrorIs(t, err, ErrInvalidTag)
+}
+
+func TestAnnotationService_UpdateNote_OnlyAuthor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	resumeRepo := mocks.NewMockResumeRepositoryI(ctrl)
+	noteRepo := mocks.NewMockNoteRepositoryI(ctrl)
+
+	userID, resumeID, noteID := uuid.New(), uuid.New(), uuid.New()
+	resumeRepo.EXPECT().GetResumeByID(userID, resumeID).Return(&models.Resume{ID: resumeID}, nil).AnyTimes()
+	noteRepo.EXPECT().GetByID(resumeID, noteID).Return(&models.ResumeNote{ID: noteID, ResumeID: resumeID, AuthorID: uuid.New()}, nil)
+
+	service := NewAnnotationService(resumeRepo, noteRepo, nil, zap.NewNop())
+
+	_, err := service.UpdateNote(userID, resumeID, noteID, "  ")
+	require.ErrorIs(t, err, ErrEmptyNote)
+	_, err = service.UpdateNote(userID, resumeID, noteID, "Новый текст")
+	require.ErrorIs(t, err, ErrNoteForbidden)
+
+	noteRepo.EXPECT().GetByID(resumeID, noteID).Return(nil, gorm.ErrRecordNotFound)
+	err = service.DeleteNote(userID, resumeID, noteID)
+	require.ErrorIs(t, err, ErrNoteNotFound)
+}
+
+func TestAnnotationService_AddNote(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	resumeRepo := mocks.NewMockResumeRepositoryI(ctrl)
+	noteRepo := mocks.NewMockNoteRepositoryI(ctrl)
+
+	userID, resumeID := uuid.New(), uuid.New()
+	resumeRepo.EXPECT().GetResumeByID(userID, resumeID).Return(&models.Resume{ID: resumeID}, nil)
+	var saved *models.ResumeNote
+	noteRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(note *models.ResumeNote) error {
+		note.ID = uuid.New()
+		saved = note
+		return nil
+	})
+	noteRepo.EXPECT().GetByID(resumeID, gomock.Any()).DoAndReturn(func(_, noteID uuid.UUID) (*models.ResumeNote, error) {
+		note := *saved
+		note.Author = models.User{Email: "hr@example.com"}
+		return &note, nil
+	})
+
+	service := NewAnnotationService(resumeRepo, noteRepo, nil, zap.NewNop())
+	dto, err := service.AddNote(userID, resumeID, "  Сильный кандидат  ")
+	require.NoError(t, err)
+	require.Equal(t, "Сильный кандидат", dto.Text)
+	require.Equal(t, userID.String(), dto.AuthorID)
+	require.Equal(t, "hr@example.com", dto.Author)
+}
+
+func TestAnnotationService_SetRating(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	resumeRepo := mocks.NewMockResumeRepositoryI(ctrl)
+	service := NewAnnotationService(resumeRepo, nil, nil, zap.NewNop())
+
+	userID, resumeID := uuid.New(), uuid.New()
+	six := 6
+	_, err := service.SetRating(userID, resumeID, &six)
+	require.ErrorIs(t, err, ErrInvalidRating)
+
+	four := 4
+	resumeRepo.EXPECT().GetResumeByID(userID, resumeID).Return(&models.Resume{ID: resumeID, Tags: []models.Tag{{Name: "remote"}, {Name: "go"}}}, nil)
+	resumeRepo.EXPECT().SetRating(resumeID, &four).Return(nil)
+	dto, err := service.SetRating(userID, resumeID, &four)
+	require.NoError(t, err)
+	require.Equal(t, 4, *dto.Rating)
+	require.Equal(t, []string{"go", "remote"}, dto.Tags)
+}
+
+func TestAnnotationService_SetTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	resumeRepo := mocks.NewMockResumeRepositoryI(ctrl)
+	tagRepo := mocks.NewMockTagRepositoryI(ctrl)
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	userID, resumeID := uuid.New(), uuid.New()
+	resumeRepo.EXPECT().GetResumeByID(userID, resumeID).Return(&models.Resume{ID: resumeID}, nil)
+	tagRepo.EXPECT().DB().Return(db)
+	tagRepo.EXPECT().WithTx(gomock.Any()).Return(tagRepo)
+	tagRepo.EXPECT().ClearResume(resumeID).Return(nil)
+	tagRepo.EXPECT().FirstOrCreate(userID, gomock.Any()).DoAndReturn(func(_ uuid.UUID, name string) (*models.Tag, error) {
+		return &models.Tag{ID: uuid.New(), Name: name}, nil
+	}).Times(2)
+	tagRepo.EXPECT().AddToResumes([]uuid.UUID{resumeID}, gomock.Len(2)).Return(nil)
+
+	service := NewAnnotationService(resumeRepo, nil, tagRepo, zap.NewNop())
+	dto, err := service.SetTags(userID, resumeID, []string{"Shortlist", "golang", "shortlist"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"golang", "shortlist"}, dto.Tags)
+}
+
+func TestAnnotationService_BulkTag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	resumeRepo := mocks.NewMockResumeRepositoryI(ctrl)
+	tagRepo := mocks.NewMockTagRepositoryI(ctrl)
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	userID := uuid.New()
+	first, second := uuid.New(), uuid.New()
+	service := NewAnnotationService(resumeRepo, nil, tagRepo, zap.NewNop())
+
+	// Одно из резюме чужое — ничего не меняется
+	resumeRepo.EXPECT().GetListRes(userID, repository.ResumeFilter{IDs: []uuid.UUID{first, second}}).
+		Return(&[]models.Resume{{ID: first}}, nil)
+	_, err = service.BulkTag(userID, []uuid.UUID{first, second, first}, []string{"go"}, nil)
+	require.ErrorIs(t, err, ErrResumeNotFound)
+
+	removedTag := models.Tag{ID: uuid.New(), Name: "reserve"}
+	resumeRepo.EXPECT().GetListRes(userID, repository.ResumeFilter{IDs: []uuid.UUID{first, second}}).
+		Return(&[]models.Resume{{ID: first}, {ID: second}}, nil)
+	tagRepo.EXPECT().DB().Return(db)
+	tagRepo.EXPECT().WithTx(gomock.Any()).Return(tagRepo)
+	tagRepo.EXPECT().GetByNames(userID, []string{"reserve"}).Return([]models.Tag{removedTag}, nil)
+	tagRepo.EXPECT().RemoveFromResumes([]uuid.UUID{first, second}, []uuid.UUID{removedTag.ID}).Return(nil)
+	tagRepo.EXPECT().FirstOrCreate(userID, "shortlist").Return(&models.Tag{ID: uuid.New(), Name: "shortlist"}, nil)
+	tagRepo.EXPECT().AddToResumes([]uuid.UUID{first, second}, gomock.Len(1)).Return(nil)
+
+	dto, err := service.BulkTag(userID, []uuid.UUID{first, second}, []string{"Shortlist"}, []string{"reserve"})
+	require.NoError(t, err)
+	require.Equal(t, 2, dto.Resumes)
+	require.Equal(t, []string{"shortlist"}, dto.Added)
+
+	// Ошибка в транзакции пробрасывается наружу
+	resumeRepo.EXPECT().GetListRes(userID, gomock.Any()).Return(&[]models.Resume{{ID: first}}, nil)
+	tagRepo.EXPECT().DB().Return(db)
+	tagRepo.EXPECT().WithTx(gomock.Any()).Return(tagRepo)
+	tagRepo.EXPECT().FirstOrCreate(userID, "go").Return(nil, errors.New("db down"))
+	_, err = service.BulkTag(userID, []uuid.UUID{first}, []string{"go"}, nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,272 @@
+package service
+
+import (
+	"CVMatch/internal/models"
+	"CVMatch/internal/repository"
+	"CVMatch/internal/response"
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrApplicationNotFound = errors.New("application not found")
+	ErrApplicationExists   = errors.New("resume already applied to the vacancy")
+	ErrUnknownStage        = errors.New("stage is not configured for the vacancy")
+	ErrSameStage           = errors.New("application is already at this stage")
+	ErrInvalidStages       = errors.New("stages must be unique names of latin letters, digits and underscores")
+	ErrStageInUse          = errors.New("removed stage still has applications")
+)
+
+// stageName — допустимое имя этапа отбора
+var stageName = regexp.MustCompile(`^[a-z0-9_]{1,32}$`)
+
+type ApplicationService struct {
+	repo        repository.ApplicationRepositoryI
+	resumeRepo  repository.ResumeRepositoryI
+	vacancyRepo repository.VacancyRepositoryI
+	log         *zap.Logger
+}
+
+func NewApplicationService(repo repository.ApplicationRepositoryI, resumeRepo repository.ResumeRepositoryI, vacancyRepo repository.VacancyRepositoryI, log *zap.Logger) *ApplicationService {
+	return &ApplicationService{
+		repo:        repo,
+		resumeRepo:  resumeRepo,
+		vacancyRepo: vacancyRepo,
+		log:         log,
+	}
+}
+
+// CreateApplication добавляет резюме в отбор на вакансию на первый этап вакансии
+func (s *ApplicationService) CreateApplication(userID, resumeID, vacancyID uuid.UUID) (*response.ApplicationDTO, error) {
+	if _, err := s.resumeRepo.GetResumeByID(userID, resumeID); err != nil {
+		s.log.Warn("Failed to get resume by ID", zap.Error(err))
+		return nil, ErrResumeNotFound
+	}
+	vacancy, err := s.vacancyRepo.GetVacancyByID(userID, vacancyID)
+	if err != nil {
+		s.log.Warn("Failed to get vacancy by ID", zap.Error(err))
+		return nil, ErrVacancyNotFound
+	}
+	if _, err := s.repo.GetByPair(resumeID, vacancyID); err == nil {
+		return nil, ErrApplicationExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		s.log.Error("Failed to get application", zap.Error(err))
+		return nil, err
+	}
+
+	application := &models.Application{
+		UserID:    userID,
+		ResumeID:  resumeID,
+		VacancyID: vacancyID,
+		Stage:     vacancy.Stages()[0],
+	}
+	transition := &models.ApplicationTransition{UserID: userID, ToStage: application.Stage}
+	txErr := s.repo.DB().Transaction(func(tx *gorm.DB) error {
+		txRepo := s.repo.WithTx(tx)
+		if err := txRepo.Create(application); err != nil {
+			s.log.Error("Failed to create application", zap.Error(err))
+			return err
+		}
+		transition.ApplicationID = application.ID
+		if err := txRepo.CreateTransition(transition); err != nil {
+			s.log.Error("Failed to save application transition", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	dto := applicationToDTO(application)
+	dto.History = []response.ApplicationTransitionDTO{transitionToDTO(transition)}
+	return dto, nil
+}
+
+// MoveApplication переводит кандидата на другой этап вакансии и записывает переход с причиной.
+// Переход возможен на любой этап, в том числе назад.
+func (s *ApplicationService) MoveApplication(userID, applicationID uuid.UUID, stage, reason string) (*response.ApplicationDTO, error) {
+	application, err := s.repo.GetByID(userID, applicationID)
+	if err != nil {
+		s.log.Warn("Failed to get application by ID", zap.Error(err))
+		return nil, ErrApplicationNotFound
+	}
+	vacancy, err := s.vacancyRepo.GetVacancyByID(userID, application.VacancyID)
+	if err != nil {
+		s.log.Warn("Failed to get vacancy by ID", zap.Error(err))
+		return nil, ErrVacancyNotFound
+	}
+	stage = strings.ToLower(strings.TrimSpace(stage))
+	if !slices.Contains(vacancy.Stages(), stage) {
+		return nil, ErrUnknownStage
+	}
+	if stage == application.Stage {
+		return nil, ErrSameStage
+	}
+
+	txErr := s.repo.DB().Transaction(func(tx *gorm.DB) error {
+		txRepo := s.repo.WithTx(tx)
+		if err := txRepo.UpdateStage(application.ID, stage); err != nil {
+			s.log.Error("Failed to update application stage", zap.Error(err))
+			return err
+		}
+		if err := txRepo.CreateTransition(&models.ApplicationTransition{
+			ApplicationID: application.ID,
+			UserID:        userID,
+			FromStage:     application.Stage,
+			ToStage:       stage,
+			Reason:        strings.TrimSpace(reason),
+		}); err != nil {
+			s.log.Error("Failed to save application transition", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+	return s.GetApplication(userID, applicationID)
+}
+
+// GetApplication возвращает отклик с историей переходов
+func (s *ApplicationService) GetApplication(userID, applicationID uuid.UUID) (*response.ApplicationDTO, error) {
+	application, err := s.repo.GetByID(userID, applicationID)
+	if err != nil {
+		s.log.Warn("Failed to get application by ID", zap.Error(err))
+		return nil, ErrApplicationNotFound
+	}
+	transitions, err := s.repo.ListTransitions(application.ID)
+	if err != nil {
+		s.log.Error("Failed to get application transitions", zap.Error(err))
+		return nil, err
+	}
+
+	dto := applicationToDTO(application)
+	dto.History = make([]response.ApplicationTransitionDTO, 0, len(transitions))
+	for i := range transitions {
+		dto.History = append(dto.History, transitionToDTO(&transitions[i]))
+	}
+	return dto, nil
+}
+
+func (s *ApplicationService) ListApplications(userID uuid.UUID, filter repository.ApplicationFilter) (*response.ApplicationListDTO, error) {
+	applications, err := s.repo.List(userID, filter)
+	if err != nil {
+		s.log.Error("Failed to get list of applications", zap.Error(err))
+		return nil, err
+	}
+	dtos := make([]*response.ApplicationDTO, 0, len(applications))
+	for i := range applications {
+		dtos = append(dtos, applicationToDTO(&applications[i]))
+	}
+	return &response.ApplicationListDTO{Applications: dtos}, nil
+}
+
+func (s *ApplicationService) DeleteApplication(userID, applicationID uuid.UUID) error {
+	if _, err := s.repo.GetByID(userID, applicationID); err != nil {
+		s.log.Warn("Failed to get application by ID", zap.Error(err))
+		return ErrApplicationNotFound
+	}
+	if err := s.repo.Delete(applicationID); err != nil {
+		s.log.Error("Failed to delete application", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// GetPipeline возвращает этапы отбора вакансии с числом кандидатов на каждом
+func (s *ApplicationService) GetPipeline(userID, vacancyID uuid.UUID) (*response.PipelineDTO, error) {
+	vacancy, err := s.vacancyRepo.GetVacancyByID(userID, vacancyID)
+	if err != nil {
+		s.log.Warn("Failed to get vacancy by ID", zap.Error(err))
+		return nil, ErrVacancyNotFound
+	}
+	counts, err := s.repo.CountByStage(vacancyID)
+	if err != nil {
+		s.log.Error("Failed to count applications by stage", zap.Error(err))
+		return nil, err
+	}
+	return pipelineToDTO(vacancyID, vacancy.Stages(), counts), nil
+}
+
+// SetPipelineStages задаёт этапы отбора вакансии по порядку; пустой список возвращает этапы
+// по умолчанию. Этап, на котором есть кандидаты, убрать нельзя.
+func (s *ApplicationService) SetPipelineStages(userID, vacancyID uuid.UUID, stages []string) (*response.PipelineDTO, error) {
+	if _, err := s.vacancyRepo.GetVacancyByID(userID, vacancyID); err != nil {
+		s.log.Warn("Failed to get vacancy by ID", zap.Error(err))
+		return nil, ErrVacancyNotFound
+	}
+	normalized, err := normalizeStages(stages)
+	if err != nil {
+		return nil, err
+	}
+	effective := normalized
+	if len(effective) == 0 {
+		effective = models.DefaultPipelineStages
+	}
+
+	counts, err := s.repo.CountByStage(vacancyID)
+	if err != nil {
+		s.log.Error("Failed to count applications by stage", zap.Error(err))
+		return nil, err
+	}
+	for stage, count := range counts {
+		if count > 0 && !slices.Contains(effective, stage) {
+			return nil, ErrStageInUse
+		}
+	}
+
+	if err := s.vacancyRepo.SetPipelineStages(vacancyID, normalized); err != nil {
+		s.log.Error("Failed to save pipeline stages", zap.Error(err))
+		return nil, err
+	}
+	return pipelineToDTO(vacancyID, effective, counts), nil
+}
+
+// normalizeStages приводит имена этапов к нижнему регистру и проверяет, что они допустимы и не повторяются
+func normalizeStages(stages []string) ([]string, error) {
+	var normalized []string
+	for _, stage := range stages {
+		stage = strings.ToLower(strings.TrimSpace(stage))
+		if !stageName.MatchString(stage) || slices.Contains(normalized, stage) {
+			return nil, ErrInvalidStages
+		}
+		normalized = append(normalized, stage)
+	}
+	return normalized, nil
+}
+
+func pipelineToDTO(vacancyID uuid.UUID, stages []string, counts map[string]int64) *response.PipelineDTO {
+	dto := &response.PipelineDTO{VacancyID: vacancyID.String(), Stages: make([]response.StageCountDTO, 0, len(stages))}
+	for _, stage := range stages {
+		dto.Stages = append(dto.Stages, response.StageCountDTO{Name: stage, Count: counts[stage]})
+	}
+	return dto
+}
+
+func applicationToDTO(application *models.Application) *response.ApplicationDTO {
+	return &response.ApplicationDTO{
+		ID:        application.ID.String(),
+		ResumeID:  application.ResumeID.String(),
+		VacancyID: application.VacancyID.String(),
+		Stage:     application.Stage,
+		CreatedAt: application.CreatedAt,
+		UpdatedAt: application.UpdatedAt,
+	}
+}
+
+func transitionToDTO(transition *models.ApplicationTransition) response.ApplicationTransitionDTO {
+	return response.ApplicationTransitionDTO{
+		FromStage: transition.FromStage,
+		ToStage:   transition.ToStage,
+		Reason:    transition.Reason,
+		UserID:    transition.UserID.String(),
+		CreatedAt: transition.CreatedAt,
+	}
+}
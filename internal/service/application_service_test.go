@@ -0,0 +1,142 @@
+package service
+
+import (
+	"CVMatch/internal/models"
+	"CVMatch/internal/repository/mocks"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestApplicationService_CreateApplication_StartsAtFirstStage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockApplicationRepositoryI(ctrl)
+	resumeRepo := mocks.NewMockResumeRepositoryI(ctrl)
+	vacancyRepo := mocks.NewMockVacancyRepositoryI(ctrl)
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	userID, resumeID, vacancyID := uuid.New(), uuid.New(), uuid.New()
+	resumeRepo.EXPECT().GetResumeByID(userID, resumeID).Return(&models.Resume{ID: resumeID}, nil)
+	vacancyRepo.EXPECT().GetVacancyByID(userID, vacancyID).Return(&models.Vacancy{ID: vacancyID, PipelineStages: []string{"sourced", "hired"}}, nil)
+	repo.EXPECT().GetByPair(resumeID, vacancyID).Return(nil, gorm.ErrRecordNotFound)
+	repo.EXPECT().DB().Return(db)
+	repo.EXPECT().WithTx(gomock.Any()).Return(repo)
+	repo.EXPECT().Create(gomock.Any()).DoAndReturn(func(a *models.Application) error {
+		a.ID = uuid.New()
+		return nil
+	})
+	repo.EXPECT().CreateTransition(gomock.Any()).DoAndReturn(func(tr *models.ApplicationTransition) error {
+		require.NotEqual(t, uuid.Nil, tr.ApplicationID)
+		require.Empty(t, tr.FromStage)
+		require.Equal(t, "sourced", tr.ToStage)
+		return nil
+	})
+
+	service := NewApplicationService(repo, resumeRepo, vacancyRepo, zap.NewNop())
+	dto, err := service.CreateApplication(userID, resumeID, vacancyID)
+	require.NoError(t, err)
+	require.Equal(t, "sourced", dto.Stage)
+	require.Len(t, dto.History, 1)
+}
+
+func TestApplicationService_CreateApplication_Duplicate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockApplicationRepositoryI(ctrl)
+	resumeRepo := mocks.NewMockResumeRepositoryI(ctrl)
+	vacancyRepo := mocks.NewMockVacancyRepositoryI(ctrl)
+
+	userID, resumeID, vacancyID := uuid.New(), uuid.New(), uuid.New()
+	resumeRepo.EXPECT().GetResumeByID(userID, resumeID).Return(&models.Resume{ID: resumeID}, nil)
+	vacancyRepo.EXPECT().GetVacancyByID(userID, vacancyID).Return(&models.Vacancy{ID: vacancyID}, nil)
+	repo.EXPECT().GetByPair(resumeID, vacancyID).Return(&models.Application{ID: uuid.New()}, nil)
+
+	service := NewApplicationService(repo, resumeRepo, vacancyRepo, zap.NewNop())
+	_, err := service.CreateApplication(userID, resumeID, vacancyID)
+	require.ErrorIs(t, err, ErrApplicationExists)
+}
+
+func TestApplicationService_MoveApplication(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockApplicationRepositoryI(ctrl)
+	vacancyRepo := mocks.NewMockVacancyRepositoryI(ctrl)
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	userID, applicationID, vacancyID := uuid.New(), uuid.New(), uuid.New()
+	application := &models.Application{ID: applicationID, VacancyID: vacancyID, Stage: models.StageScreening}
+	repo.EXPECT().GetByID(userID, applicationID).Return(application, nil).AnyTimes()
+	vacancyRepo.EXPECT().GetVacancyByID(userID, vacancyID).Return(&models.Vacancy{ID: vacancyID}, nil).AnyTimes()
+
+	service := NewApplicationService(repo, nil, vacancyRepo, zap.NewNop())
+
+	_, err = service.MoveApplication(userID, applicationID, "test_task", "")
+	require.ErrorIs(t, err, ErrUnknownStage)
+	_, err = service.MoveApplication(userID, applicationID, "Screening", "")
+	require.ErrorIs(t, err, ErrSameStage)
+
+	repo.EXPECT().DB().Return(db)
+	repo.EXPECT().WithTx(gomock.Any()).Return(repo)
+	repo.EXPECT().UpdateStage(applicationID, models.StageRejected).Return(nil)
+	repo.EXPECT().CreateTransition(gomock.Any()).DoAndReturn(func(tr *models.ApplicationTransition) error {
+		require.Equal(t, models.StageScreening, tr.FromStage)
+		require.Equal(t, models.StageRejected, tr.ToStage)
+		require.Equal(t, "Нет опыта с Kafka", tr.Reason)
+		require.Equal(t, userID, tr.UserID)
+		return nil
+	})
+	repo.EXPECT().ListTransitions(applicationID).Return([]models.ApplicationTransition{
+		{ToStage: models.StageNew},
+		{FromStage: models.StageNew, ToStage: models.StageScreening},
+		{FromStage: models.StageScreening, ToStage: models.StageRejected, Reason: "Нет опыта с Kafka"},
+	}, nil)
+
+	dto, err := service.MoveApplication(userID, applicationID, " rejected ", " Нет опыта с Kafka ")
+	require.NoError(t, err)
+	require.Len(t, dto.History, 3)
+	require.Equal(t, "Нет опыта с Kafka", dto.History[2].Reason)
+}
+
+func TestApplicationService_SetPipelineStages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockApplicationRepositoryI(ctrl)
+	vacancyRepo := mocks.NewMockVacancyRepositoryI(ctrl)
+
+	userID, vacancyID := uuid.New(), uuid.New()
+	vacancyRepo.EXPECT().GetVacancyByID(userID, vacancyID).Return(&models.Vacancy{ID: vacancyID}, nil).AnyTimes()
+	repo.EXPECT().CountByStage(vacancyID).Return(map[string]int64{models.StageNew: 2, models.StageOffer: 1}, nil).AnyTimes()
+
+	service := NewApplicationService(repo, nil, vacancyRepo, zap.NewNop())
+
+	_, err := service.SetPipelineStages(userID, vacancyID, []string{"new", "New"})
+	require.ErrorIs(t, err, ErrInvalidStages)
+	_, err = service.SetPipelineStages(userID, vacancyID, []string{"new", "тест"})
+	require.ErrorIs(t, err, ErrInvalidStages)
+	_, err = service.SetPipelineStages(userID, vacancyID, []string{"new", "hired"})
+	require.ErrorIs(t, err, ErrStageInUse)
+
+	vacancyRepo.EXPECT().SetPipelineStages(vacancyID, []string{"new", "test_task", "offer", "hired"}).Return(nil)
+	dto, err := service.SetPipelineStages(userID, vacancyID, []string{"New", "test_task", "offer", "hired"})
+	require.NoError(t, err)
+	require.Len(t, dto.Stages, 4)
+	require.Equal(t, int64(2), dto.Stages[0].Count)
+	require.Equal(t, int64(0), dto.Stages[1].Count)
+
+	vacancyRepo.EXPECT().SetPipelineStages(vacancyID, nil).Return(nil)
+	dto, err = service.SetPipelineStages(userID, vacancyID, nil)
+	require.NoError(t, err)
+	require.Len(t, dto.Stages, len(models.DefaultPipelineStages))
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"CVMatch/internal/config"
+	"CVMatch/internal/export"
+	"CVMatch/internal/llm"
+	"CVMatch/internal/matching"
+	"CVMatch/internal/models"
+	"CVMatch/internal/repository"
+	"CVMatch/internal/response"
+	"bytes"
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrCoverLetterNotFound = errors.New("cover letter not found")
+	ErrUnsupportedFormat   = errors.New("unsupported export format")
+)
+
+// Форматы выгрузки сопроводительного письма
+const (
+	FormatTXT  = "txt"
+	FormatDOCX = "docx"
+)
+
+// ExportedFile — выгруженный документ для отдачи клиенту
+type ExportedFile struct {
+	Name        string
+	ContentType string
+	Content     []byte
+}
+
+type CoverLetterService struct {
+	repo        repository.CoverLetterRepositoryI
+	matchRepo   repository.MatchingRepositoryI
+	resumeRepo  repository.ResumeRepositoryI
+	vacancyRepo repository.VacancyRepositoryI
+	log         *zap.Logger
+	cfg         *config.Config
+	writer      matching.CoverLetterWriterI
+}
+
+// NewCoverLetterService создаёт сервис сопроводительных писем. Если writer не задан,
+// письма собираются по шаблонам без LLM.
+func NewCoverLetterService(repo repository.CoverLetterRepositoryI, matchRepo repository.MatchingRepositoryI, resumeRepo repository.ResumeRepositoryI, vacancyRepo repository.VacancyRepositoryI, log *zap.Logger, cfg *config.Config, writer matching.CoverLetterWriterI) *CoverLetterService {
+	if writer == nil {
+		writer = matching.TemplateCoverLetterWriter{}
+	}
+	return &CoverLetterService{
+		repo:        repo,
+		matchRepo:   matchRepo,
+		resumeRepo:  resumeRepo,
+		vacancyRepo: vacancyRepo,
+		log:         log,
+		cfg:         cfg,
+		writer:      writer,
+	}
+}
+
+// Generate пишет новое письмо по результату сравнения и добавляет его в историю.
+// Незаданный язык берётся из резюме, тон — деловой, длина — средняя.
+func (s *CoverLetterService) Generate(ctx context.Context, userID, matchID uuid.UUID, opts matching.LetterOptions) (*response.CoverLetterDTO, error) {
+	result, err := s.matchRepo.GetMatchByID(userID, matchID)
+	if err != nil {
+		s.log.Warn("Failed to get match by ID", zap.Error(err))
+		return nil, ErrMatchNotFound
+	}
+	resume, err := s.resumeRepo.GetResumeByID(userID, result.ResumeID)
+	if err != nil {
+		s.log.Warn("Failed to get resume by ID", zap.Error(err))
+		return nil, ErrResumeNotFound
+	}
+	vacancy, err := s.vacancyRepo.GetVacancyByID(userID, result.VacancyID)
+	if err != nil {
+		s.log.Warn("Failed to get vacancy by ID", zap.Error(err))
+		return nil, ErrVacancyNotFound
+	}
+	attachResumeFile(s.resumeRepo, resume)
+
+	ctx = llm.WithOwner(ctx, llm.Owner{UserID: userID, ResumeID: &resume.ID, Operation: llm.OperationCoverLetter})
+	letter, err := s.writer.Write(ctx, resume, vacancy, result.Breakdown, opts, s.cfg)
+	if err != nil {
+		s.log.Error("Failed to write cover letter", zap.Error(err))
+		return nil, err
+	}
+	letter.UserID = userID
+	letter.MatchID = result.ID
+	letter.ResumeID = resume.ID
+	letter.VacancyID = vacancy.ID
+	if err := s.repo.Create(letter); err != nil {
+		s.log.Error("Failed to save cover letter", zap.Error(err))
+		return nil, err
+	}
+	return coverLetterToDTO(letter), nil
+}
+
+// List возвращает историю писем по результату сравнения
+func (s *CoverLetterService) List(userID, matchID uuid.UUID) (*response.CoverLetterListDTO, error) {
+	if _, err := s.matchRepo.GetMatchByID(userID, matchID); err != nil {
+		s.log.Warn("Failed to get match by ID", zap.Error(err))
+		return nil, ErrMatchNotFound
+	}
+	letters, err := s.repo.ListByMatch(userID, matchID)
+	if err != nil {
+		s.log.Error("Failed to list cover letters", zap.Error(err))
+		return nil, err
+	}
+	dto := &response.CoverLetterListDTO{Letters: []*response.CoverLetterDTO{}}
+	for i := range letters {
+		dto.Letters = append(dto.Letters, coverLetterToDTO(&letters[i]))
+	}
+	return dto, nil
+}
+
+// Export выгружает письмо простым текстом (txt) или документом Word (docx)
+func (s *CoverLetterService) Export(userID, matchID, letterID uuid.UUID, format string) (*ExportedFile, error) {
+	if format != FormatTXT && format != FormatDOCX {
+		return nil, ErrUnsupportedFormat
+	}
+	letter, err := s.repo.GetByID(userID, matchID, letterID)
+	if err != nil {
+		s.log.Warn("Failed to get cover letter by ID", zap.Error(err))
+		return nil, ErrCoverLetterNotFound
+	}
+
+	file := &ExportedFile{Name: "cover_letter_" + letter.ID.String() + "." + format}
+	switch format {
+	case FormatDOCX:
+		var buf bytes.Buffer
+		if err := export.WriteDOCX(&buf, letter.Content); err != nil {
+			s.log.Error("Failed to build DOCX", zap.Error(err))
+			return nil, err
+		}
+		file.ContentType = export.ContentTypeDOCX
+		file.Content = buf.Bytes()
+	default:
+		file.ContentType = "text/plain; charset=utf-8"
+		file.Content = []byte(letter.Content + "\n")
+	}
+	return file, nil
+}
+
+func coverLetterToDTO(letter *models.CoverLetter) *response.CoverLetterDTO {
+	return &response.CoverLetterDTO{
+		ID:            letter.ID.String(),
+		MatchID:       letter.MatchID.String(),
+		ResumeID:      letter.ResumeID.String(),
+		VacancyID:     letter.VacancyID.String(),
+		Language:      letter.Language,
+		Tone:          letter.Tone,
+		Length:        letter.Length,
+		Content:       letter.Content,
+		Source:        letter.Source,
+		Model:         letter.Model,
+		PromptVersion: letter.PromptVersion,
+		CreatedAt:     letter.CreatedAt,
+	}
+}
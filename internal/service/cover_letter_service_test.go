@@ -0,0 +1,112 @@
+package service
+
+import (
+	"CVMatch/internal/config"
+	"CVMatch/internal/export"
+	"CVMatch/internal/matching"
+	"CVMatch/internal/models"
+	"CVMatch/internal/repository/mocks"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestCoverLetterService_Generate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockCoverLetterRepositoryI(ctrl)
+	matchRepo := mocks.NewMockMatchingRepositoryI(ctrl)
+	resumeRepo := mocks.NewMockResumeRepositoryI(ctrl)
+	vacancyRepo := mocks.NewMockVacancyRepositoryI(ctrl)
+
+	userID, matchID, resumeID, vacancyID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	matchRepo.EXPECT().GetMatchByID(userID, matchID).Return(&models.MatchingResult{
+		ID:        matchID,
+		ResumeID:  resumeID,
+		VacancyID: vacancyID,
+		Breakdown: models.ScoreBreakdown{Skills: []models.SkillMatch{{Name: "Go", Requirement: models.SkillRequired, Matched: true, Credit: 1}}},
+	}, nil)
+	resumeRepo.EXPECT().GetResumeByID(userID, resumeID).Return(&models.Resume{ID: resumeID, FullName: "Иван Петров", ContentLanguage: "ru"}, nil)
+	vacancyRepo.EXPECT().GetVacancyByID(userID, vacancyID).Return(&models.Vacancy{ID: vacancyID, Title: "Go-разработчик"}, nil)
+	resumeRepo.EXPECT().GetResumeFile(resumeID).Return(nil, assert.AnError)
+	repo.EXPECT().Create(gomock.Any()).DoAndReturn(func(letter *models.CoverLetter) error {
+		require.Equal(t, userID, letter.UserID)
+		require.Equal(t, matchID, letter.MatchID)
+		require.Equal(t, resumeID, letter.ResumeID)
+		require.Equal(t, vacancyID, letter.VacancyID)
+		letter.ID = uuid.New()
+		return nil
+	})
+
+	service := NewCoverLetterService(repo, matchRepo, resumeRepo, vacancyRepo, zap.NewNop(), &config.Config{}, nil)
+	dto, err := service.Generate(context.Background(), userID, matchID, matching.LetterOptions{Length: models.LengthShort})
+	require.NoError(t, err)
+	require.Equal(t, "ru", dto.Language)
+	require.Equal(t, models.ToneFormal, dto.Tone)
+	require.Equal(t, models.LengthShort, dto.Length)
+	require.Equal(t, models.SourceTemplate, dto.Source)
+	require.Contains(t, dto.Content, "«Go-разработчик»")
+	require.Contains(t, dto.Content, "С уважением,\nИван Петров")
+}
+
+func TestCoverLetterService_Generate_MatchNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	matchRepo := mocks.NewMockMatchingRepositoryI(ctrl)
+	userID, matchID := uuid.New(), uuid.New()
+	matchRepo.EXPECT().GetMatchByID(userID, matchID).Return(nil, assert.AnError)
+
+	service := NewCoverLetterService(nil, matchRepo, nil, nil, zap.NewNop(), &config.Config{}, nil)
+	_, err := service.Generate(context.Background(), userID, matchID, matching.LetterOptions{})
+	require.ErrorIs(t, err, ErrMatchNotFound)
+}
+
+func TestCoverLetterService_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockCoverLetterRepositoryI(ctrl)
+	matchRepo := mocks.NewMockMatchingRepositoryI(ctrl)
+	userID, matchID := uuid.New(), uuid.New()
+	matchRepo.EXPECT().GetMatchByID(userID, matchID).Return(&models.MatchingResult{ID: matchID}, nil)
+	repo.EXPECT().ListByMatch(userID, matchID).Return([]models.CoverLetter{
+		{ID: uuid.New(), MatchID: matchID, Content: "Второе"},
+		{ID: uuid.New(), MatchID: matchID, Content: "Первое"},
+	}, nil)
+
+	service := NewCoverLetterService(repo, matchRepo, nil, nil, zap.NewNop(), &config.Config{}, nil)
+	dto, err := service.List(userID, matchID)
+	require.NoError(t, err)
+	require.Len(t, dto.Letters, 2)
+	require.Equal(t, "Второе", dto.Letters[0].Content)
+}
+
+func TestCoverLetterService_Export(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockCoverLetterRepositoryI(ctrl)
+	userID, matchID, letterID := uuid.New(), uuid.New(), uuid.New()
+	repo.EXPECT().GetByID(userID, matchID, letterID).Return(&models.CoverLetter{ID: letterID, Content: "Здравствуйте!\n\nС уважением,\nИван"}, nil).Times(2)
+
+	service := NewCoverLetterService(repo, nil, nil, nil, zap.NewNop(), &config.Config{}, nil)
+	txt, err := service.Export(userID, matchID, letterID, FormatTXT)
+	require.NoError(t, err)
+	require.Equal(t, "cover_letter_"+letterID.String()+".txt", txt.Name)
+	require.Equal(t, "Здравствуйте!\n\nС уважением,\nИван\n", string(txt.Content))
+
+	docx, err := service.Export(userID, matchID, letterID, FormatDOCX)
+	require.NoError(t, err)
+	require.Equal(t, export.ContentTypeDOCX, docx.ContentType)
+	require.Equal(t, "PK", string(docx.Content[:2]))
+
+	_, err = service.Export(userID, matchID, letterID, "pdf")
+	require.ErrorIs(t, err, ErrUnsupportedFormat)
+}
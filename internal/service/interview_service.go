@@ -0,0 +1,509 @@
+package service
+
+import (
+	"CVMatch/internal/config"
+	"CVMatch/internal/export"
+	"CVMatch/internal/models"
+	"CVMatch/internal/repository"
+	"CVMatch/internal/response"
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"net/mail"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInterviewNotFound  = errors.New("interview not found")
+	ErrInterviewForbidden = errors.New("only the organizer can change the interview")
+	ErrInterviewCancelled = errors.New("interview is cancelled")
+	ErrNotInterviewStage  = errors.New("application is not at an interview stage")
+	ErrInvalidSlot        = errors.New("interview must end after it starts and last at most 8 hours")
+	ErrInvalidParticipant = errors.New("participant email is invalid")
+	ErrNotInterviewer     = errors.New("only the organizer and registered participants can leave feedback")
+	ErrInvalidFeedback    = errors.New("feedback needs a known recommendation and scores from 1 to 5 for named criteria")
+	ErrCalendarNotFound   = errors.New("calendar not found")
+)
+
+const (
+	maxInterviewDuration = 8 * time.Hour
+	// calendarFeedHistory — за сколько прошедших дней интервью остаются в календаре
+	calendarFeedHistory = 90 * 24 * time.Hour
+	calendarFeedName    = "CVMatch — интервью"
+)
+
+var recommendations = []string{models.RecommendStrongYes, models.RecommendYes, models.RecommendNo, models.RecommendStrongNo}
+
+// InterviewInput — параметры интервью. Пустой Title заменяется на «Интервью: <кандидат> — <вакансия>».
+type InterviewInput struct {
+	Title        string
+	StartsAt     time.Time
+	EndsAt       time.Time
+	Location     string
+	VideoURL     string
+	Description  string
+	Participants []response.InterviewParticipantDTO
+}
+
+// FeedbackInput — отзыв интервьюера с оценками по критериям
+type FeedbackInput struct {
+	Recommendation string
+	Scores         []models.Scorecard
+	Comment        string
+}
+
+type InterviewService struct {
+	repo            repository.InterviewRepositoryI
+	applicationRepo repository.ApplicationRepositoryI
+	resumeRepo      repository.ResumeRepositoryI
+	vacancyRepo     repository.VacancyRepositoryI
+	userRepo        repository.UserRepositoryI
+	log             *zap.Logger
+	cfg             *config.Config
+}
+
+func NewInterviewService(repo repository.InterviewRepositoryI, applicationRepo repository.ApplicationRepositoryI, resumeRepo repository.ResumeRepositoryI, vacancyRepo repository.VacancyRepositoryI, userRepo repository.UserRepositoryI, log *zap.Logger, cfg *config.Config) *InterviewService {
+	return &InterviewService{
+		repo:            repo,
+		applicationRepo: applicationRepo,
+		resumeRepo:      resumeRepo,
+		vacancyRepo:     vacancyRepo,
+		userRepo:        userRepo,
+		log:             log,
+		cfg:             cfg,
+	}
+}
+
+// ScheduleInterview назначает интервью кандидату, который находится на этапе интервью
+func (s *InterviewService) ScheduleInterview(userID, applicationID uuid.UUID, input InterviewInput) (*response.InterviewDTO, error) {
+	application, err := s.applicationRepo.GetByID(userID, applicationID)
+	if err != nil {
+		s.log.Warn("Failed to get application by ID", zap.Error(err))
+		return nil, ErrApplicationNotFound
+	}
+	if !isInterviewStage(application.Stage) {
+		return nil, ErrNotInterviewStage
+	}
+	if err := validateSlot(input.StartsAt, input.EndsAt); err != nil {
+		return nil, err
+	}
+	participants, err := s.resolveParticipants(input.Participants)
+	if err != nil {
+		return nil, err
+	}
+
+	title := strings.TrimSpace(input.Title)
+	if title == "" {
+		resume, err := s.resumeRepo.GetResumeByID(userID, application.ResumeID)
+		if err != nil {
+			s.log.Warn("Failed to get resume by ID", zap.Error(err))
+			return nil, ErrResumeNotFound
+		}
+		vacancy, err := s.vacancyRepo.GetVacancyByID(userID, application.VacancyID)
+		if err != nil {
+			s.log.Warn("Failed to get vacancy by ID", zap.Error(err))
+			return nil, ErrVacancyNotFound
+		}
+		title = fmt.Sprintf("Интервью: %s — %s", resume.FullName, vacancy.Title)
+	}
+
+	interview := &models.Interview{
+		UserID:        userID,
+		ApplicationID: applicationID,
+		Title:         title,
+		StartsAt:      input.StartsAt.UTC(),
+		EndsAt:        input.EndsAt.UTC(),
+		Location:      strings.TrimSpace(input.Location),
+		VideoURL:      strings.TrimSpace(input.VideoURL),
+		Description:   strings.TrimSpace(input.Description),
+		Status:        models.InterviewScheduled,
+		Participants:  participants,
+	}
+	if err := s.repo.Create(interview); err != nil {
+		s.log.Error("Failed to create interview", zap.Error(err))
+		return nil, err
+	}
+	return interviewToDTO(interview), nil
+}
+
+// UpdateInterview переносит интервью или меняет его место и участников; доступно только организатору
+func (s *InterviewService) UpdateInterview(userID, interviewID uuid.UUID, input InterviewInput) (*response.InterviewDTO, error) {
+	interview, err := s.organizedInterview(userID, interviewID)
+	if err != nil {
+		return nil, err
+	}
+	if interview.Status == models.InterviewCancelled {
+		return nil, ErrInterviewCancelled
+	}
+	if err := validateSlot(input.StartsAt, input.EndsAt); err != nil {
+		return nil, err
+	}
+	participants, err := s.resolveParticipants(input.Participants)
+	if err != nil {
+		return nil, err
+	}
+
+	if title := strings.TrimSpace(input.Title); title != "" {
+		interview.Title = title
+	}
+	interview.StartsAt = input.StartsAt.UTC()
+	interview.EndsAt = input.EndsAt.UTC()
+	interview.Location = strings.TrimSpace(input.Location)
+	interview.VideoURL = strings.TrimSpace(input.VideoURL)
+	interview.Description = strings.TrimSpace(input.Description)
+	interview.Sequence++
+
+	txErr := s.repo.DB().Transaction(func(tx *gorm.DB) error {
+		txRepo := s.repo.WithTx(tx)
+		if err := txRepo.Update(interview); err != nil {
+			s.log.Error("Failed to update interview", zap.Error(err))
+			return err
+		}
+		if err := txRepo.ReplaceParticipants(interview.ID, participants); err != nil {
+			s.log.Error("Failed to save interview participants", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+	return s.GetInterview(userID, interviewID)
+}
+
+// CancelInterview отменяет интервью. Запись остаётся, чтобы календари подписчиков убрали событие.
+func (s *InterviewService) CancelInterview(userID, interviewID uuid.UUID) (*response.InterviewDTO, error) {
+	interview, err := s.organizedInterview(userID, interviewID)
+	if err != nil {
+		return nil, err
+	}
+	if interview.Status != models.InterviewCancelled {
+		interview.Status = models.InterviewCancelled
+		interview.Sequence++
+		if err := s.repo.Update(interview); err != nil {
+			s.log.Error("Failed to cancel interview", zap.Error(err))
+			return nil, err
+		}
+	}
+	return s.GetInterview(userID, interviewID)
+}
+
+// GetInterview возвращает интервью с отзывами; доступно организатору и участникам
+func (s *InterviewService) GetInterview(userID, interviewID uuid.UUID) (*response.InterviewDTO, error) {
+	interview, err := s.repo.GetByID(userID, interviewID)
+	if err != nil {
+		s.log.Warn("Failed to get interview by ID", zap.Error(err))
+		return nil, ErrInterviewNotFound
+	}
+	feedback, err := s.repo.ListFeedback(interview.ID)
+	if err != nil {
+		s.log.Error("Failed to get interview feedback", zap.Error(err))
+		return nil, err
+	}
+
+	dto := interviewToDTO(interview)
+	dto.Feedback = make([]response.InterviewFeedbackDTO, 0, len(feedback))
+	for i := range feedback {
+		dto.Feedback = append(dto.Feedback, feedbackToDTO(&feedback[i]))
+	}
+	return dto, nil
+}
+
+func (s *InterviewService) ListInterviews(userID uuid.UUID, filter repository.InterviewFilter) (*response.InterviewListDTO, error) {
+	interviews, err := s.repo.List(userID, filter)
+	if err != nil {
+		s.log.Error("Failed to get list of interviews", zap.Error(err))
+		return nil, err
+	}
+	dtos := make([]*response.InterviewDTO, 0, len(interviews))
+	for i := range interviews {
+		dtos = append(dtos, interviewToDTO(&interviews[i]))
+	}
+	return &response.InterviewListDTO{Interviews: dtos}, nil
+}
+
+// SubmitFeedback сохраняет отзыв организатора или зарегистрированного участника;
+// повторная отправка заменяет прежний отзыв
+func (s *InterviewService) SubmitFeedback(userID, interviewID uuid.UUID, input FeedbackInput) (*response.InterviewDTO, error) {
+	interview, err := s.repo.GetByID(userID, interviewID)
+	if err != nil {
+		s.log.Warn("Failed to get interview by ID", zap.Error(err))
+		return nil, ErrInterviewNotFound
+	}
+	if !isInterviewer(interview, userID) {
+		return nil, ErrNotInterviewer
+	}
+	if interview.Status == models.InterviewCancelled {
+		return nil, ErrInterviewCancelled
+	}
+	feedback, err := buildFeedback(input)
+	if err != nil {
+		return nil, err
+	}
+	feedback.InterviewID = interview.ID
+	feedback.AuthorID = userID
+	if err := s.repo.SaveFeedback(feedback); err != nil {
+		s.log.Error("Failed to save interview feedback", zap.Error(err))
+		return nil, err
+	}
+	return s.GetInterview(userID, interviewID)
+}
+
+// ExportInterview выгружает интервью одним событием iCalendar
+func (s *InterviewService) ExportInterview(userID, interviewID uuid.UUID) (*ExportedFile, error) {
+	interview, err := s.repo.GetByID(userID, interviewID)
+	if err != nil {
+		s.log.Warn("Failed to get interview by ID", zap.Error(err))
+		return nil, ErrInterviewNotFound
+	}
+	var buf bytes.Buffer
+	if err := export.WriteICS(&buf, "", []export.CalendarEvent{interviewToEvent(interview)}); err != nil {
+		s.log.Error("Failed to write interview calendar", zap.Error(err))
+		return nil, err
+	}
+	return &ExportedFile{
+		Name:        "interview-" + interview.ID.String() + ".ics",
+		ContentType: export.ContentTypeICS,
+		Content:     buf.Bytes(),
+	}, nil
+}
+
+// CalendarLink возвращает ссылку на календарь интервью пользователя, выдавая её при первом запросе
+func (s *InterviewService) CalendarLink(userID uuid.UUID) (*response.CalendarLinkDTO, error) {
+	user, err := s.userRepo.FindByID(userID.String())
+	if err != nil {
+		s.log.Warn("Failed to get user by ID", zap.Error(err))
+		return nil, ErrUserNotFound
+	}
+	if user.CalendarToken != nil {
+		return &response.CalendarLinkDTO{URL: s.calendarURL(*user.CalendarToken)}, nil
+	}
+	return s.ResetCalendarLink(userID)
+}
+
+// ResetCalendarLink выдаёт новую ссылку на календарь; прежняя перестаёт работать
+func (s *InterviewService) ResetCalendarLink(userID uuid.UUID) (*response.CalendarLinkDTO, error) {
+	raw := make([]byte, 20)
+	if _, err := rand.Read(raw); err != nil {
+		s.log.Error("Failed to generate calendar token", zap.Error(err))
+		return nil, err
+	}
+	token := hex.EncodeToString(raw)
+	if err := s.userRepo.SetCalendarToken(userID, token); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		s.log.Error("Failed to save calendar token", zap.Error(err))
+		return nil, err
+	}
+	return &response.CalendarLinkDTO{URL: s.calendarURL(token)}, nil
+}
+
+// CalendarFeed собирает календарь пользователя по секрету из ссылки: предстоящие интервью
+// и интервью за последние 90 дней, отменённые — со статусом CANCELLED
+func (s *InterviewService) CalendarFeed(token string) (*ExportedFile, error) {
+	token = strings.TrimSuffix(token, ".ics")
+	if token == "" {
+		return nil, ErrCalendarNotFound
+	}
+	user, err := s.userRepo.FindByCalendarToken(token)
+	if err != nil {
+		s.log.Warn("Failed to get user by calendar token", zap.Error(err))
+		return nil, ErrCalendarNotFound
+	}
+	since := time.Now().Add(-calendarFeedHistory)
+	interviews, err := s.repo.List(user.ID, repository.InterviewFilter{From: &since})
+	if err != nil {
+		s.log.Error("Failed to get list of interviews", zap.Error(err))
+		return nil, err
+	}
+	events := make([]export.CalendarEvent, 0, len(interviews))
+	for i := range interviews {
+		events = append(events, interviewToEvent(&interviews[i]))
+	}
+	var buf bytes.Buffer
+	if err := export.WriteICS(&buf, calendarFeedName, events); err != nil {
+		s.log.Error("Failed to write interview calendar", zap.Error(err))
+		return nil, err
+	}
+	return &ExportedFile{Name: "interviews.ics", ContentType: export.ContentTypeICS, Content: buf.Bytes()}, nil
+}
+
+func (s *InterviewService) calendarURL(token string) string {
+	return s.cfg.BaseURL + "/calendar/" + token + ".ics"
+}
+
+func (s *InterviewService) organizedInterview(userID, interviewID uuid.UUID) (*models.Interview, error) {
+	interview, err := s.repo.GetByID(userID, interviewID)
+	if err != nil {
+		s.log.Warn("Failed to get interview by ID", zap.Error(err))
+		return nil, ErrInterviewNotFound
+	}
+	if interview.UserID != userID {
+		return nil, ErrInterviewForbidden
+	}
+	return interview, nil
+}
+
+// resolveParticipants проверяет адреса, убирает повторы и связывает участников с пользователями по email
+func (s *InterviewService) resolveParticipants(items []response.InterviewParticipantDTO) ([]models.InterviewParticipant, error) {
+	var participants []models.InterviewParticipant
+	var emails []string
+	for _, item := range items {
+		address, err := mail.ParseAddress(strings.TrimSpace(item.Email))
+		if err != nil {
+			return nil, ErrInvalidParticipant
+		}
+		email := strings.ToLower(address.Address)
+		if slices.Contains(emails, email) {
+			continue
+		}
+		name := strings.TrimSpace(item.Name)
+		if name == "" {
+			name = address.Name
+		}
+		emails = append(emails, email)
+		participants = append(participants, models.InterviewParticipant{Name: name, Email: email})
+	}
+
+	users, err := s.userRepo.FindByEmails(emails)
+	if err != nil {
+		s.log.Error("Failed to get users by emails", zap.Error(err))
+		return nil, err
+	}
+	for i := range participants {
+		for _, user := range users {
+			if strings.EqualFold(user.Email, participants[i].Email) {
+				id := user.ID
+				participants[i].UserID = &id
+				if participants[i].Name == "" {
+					participants[i].Name = user.Nickname
+				}
+			}
+		}
+	}
+	return participants, nil
+}
+
+// isInterviewStage — этап интервью по умолчанию или свой этап вакансии с interview в имени
+// (tech_interview, final_interview)
+func isInterviewStage(stage string) bool {
+	return strings.Contains(stage, models.StageInterview)
+}
+
+func validateSlot(startsAt, endsAt time.Time) error {
+	if startsAt.IsZero() || !endsAt.After(startsAt) || endsAt.Sub(startsAt) > maxInterviewDuration {
+		return ErrInvalidSlot
+	}
+	return nil
+}
+
+func isInterviewer(interview *models.Interview, userID uuid.UUID) bool {
+	if interview.UserID == userID {
+		return true
+	}
+	for _, participant := range interview.Participants {
+		if participant.UserID != nil && *participant.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+func buildFeedback(input FeedbackInput) (*models.InterviewFeedback, error) {
+	recommendation := strings.ToLower(strings.TrimSpace(input.Recommendation))
+	if !slices.Contains(recommendations, recommendation) || len(input.Scores) == 0 {
+		return nil, ErrInvalidFeedback
+	}
+	feedback := &models.InterviewFeedback{Recommendation: recommendation, Comment: strings.TrimSpace(input.Comment)}
+	for _, score := range input.Scores {
+		criterion := strings.TrimSpace(score.Criterion)
+		if criterion == "" || score.Score < 1 || score.Score > 5 {
+			return nil, ErrInvalidFeedback
+		}
+		feedback.Scores = append(feedback.Scores, models.Scorecard{
+			Criterion: criterion,
+			Score:     score.Score,
+			Comment:   strings.TrimSpace(score.Comment),
+		})
+	}
+	return feedback, nil
+}
+
+func interviewToEvent(interview *models.Interview) export.CalendarEvent {
+	event := export.CalendarEvent{
+		UID:         interview.ID.String() + "@cvmatch",
+		Summary:     interview.Title,
+		Description: interview.Description,
+		Location:    interview.Location,
+		URL:         interview.VideoURL,
+		Start:       interview.StartsAt,
+		End:         interview.EndsAt,
+		Updated:     interview.UpdatedAt,
+		Sequence:    interview.Sequence,
+		Cancelled:   interview.Status == models.InterviewCancelled,
+	}
+	// Без адреса календарь показывает в месте встречи ссылку на видеозвонок
+	if event.Location == "" {
+		event.Location = interview.VideoURL
+	}
+	for _, participant := range interview.Participants {
+		event.Attendees = append(event.Attendees, export.CalendarAttendee{Name: participant.Name, Email: participant.Email})
+	}
+	return event
+}
+
+func interviewToDTO(interview *models.Interview) *response.InterviewDTO {
+	dto := &response.InterviewDTO{
+		ID:            interview.ID.String(),
+		ApplicationID: interview.ApplicationID.String(),
+		OrganizerID:   interview.UserID.String(),
+		Title:         interview.Title,
+		StartsAt:      interview.StartsAt,
+		EndsAt:        interview.EndsAt,
+		Location:      interview.Location,
+		VideoURL:      interview.VideoURL,
+		Description:   interview.Description,
+		Status:        interview.Status,
+		Participants:  make([]response.InterviewParticipantDTO, 0, len(interview.Participants)),
+		CreatedAt:     interview.CreatedAt,
+		UpdatedAt:     interview.UpdatedAt,
+	}
+	for _, participant := range interview.Participants {
+		item := response.InterviewParticipantDTO{Name: participant.Name, Email: participant.Email}
+		if participant.UserID != nil {
+			item.UserID = participant.UserID.String()
+		}
+		dto.Participants = append(dto.Participants, item)
+	}
+	return dto
+}
+
+func feedbackToDTO(feedback *models.InterviewFeedback) response.InterviewFeedbackDTO {
+	dto := response.InterviewFeedbackDTO{
+		AuthorID:       feedback.AuthorID.String(),
+		Recommendation: feedback.Recommendation,
+		Scores:         make([]response.ScorecardDTO, 0, len(feedback.Scores)),
+		Comment:        feedback.Comment,
+		CreatedAt:      feedback.CreatedAt,
+		UpdatedAt:      feedback.UpdatedAt,
+	}
+	total := 0
+	for _, score := range feedback.Scores {
+		dto.Scores = append(dto.Scores, response.ScorecardDTO{Criterion: score.Criterion, Score: score.Score, Comment: score.Comment})
+		total += score.Score
+	}
+	if len(feedback.Scores) > 0 {
+		dto.AverageScore = math.Round(float64(total)/float64(len(feedback.Scores))*10) / 10
+	}
+	return dto
+}
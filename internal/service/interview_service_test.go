@@ -0,0 +1,188 @@
+package service
+
+import (
+	"CVMatch/internal/config"
+	"CVMatch/internal/models"
+	"CVMatch/internal/repository"
+	"CVMatch/internal/repository/mocks"
+	"CVMatch/internal/response"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestInterviewService_ScheduleInterview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockInterviewRepositoryI(ctrl)
+	applicationRepo := mocks.NewMockApplicationRepositoryI(ctrl)
+	resumeRepo := mocks.NewMockResumeRepositoryI(ctrl)
+	vacancyRepo := mocks.NewMockVacancyRepositoryI(ctrl)
+	userRepo := mocks.NewMockUserRepositoryI(ctrl)
+	service := NewInterviewService(repo, applicationRepo, resumeRepo, vacancyRepo, userRepo, zap.NewNop(), &config.Config{})
+
+	userID, applicationID, resumeID, vacancyID, annaID := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	start := time.Date(2026, 3, 10, 12, 0, 0, 0, time.FixedZone("MSK", 3*60*60))
+	input := InterviewInput{
+		StartsAt: start,
+		EndsAt:   start.Add(time.Hour),
+		VideoURL: "https://meet.example.com/abc",
+		Participants: []response.InterviewParticipantDTO{
+			{Email: "Anna@Example.com"},
+			{Email: "anna@example.com"},
+			{Name: "Гость", Email: "guest@example.com"},
+		},
+	}
+
+	applicationRepo.EXPECT().GetByID(userID, applicationID).Return(&models.Application{ID: applicationID, Stage: models.StageScreening}, nil)
+	_, err := service.ScheduleInterview(userID, applicationID, input)
+	require.ErrorIs(t, err, ErrNotInterviewStage)
+
+	application := &models.Application{ID: applicationID, ResumeID: resumeID, VacancyID: vacancyID, Stage: "tech_interview"}
+	applicationRepo.EXPECT().GetByID(userID, applicationID).Return(application, nil).AnyTimes()
+	_, err = service.ScheduleInterview(userID, applicationID, InterviewInput{StartsAt: start, EndsAt: start})
+	require.ErrorIs(t, err, ErrInvalidSlot)
+	_, err = service.ScheduleInterview(userID, applicationID, InterviewInput{StartsAt: start, EndsAt: start.Add(time.Hour),
+		Participants: []response.InterviewParticipantDTO{{Email: "not-an-email"}}})
+	require.ErrorIs(t, err, ErrInvalidParticipant)
+
+	userRepo.EXPECT().FindByEmails([]string{"anna@example.com", "guest@example.com"}).
+		Return([]models.User{{ID: annaID, Email: "anna@example.com", Nickname: "Анна"}}, nil)
+	resumeRepo.EXPECT().GetResumeByID(userID, resumeID).Return(&models.Resume{FullName: "Иван Петров"}, nil)
+	vacancyRepo.EXPECT().GetVacancyByID(userID, vacancyID).Return(&models.Vacancy{Title: "Go Developer"}, nil)
+	repo.EXPECT().Create(gomock.Any()).DoAndReturn(func(interview *models.Interview) error {
+		require.Equal(t, time.UTC, interview.StartsAt.Location())
+		require.Equal(t, models.InterviewScheduled, interview.Status)
+		return nil
+	})
+
+	dto, err := service.ScheduleInterview(userID, applicationID, input)
+	require.NoError(t, err)
+	require.Equal(t, "Интервью: Иван Петров — Go Developer", dto.Title)
+	require.Len(t, dto.Participants, 2)
+	require.Equal(t, "Анна", dto.Participants[0].Name)
+	require.Equal(t, annaID.String(), dto.Participants[0].UserID)
+	require.Empty(t, dto.Participants[1].UserID)
+}
+
+func TestInterviewService_OnlyOrganizerChangesInterview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockInterviewRepositoryI(ctrl)
+	service := NewInterviewService(repo, nil, nil, nil, nil, zap.NewNop(), &config.Config{})
+
+	organizerID, participantID, interviewID := uuid.New(), uuid.New(), uuid.New()
+	interview := &models.Interview{ID: interviewID, UserID: organizerID, Status: models.InterviewScheduled,
+		Participants: []models.InterviewParticipant{{UserID: &participantID, Email: "anna@example.com"}}}
+	repo.EXPECT().GetByID(participantID, interviewID).Return(interview, nil).AnyTimes()
+
+	_, err := service.CancelInterview(participantID, interviewID)
+	require.ErrorIs(t, err, ErrInterviewForbidden)
+
+	repo.EXPECT().GetByID(organizerID, interviewID).Return(interview, nil).AnyTimes()
+	repo.EXPECT().Update(gomock.Any()).DoAndReturn(func(i *models.Interview) error {
+		require.Equal(t, models.InterviewCancelled, i.Status)
+		require.Equal(t, 1, i.Sequence)
+		return nil
+	})
+	repo.EXPECT().ListFeedback(interviewID).Return(nil, nil)
+	dto, err := service.CancelInterview(organizerID, interviewID)
+	require.NoError(t, err)
+	require.Equal(t, models.InterviewCancelled, dto.Status)
+
+	start := time.Now().Add(time.Hour)
+	_, err = service.UpdateInterview(organizerID, interviewID, InterviewInput{StartsAt: start, EndsAt: start.Add(time.Hour)})
+	require.ErrorIs(t, err, ErrInterviewCancelled)
+}
+
+func TestInterviewService_SubmitFeedback(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockInterviewRepositoryI(ctrl)
+	service := NewInterviewService(repo, nil, nil, nil, nil, zap.NewNop(), &config.Config{})
+
+	organizerID, participantID, strangerID, interviewID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	interview := &models.Interview{ID: interviewID, UserID: organizerID, Status: models.InterviewScheduled,
+		Participants: []models.InterviewParticipant{{UserID: &participantID, Email: "anna@example.com"}, {Email: "guest@example.com"}}}
+	repo.EXPECT().GetByID(gomock.Any(), interviewID).Return(interview, nil).AnyTimes()
+
+	valid := FeedbackInput{Recommendation: "Yes", Scores: []models.Scorecard{{Criterion: " Go ", Score: 4}, {Criterion: "SQL", Score: 3}}}
+	_, err := service.SubmitFeedback(strangerID, interviewID, valid)
+	require.ErrorIs(t, err, ErrNotInterviewer)
+	_, err = service.SubmitFeedback(participantID, interviewID, FeedbackInput{Recommendation: "maybe", Scores: valid.Scores})
+	require.ErrorIs(t, err, ErrInvalidFeedback)
+	_, err = service.SubmitFeedback(participantID, interviewID, FeedbackInput{Recommendation: "yes", Scores: []models.Scorecard{{Criterion: "Go", Score: 6}}})
+	require.ErrorIs(t, err, ErrInvalidFeedback)
+
+	var saved *models.InterviewFeedback
+	repo.EXPECT().SaveFeedback(gomock.Any()).DoAndReturn(func(f *models.InterviewFeedback) error {
+		saved = f
+		return nil
+	})
+	repo.EXPECT().ListFeedback(interviewID).DoAndReturn(func(uuid.UUID) ([]models.InterviewFeedback, error) {
+		return []models.InterviewFeedback{*saved}, nil
+	})
+	dto, err := service.SubmitFeedback(participantID, interviewID, valid)
+	require.NoError(t, err)
+	require.Equal(t, participantID, saved.AuthorID)
+	require.Equal(t, models.RecommendYes, saved.Recommendation)
+	require.Equal(t, "Go", saved.Scores[0].Criterion)
+	require.Len(t, dto.Feedback, 1)
+	require.Equal(t, 3.5, dto.Feedback[0].AverageScore)
+}
+
+func TestInterviewService_CalendarFeed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockInterviewRepositoryI(ctrl)
+	userRepo := mocks.NewMockUserRepositoryI(ctrl)
+	service := NewInterviewService(repo, nil, nil, nil, userRepo, zap.NewNop(), &config.Config{BaseURL: "https://cvmatch.example.com"})
+
+	userID := uuid.New()
+	var token string
+	userRepo.EXPECT().FindByID(userID.String()).Return(&models.User{ID: userID}, nil)
+	userRepo.EXPECT().SetCalendarToken(userID, gomock.Any()).DoAndReturn(func(_ uuid.UUID, t string) error {
+		token = t
+		return nil
+	})
+	link, err := service.CalendarLink(userID)
+	require.NoError(t, err)
+	require.Len(t, token, 40)
+	require.Equal(t, "https://cvmatch.example.com/calendar/"+token+".ics", link.URL)
+
+	// Повторный запрос отдаёт ту же ссылку
+	userRepo.EXPECT().FindByID(userID.String()).Return(&models.User{ID: userID, CalendarToken: &token}, nil)
+	again, err := service.CalendarLink(userID)
+	require.NoError(t, err)
+	require.Equal(t, link.URL, again.URL)
+
+	userRepo.EXPECT().FindByCalendarToken("unknown").Return(nil, gorm.ErrRecordNotFound)
+	_, err = service.CalendarFeed("unknown.ics")
+	require.ErrorIs(t, err, ErrCalendarNotFound)
+
+	start := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	userRepo.EXPECT().FindByCalendarToken(token).Return(&models.User{ID: userID}, nil)
+	repo.EXPECT().List(userID, gomock.Any()).DoAndReturn(func(_ uuid.UUID, filter repository.InterviewFilter) ([]models.Interview, error) {
+		require.NotNil(t, filter.From)
+		return []models.Interview{
+			{ID: uuid.New(), Title: "Техническое", StartsAt: start, EndsAt: start.Add(time.Hour), Status: models.InterviewScheduled, VideoURL: "https://meet.example.com/abc"},
+			{ID: uuid.New(), Title: "Финальное", StartsAt: start, EndsAt: start.Add(time.Hour), Status: models.InterviewCancelled, Sequence: 1},
+		}, nil
+	})
+	file, err := service.CalendarFeed(token + ".ics")
+	require.NoError(t, err)
+	ics := string(file.Content)
+	require.Equal(t, 2, strings.Count(ics, "BEGIN:VEVENT"))
+	require.Contains(t, ics, "LOCATION:https://meet.example.com/abc")
+	require.Contains(t, ics, "STATUS:CANCELLED")
+}
@@ -0,0 +1,429 @@
+package service
+
+import (
+	"CVMatch/internal/config"
+	"CVMatch/internal/export"
+	"CVMatch/internal/matching"
+	"CVMatch/internal/models"
+	"CVMatch/internal/repository"
+	"CVMatch/internal/response"
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrMatchJobNotFound = errors.New("match job not found")
+	ErrEmptyMatrix      = errors.New("no resumes or vacancies selected")
+	ErrMatrixTooLarge   = errors.New("too many resume and vacancy pairs")
+	ErrJobNotFinished   = errors.New("match job is not finished")
+)
+
+// Форматы выгрузки матрицы сравнений
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// errJobInterrupted — причина, с которой завершаются задачи, прерванные остановкой сервера
+const errJobInterrupted = "interrupted by server restart"
+
+// MatrixSelection — какие резюме и вакансии сравнивать. Каждый ID из фильтров должен
+// принадлежать пользователю; остальные условия фильтра сужают выборку.
+type MatrixSelection struct {
+	Resumes   repository.ResumeFilter
+	Vacancies repository.VacancyFilter
+}
+
+type MatrixService struct {
+	jobRepo     repository.MatchJobRepositoryI
+	matchRepo   repository.MatchingRepositoryI
+	resumeRepo  repository.ResumeRepositoryI
+	vacancyRepo repository.VacancyRepositoryI
+	profileRepo repository.MatchingProfileRepositoryI
+	log         *zap.Logger
+	cfg         *config.Config
+	// jobs — запущенные задачи; тесты ждут их завершения
+	jobs sync.WaitGroup
+}
+
+func NewMatrixService(jobRepo repository.MatchJobRepositoryI, matchRepo repository.MatchingRepositoryI, resumeRepo repository.ResumeRepositoryI, vacancyRepo repository.VacancyRepositoryI, profileRepo repository.MatchingProfileRepositoryI, log *zap.Logger, cfg *config.Config) *MatrixService {
+	return &MatrixService{
+		jobRepo:     jobRepo,
+		matchRepo:   matchRepo,
+		resumeRepo:  resumeRepo,
+		vacancyRepo: vacancyRepo,
+		profileRepo: profileRepo,
+		log:         log,
+		cfg:         cfg,
+	}
+}
+
+// FailInterrupted завершает с ошибкой задачи, оставшиеся незавершёнными после прошлого запуска сервера
+func (s *MatrixService) FailInterrupted() {
+	n, err := s.jobRepo.FailUnfinished(errJobInterrupted)
+	if err != nil {
+		s.log.Error("Failed to mark interrupted match jobs", zap.Error(err))
+		return
+	}
+	if n > 0 {
+		s.log.Warn("Interrupted match jobs marked as failed", zap.Int64("jobs", n))
+	}
+}
+
+// StartMatrix выбирает резюме и вакансии, создаёт задачу и считает матрицу в фоне
+func (s *MatrixService) StartMatrix(userID uuid.UUID, selection MatrixSelection) (*response.MatchJobDTO, error) {
+	resumes, err := s.selectResumes(userID, selection.Resumes)
+	if err != nil {
+		return nil, err
+	}
+	vacancies, err := s.selectVacancies(userID, selection.Vacancies)
+	if err != nil {
+		return nil, err
+	}
+
+	total := len(*resumes) * len(*vacancies)
+	if total == 0 {
+		return nil, ErrEmptyMatrix
+	}
+	if s.cfg.Matrix.MaxPairs > 0 && total > s.cfg.Matrix.MaxPairs {
+		return nil, ErrMatrixTooLarge
+	}
+
+	job := &models.MatchJob{UserID: userID, Status: models.JobPending, Total: total}
+	for _, r := range *resumes {
+		job.ResumeIDs = append(job.ResumeIDs, r.ID)
+	}
+	for _, v := range *vacancies {
+		job.VacancyIDs = append(job.VacancyIDs, v.ID)
+	}
+	if err := s.jobRepo.Create(job); err != nil {
+		s.log.Error("Failed to create match job", zap.Error(err))
+		return nil, err
+	}
+
+	s.jobs.Add(1)
+	go s.run(*job)
+	return matchJobToDTO(job), nil
+}
+
+// selectResumes проверяет, что все резюме из filter.IDs принадлежат пользователю, и применяет фильтр
+func (s *MatrixService) selectResumes(userID uuid.UUID, filter repository.ResumeFilter) (*[]models.Resume, error) {
+	if len(filter.IDs) > 0 {
+		owned, err := s.resumeRepo.GetListRes(userID, repository.ResumeFilter{IDs: filter.IDs})
+		if err != nil {
+			s.log.Error("Failed to get list of resumes", zap.Error(err))
+			return nil, err
+		}
+		if len(*owned) < countUnique(filter.IDs) {
+			return nil, ErrResumeNotFound
+		}
+	}
+	resumes, err := s.resumeRepo.GetListRes(userID, filter)
+	if err != nil {
+		s.log.Error("Failed to get list of resumes", zap.Error(err))
+		return nil, err
+	}
+	return resumes, nil
+}
+
+// selectVacancies проверяет, что все вакансии из filter.IDs принадлежат пользователю, и применяет фильтр
+func (s *MatrixService) selectVacancies(userID uuid.UUID, filter repository.VacancyFilter) (*[]models.Vacancy, error) {
+	if len(filter.IDs) > 0 {
+		owned, err := s.vacancyRepo.GetListVacancy(userID, repository.VacancyFilter{IDs: filter.IDs})
+		if err != nil {
+			s.log.Error("Failed to get list of vacancies", zap.Error(err))
+			return nil, err
+		}
+		if len(*owned) < countUnique(filter.IDs) {
+			return nil, ErrVacancyNotFound
+		}
+	}
+	vacancies, err := s.vacancyRepo.GetListVacancy(userID, filter)
+	if err != nil {
+		s.log.Error("Failed to get list of vacancies", zap.Error(err))
+		return nil, err
+	}
+	return vacancies, nil
+}
+
+func (s *MatrixService) GetJob(userID, jobID uuid.UUID) (*response.MatchJobDTO, error) {
+	job, err := s.jobRepo.GetByID(userID, jobID)
+	if err != nil {
+		s.log.Warn("Failed to get match job by ID", zap.Error(err))
+		return nil, ErrMatchJobNotFound
+	}
+	return matchJobToDTO(job), nil
+}
+
+// Export выгружает оценки завершённой задачи таблицей: строка — резюме, столбец — вакансия.
+// Пустая ячейка — пару не удалось посчитать.
+func (s *MatrixService) Export(userID, jobID uuid.UUID, format string) (*ExportedFile, error) {
+	if format != FormatCSV && format != FormatXLSX {
+		return nil, ErrUnsupportedFormat
+	}
+	job, err := s.jobRepo.GetByID(userID, jobID)
+	if err != nil {
+		s.log.Warn("Failed to get match job by ID", zap.Error(err))
+		return nil, ErrMatchJobNotFound
+	}
+	if job.Status != models.JobDone {
+		return nil, ErrJobNotFinished
+	}
+
+	rows, err := s.matrixRows(userID, job)
+	if err != nil {
+		return nil, err
+	}
+	file := &ExportedFile{Name: "match_matrix_" + job.ID.String() + "." + format}
+	var buf bytes.Buffer
+	switch format {
+	case FormatXLSX:
+		err = export.WriteXLSX(&buf, "Matrix", rows)
+		file.ContentType = export.ContentTypeXLSX
+	default:
+		err = export.WriteCSV(&buf, rows)
+		file.ContentType = export.ContentTypeCSV
+	}
+	if err != nil {
+		s.log.Error("Failed to build match matrix file", zap.Error(err), zap.String("format", format))
+		return nil, err
+	}
+	file.Content = buf.Bytes()
+	return file, nil
+}
+
+// matrixRows собирает таблицу оценок в порядке резюме и вакансий задачи; удалённые с тех пор
+// резюме и вакансии в таблицу не попадают
+func (s *MatrixService) matrixRows(userID uuid.UUID, job *models.MatchJob) ([][]any, error) {
+	resumes, err := s.resumeRepo.GetListRes(userID, repository.ResumeFilter{IDs: job.ResumeIDs})
+	if err != nil {
+		s.log.Error("Failed to get list of resumes", zap.Error(err))
+		return nil, err
+	}
+	vacancies, err := s.vacancyRepo.GetListVacancy(userID, repository.VacancyFilter{IDs: job.VacancyIDs})
+	if err != nil {
+		s.log.Error("Failed to get list of vacancies", zap.Error(err))
+		return nil, err
+	}
+	results, err := s.matchRepo.ListByJob(job.ID)
+	if err != nil {
+		s.log.Error("Failed to get match job results", zap.Error(err))
+		return nil, err
+	}
+
+	names := make(map[uuid.UUID]string, len(*resumes))
+	for _, r := range *resumes {
+		names[r.ID] = r.FullName
+	}
+	titles := make(map[uuid.UUID]string, len(*vacancies))
+	for _, v := range *vacancies {
+		titles[v.ID] = v.Title
+	}
+	scores := make(map[[2]uuid.UUID]float64, len(results))
+	for _, r := range results {
+		scores[[2]uuid.UUID{r.ResumeID, r.VacancyID}] = r.Score
+	}
+
+	var vacancyIDs []uuid.UUID
+	header := []any{"resume_id", "candidate"}
+	for _, id := range job.VacancyIDs {
+		if title, ok := titles[id]; ok {
+			vacancyIDs = append(vacancyIDs, id)
+			header = append(header, title)
+		}
+	}
+	rows := [][]any{header}
+	for _, resumeID := range job.ResumeIDs {
+		name, ok := names[resumeID]
+		if !ok {
+			continue
+		}
+		row := []any{resumeID.String(), name}
+		for _, vacancyID := range vacancyIDs {
+			if score, ok := scores[[2]uuid.UUID{resumeID, vacancyID}]; ok {
+				row = append(row, score)
+			} else {
+				row = append(row, nil)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// matrixVacancy — вакансия задачи с профилем сравнения, выбранным один раз на всю задачу
+type matrixVacancy struct {
+	vacancy *models.Vacancy
+	profile models.ProfileSnapshot
+}
+
+type matrixPair struct {
+	resume  *models.Resume
+	vacancy matrixVacancy
+}
+
+// run считает пары резюме и вакансии пулом из cfg.Matrix.Workers обработчиков.
+// Резюме загружаются по одному, пока обработчики считают предыдущие; прогресс сохраняется
+// примерно на каждом проценте пар.
+func (s *MatrixService) run(job models.MatchJob) {
+	defer s.jobs.Done()
+	log := s.log.With(zap.String("job_id", job.ID.String()))
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("Match job panicked", zap.Any("panic", r))
+			s.finish(log, job.ID, models.JobFailed, fmt.Sprintf("internal error: %v", r))
+		}
+	}()
+
+	if err := s.jobRepo.Start(job.ID); err != nil {
+		log.Error("Failed to start match job", zap.Error(err))
+	}
+	vacancies, missing, err := s.loadVacancies(job)
+	if err != nil {
+		log.Error("Failed to load vacancies for match job", zap.Error(err))
+		s.finish(log, job.ID, models.JobFailed, err.Error())
+		return
+	}
+
+	pairs := make(chan matrixPair)
+	outcomes := make(chan error)
+	var senders sync.WaitGroup
+	senders.Add(1)
+	go func() {
+		defer senders.Done()
+		defer close(pairs)
+		for range missing * len(job.ResumeIDs) {
+			outcomes <- ErrVacancyNotFound
+		}
+		for _, resumeID := range job.ResumeIDs {
+			resume, err := s.resumeRepo.LoadResume(resumeID)
+			if err != nil {
+				for range vacancies {
+					outcomes <- ErrResumeNotFound
+				}
+				continue
+			}
+			attachResumeFile(s.resumeRepo, resume)
+			for _, v := range vacancies {
+				pairs <- matrixPair{resume: resume, vacancy: v}
+			}
+		}
+	}()
+	workers := max(s.cfg.Matrix.Workers, 1)
+	for range workers {
+		senders.Add(1)
+		go func() {
+			defer senders.Done()
+			for pair := range pairs {
+				outcomes <- s.matchPair(job.ID, pair)
+			}
+		}()
+	}
+	go func() {
+		senders.Wait()
+		close(outcomes)
+	}()
+
+	step := max(job.Total/100, 1)
+	completed, failed := 0, 0
+	for err := range outcomes {
+		if err != nil {
+			failed++
+			log.Warn("Failed to match pair", zap.Error(err))
+		} else {
+			completed++
+		}
+		if (completed+failed)%step == 0 {
+			if err := s.jobRepo.UpdateProgress(job.ID, completed, failed); err != nil {
+				log.Error("Failed to save match job progress", zap.Error(err))
+			}
+		}
+	}
+	if err := s.jobRepo.UpdateProgress(job.ID, completed, failed); err != nil {
+		log.Error("Failed to save match job progress", zap.Error(err))
+	}
+	s.finish(log, job.ID, models.JobDone, "")
+	log.Info("Match job finished", zap.Int("completed", completed), zap.Int("failed", failed))
+}
+
+// loadVacancies загружает вакансии задачи и их профили сравнения; missing — сколько вакансий
+// удалено после создания задачи
+func (s *MatrixService) loadVacancies(job models.MatchJob) ([]matrixVacancy, int, error) {
+	var vacancies []matrixVacancy
+	missing := 0
+	for _, vacancyID := range job.VacancyIDs {
+		vacancy, err := s.vacancyRepo.LoadVacancy(vacancyID)
+		if err != nil {
+			missing++
+			continue
+		}
+		profile, err := resolveProfile(s.profileRepo, vacancy)
+		if err != nil {
+			return nil, 0, err
+		}
+		vacancies = append(vacancies, matrixVacancy{vacancy: vacancy, profile: profile})
+	}
+	return vacancies, missing, nil
+}
+
+func (s *MatrixService) matchPair(jobID uuid.UUID, pair matrixPair) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("matching panicked: %v", r)
+		}
+	}()
+	calc := matching.CalculateWithProfile(pair.resume, pair.vacancy.vacancy, pair.vacancy.profile)
+	return s.matchRepo.Create(&models.MatchingResult{
+		ResumeID:  pair.resume.ID,
+		VacancyID: pair.vacancy.vacancy.ID,
+		Score:     calc.Score,
+		Knockout:  calc.Knockout,
+		Breakdown: calc.Breakdown,
+		ProfileID: pair.vacancy.profile.ID,
+		Profile:   pair.vacancy.profile,
+		JobID:     &jobID,
+
+		ResumeVersion:  pair.resume.Version,
+		VacancyVersion: pair.vacancy.vacancy.Version,
+	})
+}
+
+func (s *MatrixService) finish(log *zap.Logger, jobID uuid.UUID, status, errMsg string) {
+	if err := s.jobRepo.Finish(jobID, status, errMsg); err != nil {
+		log.Error("Failed to finish match job", zap.Error(err))
+	}
+}
+
+func countUnique(ids []uuid.UUID) int {
+	seen := make(map[uuid.UUID]struct{}, len(ids))
+	for _, id := range ids {
+		seen[id] = struct{}{}
+	}
+	return len(seen)
+}
+
+func matchJobToDTO(job *models.MatchJob) *response.MatchJobDTO {
+	dto := &response.MatchJobDTO{
+		ID:         job.ID.String(),
+		Status:     job.Status,
+		Resumes:    len(job.ResumeIDs),
+		Vacancies:  len(job.VacancyIDs),
+		Total:      job.Total,
+		Completed:  job.Completed,
+		Failed:     job.Failed,
+		Error:      job.Error,
+		CreatedAt:  job.CreatedAt,
+		StartedAt:  job.StartedAt,
+		FinishedAt: job.FinishedAt,
+	}
+	if job.Total > 0 {
+		dto.Progress = math.Round(float64(job.Completed+job.Failed)/float64(job.Total)*1000) / 10
+	}
+	return dto
+}
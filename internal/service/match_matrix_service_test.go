@@ -0,0 +1,154 @@
+package service
+
+import (
+	"CVMatch/internal/config"
+	"CVMatch/internal/models"
+	"CVMatch/internal/repository"
+	"CVMatch/internal/repository/mocks"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestMatrixService_StartMatrix_ComputesAllPairs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	jobRepo := mocks.NewMockMatchJobRepositoryI(ctrl)
+	matchRepo := mocks.NewMockMatchingRepositoryI(ctrl)
+	resumeRepo := mocks.NewMockResumeRepositoryI(ctrl)
+	vacancyRepo := mocks.NewMockVacancyRepositoryI(ctrl)
+
+	userID, jobID := uuid.New(), uuid.New()
+	goDev := models.Resume{ID: uuid.New(), Skills: []models.Skill{{Name: "Go"}}}
+	javaDev := models.Resume{ID: uuid.New(), Skills: []models.Skill{{Name: "Java"}}}
+	backend := models.Vacancy{ID: uuid.New(), Skills: []models.Skill{{Name: "Go"}}}
+	deleted := models.Vacancy{ID: uuid.New()}
+
+	resumeRepo.EXPECT().GetListRes(userID, repository.ResumeFilter{Seniority: "middle"}).Return(&[]models.Resume{goDev, javaDev}, nil)
+	vacancyRepo.EXPECT().GetListVacancy(userID, repository.VacancyFilter{IDs: []uuid.UUID{backend.ID, deleted.ID}}).Return(&[]models.Vacancy{backend, deleted}, nil).Times(2)
+	jobRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(job *models.MatchJob) error {
+		require.Equal(t, 4, job.Total)
+		require.Equal(t, models.JobPending, job.Status)
+		job.ID = jobID
+		return nil
+	})
+	jobRepo.EXPECT().Start(jobID).Return(nil)
+	vacancyRepo.EXPECT().LoadVacancy(backend.ID).Return(&backend, nil)
+	vacancyRepo.EXPECT().LoadVacancy(deleted.ID).Return(nil, assert.AnError)
+	resumeRepo.EXPECT().LoadResume(goDev.ID).Return(&goDev, nil)
+	resumeRepo.EXPECT().LoadResume(javaDev.ID).Return(&javaDev, nil)
+	resumeRepo.EXPECT().GetResumeFile(gomock.Any()).Return(nil, assert.AnError).Times(2)
+
+	var mu sync.Mutex
+	scores := map[uuid.UUID]float64{}
+	matchRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(r *models.MatchingResult) error {
+		require.Equal(t, &jobID, r.JobID)
+		require.Equal(t, "default", r.Profile.Name)
+		mu.Lock()
+		scores[r.ResumeID] = r.Score
+		mu.Unlock()
+		return nil
+	}).Times(2)
+	var completed, failed int
+	jobRepo.EXPECT().UpdateProgress(jobID, gomock.Any(), gomock.Any()).DoAndReturn(func(_ uuid.UUID, c, f int) error {
+		completed, failed = c, f
+		return nil
+	}).MinTimes(1)
+	jobRepo.EXPECT().Finish(jobID, models.JobDone, "").Return(nil)
+
+	service := NewMatrixService(jobRepo, matchRepo, resumeRepo, vacancyRepo, nil, zap.NewNop(), &config.Config{Matrix: config.MatrixConfig{Workers: 2}})
+	dto, err := service.StartMatrix(userID, MatrixSelection{
+		Resumes:   repository.ResumeFilter{Seniority: "middle"},
+		Vacancies: repository.VacancyFilter{IDs: []uuid.UUID{backend.ID, deleted.ID}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, jobID.String(), dto.ID)
+	require.Equal(t, 2, dto.Resumes)
+	require.Equal(t, 2, dto.Vacancies)
+
+	service.jobs.Wait()
+	require.Equal(t, 2, completed)
+	require.Equal(t, 2, failed)
+	require.Len(t, scores, 2)
+	require.Greater(t, scores[goDev.ID], scores[javaDev.ID])
+}
+
+func TestMatrixService_StartMatrix_Validation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	resumeRepo := mocks.NewMockResumeRepositoryI(ctrl)
+	vacancyRepo := mocks.NewMockVacancyRepositoryI(ctrl)
+	userID, foreign := uuid.New(), uuid.New()
+	resumes := &[]models.Resume{{ID: uuid.New()}, {ID: uuid.New()}}
+	vacancies := &[]models.Vacancy{{ID: uuid.New()}, {ID: uuid.New()}}
+
+	service := NewMatrixService(nil, nil, resumeRepo, vacancyRepo, nil, zap.NewNop(), &config.Config{Matrix: config.MatrixConfig{MaxPairs: 3}})
+
+	resumeRepo.EXPECT().GetListRes(userID, repository.ResumeFilter{IDs: []uuid.UUID{foreign}}).Return(&[]models.Resume{}, nil)
+	_, err := service.StartMatrix(userID, MatrixSelection{Resumes: repository.ResumeFilter{IDs: []uuid.UUID{foreign}}})
+	require.ErrorIs(t, err, ErrResumeNotFound)
+
+	resumeRepo.EXPECT().GetListRes(userID, repository.ResumeFilter{}).Return(resumes, nil).Times(2)
+	vacancyRepo.EXPECT().GetListVacancy(userID, repository.VacancyFilter{}).Return(&[]models.Vacancy{}, nil)
+	_, err = service.StartMatrix(userID, MatrixSelection{})
+	require.ErrorIs(t, err, ErrEmptyMatrix)
+
+	vacancyRepo.EXPECT().GetListVacancy(userID, repository.VacancyFilter{}).Return(vacancies, nil)
+	_, err = service.StartMatrix(userID, MatrixSelection{})
+	require.ErrorIs(t, err, ErrMatrixTooLarge)
+}
+
+func TestMatrixService_Export(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	jobRepo := mocks.NewMockMatchJobRepositoryI(ctrl)
+	matchRepo := mocks.NewMockMatchingRepositoryI(ctrl)
+	resumeRepo := mocks.NewMockResumeRepositoryI(ctrl)
+	vacancyRepo := mocks.NewMockVacancyRepositoryI(ctrl)
+
+	userID, jobID := uuid.New(), uuid.New()
+	ivan, anna := uuid.New(), uuid.New()
+	backend, qa := uuid.New(), uuid.New()
+	job := &models.MatchJob{ID: jobID, UserID: userID, Status: models.JobDone, ResumeIDs: []uuid.UUID{ivan, anna}, VacancyIDs: []uuid.UUID{backend, qa}}
+	jobRepo.EXPECT().GetByID(userID, jobID).Return(job, nil)
+	resumeRepo.EXPECT().GetListRes(userID, repository.ResumeFilter{IDs: job.ResumeIDs}).Return(&[]models.Resume{{ID: anna, FullName: "Анна"}, {ID: ivan, FullName: "Иван"}}, nil)
+	vacancyRepo.EXPECT().GetListVacancy(userID, repository.VacancyFilter{IDs: job.VacancyIDs}).Return(&[]models.Vacancy{{ID: qa, Title: "QA"}, {ID: backend, Title: "Go backend"}}, nil)
+	matchRepo.EXPECT().ListByJob(jobID).Return([]models.MatchingResult{
+		{ResumeID: ivan, VacancyID: backend, Score: 81.5},
+		{ResumeID: ivan, VacancyID: qa, Score: 20},
+		{ResumeID: anna, VacancyID: qa, Score: 64},
+	}, nil)
+
+	service := NewMatrixService(jobRepo, matchRepo, resumeRepo, vacancyRepo, nil, zap.NewNop(), &config.Config{})
+	file, err := service.Export(userID, jobID, FormatCSV)
+	require.NoError(t, err)
+	require.Equal(t, "match_matrix_"+jobID.String()+".csv", file.Name)
+	lines := strings.Split(strings.TrimPrefix(string(file.Content), "\ufeff"), "\n")
+	require.Equal(t, "resume_id,candidate,Go backend,QA", lines[0])
+	require.Equal(t, ivan.String()+",Иван,81.5,20", lines[1])
+	require.Equal(t, anna.String()+",Анна,,64", lines[2])
+}
+
+func TestMatrixService_Export_NotFinished(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	jobRepo := mocks.NewMockMatchJobRepositoryI(ctrl)
+	userID, jobID := uuid.New(), uuid.New()
+	jobRepo.EXPECT().GetByID(userID, jobID).Return(&models.MatchJob{ID: jobID, Status: models.JobRunning}, nil)
+
+	service := NewMatrixService(jobRepo, nil, nil, nil, nil, zap.NewNop(), &config.Config{})
+	_, err := service.Export(userID, jobID, FormatXLSX)
+	require.ErrorIs(t, err, ErrJobNotFinished)
+	_, err = service.Export(userID, jobID, "pdf")
+	require.ErrorIs(t, err, ErrUnsupportedFormat)
+}
@@ -0,0 +1,251 @@
+package service
+
+import (
+	"CVMatch/internal/config"
+	"CVMatch/internal/llm"
+	"CVMatch/internal/matching"
+	"CVMatch/internal/models"
+	"CVMatch/internal/repository"
+	"CVMatch/internal/response"
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrResumeNotFound          = errors.New("resume not found")
+	ErrMatchNotFound           = errors.New("match not found")
+	ErrRecommendationsNotFound = errors.New("recommendations are not generated yet")
+)
+
+type MatchService struct {
+	resumeRepo  repository.ResumeRepositoryI
+	vacancyRepo repository.VacancyRepositoryI
+	repo        repository.MatchingRepositoryI
+	profileRepo repository.MatchingProfileRepositoryI
+	log         *zap.Logger
+	cfg         *config.Config
+	recommender matching.RecommenderI
+}
+
+// NewMatchService создаёт сервис сравнения. Если recommender не задан, советы по резюме
+// составляются шаблонами без LLM. Без profileRepo применяется встроенный профиль сравнения.
+func NewMatchService(resumeRepo repository.ResumeRepositoryI, vacancyRepo repository.VacancyRepositoryI, repo repository.MatchingRepositoryI, profileRepo repository.MatchingProfileRepositoryI, log *zap.Logger, cfg *config.Config, recommender matching.RecommenderI) *MatchService {
+	if recommender == nil {
+		recommender = matching.TemplateRecommender{}
+	}
+	return &MatchService{
+		resumeRepo:  resumeRepo,
+		vacancyRepo: vacancyRepo,
+		repo:        repo,
+		profileRepo: profileRepo,
+		log:         log,
+		cfg:         cfg,
+		recommender: recommender,
+	}
+}
+
+// CreateMatch сравнивает резюме с вакансией и сохраняет результат
+func (s *MatchService) CreateMatch(userID, resumeID, vacancyID uuid.UUID) (*response.MatchResultDTO, error) {
+	resume, err := s.resumeRepo.GetResumeByID(userID, resumeID)
+	if err != nil {
+		s.log.Warn("Failed to get resume by ID", zap.Error(err))
+		return nil, ErrResumeNotFound
+	}
+	vacancy, err := s.vacancyRepo.GetVacancyByID(userID, vacancyID)
+	if err != nil {
+		s.log.Warn("Failed to get vacancy by ID", zap.Error(err))
+		return nil, ErrVacancyNotFound
+	}
+
+	profile, err := resolveProfile(s.profileRepo, vacancy)
+	if err != nil {
+		s.log.Error("Failed to get matching profile", zap.Error(err))
+		return nil, err
+	}
+
+	attachResumeFile(s.resumeRepo, resume)
+	calc := matching.CalculateWithProfile(resume, vacancy, profile)
+	result := &models.MatchingResult{
+		ResumeID:  resume.ID,
+		VacancyID: vacancy.ID,
+		Score:     calc.Score,
+		Knockout:  calc.Knockout,
+		Breakdown: calc.Breakdown,
+		ProfileID: profile.ID,
+		Profile:   profile,
+
+		ResumeVersion:  resume.Version,
+		VacancyVersion: vacancy.Version,
+	}
+	if err := s.repo.Create(result); err != nil {
+		s.log.Error("Failed to save matching result", zap.Error(err))
+		return nil, err
+	}
+	return matchToDTO(result), nil
+}
+
+func (s *MatchService) GetMatchByID(userID, matchID uuid.UUID) (*response.MatchResultDTO, error) {
+	result, err := s.repo.GetMatchByID(userID, matchID)
+	if err != nil {
+		s.log.Warn("Failed to get match by ID", zap.Error(err))
+		return nil, ErrMatchNotFound
+	}
+	return matchToDTO(result), nil
+}
+
+// GetRecommendations возвращает сохранённые советы по результату сравнения, не обращаясь к LLM
+func (s *MatchService) GetRecommendations(userID, matchID uuid.UUID) (*response.RecommendationsDTO, error) {
+	result, err := s.repo.GetMatchByID(userID, matchID)
+	if err != nil {
+		s.log.Warn("Failed to get match by ID", zap.Error(err))
+		return nil, ErrMatchNotFound
+	}
+	if result.Recommendations == nil {
+		return nil, ErrRecommendationsNotFound
+	}
+	return recommendationsToDTO(result.ID, result.Recommendations), nil
+}
+
+// GenerateRecommendations составляет советы, как доработать резюме под вакансию из результата
+// сравнения, и сохраняет их в результате, заменяя прежние
+func (s *MatchService) GenerateRecommendations(ctx context.Context, userID, matchID uuid.UUID) (*response.RecommendationsDTO, error) {
+	result, err := s.repo.GetMatchByID(userID, matchID)
+	if err != nil {
+		s.log.Warn("Failed to get match by ID", zap.Error(err))
+		return nil, ErrMatchNotFound
+	}
+
+	resume, err := s.resumeRepo.GetResumeByID(userID, result.ResumeID)
+	if err != nil {
+		s.log.Warn("Failed to get resume by ID", zap.Error(err))
+		return nil, ErrResumeNotFound
+	}
+	vacancy, err := s.vacancyRepo.GetVacancyByID(userID, result.VacancyID)
+	if err != nil {
+		s.log.Warn("Failed to get vacancy by ID", zap.Error(err))
+		return nil, ErrVacancyNotFound
+	}
+	attachResumeFile(s.resumeRepo, resume)
+
+	ctx = llm.WithOwner(ctx, llm.Owner{UserID: userID, ResumeID: &resume.ID, Operation: llm.OperationRecommend})
+	recommendations, err := s.recommender.Recommend(ctx, resume, vacancy, result.Breakdown, s.cfg)
+	if err != nil {
+		s.log.Error("Failed to generate recommendations", zap.Error(err))
+		return nil, err
+	}
+	if err := s.repo.UpdateRecommendations(result.ID, recommendations); err != nil {
+		s.log.Error("Failed to save recommendations", zap.Error(err))
+		return nil, err
+	}
+	return recommendationsToDTO(result.ID, recommendations), nil
+}
+
+// resolveProfile выбирает профиль сравнения: привязанный к вакансии, затем профиль по умолчанию
+// владельца вакансии, затем встроенный. Профиль не зависит от того, кто из участников рабочего
+// пространства запустил сравнение, поэтому пара резюме и вакансии у всех оценивается одинаково.
+func resolveProfile(profileRepo repository.MatchingProfileRepositoryI, vacancy *models.Vacancy) (models.ProfileSnapshot, error) {
+	if profileRepo == nil {
+		return matching.DefaultProfile(), nil
+	}
+	if vacancy.MatchingProfileID != nil {
+		profile, err := profileRepo.LoadProfile(*vacancy.MatchingProfileID)
+		if err == nil {
+			return matching.Snapshot(profile), nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.ProfileSnapshot{}, err
+		}
+	}
+	profile, err := profileRepo.GetDefault(vacancy.UserID)
+	if err == nil {
+		return matching.Snapshot(profile), nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.ProfileSnapshot{}, err
+	}
+	return matching.DefaultProfile(), nil
+}
+
+// attachResumeFile подгружает текст документа: он нужен для подтверждений, близости текстов
+// и рекомендаций, но без него сравнение всё равно работает
+func attachResumeFile(resumeRepo repository.ResumeRepositoryI, resume *models.Resume) {
+	if file, err := resumeRepo.GetResumeFile(resume.ID); err == nil {
+		resume.File = *file
+	}
+}
+
+func recommendationsToDTO(matchID uuid.UUID, rec *models.Recommendations) *response.RecommendationsDTO {
+	dto := &response.RecommendationsDTO{
+		MatchID:         matchID.String(),
+		MissingKeywords: append([]string{}, rec.MissingKeywords...),
+		BulletRewrites:  []response.BulletRewriteDTO{},
+		SectionsToAdd:   []response.SectionSuggestionDTO{},
+		Source:          rec.Source,
+		Language:        rec.Language,
+		Model:           rec.Model,
+		PromptVersion:   rec.PromptVersion,
+		GeneratedAt:     rec.GeneratedAt,
+	}
+	for _, b := range rec.BulletRewrites {
+		dto.BulletRewrites = append(dto.BulletRewrites, response.BulletRewriteDTO{Original: b.Original, Suggested: b.Suggested, Reason: b.Reason})
+	}
+	for _, section := range rec.SectionsToAdd {
+		dto.SectionsToAdd = append(dto.SectionsToAdd, response.SectionSuggestionDTO{Section: section.Section, Reason: section.Reason})
+	}
+	return dto
+}
+
+func matchToDTO(result *models.MatchingResult) *response.MatchResultDTO {
+	dto := &response.MatchResultDTO{
+		ID:              result.ID.String(),
+		ResumeID:        result.ResumeID.String(),
+		VacancyID:       result.VacancyID.String(),
+		Score:           result.Score,
+		Knockout:        result.Knockout,
+		NoData:          result.Breakdown.NoData,
+		MatchedSkills:   result.Breakdown.MatchedSkills(),
+		UnmatchedSkills: result.Breakdown.UnmatchedSkills(),
+		MissingRequired: result.Breakdown.MissingRequired(),
+		Components:      []response.ScoreComponentDTO{},
+		SkillBreakdown:  []response.SkillMatchDTO{},
+		ResumeVersion:   result.ResumeVersion,
+		VacancyVersion:  result.VacancyVersion,
+		Stale:           result.Stale,
+		CreatedAt:       result.CreatedAt,
+	}
+	for _, c := range result.Breakdown.Components {
+		component := response.ScoreComponentDTO{
+			Name:         c.Name,
+			Score:        c.Score,
+			Weight:       c.Weight,
+			Contribution: c.Contribution,
+			Applicable:   c.Applicable,
+			Details:      c.Details,
+			Evidence:     []response.EvidenceDTO{},
+		}
+		for _, e := range c.Evidence {
+			component.Evidence = append(component.Evidence, response.EvidenceDTO{Requirement: e.Requirement, Source: e.Source, Snippet: e.Snippet})
+		}
+		dto.Components = append(dto.Components, component)
+	}
+	for _, skill := range result.Breakdown.Skills {
+		dto.SkillBreakdown = append(dto.SkillBreakdown, response.SkillMatchDTO{
+			Name:        skill.Name,
+			Requirement: skill.Requirement,
+			Weight:      skill.Weight,
+			Matched:     skill.Matched,
+			MinYears:    skill.MinYears,
+			Years:       skill.Years,
+			Credit:      skill.Credit,
+		})
+	}
+	// у результатов, посчитанных до появления профилей, снимка профиля нет
+	if result.Profile.Name != "" {
+		dto.Profile = snapshotToDTO(result.Profile)
+	}
+	return dto
+}
@@ -0,0 +1,266 @@
+package service
+
+import (
+	"CVMatch/internal/config"
+	"CVMatch/internal/models"
+	"CVMatch/internal/repository/mocks"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestMatchService_CreateMatch_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	resumeRepo := mocks.NewMockResumeRepositoryI(ctrl)
+	vacancyRepo := mocks.NewMockVacancyRepositoryI(ctrl)
+	matchRepo := mocks.NewMockMatchingRepositoryI(ctrl)
+
+	userID, resumeID, vacancyID := uuid.New(), uuid.New(), uuid.New()
+	resumeRepo.EXPECT().GetResumeByID(userID, resumeID).Return(&models.Resume{
+		ID:        resumeID,
+		Seniority: "middle",
+		Skills:    []models.Skill{{Name: "Go"}},
+	}, nil)
+	vacancyRepo.EXPECT().GetVacancyByID(userID, vacancyID).Return(&models.Vacancy{
+		ID:        vacancyID,
+		Seniority: "senior",
+		Skills:    []models.Skill{{Name: "Go"}, {Name: "Kafka"}},
+	}, nil)
+	resumeRepo.EXPECT().GetResumeFile(resumeID).Return(&models.ResumeFile{RawText: "Backend developer, Go"}, nil)
+	matchRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(r *models.MatchingResult) error {
+		require.Equal(t, []string{"Go"}, r.Breakdown.MatchedSkills())
+		require.Len(t, r.Breakdown.Components, 6)
+		return nil
+	})
+
+	service := NewMatchService(resumeRepo, vacancyRepo, matchRepo, nil, zap.NewNop(), &config.Config{}, nil)
+	dto, err := service.CreateMatch(userID, resumeID, vacancyID)
+	require.NoError(t, err)
+	require.Equal(t, 55.5, dto.Score)
+	require.Equal(t, []string{"Go"}, dto.MatchedSkills)
+	require.Equal(t, []string{"Kafka"}, dto.UnmatchedSkills)
+	require.Equal(t, "skills", dto.Components[0].Name)
+	require.Equal(t, 50.0, dto.Components[0].Score)
+	require.Equal(t, "resume_text", dto.Components[0].Evidence[0].Source)
+	require.Equal(t, "Backend developer, Go", dto.Components[0].Evidence[0].Snippet)
+}
+
+func TestMatchService_CreateMatch_Knockout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	resumeRepo := mocks.NewMockResumeRepositoryI(ctrl)
+	vacancyRepo := mocks.NewMockVacancyRepositoryI(ctrl)
+	matchRepo := mocks.NewMockMatchingRepositoryI(ctrl)
+
+	userID, resumeID, vacancyID := uuid.New(), uuid.New(), uuid.New()
+	goID, kafkaID := uuid.New(), uuid.New()
+	resumeRepo.EXPECT().GetResumeByID(userID, resumeID).Return(&models.Resume{
+		ID:     resumeID,
+		Skills: []models.Skill{{Name: "Go"}},
+	}, nil)
+	vacancyRepo.EXPECT().GetVacancyByID(userID, vacancyID).Return(&models.Vacancy{
+		ID:     vacancyID,
+		Skills: []models.Skill{{ID: goID, Name: "Go"}, {ID: kafkaID, Name: "Kafka"}},
+		Requirements: []models.VacancySkill{
+			{SkillID: goID, Requirement: models.SkillPreferred, Weight: 1},
+			{SkillID: kafkaID, Requirement: models.SkillRequired, Weight: 1},
+		},
+	}, nil)
+	resumeRepo.EXPECT().GetResumeFile(resumeID).Return(nil, assert.AnError)
+	matchRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(r *models.MatchingResult) error {
+		require.True(t, r.Knockout)
+		require.Equal(t, []string{"Kafka"}, r.Breakdown.MissingRequired())
+		return nil
+	})
+
+	service := NewMatchService(resumeRepo, vacancyRepo, matchRepo, nil, zap.NewNop(), &config.Config{}, nil)
+	dto, err := service.CreateMatch(userID, resumeID, vacancyID)
+	require.NoError(t, err)
+	require.True(t, dto.Knockout)
+	require.Equal(t, 40.0, dto.Score)
+	require.Equal(t, []string{"Kafka"}, dto.MissingRequired)
+	require.Len(t, dto.SkillBreakdown, 2)
+	require.True(t, dto.SkillBreakdown[0].Matched)
+}
+
+func TestMatchService_CreateMatch_UsesVacancyProfile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	resumeRepo := mocks.NewMockResumeRepositoryI(ctrl)
+	vacancyRepo := mocks.NewMockVacancyRepositoryI(ctrl)
+	matchRepo := mocks.NewMockMatchingRepositoryI(ctrl)
+	profileRepo := mocks.NewMockMatchingProfileRepositoryI(ctrl)
+
+	userID, ownerID, resumeID, vacancyID, profileID := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	resumeRepo.EXPECT().GetResumeByID(userID, resumeID).Return(&models.Resume{
+		ID:     resumeID,
+		Skills: []models.Skill{{Name: "Go"}},
+	}, nil)
+	// Вакансию коллеги по рабочему пространству сравнение считает по её профилю
+	vacancyRepo.EXPECT().GetVacancyByID(userID, vacancyID).Return(&models.Vacancy{
+		ID:                vacancyID,
+		UserID:            ownerID,
+		Skills:            []models.Skill{{Name: "Go"}, {Name: "Kafka"}},
+		MatchingProfileID: &profileID,
+	}, nil)
+	profileRepo.EXPECT().LoadProfile(profileID).Return(&models.MatchingProfile{
+		ID:            profileID,
+		Name:          "skills only",
+		Weights:       models.ComponentWeights{Skills: 1},
+		Normalization: models.NormalizationRenormalize,
+	}, nil)
+	resumeRepo.EXPECT().GetResumeFile(resumeID).Return(nil, assert.AnError)
+	matchRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(r *models.MatchingResult) error {
+		require.Equal(t, &profileID, r.ProfileID)
+		require.Equal(t, "skills only", r.Profile.Name)
+		return nil
+	})
+
+	service := NewMatchService(resumeRepo, vacancyRepo, matchRepo, profileRepo, zap.NewNop(), &config.Config{}, nil)
+	dto, err := service.CreateMatch(userID, resumeID, vacancyID)
+	require.NoError(t, err)
+	require.Equal(t, 50.0, dto.Score)
+	require.NotNil(t, dto.Profile)
+	require.Equal(t, profileID.String(), dto.Profile.ID)
+	require.Equal(t, 1.0, dto.Profile.Weights.Skills)
+}
+
+func TestMatchService_CreateMatch_UsesOwnerDefaultProfile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	resumeRepo := mocks.NewMockResumeRepositoryI(ctrl)
+	vacancyRepo := mocks.NewMockVacancyRepositoryI(ctrl)
+	matchRepo := mocks.NewMockMatchingRepositoryI(ctrl)
+	profileRepo := mocks.NewMockMatchingProfileRepositoryI(ctrl)
+
+	userID, ownerID, resumeID, vacancyID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	resumeRepo.EXPECT().GetResumeByID(userID, resumeID).Return(&models.Resume{ID: resumeID}, nil)
+	vacancyRepo.EXPECT().GetVacancyByID(userID, vacancyID).Return(&models.Vacancy{ID: vacancyID, UserID: ownerID}, nil)
+	profileRepo.EXPECT().GetDefault(ownerID).Return(nil, gorm.ErrRecordNotFound)
+	resumeRepo.EXPECT().GetResumeFile(resumeID).Return(nil, assert.AnError)
+	matchRepo.EXPECT().Create(gomock.Any()).Return(nil)
+
+	service := NewMatchService(resumeRepo, vacancyRepo, matchRepo, profileRepo, zap.NewNop(), &config.Config{}, nil)
+	dto, err := service.CreateMatch(userID, resumeID, vacancyID)
+	require.NoError(t, err)
+	require.NotNil(t, dto.Profile)
+	require.Empty(t, dto.Profile.ID)
+	require.Equal(t, "default", dto.Profile.Name)
+}
+
+func TestMatchService_CreateMatch_VacancyNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	resumeRepo := mocks.NewMockResumeRepositoryI(ctrl)
+	vacancyRepo := mocks.NewMockVacancyRepositoryI(ctrl)
+	matchRepo := mocks.NewMockMatchingRepositoryI(ctrl)
+
+	userID, resumeID, vacancyID := uuid.New(), uuid.New(), uuid.New()
+	resumeRepo.EXPECT().GetResumeByID(userID, resumeID).Return(&models.Resume{ID: resumeID}, nil)
+	vacancyRepo.EXPECT().GetVacancyByID(userID, vacancyID).Return(nil, assert.AnError)
+
+	service := NewMatchService(resumeRepo, vacancyRepo, matchRepo, nil, zap.NewNop(), &config.Config{}, nil)
+	dto, err := service.CreateMatch(userID, resumeID, vacancyID)
+	require.ErrorIs(t, err, ErrVacancyNotFound)
+	require.Nil(t, dto)
+}
+
+func TestMatchService_GenerateRecommendations_Stores(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	resumeRepo := mocks.NewMockResumeRepositoryI(ctrl)
+	vacancyRepo := mocks.NewMockVacancyRepositoryI(ctrl)
+	matchRepo := mocks.NewMockMatchingRepositoryI(ctrl)
+
+	userID, matchID, resumeID, vacancyID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	matchRepo.EXPECT().GetMatchByID(userID, matchID).Return(&models.MatchingResult{
+		ID:        matchID,
+		ResumeID:  resumeID,
+		VacancyID: vacancyID,
+		Breakdown: models.ScoreBreakdown{Skills: []models.SkillMatch{
+			{Name: "Go", Requirement: models.SkillRequired, Matched: true, Credit: 1},
+			{Name: "Kafka", Requirement: models.SkillPreferred},
+			{Name: "PostgreSQL", Requirement: models.SkillRequired},
+		}},
+	}, nil)
+	resumeRepo.EXPECT().GetResumeByID(userID, resumeID).Return(&models.Resume{
+		ID:         resumeID,
+		Language:   "ru",
+		Skills:     []models.Skill{{Name: "Go"}},
+		Experience: []models.Experience{{Company: "Ozon", Description: "Разрабатывал сервис заказов. Поддерживал CI."}},
+	}, nil)
+	vacancyRepo.EXPECT().GetVacancyByID(userID, vacancyID).Return(&models.Vacancy{ID: vacancyID, Skills: []models.Skill{{Name: "Go"}}}, nil)
+	resumeRepo.EXPECT().GetResumeFile(resumeID).Return(nil, assert.AnError)
+	matchRepo.EXPECT().UpdateRecommendations(matchID, gomock.Any()).DoAndReturn(func(_ uuid.UUID, rec *models.Recommendations) error {
+		require.Equal(t, models.SourceTemplate, rec.Source)
+		return nil
+	})
+
+	service := NewMatchService(resumeRepo, vacancyRepo, matchRepo, nil, zap.NewNop(), &config.Config{}, nil)
+	dto, err := service.GenerateRecommendations(context.Background(), userID, matchID)
+	require.NoError(t, err)
+	require.Equal(t, matchID.String(), dto.MatchID)
+	require.Equal(t, []string{"PostgreSQL", "Kafka"}, dto.MissingKeywords)
+	require.Len(t, dto.BulletRewrites, 1)
+	require.Equal(t, "Разрабатывал сервис заказов.", dto.BulletRewrites[0].Original)
+	require.Contains(t, dto.BulletRewrites[0].Suggested, "используя Go")
+	require.NotEmpty(t, dto.SectionsToAdd)
+}
+
+func TestMatchService_GetRecommendations_ReturnsStored(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	matchRepo := mocks.NewMockMatchingRepositoryI(ctrl)
+	userID, matchID := uuid.New(), uuid.New()
+	matchRepo.EXPECT().GetMatchByID(userID, matchID).Return(&models.MatchingResult{
+		ID:              matchID,
+		Recommendations: &models.Recommendations{MissingKeywords: []string{"Kafka"}, Source: models.SourceLLM},
+	}, nil)
+
+	service := NewMatchService(nil, nil, matchRepo, nil, zap.NewNop(), &config.Config{}, nil)
+	dto, err := service.GetRecommendations(userID, matchID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Kafka"}, dto.MissingKeywords)
+	require.Equal(t, models.SourceLLM, dto.Source)
+}
+
+func TestMatchService_GetRecommendations_NotGenerated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Чтение не составляет советы: ни LLM, ни запись в результат не вызываются
+	matchRepo := mocks.NewMockMatchingRepositoryI(ctrl)
+	userID, matchID := uuid.New(), uuid.New()
+	matchRepo.EXPECT().GetMatchByID(userID, matchID).Return(&models.MatchingResult{ID: matchID}, nil)
+
+	service := NewMatchService(nil, nil, matchRepo, nil, zap.NewNop(), &config.Config{}, nil)
+	_, err := service.GetRecommendations(userID, matchID)
+	require.ErrorIs(t, err, ErrRecommendationsNotFound)
+}
+
+func TestMatchService_GetRecommendations_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	matchRepo := mocks.NewMockMatchingRepositoryI(ctrl)
+	userID, matchID := uuid.New(), uuid.New()
+	matchRepo.EXPECT().GetMatchByID(userID, matchID).Return(nil, assert.AnError)
+
+	service := NewMatchService(nil, nil, matchRepo, nil, zap.NewNop(), &config.Config{}, nil)
+	_, err := service.GenerateRecommendations(context.Background(), userID, matchID)
+	require.ErrorIs(t, err, ErrMatchNotFound)
+}
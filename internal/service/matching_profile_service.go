@@ -0,0 +1,192 @@
+package service
+
+import (
+	"CVMatch/internal/matching"
+	"CVMatch/internal/models"
+	"CVMatch/internal/repository"
+	"CVMatch/internal/response"
+	"errors"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrMatchingProfileNotFound = errors.New("matching profile not found")
+	ErrInvalidWeights          = errors.New("at least one component weight must be positive")
+)
+
+type MatchingProfileService struct {
+	repo        repository.MatchingProfileRepositoryI
+	vacancyRepo repository.VacancyRepositoryI
+	log         *zap.Logger
+	rematch     RematchNotifierI
+}
+
+func NewMatchingProfileService(repo repository.MatchingProfileRepositoryI, vacancyRepo repository.VacancyRepositoryI, log *zap.Logger, rematch RematchNotifierI) *MatchingProfileService {
+	return &MatchingProfileService{
+		repo:        repo,
+		vacancyRepo: vacancyRepo,
+		log:         log,
+		rematch:     rematch,
+	}
+}
+
+// DefaultMatchingProfile — настройки встроенного профиля, от которых отталкивается новый профиль
+func DefaultMatchingProfile() *response.MatchingProfileDTO {
+	return snapshotToDTO(matching.DefaultProfile())
+}
+
+// CreateProfile сохраняет профиль сравнения
+func (s *MatchingProfileService) CreateProfile(userID uuid.UUID, dto *response.MatchingProfileDTO) (*response.MatchingProfileDTO, error) {
+	profile := &models.MatchingProfile{UserID: userID}
+	if err := applyProfileDTO(profile, dto); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(profile); err != nil {
+		s.log.Error("Failed to create matching profile", zap.Error(err))
+		return nil, err
+	}
+	if profile.IsDefault {
+		notifyRematch(s.rematch)
+	}
+	return profileToDTO(profile), nil
+}
+
+// UpdateProfile заменяет настройки профиля целиком.
+// Результаты сравнения с вакансиями, которые считаются по профилю, пересчитываются.
+func (s *MatchingProfileService) UpdateProfile(userID, profileID uuid.UUID, dto *response.MatchingProfileDTO) (*response.MatchingProfileDTO, error) {
+	profile, err := s.repo.GetByID(userID, profileID)
+	if err != nil {
+		s.log.Warn("Failed to get matching profile by ID", zap.Error(err))
+		return nil, ErrMatchingProfileNotFound
+	}
+	if err := applyProfileDTO(profile, dto); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(profile); err != nil {
+		s.log.Error("Failed to update matching profile", zap.Error(err))
+		return nil, err
+	}
+	notifyRematch(s.rematch)
+	return profileToDTO(profile), nil
+}
+
+func (s *MatchingProfileService) GetProfile(userID, profileID uuid.UUID) (*response.MatchingProfileDTO, error) {
+	profile, err := s.repo.GetByID(userID, profileID)
+	if err != nil {
+		s.log.Warn("Failed to get matching profile by ID", zap.Error(err))
+		return nil, ErrMatchingProfileNotFound
+	}
+	return profileToDTO(profile), nil
+}
+
+func (s *MatchingProfileService) ListProfiles(userID uuid.UUID) (*response.MatchingProfileListDTO, error) {
+	profiles, err := s.repo.List(userID)
+	if err != nil {
+		s.log.Error("Failed to list matching profiles", zap.Error(err))
+		return nil, err
+	}
+	dto := &response.MatchingProfileListDTO{Profiles: []*response.MatchingProfileDTO{}}
+	for i := range profiles {
+		dto.Profiles = append(dto.Profiles, profileToDTO(&profiles[i]))
+	}
+	return dto, nil
+}
+
+// DeleteProfile удаляет профиль; вакансии с этим профилем переходят на профиль по умолчанию,
+// результаты сравнения с ними пересчитываются
+func (s *MatchingProfileService) DeleteProfile(userID, profileID uuid.UUID) error {
+	if err := s.repo.Delete(userID, profileID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrMatchingProfileNotFound
+		}
+		s.log.Error("Failed to delete matching profile", zap.Error(err))
+		return err
+	}
+	notifyRematch(s.rematch)
+	return nil
+}
+
+// AttachToVacancy привязывает профиль к вакансии пользователя; nil отвязывает профиль.
+// Результаты сравнения с вакансией после этого пересчитываются по новому профилю.
+func (s *MatchingProfileService) AttachToVacancy(userID, vacancyID uuid.UUID, profileID *uuid.UUID) error {
+	if _, err := s.vacancyRepo.GetVacancyByID(userID, vacancyID); err != nil {
+		s.log.Warn("Failed to get vacancy by ID", zap.Error(err))
+		return ErrVacancyNotFound
+	}
+	if profileID != nil {
+		if _, err := s.repo.GetByID(userID, *profileID); err != nil {
+			s.log.Warn("Failed to get matching profile by ID", zap.Error(err))
+			return ErrMatchingProfileNotFound
+		}
+	}
+	if err := s.vacancyRepo.SetMatchingProfile(vacancyID, profileID); err != nil {
+		s.log.Error("Failed to attach matching profile", zap.Error(err))
+		return err
+	}
+	notifyRematch(s.rematch)
+	return nil
+}
+
+func applyProfileDTO(profile *models.MatchingProfile, dto *response.MatchingProfileDTO) error {
+	w := dto.Weights
+	if w.Skills+w.Experience+w.Seniority+w.Education+w.Location+w.Semantic <= 0 {
+		return ErrInvalidWeights
+	}
+
+	profile.Weights = models.ComponentWeights{
+		Skills:     w.Skills,
+		Experience: w.Experience,
+		Seniority:  w.Seniority,
+		Education:  w.Education,
+		Location:   w.Location,
+		Semantic:   w.Semantic,
+	}
+
+	profile.Name = dto.Name
+	profile.IsDefault = dto.IsDefault
+	profile.Knockout = models.KnockoutRules{
+		MissingRequired:    dto.Knockout.MissingRequired,
+		BelowMinExperience: dto.Knockout.BelowMinExperience,
+		Cap:                dto.Knockout.Cap,
+	}
+	profile.Normalization = dto.Normalization
+	if profile.Normalization == "" {
+		profile.Normalization = models.NormalizationRenormalize
+	}
+	return nil
+}
+
+func profileToDTO(profile *models.MatchingProfile) *response.MatchingProfileDTO {
+	dto := snapshotToDTO(matching.Snapshot(profile))
+	dto.IsDefault = profile.IsDefault
+	dto.CreatedAt = &profile.CreatedAt
+	dto.UpdatedAt = &profile.UpdatedAt
+	return dto
+}
+
+func snapshotToDTO(snapshot models.ProfileSnapshot) *response.MatchingProfileDTO {
+	dto := &response.MatchingProfileDTO{
+		Name: snapshot.Name,
+		Weights: response.ComponentWeightsDTO{
+			Skills:     snapshot.Weights.Skills,
+			Experience: snapshot.Weights.Experience,
+			Seniority:  snapshot.Weights.Seniority,
+			Education:  snapshot.Weights.Education,
+			Location:   snapshot.Weights.Location,
+			Semantic:   snapshot.Weights.Semantic,
+		},
+		Knockout: response.KnockoutRulesDTO{
+			MissingRequired:    snapshot.Knockout.MissingRequired,
+			BelowMinExperience: snapshot.Knockout.BelowMinExperience,
+			Cap:                snapshot.Knockout.Cap,
+		},
+		Normalization: snapshot.Normalization,
+	}
+	if snapshot.ID != nil {
+		dto.ID = snapshot.ID.String()
+	}
+	return dto
+}
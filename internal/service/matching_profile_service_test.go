@@ -0,0 +1,112 @@
+package service
+
+import (
+	"CVMatch/internal/matching"
+	"CVMatch/internal/models"
+	"CVMatch/internal/repository/mocks"
+	"CVMatch/internal/response"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestMatchingProfileService_CreateProfile_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMatchingProfileRepositoryI(ctrl)
+	userID := uuid.New()
+	repo.EXPECT().Create(gomock.Any()).DoAndReturn(func(p *models.MatchingProfile) error {
+		require.Equal(t, userID, p.UserID)
+		require.Equal(t, matching.DefaultWeights, p.Weights)
+		require.True(t, p.Knockout.MissingRequired)
+		p.ID = uuid.New()
+		return nil
+	})
+
+	dto := DefaultMatchingProfile()
+	dto.Name = "backend"
+	dto.IsDefault = true
+
+	service := NewMatchingProfileService(repo, nil, zap.NewNop(), nil)
+	created, err := service.CreateProfile(userID, dto)
+	require.NoError(t, err)
+	require.NotEmpty(t, created.ID)
+	require.Equal(t, "backend", created.Name)
+	require.True(t, created.IsDefault)
+	require.Equal(t, models.NormalizationRenormalize, created.Normalization)
+}
+
+func TestMatchingProfileService_CreateProfile_InvalidWeights(t *testing.T) {
+	service := NewMatchingProfileService(nil, nil, zap.NewNop(), nil)
+	_, err := service.CreateProfile(uuid.New(), &response.MatchingProfileDTO{Name: "empty"})
+	require.ErrorIs(t, err, ErrInvalidWeights)
+}
+
+func TestMatchingProfileService_DeleteProfile_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMatchingProfileRepositoryI(ctrl)
+	userID, profileID := uuid.New(), uuid.New()
+	repo.EXPECT().Delete(userID, profileID).Return(gorm.ErrRecordNotFound)
+
+	service := NewMatchingProfileService(repo, nil, zap.NewNop(), nil)
+	require.ErrorIs(t, service.DeleteProfile(userID, profileID), ErrMatchingProfileNotFound)
+}
+
+func TestMatchingProfileService_UpdateAndDelete_Rematch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMatchingProfileRepositoryI(ctrl)
+	userID, profileID := uuid.New(), uuid.New()
+	repo.EXPECT().GetByID(userID, profileID).Return(&models.MatchingProfile{ID: profileID, UserID: userID}, nil)
+	repo.EXPECT().Update(gomock.Any()).Return(nil)
+	repo.EXPECT().Delete(userID, profileID).Return(nil)
+
+	notifier := &countingNotifier{}
+	service := NewMatchingProfileService(repo, nil, zap.NewNop(), notifier)
+	dto := DefaultMatchingProfile()
+	dto.Name = "backend"
+	_, err := service.UpdateProfile(userID, profileID, dto)
+	require.NoError(t, err)
+	require.NoError(t, service.DeleteProfile(userID, profileID))
+	require.Equal(t, 2, notifier.calls)
+}
+
+func TestMatchingProfileService_AttachToVacancy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMatchingProfileRepositoryI(ctrl)
+	vacancyRepo := mocks.NewMockVacancyRepositoryI(ctrl)
+	userID, vacancyID, profileID := uuid.New(), uuid.New(), uuid.New()
+	vacancyRepo.EXPECT().GetVacancyByID(userID, vacancyID).Return(&models.Vacancy{ID: vacancyID}, nil).Times(2)
+	repo.EXPECT().GetByID(userID, profileID).Return(&models.MatchingProfile{ID: profileID}, nil)
+	vacancyRepo.EXPECT().SetMatchingProfile(vacancyID, &profileID).Return(nil)
+	vacancyRepo.EXPECT().SetMatchingProfile(vacancyID, nil).Return(nil)
+
+	service := NewMatchingProfileService(repo, vacancyRepo, zap.NewNop(), nil)
+	require.NoError(t, service.AttachToVacancy(userID, vacancyID, &profileID))
+	require.NoError(t, service.AttachToVacancy(userID, vacancyID, nil))
+}
+
+func TestMatchingProfileService_AttachToVacancy_ProfileNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMatchingProfileRepositoryI(ctrl)
+	vacancyRepo := mocks.NewMockVacancyRepositoryI(ctrl)
+	userID, vacancyID, profileID := uuid.New(), uuid.New(), uuid.New()
+	vacancyRepo.EXPECT().GetVacancyByID(userID, vacancyID).Return(&models.Vacancy{ID: vacancyID}, nil)
+	repo.EXPECT().GetByID(userID, profileID).Return(nil, assert.AnError)
+
+	service := NewMatchingProfileService(repo, vacancyRepo, zap.NewNop(), nil)
+	require.ErrorIs(t, service.AttachToVacancy(userID, vacancyID, &profileID), ErrMatchingProfileNotFound)
+}
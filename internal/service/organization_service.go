@@ -0,0 +1,299 @@
+package service
+
+import (
+	"CVMatch/internal/models"
+	"CVMatch/internal/repository"
+	"CVMatch/internal/response"
+	"errors"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrOrganizationNotFound  = errors.New("organization not found")
+	ErrOrganizationForbidden = errors.New("only organization owners can manage members")
+	ErrInvalidOrganization   = errors.New("organization name must be 1-255 characters")
+	ErrInvalidOrgRole        = errors.New("role must be one of owner, recruiter, hiring_manager, viewer")
+	ErrMemberNotFound        = errors.New("member not found")
+	ErrMemberExists          = errors.New("user is already a member of the organization")
+	ErrLastOwner             = errors.New("organization must keep at least one owner")
+	ErrPersonalWorkspace     = errors.New("personal workspace cannot be shared")
+)
+
+const maxOrganizationName = 255
+
+// OrganizationService — организации, их участники и выбор рабочего пространства
+type OrganizationService struct {
+	repo     repository.OrganizationRepositoryI
+	userRepo repository.UserRepositoryI
+	log      *zap.Logger
+}
+
+func NewOrganizationService(repo repository.OrganizationRepositoryI, userRepo repository.UserRepositoryI, log *zap.Logger) *OrganizationService {
+	return &OrganizationService{
+		repo:     repo,
+		userRepo: userRepo,
+		log:      log,
+	}
+}
+
+// CreateOrganization создаёт организацию, в которой пользователь становится владельцем.
+// Текущее рабочее пространство не меняется.
+func (s *OrganizationService) CreateOrganization(userID uuid.UUID, name string) (*response.OrganizationDTO, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxOrganizationName {
+		return nil, ErrInvalidOrganization
+	}
+	organization := &models.Organization{Name: name}
+	txErr := s.repo.DB().Transaction(func(tx *gorm.DB) error {
+		txRepo := s.repo.WithTx(tx)
+		if err := txRepo.Create(organization); err != nil {
+			s.log.Error("Failed to create organization", zap.Error(err))
+			return err
+		}
+		member := &models.OrganizationMember{OrganizationID: organization.ID, UserID: userID, Role: models.OrgRoleOwner}
+		if err := txRepo.AddMember(member); err != nil {
+			s.log.Error("Failed to add organization owner", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+	return &response.OrganizationDTO{ID: organization.ID.String(), Name: organization.Name, Role: models.OrgRoleOwner}, nil
+}
+
+// ListOrganizations возвращает организации пользователя и отмечает текущее рабочее пространство
+func (s *OrganizationService) ListOrganizations(userID uuid.UUID) (*response.OrganizationListDTO, error) {
+	memberships, err := s.repo.ListByUser(userID)
+	if err != nil {
+		s.log.Error("Failed to get list of organizations", zap.Error(err))
+		return nil, err
+	}
+	active, err := s.repo.ActiveMember(userID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		s.log.Error("Failed to get active workspace", zap.Error(err))
+		return nil, err
+	}
+	dto := &response.OrganizationListDTO{Organizations: make([]response.OrganizationDTO, 0, len(memberships))}
+	for _, membership := range memberships {
+		dto.Organizations = append(dto.Organizations, response.OrganizationDTO{
+			ID:       membership.ID.String(),
+			Name:     membership.Name,
+			Personal: membership.Personal,
+			Role:     membership.Role,
+			Active:   active != nil && active.OrganizationID == membership.ID,
+		})
+	}
+	return dto, nil
+}
+
+// GetOrganization возвращает организацию с участниками; доступна любому её участнику
+func (s *OrganizationService) GetOrganization(userID, organizationID uuid.UUID) (*response.OrganizationDTO, error) {
+	organization, member, err := s.membership(userID, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.repo.ListMembers(organizationID)
+	if err != nil {
+		s.log.Error("Failed to get organization members", zap.Error(err))
+		return nil, err
+	}
+	dto, err := s.organizationDTO(userID, organization, member.Role)
+	if err != nil {
+		return nil, err
+	}
+	dto.Members = make([]response.OrganizationMemberDTO, 0, len(members))
+	for i := range members {
+		dto.Members = append(dto.Members, memberToDTO(&members[i]))
+	}
+	return dto, nil
+}
+
+// SwitchWorkspace делает организацию текущим рабочим пространством пользователя
+func (s *OrganizationService) SwitchWorkspace(userID, organizationID uuid.UUID) (*response.OrganizationDTO, error) {
+	organization, member, err := s.membership(userID, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetActive(userID, organizationID); err != nil {
+		s.log.Error("Failed to switch workspace", zap.Error(err))
+		return nil, err
+	}
+	return &response.OrganizationDTO{
+		ID:       organization.ID.String(),
+		Name:     organization.Name,
+		Personal: organization.Personal,
+		Role:     member.Role,
+		Active:   true,
+	}, nil
+}
+
+// AddMember приглашает зарегистрированного пользователя по email; доступно только владельцам
+func (s *OrganizationService) AddMember(userID, organizationID uuid.UUID, email, role string) (*response.OrganizationMemberDTO, error) {
+	if !slices.Contains(models.OrgRoles, role) {
+		return nil, ErrInvalidOrgRole
+	}
+	organization, err := s.ownedOrganization(userID, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	if organization.Personal {
+		return nil, ErrPersonalWorkspace
+	}
+	user, err := s.userRepo.FindByEmail(strings.TrimSpace(email))
+	if err != nil {
+		s.log.Warn("Failed to find user by email", zap.Error(err))
+		return nil, ErrUserNotFound
+	}
+	if _, err := s.repo.GetMember(organizationID, user.ID); err == nil {
+		return nil, ErrMemberExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		s.log.Error("Failed to get organization member", zap.Error(err))
+		return nil, err
+	}
+	member := &models.OrganizationMember{OrganizationID: organizationID, UserID: user.ID, Role: role}
+	if err := s.repo.AddMember(member); err != nil {
+		s.log.Error("Failed to add organization member", zap.Error(err))
+		return nil, err
+	}
+	member.User = *user
+	dto := memberToDTO(member)
+	return &dto, nil
+}
+
+// UpdateMemberRole меняет роль участника; доступно только владельцам.
+// Последнего владельца понизить нельзя.
+func (s *OrganizationService) UpdateMemberRole(userID, organizationID, memberID uuid.UUID, role string) error {
+	if !slices.Contains(models.OrgRoles, role) {
+		return ErrInvalidOrgRole
+	}
+	if _, err := s.ownedOrganization(userID, organizationID); err != nil {
+		return err
+	}
+	member, err := s.repo.GetMember(organizationID, memberID)
+	if err != nil {
+		s.log.Warn("Failed to get organization member", zap.Error(err))
+		return ErrMemberNotFound
+	}
+	if member.Role == models.OrgRoleOwner && role != models.OrgRoleOwner {
+		if err := s.keepOwner(organizationID); err != nil {
+			return err
+		}
+	}
+	if err := s.repo.UpdateMemberRole(organizationID, memberID, role); err != nil {
+		s.log.Error("Failed to update member role", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// RemoveMember исключает участника: владелец может исключить любого, остальные — только выйти сами.
+// Исключённый, если работал в этой организации, возвращается в личное пространство.
+func (s *OrganizationService) RemoveMember(userID, organizationID, memberID uuid.UUID) error {
+	organization, requester, err := s.membership(userID, organizationID)
+	if err != nil {
+		return err
+	}
+	if organization.Personal {
+		return ErrPersonalWorkspace
+	}
+	if memberID != userID && requester.Role != models.OrgRoleOwner {
+		return ErrOrganizationForbidden
+	}
+	member, err := s.repo.GetMember(organizationID, memberID)
+	if err != nil {
+		s.log.Warn("Failed to get organization member", zap.Error(err))
+		return ErrMemberNotFound
+	}
+	if member.Role == models.OrgRoleOwner {
+		if err := s.keepOwner(organizationID); err != nil {
+			return err
+		}
+	}
+
+	return s.repo.DB().Transaction(func(tx *gorm.DB) error {
+		txRepo := s.repo.WithTx(tx)
+		if err := txRepo.RemoveMember(organizationID, memberID); err != nil {
+			s.log.Error("Failed to remove organization member", zap.Error(err))
+			return err
+		}
+		if err := txRepo.ResetActive(organizationID, memberID); err != nil {
+			s.log.Error("Failed to reset active workspace", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+}
+
+// membership возвращает организацию и участие в ней пользователя. Чужие организации
+// выглядят несуществующими.
+func (s *OrganizationService) membership(userID, organizationID uuid.UUID) (*models.Organization, *models.OrganizationMember, error) {
+	member, err := s.repo.GetMember(organizationID, userID)
+	if err != nil {
+		s.log.Warn("Failed to get organization member", zap.Error(err))
+		return nil, nil, ErrOrganizationNotFound
+	}
+	organization, err := s.repo.GetByID(organizationID)
+	if err != nil {
+		s.log.Warn("Failed to get organization by ID", zap.Error(err))
+		return nil, nil, ErrOrganizationNotFound
+	}
+	return organization, member, nil
+}
+
+// ownedOrganization возвращает организацию, если пользователь — её владелец
+func (s *OrganizationService) ownedOrganization(userID, organizationID uuid.UUID) (*models.Organization, error) {
+	organization, member, err := s.membership(userID, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	if member.Role != models.OrgRoleOwner {
+		return nil, ErrOrganizationForbidden
+	}
+	return organization, nil
+}
+
+// keepOwner не даёт лишить организацию последнего владельца
+func (s *OrganizationService) keepOwner(organizationID uuid.UUID) error {
+	owners, err := s.repo.CountOwners(organizationID)
+	if err != nil {
+		s.log.Error("Failed to count organization owners", zap.Error(err))
+		return err
+	}
+	if owners <= 1 {
+		return ErrLastOwner
+	}
+	return nil
+}
+
+func (s *OrganizationService) organizationDTO(userID uuid.UUID, organization *models.Organization, role string) (*response.OrganizationDTO, error) {
+	active, err := s.repo.ActiveMember(userID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		s.log.Error("Failed to get active workspace", zap.Error(err))
+		return nil, err
+	}
+	return &response.OrganizationDTO{
+		ID:       organization.ID.String(),
+		Name:     organization.Name,
+		Personal: organization.Personal,
+		Role:     role,
+		Active:   active != nil && active.OrganizationID == organization.ID,
+	}, nil
+}
+
+func memberToDTO(member *models.OrganizationMember) response.OrganizationMemberDTO {
+	return response.OrganizationMemberDTO{
+		UserID:   member.UserID.String(),
+		Email:    member.User.Email,
+		Nickname: member.User.Nickname,
+		Role:     member.Role,
+		JoinedAt: member.CreatedAt,
+	}
+}
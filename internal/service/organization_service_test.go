@@ -0,0 +1,166 @@
+package service
+
+import (
+	"CVMatch/internal/models"
+	"CVMatch/internal/repository"
+	"CVMatch/internal/repository/mocks"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestOrganizationService_CreateOrganization_OwnerMembership(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockOrganizationRepositoryI(ctrl)
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	userID := uuid.New()
+	repo.EXPECT().DB().Return(db)
+	repo.EXPECT().WithTx(gomock.Any()).Return(repo)
+	repo.EXPECT().Create(gomock.Any()).DoAndReturn(func(o *models.Organization) error {
+		require.Equal(t, "Рекрутинг", o.Name)
+		require.False(t, o.Personal)
+		o.ID = uuid.New()
+		return nil
+	})
+	repo.EXPECT().AddMember(gomock.Any()).DoAndReturn(func(m *models.OrganizationMember) error {
+		require.Equal(t, userID, m.UserID)
+		require.Equal(t, models.OrgRoleOwner, m.Role)
+		return nil
+	})
+
+	service := NewOrganizationService(repo, nil, zap.NewNop())
+	dto, err := service.CreateOrganization(userID, "  Рекрутинг ")
+	require.NoError(t, err)
+	require.Equal(t, models.OrgRoleOwner, dto.Role)
+
+	_, err = service.CreateOrganization(userID, " ")
+	require.ErrorIs(t, err, ErrInvalidOrganization)
+}
+
+func TestOrganizationService_ListOrganizations_MarksActive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockOrganizationRepositoryI(ctrl)
+	userID, personalID, teamID := uuid.New(), uuid.New(), uuid.New()
+	repo.EXPECT().ListByUser(userID).Return([]repository.OrganizationMembership{
+		{ID: personalID, Name: "anna@example.com", Personal: true, Role: models.OrgRoleOwner},
+		{ID: teamID, Name: "Команда", Role: models.OrgRoleRecruiter},
+	}, nil)
+	repo.EXPECT().ActiveMember(userID).Return(&models.OrganizationMember{OrganizationID: teamID, UserID: userID}, nil)
+
+	service := NewOrganizationService(repo, nil, zap.NewNop())
+	dto, err := service.ListOrganizations(userID)
+	require.NoError(t, err)
+	require.Len(t, dto.Organizations, 2)
+	require.False(t, dto.Organizations[0].Active)
+	require.True(t, dto.Organizations[1].Active)
+}
+
+func TestOrganizationService_SwitchWorkspace_RequiresMembership(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockOrganizationRepositoryI(ctrl)
+	userID, organizationID := uuid.New(), uuid.New()
+	repo.EXPECT().GetMember(organizationID, userID).Return(nil, gorm.ErrRecordNotFound)
+
+	service := NewOrganizationService(repo, nil, zap.NewNop())
+	_, err := service.SwitchWorkspace(userID, organizationID)
+	require.ErrorIs(t, err, ErrOrganizationNotFound)
+}
+
+func TestOrganizationService_AddMember(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockOrganizationRepositoryI(ctrl)
+	userRepo := mocks.NewMockUserRepositoryI(ctrl)
+	ownerID, organizationID := uuid.New(), uuid.New()
+	colleague := &models.User{ID: uuid.New(), Email: "oleg@example.com", Nickname: "Олег"}
+	repo.EXPECT().GetMember(organizationID, ownerID).Return(&models.OrganizationMember{Role: models.OrgRoleOwner}, nil)
+	repo.EXPECT().GetByID(organizationID).Return(&models.Organization{ID: organizationID, Name: "Команда"}, nil)
+	userRepo.EXPECT().FindByEmail("oleg@example.com").Return(colleague, nil)
+	repo.EXPECT().GetMember(organizationID, colleague.ID).Return(nil, gorm.ErrRecordNotFound)
+	repo.EXPECT().AddMember(gomock.Any()).DoAndReturn(func(m *models.OrganizationMember) error {
+		require.Equal(t, models.OrgRoleHiringManager, m.Role)
+		return nil
+	})
+
+	service := NewOrganizationService(repo, userRepo, zap.NewNop())
+	dto, err := service.AddMember(ownerID, organizationID, " oleg@example.com", models.OrgRoleHiringManager)
+	require.NoError(t, err)
+	require.Equal(t, "Олег", dto.Nickname)
+
+	_, err = service.AddMember(ownerID, organizationID, "oleg@example.com", "admin")
+	require.ErrorIs(t, err, ErrInvalidOrgRole)
+}
+
+func TestOrganizationService_AddMember_Forbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockOrganizationRepositoryI(ctrl)
+	userID, organizationID, personalID := uuid.New(), uuid.New(), uuid.New()
+	repo.EXPECT().GetMember(organizationID, userID).Return(&models.OrganizationMember{Role: models.OrgRoleRecruiter}, nil)
+	repo.EXPECT().GetByID(organizationID).Return(&models.Organization{ID: organizationID}, nil)
+	repo.EXPECT().GetMember(personalID, userID).Return(&models.OrganizationMember{Role: models.OrgRoleOwner}, nil)
+	repo.EXPECT().GetByID(personalID).Return(&models.Organization{ID: personalID, Personal: true}, nil)
+
+	service := NewOrganizationService(repo, nil, zap.NewNop())
+	_, err := service.AddMember(userID, organizationID, "oleg@example.com", models.OrgRoleViewer)
+	require.ErrorIs(t, err, ErrOrganizationForbidden)
+	_, err = service.AddMember(userID, personalID, "oleg@example.com", models.OrgRoleViewer)
+	require.ErrorIs(t, err, ErrPersonalWorkspace)
+}
+
+func TestOrganizationService_UpdateMemberRole_KeepsLastOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockOrganizationRepositoryI(ctrl)
+	ownerID, organizationID := uuid.New(), uuid.New()
+	owner := &models.OrganizationMember{OrganizationID: organizationID, UserID: ownerID, Role: models.OrgRoleOwner}
+	repo.EXPECT().GetMember(organizationID, ownerID).Return(owner, nil).Times(2)
+	repo.EXPECT().GetByID(organizationID).Return(&models.Organization{ID: organizationID}, nil)
+	repo.EXPECT().CountOwners(organizationID).Return(int64(1), nil)
+
+	service := NewOrganizationService(repo, nil, zap.NewNop())
+	err := service.UpdateMemberRole(ownerID, organizationID, ownerID, models.OrgRoleViewer)
+	require.ErrorIs(t, err, ErrLastOwner)
+}
+
+func TestOrganizationService_RemoveMember(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockOrganizationRepositoryI(ctrl)
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	viewerID, recruiterID, organizationID := uuid.New(), uuid.New(), uuid.New()
+	team := &models.Organization{ID: organizationID, Name: "Команда"}
+	repo.EXPECT().GetByID(organizationID).Return(team, nil).AnyTimes()
+	repo.EXPECT().GetMember(organizationID, viewerID).Return(&models.OrganizationMember{UserID: viewerID, Role: models.OrgRoleViewer}, nil).AnyTimes()
+
+	service := NewOrganizationService(repo, nil, zap.NewNop())
+	// Не владелец не может исключать других
+	err = service.RemoveMember(viewerID, organizationID, recruiterID)
+	require.ErrorIs(t, err, ErrOrganizationForbidden)
+
+	// Но может выйти сам, и тогда возвращается в личное пространство
+	repo.EXPECT().DB().Return(db)
+	repo.EXPECT().WithTx(gomock.Any()).Return(repo)
+	repo.EXPECT().RemoveMember(organizationID, viewerID).Return(nil)
+	repo.EXPECT().ResetActive(organizationID, viewerID).Return(nil)
+	require.NoError(t, service.RemoveMember(viewerID, organizationID, viewerID))
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"CVMatch/internal/matching"
+	"CVMatch/internal/models"
+	"CVMatch/internal/repository"
+	"context"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// rematchBatch — сколько устаревших результатов выбирается за один запрос
+const rematchBatch = 100
+
+// RematchNotifierI получает сигнал, что после правки резюме или вакансии появились устаревшие результаты сравнения
+type RematchNotifierI interface {
+	Notify()
+}
+
+// RematchService пересчитывает результаты сравнения, посчитанные по прежним версиям резюме и вакансий.
+// Устаревшие результаты определяются по версиям, поэтому сигнал Notify только ускоряет пересчёт:
+// всё, что не успело пересчитаться до остановки, подхватывается при следующем запуске.
+type RematchService struct {
+	matchRepo   repository.MatchingRepositoryI
+	resumeRepo  repository.ResumeRepositoryI
+	vacancyRepo repository.VacancyRepositoryI
+	profileRepo repository.MatchingProfileRepositoryI
+	log         *zap.Logger
+	wake        chan struct{}
+}
+
+func NewRematchService(matchRepo repository.MatchingRepositoryI, resumeRepo repository.ResumeRepositoryI, vacancyRepo repository.VacancyRepositoryI, profileRepo repository.MatchingProfileRepositoryI, log *zap.Logger) *RematchService {
+	return &RematchService{
+		matchRepo:   matchRepo,
+		resumeRepo:  resumeRepo,
+		vacancyRepo: vacancyRepo,
+		profileRepo: profileRepo,
+		log:         log,
+		wake:        make(chan struct{}, 1),
+	}
+}
+
+// Notify будит пересчёт и не блокируется: несколько правок подряд сливаются в один проход
+func (s *RematchService) Notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run пересчитывает устаревшие результаты при запуске и после каждого сигнала, пока не отменён ctx
+func (s *RematchService) Run(ctx context.Context) {
+	for {
+		s.RecomputeStale()
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.wake:
+		}
+	}
+}
+
+// RecomputeStale пересчитывает все устаревшие результаты и возвращает число пересчитанных.
+// Результат, который не удалось пересчитать, остаётся устаревшим до следующего прохода.
+func (s *RematchService) RecomputeStale() int {
+	recomputed := 0
+	after := uuid.Nil
+	for {
+		stale, err := s.matchRepo.ListStale(after, rematchBatch)
+		if err != nil {
+			s.log.Error("Failed to list stale matches", zap.Error(err))
+			return recomputed
+		}
+		for _, m := range stale {
+			if err := s.recompute(m); err != nil {
+				s.log.Warn("Failed to recompute match", zap.String("match_id", m.ID.String()), zap.Error(err))
+				continue
+			}
+			recomputed++
+		}
+		if len(stale) < rematchBatch {
+			return recomputed
+		}
+		after = stale[len(stale)-1].ID
+	}
+}
+
+func (s *RematchService) recompute(m repository.StaleMatch) error {
+	resume, err := s.resumeRepo.LoadResume(m.ResumeID)
+	if err != nil {
+		return err
+	}
+	attachResumeFile(s.resumeRepo, resume)
+	vacancy, err := s.vacancyRepo.LoadVacancy(m.VacancyID)
+	if err != nil {
+		return err
+	}
+	profile, err := resolveProfile(s.profileRepo, vacancy)
+	if err != nil {
+		return err
+	}
+
+	calc := matching.CalculateWithProfile(resume, vacancy, profile)
+	return s.matchRepo.UpdateComputed(&models.MatchingResult{
+		ID:             m.ID,
+		Score:          calc.Score,
+		Knockout:       calc.Knockout,
+		Breakdown:      calc.Breakdown,
+		ProfileID:      profile.ID,
+		Profile:        profile,
+		ResumeVersion:  resume.Version,
+		VacancyVersion: vacancy.Version,
+	})
+}
+
+// notifyRematch сообщает о правке, если получатель задан
+func notifyRematch(notifier RematchNotifierI) {
+	if notifier != nil {
+		notifier.Notify()
+	}
+}
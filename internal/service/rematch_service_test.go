@@ -0,0 +1,83 @@
+package service
+
+import (
+	"CVMatch/internal/matching"
+	"CVMatch/internal/models"
+	"CVMatch/internal/repository"
+	"CVMatch/internal/repository/mocks"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestRematchService_RecomputeStale(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	matchRepo := mocks.NewMockMatchingRepositoryI(ctrl)
+	resumeRepo := mocks.NewMockResumeRepositoryI(ctrl)
+	vacancyRepo := mocks.NewMockVacancyRepositoryI(ctrl)
+
+	userID, resumeID, vacancyID := uuid.New(), uuid.New(), uuid.New()
+	stale := repository.StaleMatch{ID: uuid.New(), ResumeID: resumeID, VacancyID: vacancyID, UserID: userID}
+	missing := repository.StaleMatch{ID: uuid.New(), ResumeID: uuid.New(), VacancyID: vacancyID, UserID: userID}
+	matchRepo.EXPECT().ListStale(uuid.Nil, rematchBatch).Return([]repository.StaleMatch{stale, missing}, nil)
+
+	resumeRepo.EXPECT().LoadResume(resumeID).Return(&models.Resume{
+		ID:      resumeID,
+		Version: 3,
+		Skills:  []models.Skill{{Name: "Go"}, {Name: "Kafka"}},
+	}, nil)
+	resumeRepo.EXPECT().GetResumeFile(resumeID).Return(nil, assert.AnError)
+	resumeRepo.EXPECT().LoadResume(missing.ResumeID).Return(nil, assert.AnError)
+	vacancyRepo.EXPECT().LoadVacancy(vacancyID).Return(&models.Vacancy{
+		ID:      vacancyID,
+		Version: 2,
+		Skills:  []models.Skill{{Name: "Go"}, {Name: "Kafka"}},
+	}, nil)
+
+	var saved *models.MatchingResult
+	matchRepo.EXPECT().UpdateComputed(gomock.Any()).DoAndReturn(func(r *models.MatchingResult) error {
+		saved = r
+		return nil
+	})
+
+	service := NewRematchService(matchRepo, resumeRepo, vacancyRepo, nil, zap.NewNop())
+	require.Equal(t, 1, service.RecomputeStale())
+	require.NotNil(t, saved)
+	require.Equal(t, stale.ID, saved.ID)
+	require.Equal(t, 3, saved.ResumeVersion)
+	require.Equal(t, 2, saved.VacancyVersion)
+	require.Equal(t, []string{"Go", "Kafka"}, saved.Breakdown.MatchedSkills())
+	require.Equal(t, matching.DefaultProfileName, saved.Profile.Name)
+}
+
+func TestRematchService_RecomputeStale_Batches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	matchRepo := mocks.NewMockMatchingRepositoryI(ctrl)
+	resumeRepo := mocks.NewMockResumeRepositoryI(ctrl)
+
+	batch := make([]repository.StaleMatch, rematchBatch)
+	for i := range batch {
+		batch[i] = repository.StaleMatch{ID: uuid.New(), ResumeID: uuid.New(), VacancyID: uuid.New()}
+	}
+	matchRepo.EXPECT().ListStale(uuid.Nil, rematchBatch).Return(batch, nil)
+	matchRepo.EXPECT().ListStale(batch[rematchBatch-1].ID, rematchBatch).Return(nil, nil)
+	resumeRepo.EXPECT().LoadResume(gomock.Any()).Return(nil, assert.AnError).Times(rematchBatch)
+
+	service := NewRematchService(matchRepo, resumeRepo, nil, nil, zap.NewNop())
+	require.Equal(t, 0, service.RecomputeStale())
+}
+
+func TestRematchService_NotifyDoesNotBlock(t *testing.T) {
+	service := NewRematchService(nil, nil, nil, nil, zap.NewNop())
+	service.Notify()
+	service.Notify()
+	require.Len(t, service.wake, 1)
+}
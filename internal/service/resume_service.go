@@ -1,277 +1,672 @@
-package service
-
-import (
-	"CVMatch/internal/config"
-	"CVMatch/internal/models"
-	"CVMatch/internal/parser"
-	"CVMatch/internal/repository"
-	"CVMatch/internal/response"
-	"encoding/json"
-	"os"
-	"strings"
-
-	"github.com/google/uuid"
-	"go.uber.org/zap"
-	"gorm.io/gorm"
-)
-
-type ResumeService struct {
-	repo   repository.ResumeRepositoryI
-	log    *zap.Logger
-	cfg    *config.Config
-	parser parser.ResumeParserI
-}
-
-func NewResumeService(repo repository.ResumeRepositoryI, log *zap.Logger, cfg *config.Config, parser parser.ResumeParserI) *ResumeService {
-	return &ResumeService{
-		repo:   repo,
-		log:    log,
-		cfg:    cfg,
-		parser: parser,
-	}
-}
-
-func (s *ResumeService) CreateResumeWithUser(path string, userID uuid.UUID) (*response.ParsedResumeDTO, error) {
-	llmRes, err := s.parser.ParseResume(path, s.cfg)
-	if err != nil {
-		s.log.Error("Failed to parse resume", zap.Error(err))
-		return nil, err
-	}
-
-	llmRes = strings.TrimSpace(llmRes)
-	if strings.HasPrefix(llmRes, "```") {
-		llmRes = strings.TrimPrefix(llmRes, "```")
-		llmRes = strings.TrimSpace(llmRes)
-	}
-	if strings.HasSuffix(llmRes, "```") {
-		llmRes = strings.TrimSuffix(llmRes, "```")
-		llmRes = strings.TrimSpace(llmRes)
-	}
-
-	var dto response.ParsedResumeDTO
-	if err := json.Unmarshal([]byte(llmRes), &dto); err != nil {
-		s.log.Error("Failed to unmarshal resume DTO", zap.Error(err))
-		return nil, err
-	}
-
-	// Открываем транзакцию
-	txErr := s.repo.DB().Transaction(func(tx *gorm.DB) error {
-		txRepo := s.repo.WithTx(tx)
-
-		var skills []*models.Skill
-		for _, skillName := range dto.Skills {
-			skill, err := txRepo.FirstOrCreateSkill(skillName)
-			if err != nil {
-				s.log.Error("Failed to find or create skill", zap.String("skill", skillName), zap.Error(err))
-				return err
-			}
-			skills = append(skills, skill)
-		}
-
-		var experience []models.Experience
-		for _, exp := range dto.Experience {
-			experience = append(experience, models.Experience{
-				Company:     exp.Company,
-				Position:    exp.Position,
-				StartDate:   exp.StartDate,
-				EndDate:     exp.EndDate,
-				Description: exp.Description,
-			})
-		}
-
-		var education []models.Education
-		for _, edu := range dto.Education {
-			education = append(education, models.Education{
-				Institution: edu.Institution,
-				Degree:      edu.Degree,
-				Field:       edu.Field,
-				StartDate:   edu.StartDate,
-				EndDate:     edu.EndDate,
-			})
-		}
-
-		resume := &models.Resume{
-			ID:         uuid.New(),
-			UserID:     userID,
-			FullName:   dto.FullName,
-			Email:      dto.Email,
-			Phone:      dto.Phone,
-			Location:   dto.Location,
-			Experience: experience,
-			Education:  education,
-		}
-
-		if err := txRepo.Create(resume); err != nil {
-			s.log.Error("Failed to save resume", zap.Error(err))
-			return err
-		}
-
-		if len(skills) > 0 {
-			if err := txRepo.AssociateSkills(resume, skills); err != nil {
-				s.log.Error("Failed to associate skills", zap.Error(err))
-				return err
-			}
-		}
-
-		file := &models.ResumeFile{
-			ResumeID: resume.ID,
-			Path:     path,
-			MimeType: "application/pdf",
-		}
-		if err := txRepo.CreateFile(file); err != nil {
-			s.log.Error("Failed to save resume file", zap.Error(err))
-			return err
-		}
-
-		dto.ID = resume.ID.String()
-		return nil
-	})
-
-	if txErr != nil {
-		return nil, txErr
-	}
-
-	// Уже вне транзакции — получаем URL
-	fileUrl, err := s.GetResumeFileURL(uuid.MustParse(dto.ID))
-	if err != nil {
-		s.log.Error("Failed to get resume file URL", zap.Error(err))
-		return nil, err
-	}
-	dto.FileURL = fileUrl
-
-	return &dto, nil
-}
-
-func (s *ResumeService) GetListResume(userID uuid.UUID) (*response.ResumeListDTO, error) {
-	resumes, err := s.repo.GetListRes(userID)
-	if err != nil {
-		s.log.Error("Failed to get list of resumes", zap.Error(err))
-		return nil, err
-	}
-
-	var dtos []*response.ResumeListItemDTO
-	for _, resume := range *resumes {
-		fileUrl, err := s.GetResumeFileURL(resume.ID)
-		if err != nil {
-			s.log.Error("Failed to get resume file URL", zap.Error(err))
-			return nil, err
-		}
-		dto := &response.ResumeListItemDTO{
-			ID:        resume.ID.String(),
-			FullName:  resume.FullName,
-			FileURL:   fileUrl,
-			CreatedAt: resume.CreatedAt,
-		}
-		dtos = append(dtos, dto)
-	}
-	return &response.ResumeListDTO{Resumes: dtos}, nil
-}
-
-func (s *ResumeService) GetResumeFileURL(resumeID uuid.UUID) (string, error) {
-	url, err := s.repo.GetResFileURL(resumeID)
-	if err != nil {
-		s.log.Error("Failed to get resume file URL", zap.Error(err))
-		return "", err
-	}
-	url = strings.ReplaceAll(url, "./", s.cfg.BaseURL+"/")
-	return url, nil
-}
-
-func (s *ResumeService) GetResumeByID(userID, resumeID uuid.UUID) (*response.ParsedResumeDTO, error) {
-	resume, err := s.repo.GetResumeByID(userID, resumeID)
-	if err != nil {
-		s.log.Error("Failed to get resume by ID", zap.Error(err))
-		return nil, err
-	}
-
-	fileUrl, err := s.GetResumeFileURL(resume.ID)
-	if err != nil {
-		s.log.Error("Failed to get resume file URL", zap.Error(err))
-		return nil, err
-	}
-
-	var dto response.ParsedResumeDTO
-	dto.ID = resume.ID.String()
-	dto.FullName = resume.FullName
-	dto.Email = resume.Email
-	dto.Phone = resume.Phone
-	dto.Location = resume.Location
-	dto.FileURL = fileUrl
-	for _, skill := range resume.Skills {
-		dto.Skills = append(dto.Skills, skill.Name)
-	}
-	for _, exp := range resume.Experience {
-		dto.Experience = append(dto.Experience, response.ExperienceDTO{
-			Company:     exp.Company,
-			Position:    exp.Position,
-			StartDate:   exp.StartDate,
-			EndDate:     exp.EndDate,
-			Description: exp.Description,
-		})
-	}
-	for _, edu := range resume.Education {
-		dto.Education = append(dto.Education, response.EducationDTO{
-			Institution: edu.Institution,
-			Degree:      edu.Degree,
-			Field:       edu.Field,
-			StartDate:   edu.StartDate,
-			EndDate:     edu.EndDate,
-		})
-	}
-
-	return &dto, nil
-}
-
-func (s *ResumeService) DeleteResume(userID, resumeID uuid.UUID) error {
-	txErr := s.repo.DB().Transaction(func(tx *gorm.DB) error {
-		txRepo := s.repo.WithTx(tx)
-		_, err := txRepo.GetResumeByID(userID, resumeID)
-		if err != nil {
-			s.log.Error("Failed to get resume by ID", zap.Error(err))
-			return err
-		}
-		skills, err := txRepo.GetSkillsByResumeID(resumeID)
-		if err != nil {
-			s.log.Error("Failed to get skills by resume ID", zap.Error(err))
-			return err
-		}
-		for _, skill := range skills {
-			if err := txRepo.DeleteSkillFromResume(resumeID, skill.ID); err != nil {
-				s.log.Error("Failed to delete skill from resume", zap.Error(err))
-				return err
-			}
-			if err := txRepo.DeleteUnusedSkill(skill.ID); err != nil {
-				s.log.Error("Failed to delete unused skill", zap.Error(err))
-				return err
-			}
-		}
-		if err := txRepo.DeleteUnusedEdAndEx(resumeID); err != nil {
-			s.log.Error("Failed to delete unused education and experience", zap.Error(err))
-			return err
-		}
-		if err := txRepo.DeleteUnusedMatching(resumeID); err != nil {
-			s.log.Error("Failed to delete unused matching", zap.Error(err))
-			return err
-		}
-		path, err := txRepo.GetResFileURL(resumeID)
-		if err != nil {
-			s.log.Error("Failed to get resume file path", zap.Error(err))
-			return err
-		}
-		if err := txRepo.DeleteResumeFile(resumeID); err != nil {
-			s.log.Error("Failed to delete resume file", zap.Error(err))
-			return err
-		}
-		os.Remove(path)
-		if err := txRepo.DeleteResume(resumeID); err != nil {
-			s.log.Error("Failed to delete resume", zap.Error(err))
-			return err
-		}
-		return nil
-	})
-	if txErr != nil {
-		return txErr
-	}
-	return nil
-
-}
+package service
+
+import (
+	"CVMatch/internal/ats"
+	"CVMatch/internal/config"
+	"CVMatch/internal/llm"
+	"CVMatch/internal/models"
+	"CVMatch/internal/parser"
+	"CVMatch/internal/repository"
+	"CVMatch/internal/response"
+	"context"
+	"encoding/json"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type ResumeService struct {
+	repo    repository.ResumeRepositoryI
+	log     *zap.Logger
+	cfg     *config.Config
+	parser  parser.ResumeParserI
+	rematch RematchNotifierI
+}
+
+// NewResumeService создаёт сервис резюме. rematch получает сигнал после правки резюме
+// и может быть nil, тогда результаты сравнения пересчитываются только при следующем запуске.
+func NewResumeService(repo repository.ResumeRepositoryI, log *zap.Logger, cfg *config.Config, parser parser.ResumeParserI, rematch RematchNotifierI) *ResumeService {
+	return &ResumeService{
+		repo:    repo,
+		log:     log,
+		cfg:     cfg,
+		parser:  parser,
+		rematch: rematch,
+	}
+}
+
+func (s *ResumeService) CreateResumeWithUser(ctx context.Context, path string, userID uuid.UUID) (*response.ParsedResumeDTO, error) {
+	// ID резюме известен до разбора, чтобы расход токенов LLM был привязан к нему
+	resumeID := uuid.New()
+	ctx = llm.WithOwner(ctx, llm.Owner{UserID: userID, ResumeID: &resumeID, Operation: llm.OperationResumeParse})
+
+	parsed, err := s.parser.ParseResume(ctx, path, s.cfg)
+	if err != nil {
+		s.log.Error("Failed to parse resume", zap.Error(err))
+		return nil, err
+	}
+
+	decoded, err := parser.DecodeOutput(parsed.Output)
+	if err != nil {
+		s.log.Error("Failed to unmarshal resume DTO", zap.Error(err))
+		return nil, err
+	}
+	dto := *decoded
+	// Модель может повторить навык в разном регистре; правка резюме убирает повторы так же
+	dto.Skills = uniqueSkillNames(dto.Skills)
+
+	dto.Confidence = parser.ScoreConfidence(confidenceInput(&dto), parsed.Text, dto.Confidence)
+	dto.NeedsReview = parser.NeedsReview(dto.Confidence, s.cfg.ReviewThreshold)
+	confidence, err := json.Marshal(dto.Confidence)
+	if err != nil {
+		return nil, err
+	}
+
+	// Открываем транзакцию
+	txErr := s.repo.DB().Transaction(func(tx *gorm.DB) error {
+		txRepo := s.repo.WithTx(tx)
+
+		var skills []*models.Skill
+		for _, skillName := range dto.Skills {
+			skill, err := txRepo.FirstOrCreateSkill(skillName)
+			if err != nil {
+				s.log.Error("Failed to find or create skill", zap.String("skill", skillName), zap.Error(err))
+				return err
+			}
+			skills = append(skills, skill)
+		}
+
+		experience, totalMonths := buildExperience(dto.Experience, time.Now())
+		for i := range dto.Experience {
+			dto.Experience[i].Months = experience[i].Months
+			dto.Experience[i].IsCurrent = experience[i].IsCurrent
+		}
+		dto.ExperienceMonths = totalMonths
+		dto.YearsOfExperience = yearsFromMonths(totalMonths)
+		position := latestPosition(experience)
+		dto.TitleFamily = parser.NormalizeTitleFamily(position)
+		dto.Seniority = parser.InferSeniority(position, totalMonths)
+
+		resume := &models.Resume{
+			ID:         resumeID,
+			UserID:     userID,
+			FullName:   dto.FullName,
+			Email:      dto.Email,
+			Phone:      dto.Phone,
+			Location:   dto.Location,
+			Experience: experience,
+			Education:  buildEducation(dto.Education),
+			Version:    1,
+
+			ExperienceMonths: totalMonths,
+			TitleFamily:      dto.TitleFamily,
+			Seniority:        dto.Seniority,
+			Confidence:       string(confidence),
+			NeedsReview:      dto.NeedsReview,
+			Language:         parsed.Language,
+			ContentLanguage:  parsed.ContentLanguage,
+			PromptVersion:    parsed.PromptVersion,
+			LLMModel:         parsed.Model,
+		}
+		applyExtendedSections(&dto, resume)
+
+		if err := txRepo.Create(resume); err != nil {
+			s.log.Error("Failed to save resume", zap.Error(err))
+			return err
+		}
+
+		if len(skills) > 0 {
+			if err := txRepo.AssociateSkills(resume, skills); err != nil {
+				s.log.Error("Failed to associate skills", zap.Error(err))
+				return err
+			}
+		}
+
+		file := &models.ResumeFile{
+			ResumeID:         resume.ID,
+			Path:             path,
+			MimeType:         "application/pdf",
+			RawText:          parsed.Text,
+			PageCount:        parsed.Pages,
+			Language:         parsed.Language,
+			ExtractionMethod: parsed.ExtractionMethod,
+		}
+		if err := txRepo.CreateFile(file); err != nil {
+			s.log.Error("Failed to save resume file", zap.Error(err))
+			return err
+		}
+
+		dto.ID = resume.ID.String()
+		dto.Version = resume.Version
+		return nil
+	})
+
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	// Уже вне транзакции — получаем URL
+	fileUrl, err := s.GetResumeFileURL(uuid.MustParse(dto.ID))
+	if err != nil {
+		s.log.Error("Failed to get resume file URL", zap.Error(err))
+		return nil, err
+	}
+	dto.FileURL = fileUrl
+
+	return &dto, nil
+}
+
+// UpdateResume заменяет разобранные данные резюме исправленными пользователем: контакты, навыки,
+// опыт, образование и остальные разделы. Стаж, семейство должности и уровень пересчитываются,
+// резюме перестаёт требовать проверки. Версия резюме растёт, и результаты сравнения с ним
+// пересчитываются в фоне.
+func (s *ResumeService) UpdateResume(userID, resumeID uuid.UUID, dto *response.ParsedResumeDTO) (*response.ParsedResumeDTO, error) {
+	txErr := s.repo.DB().Transaction(func(tx *gorm.DB) error {
+		txRepo := s.repo.WithTx(tx)
+
+		resume, err := txRepo.GetResumeByID(userID, resumeID)
+		if err != nil {
+			s.log.Warn("Failed to get resume by ID", zap.Error(err))
+			return ErrResumeNotFound
+		}
+		previous, err := txRepo.GetSkillsByResumeID(resumeID)
+		if err != nil {
+			s.log.Error("Failed to get skills by resume ID", zap.Error(err))
+			return err
+		}
+		for _, skill := range previous {
+			if err := txRepo.DeleteSkillFromResume(resumeID, skill.ID); err != nil {
+				s.log.Error("Failed to delete skill from resume", zap.Error(err))
+				return err
+			}
+		}
+		if err := txRepo.DeleteUnusedEdAndEx(resumeID); err != nil {
+			s.log.Error("Failed to delete unused education and experience", zap.Error(err))
+			return err
+		}
+		if err := txRepo.DeleteResumeSections(resumeID); err != nil {
+			s.log.Error("Failed to delete resume sections", zap.Error(err))
+			return err
+		}
+
+		var skills []*models.Skill
+		for _, skillName := range uniqueSkillNames(dto.Skills) {
+			skill, err := txRepo.FirstOrCreateSkill(skillName)
+			if err != nil {
+				s.log.Error("Failed to find or create skill", zap.String("skill", skillName), zap.Error(err))
+				return err
+			}
+			skills = append(skills, skill)
+		}
+
+		experience, totalMonths := buildExperience(dto.Experience, time.Now())
+		position := latestPosition(experience)
+		resume.FullName = dto.FullName
+		resume.Email = dto.Email
+		resume.Phone = dto.Phone
+		resume.Location = dto.Location
+		resume.Experience = experience
+		resume.Education = buildEducation(dto.Education)
+		resume.ExperienceMonths = totalMonths
+		resume.TitleFamily = parser.NormalizeTitleFamily(position)
+		resume.Seniority = parser.InferSeniority(position, totalMonths)
+		resume.Languages, resume.Certifications, resume.Projects, resume.Links = nil, nil, nil, nil
+		resume.DesiredSalary, resume.SalaryCurrency = nil, ""
+		applyExtendedSections(dto, resume)
+		resume.NeedsReview = false
+		resume.Version++
+		resume.Skills = nil
+
+		if err := txRepo.Update(resume); err != nil {
+			s.log.Error("Failed to update resume", zap.Error(err))
+			return err
+		}
+		if len(skills) > 0 {
+			if err := txRepo.AssociateSkills(resume, skills); err != nil {
+				s.log.Error("Failed to associate skills", zap.Error(err))
+				return err
+			}
+		}
+		for _, skill := range previous {
+			if err := txRepo.DeleteUnusedSkill(skill.ID); err != nil {
+				s.log.Error("Failed to delete unused skill", zap.Error(err))
+				return err
+			}
+		}
+		return nil
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	notifyRematch(s.rematch)
+	return s.GetResumeByID(userID, resumeID)
+}
+
+// buildEducation нормализует даты образования
+func buildEducation(items []response.EducationDTO) []models.Education {
+	var education []models.Education
+	for _, edu := range items {
+		start := parser.NormalizeDate(edu.StartDate)
+		end := parser.NormalizeDate(edu.EndDate)
+		education = append(education, models.Education{
+			Institution:    edu.Institution,
+			Degree:         edu.Degree,
+			Field:          edu.Field,
+			StartDate:      edu.StartDate,
+			EndDate:        edu.EndDate,
+			StartOn:        start.Time,
+			StartPrecision: string(start.Precision),
+			EndOn:          end.Time,
+			EndPrecision:   string(end.Precision),
+		})
+	}
+	return education
+}
+
+// buildExperience нормализует даты опыта работы и считает стаж по каждой позиции
+// и суммарный стаж без двойного учёта пересекающихся периодов
+func buildExperience(items []response.ExperienceDTO, now time.Time) ([]models.Experience, int) {
+	experience := make([]models.Experience, 0, len(items))
+	periods := make([]parser.Period, 0, len(items))
+	for _, exp := range items {
+		start := parser.NormalizeDate(exp.StartDate)
+		end := parser.NormalizeDate(exp.EndDate)
+		// Нераспознанная дата окончания не должна превращаться в "по настоящее время"
+		if end.IsZero() && strings.TrimSpace(exp.EndDate) != "" {
+			end = start
+		}
+		period := parser.Period{Start: start, End: end}
+		periods = append(periods, period)
+
+		experience = append(experience, models.Experience{
+			Company:        exp.Company,
+			Position:       exp.Position,
+			StartDate:      exp.StartDate,
+			EndDate:        exp.EndDate,
+			StartOn:        start.Time,
+			StartPrecision: string(start.Precision),
+			EndOn:          end.Time,
+			EndPrecision:   string(end.Precision),
+			IsCurrent:      start.Time != nil && (end.Present || end.IsZero()),
+			Months:         period.Months(now),
+			Description:    exp.Description,
+		})
+	}
+	return experience, parser.TotalMonths(periods, now)
+}
+
+// confidenceInput собирает значения, которые сверяются с исходным текстом резюме
+func confidenceInput(dto *response.ParsedResumeDTO) parser.ConfidenceInput {
+	in := parser.ConfidenceInput{
+		FullName: dto.FullName,
+		Email:    dto.Email,
+		Phone:    dto.Phone,
+		Location: dto.Location,
+		Skills:   dto.Skills,
+	}
+	for _, exp := range dto.Experience {
+		if exp.Company != "" {
+			in.Companies = append(in.Companies, exp.Company)
+		}
+		for _, d := range []string{exp.StartDate, exp.EndDate} {
+			if strings.TrimSpace(d) != "" {
+				in.Dates = append(in.Dates, d)
+			}
+		}
+	}
+	for _, edu := range dto.Education {
+		if edu.Institution != "" {
+			in.Institutions = append(in.Institutions, edu.Institution)
+		}
+	}
+	return in
+}
+
+// applyExtendedSections переносит языки, сертификаты, проекты, ссылки и пожелания
+// кандидата из DTO в модель, попутно нормализуя значения в самом DTO
+func applyExtendedSections(dto *response.ParsedResumeDTO, resume *models.Resume) {
+	for _, lang := range dto.Languages {
+		if strings.TrimSpace(lang.Name) == "" {
+			continue
+		}
+		resume.Languages = append(resume.Languages, models.ResumeLanguage{Name: lang.Name, Level: lang.Level})
+	}
+	for _, cert := range dto.Certifications {
+		if strings.TrimSpace(cert.Name) == "" {
+			continue
+		}
+		resume.Certifications = append(resume.Certifications, models.Certification{
+			Name:   cert.Name,
+			Issuer: cert.Issuer,
+			Date:   cert.Date,
+			URL:    cert.URL,
+		})
+	}
+	for _, project := range dto.Projects {
+		if strings.TrimSpace(project.Name) == "" {
+			continue
+		}
+		technologies, _ := json.Marshal(project.Technologies)
+		resume.Projects = append(resume.Projects, models.Project{
+			Name:         project.Name,
+			Description:  project.Description,
+			URL:          project.URL,
+			Technologies: string(technologies),
+		})
+	}
+	for i := range dto.Links {
+		if strings.TrimSpace(dto.Links[i].URL) == "" {
+			continue
+		}
+		dto.Links[i].Type = parser.DetectLinkType(dto.Links[i].URL, dto.Links[i].Type)
+		resume.Links = append(resume.Links, models.ResumeLink{Type: dto.Links[i].Type, URL: dto.Links[i].URL})
+	}
+	if dto.Salary != nil {
+		dto.Salary.Currency = parser.NormalizeCurrency(dto.Salary.Currency)
+		if dto.Salary.Amount != nil && *dto.Salary.Amount > 0 {
+			resume.DesiredSalary = dto.Salary.Amount
+			resume.SalaryCurrency = dto.Salary.Currency
+		}
+	}
+	dto.Remote = parser.NormalizeRemotePreference(dto.Remote)
+	resume.ReadyToRelocate = dto.Relocation
+	resume.RemotePreference = dto.Remote
+}
+
+// extendedSectionsToDTO — обратное преобразование для выдачи сохранённого резюме
+func extendedSectionsToDTO(resume *models.Resume, dto *response.ParsedResumeDTO) {
+	for _, lang := range resume.Languages {
+		dto.Languages = append(dto.Languages, response.LanguageDTO{Name: lang.Name, Level: lang.Level})
+	}
+	for _, cert := range resume.Certifications {
+		dto.Certifications = append(dto.Certifications, response.CertificationDTO{
+			Name:   cert.Name,
+			Issuer: cert.Issuer,
+			Date:   cert.Date,
+			URL:    cert.URL,
+		})
+	}
+	for _, project := range resume.Projects {
+		var technologies []string
+		_ = json.Unmarshal([]byte(project.Technologies), &technologies)
+		dto.Projects = append(dto.Projects, response.ProjectDTO{
+			Name:         project.Name,
+			Description:  project.Description,
+			URL:          project.URL,
+			Technologies: technologies,
+		})
+	}
+	for _, link := range resume.Links {
+		dto.Links = append(dto.Links, response.LinkDTO{Type: link.Type, URL: link.URL})
+	}
+	if resume.DesiredSalary != nil {
+		dto.Salary = &response.SalaryDTO{Amount: resume.DesiredSalary, Currency: resume.SalaryCurrency}
+	}
+	dto.Relocation = resume.ReadyToRelocate
+	dto.Remote = resume.RemotePreference
+}
+
+// latestPosition возвращает должность с текущего или последнего по дате начала места работы
+func latestPosition(experience []models.Experience) string {
+	var latest *models.Experience
+	for i := range experience {
+		exp := &experience[i]
+		if exp.Position == "" {
+			continue
+		}
+		switch {
+		case latest == nil:
+			latest = exp
+		case exp.IsCurrent && !latest.IsCurrent:
+			latest = exp
+		case exp.IsCurrent == latest.IsCurrent && exp.StartOn != nil &&
+			(latest.StartOn == nil || exp.StartOn.After(*latest.StartOn)):
+			latest = exp
+		}
+	}
+	if latest == nil {
+		return ""
+	}
+	return latest.Position
+}
+
+func yearsFromMonths(months int) float64 {
+	return math.Round(float64(months)/12*10) / 10
+}
+
+func (s *ResumeService) GetListResume(userID uuid.UUID, filter repository.ResumeFilter) (*response.ResumeListDTO, error) {
+	resumes, err := s.repo.GetListRes(userID, filter)
+	if err != nil {
+		s.log.Error("Failed to get list of resumes", zap.Error(err))
+		return nil, err
+	}
+
+	var dtos []*response.ResumeListItemDTO
+	for _, resume := range *resumes {
+		fileUrl, err := s.GetResumeFileURL(resume.ID)
+		if err != nil {
+			s.log.Error("Failed to get resume file URL", zap.Error(err))
+			return nil, err
+		}
+		dto := &response.ResumeListItemDTO{
+			ID:                resume.ID.String(),
+			FullName:          resume.FullName,
+			FileURL:           fileUrl,
+			YearsOfExperience: yearsFromMonths(resume.ExperienceMonths),
+			TitleFamily:       resume.TitleFamily,
+			Seniority:         resume.Seniority,
+			NeedsReview:       resume.NeedsReview,
+			Language:          resume.Language,
+			Tags:              tagNames(resume.Tags),
+			Rating:            resume.Rating,
+			CreatedAt:         resume.CreatedAt,
+		}
+		dtos = append(dtos, dto)
+	}
+	return &response.ResumeListDTO{Resumes: dtos}, nil
+}
+
+func (s *ResumeService) GetResumeFileURL(resumeID uuid.UUID) (string, error) {
+	url, err := s.repo.GetResFileURL(resumeID)
+	if err != nil {
+		s.log.Error("Failed to get resume file URL", zap.Error(err))
+		return "", err
+	}
+	url = strings.ReplaceAll(url, "./", s.cfg.BaseURL+"/")
+	return url, nil
+}
+
+func (s *ResumeService) GetResumeByID(userID, resumeID uuid.UUID) (*response.ParsedResumeDTO, error) {
+	resume, err := s.repo.GetResumeByID(userID, resumeID)
+	if err != nil {
+		s.log.Error("Failed to get resume by ID", zap.Error(err))
+		return nil, err
+	}
+
+	fileUrl, err := s.GetResumeFileURL(resume.ID)
+	if err != nil {
+		s.log.Error("Failed to get resume file URL", zap.Error(err))
+		return nil, err
+	}
+
+	var dto response.ParsedResumeDTO
+	dto.ID = resume.ID.String()
+	dto.FullName = resume.FullName
+	dto.Email = resume.Email
+	dto.Phone = resume.Phone
+	dto.Location = resume.Location
+	dto.FileURL = fileUrl
+	dto.ExperienceMonths = resume.ExperienceMonths
+	dto.YearsOfExperience = yearsFromMonths(resume.ExperienceMonths)
+	dto.TitleFamily = resume.TitleFamily
+	dto.Seniority = resume.Seniority
+	dto.Version = resume.Version
+	dto.Tags = tagNames(resume.Tags)
+	dto.Rating = resume.Rating
+	extendedSectionsToDTO(resume, &dto)
+	dto.NeedsReview = resume.NeedsReview
+	dto.Language = resume.Language
+	dto.ContentLanguage = resume.ContentLanguage
+	dto.PromptVersion = resume.PromptVersion
+	dto.Model = resume.LLMModel
+	_ = json.Unmarshal([]byte(resume.Confidence), &dto.Confidence)
+	for _, skill := range resume.Skills {
+		dto.Skills = append(dto.Skills, skill.Name)
+	}
+	for _, exp := range resume.Experience {
+		dto.Experience = append(dto.Experience, response.ExperienceDTO{
+			Company:     exp.Company,
+			Position:    exp.Position,
+			StartDate:   exp.StartDate,
+			EndDate:     exp.EndDate,
+			Description: exp.Description,
+			Months:      exp.Months,
+			IsCurrent:   exp.IsCurrent,
+		})
+	}
+	for _, edu := range resume.Education {
+		dto.Education = append(dto.Education, response.EducationDTO{
+			Institution: edu.Institution,
+			Degree:      edu.Degree,
+			Field:       edu.Field,
+			StartDate:   edu.StartDate,
+			EndDate:     edu.EndDate,
+		})
+	}
+
+	return &dto, nil
+}
+
+// GetResumeText возвращает сохранённый при загрузке текст резюме без повторного разбора PDF
+func (s *ResumeService) GetResumeText(userID, resumeID uuid.UUID) (*response.ResumeTextDTO, error) {
+	if _, err := s.repo.GetResumeByID(userID, resumeID); err != nil {
+		s.log.Warn("Failed to get resume by ID", zap.Error(err))
+		return nil, ErrResumeNotFound
+	}
+	file, err := s.repo.GetResumeFile(resumeID)
+	if err != nil {
+		s.log.Error("Failed to get resume file", zap.Error(err))
+		return nil, err
+	}
+	return &response.ResumeTextDTO{
+		ResumeID:         resumeID.String(),
+		Text:             file.RawText,
+		PageCount:        file.PageCount,
+		Language:         file.Language,
+		ExtractionMethod: file.ExtractionMethod,
+	}, nil
+}
+
+// GetATSReport проверяет, насколько резюме удобно для ATS, по сохранённому тексту и структуре PDF.
+// Если файл резюме недоступен, проверяется только текст.
+func (s *ResumeService) GetATSReport(userID, resumeID uuid.UUID) (*response.ATSReportDTO, error) {
+	if _, err := s.repo.GetResumeByID(userID, resumeID); err != nil {
+		s.log.Warn("Failed to get resume by ID", zap.Error(err))
+		return nil, ErrResumeNotFound
+	}
+	file, err := s.repo.GetResumeFile(resumeID)
+	if err != nil {
+		s.log.Error("Failed to get resume file", zap.Error(err))
+		return nil, err
+	}
+	layout, err := parser.AnalyzeLayout(file.Path)
+	if err != nil {
+		s.log.Warn("Failed to analyze resume layout", zap.String("path", file.Path), zap.Error(err))
+		layout = nil
+	}
+
+	// У резюме, загруженных до сохранения текста документа, его нет в базе: извлекаем заново
+	text, pages := file.RawText, file.PageCount
+	if strings.TrimSpace(text) == "" {
+		if extracted, err := parser.ExtractPDF(file.Path); err == nil {
+			text = extracted.Text
+			if pages == 0 {
+				pages = extracted.Pages
+			}
+		} else {
+			s.log.Warn("Failed to extract resume text", zap.String("path", file.Path), zap.Error(err))
+		}
+	}
+
+	report := ats.Analyze(text, pages, layout)
+	dto := &response.ATSReportDTO{
+		ResumeID:      resumeID.String(),
+		Score:         report.Score,
+		Pages:         report.Pages,
+		Words:         report.Words,
+		LayoutChecked: report.LayoutChecked,
+		Findings:      []response.ATSFindingDTO{},
+		Keywords:      []response.KeywordStatDTO{},
+	}
+	for _, f := range report.Findings {
+		dto.Findings = append(dto.Findings, response.ATSFindingDTO{Code: f.Code, Severity: f.Severity, Message: f.Message, Suggestion: f.Suggestion})
+	}
+	for _, k := range report.Keywords {
+		dto.Keywords = append(dto.Keywords, response.KeywordStatDTO{Keyword: k.Keyword, Count: k.Count, Density: k.Density})
+	}
+	return dto, nil
+}
+
+func (s *ResumeService) DeleteResume(userID, resumeID uuid.UUID) error {
+	txErr := s.repo.DB().Transaction(func(tx *gorm.DB) error {
+		txRepo := s.repo.WithTx(tx)
+		_, err := txRepo.GetResumeByID(userID, resumeID)
+		if err != nil {
+			s.log.Error("Failed to get resume by ID", zap.Error(err))
+			return err
+		}
+		skills, err := txRepo.GetSkillsByResumeID(resumeID)
+		if err != nil {
+			s.log.Error("Failed to get skills by resume ID", zap.Error(err))
+			return err
+		}
+		for _, skill := range skills {
+			if err := txRepo.DeleteSkillFromResume(resumeID, skill.ID); err != nil {
+				s.log.Error("Failed to delete skill from resume", zap.Error(err))
+				return err
+			}
+			if err := txRepo.DeleteUnusedSkill(skill.ID); err != nil {
+				s.log.Error("Failed to delete unused skill", zap.Error(err))
+				return err
+			}
+		}
+		if err := txRepo.DeleteUnusedEdAndEx(resumeID); err != nil {
+			s.log.Error("Failed to delete unused education and experience", zap.Error(err))
+			return err
+		}
+		if err := txRepo.DeleteResumeSections(resumeID); err != nil {
+			s.log.Error("Failed to delete resume sections", zap.Error(err))
+			return err
+		}
+		if err := txRepo.DeleteUnusedMatching(resumeID); err != nil {
+			s.log.Error("Failed to delete unused matching", zap.Error(err))
+			return err
+		}
+		path, err := txRepo.GetResFileURL(resumeID)
+		if err != nil {
+			s.log.Error("Failed to get resume file path", zap.Error(err))
+			return err
+		}
+		if err := txRepo.DeleteResumeFile(resumeID); err != nil {
+			s.log.Error("Failed to delete resume file", zap.Error(err))
+			return err
+		}
+		os.Remove(path)
+		if err := txRepo.DeleteResume(resumeID); err != nil {
+			s.log.Error("Failed to delete resume", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+	if txErr != nil {
+		return txErr
+	}
+	return nil
+
+}
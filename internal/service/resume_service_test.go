@@ -3,8 +3,13 @@ package service
 import (
 	"CVMatch/internal/config"
 	"CVMatch/internal/models"
+	"CVMatch/internal/parser"
+	"CVMatch/internal/repository"
 	"CVMatch/internal/repository/mocks"
+	"CVMatch/internal/response"
+	"context"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -30,10 +35,10 @@ func TestResumeService_CreateResumeWithUser_ParseError(t *testing.T) {
 
 	mockRepo.EXPECT().DB().Return(db).AnyTimes()
 	mockParser := mocks.NewMockResumeParserI(ctrl)
-	mockParser.EXPECT().ParseResume(gomock.Any(), gomock.Any()).Return("", assert.AnError)
+	mockParser.EXPECT().ParseResume(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
 
-	service := NewResumeService(mockRepo, log, cfg, mockParser)
-	dto, err := service.CreateResumeWithUser(fakePath, userID)
+	service := NewResumeService(mockRepo, log, cfg, mockParser, nil)
+	dto, err := service.CreateResumeWithUser(context.Background(), fakePath, userID)
 	require.Error(t, err)
 	require.Nil(t, dto)
 }
@@ -53,10 +58,10 @@ func TestResumeService_CreateResumeWithUser_UnmarshalError(t *testing.T) {
 
 	mockRepo.EXPECT().DB().Return(db).AnyTimes()
 	mockParser := mocks.NewMockResumeParserI(ctrl)
-	mockParser.EXPECT().ParseResume(gomock.Any(), gomock.Any()).Return("not a json", nil)
+	mockParser.EXPECT().ParseResume(gomock.Any(), gomock.Any(), gomock.Any()).Return(&parser.ParseResult{Output: "not a json"}, nil)
 
-	service := NewResumeService(mockRepo, log, cfg, mockParser)
-	dto, err := service.CreateResumeWithUser(fakePath, userID)
+	service := NewResumeService(mockRepo, log, cfg, mockParser, nil)
+	dto, err := service.CreateResumeWithUser(context.Background(), fakePath, userID)
 	require.Error(t, err)
 	require.Nil(t, dto)
 }
@@ -79,10 +84,10 @@ func TestResumeService_CreateResumeWithUser_TransactionError(t *testing.T) {
 	mockRepo.EXPECT().FirstOrCreateSkill("Go").Return(&models.Skill{ID: uuid.New(), Name: "Go"}, nil)
 	mockRepo.EXPECT().Create(gomock.Any()).Return(assert.AnError)
 	mockParser := mocks.NewMockResumeParserI(ctrl)
-	mockParser.EXPECT().ParseResume(gomock.Any(), gomock.Any()).Return(`{"full_name":"Иван Иванов","email":"ivan@test.com","phone":"+79999999999","location":"Москва","skills":["Go"],"experience":[],"education":[]}`, nil)
+	mockParser.EXPECT().ParseResume(gomock.Any(), gomock.Any(), gomock.Any()).Return(&parser.ParseResult{Output: `{"full_name":"Иван Иванов","email":"ivan@test.com","phone":"+79999999999","location":"Москва","skills":["Go"],"experience":[],"education":[]}`}, nil)
 
-	service := NewResumeService(mockRepo, log, cfg, mockParser)
-	dto, err := service.CreateResumeWithUser(fakePath, userID)
+	service := NewResumeService(mockRepo, log, cfg, mockParser, nil)
+	dto, err := service.CreateResumeWithUser(context.Background(), fakePath, userID)
 	require.Error(t, err)
 	require.Nil(t, dto)
 }
@@ -108,10 +113,10 @@ func TestResumeService_CreateResumeWithUser_GetResFileURLError(t *testing.T) {
 	mockRepo.EXPECT().CreateFile(gomock.Any()).Return(nil)
 	mockRepo.EXPECT().GetResFileURL(gomock.Any()).Return("", assert.AnError)
 	mockParser := mocks.NewMockResumeParserI(ctrl)
-	mockParser.EXPECT().ParseResume(gomock.Any(), gomock.Any()).Return(`{"full_name":"Иван Иванов","email":"ivan@test.com","phone":"+79999999999","location":"Москва","skills":["Go"],"experience":[],"education":[]}`, nil)
+	mockParser.EXPECT().ParseResume(gomock.Any(), gomock.Any(), gomock.Any()).Return(&parser.ParseResult{Output: `{"full_name":"Иван Иванов","email":"ivan@test.com","phone":"+79999999999","location":"Москва","skills":["Go"],"experience":[],"education":[]}`}, nil)
 
-	service := NewResumeService(mockRepo, log, cfg, mockParser)
-	dto, err := service.CreateResumeWithUser(fakePath, userID)
+	service := NewResumeService(mockRepo, log, cfg, mockParser, nil)
+	dto, err := service.CreateResumeWithUser(context.Background(), fakePath, userID)
 	require.Error(t, err)
 	require.Nil(t, dto)
 }
@@ -141,12 +146,13 @@ func TestResumeService_CreateResumeWithUser_Success(t *testing.T) {
 	mockRepo.EXPECT().CreateFile(gomock.Any()).Return(nil)
 	mockRepo.EXPECT().GetResFileURL(gomock.Any()).Return("./uploads/test.pdf", nil)
 	mockParser := mocks.NewMockResumeParserI(ctrl)
-	mockParser.EXPECT().ParseResume(gomock.Any(), gomock.Any()).Return(`{"full_name":"Иван Иванов","email":"ivan@test.com","phone":"+79999999999","location":"Москва","skills":["Go"],"experience":[],"education":[]}`, nil)
+	mockParser.EXPECT().ParseResume(gomock.Any(), gomock.Any(), gomock.Any()).Return(&parser.ParseResult{Output: `{"full_name":"Иван Иванов","email":"ivan@test.com","phone":"+79999999999","location":"Москва","skills":["Go"," go ",""],"experience":[],"education":[]}`}, nil)
 
-	service := NewResumeService(mockRepo, log, cfg, mockParser)
-	dto, err := service.CreateResumeWithUser(fakePath, userID)
+	service := NewResumeService(mockRepo, log, cfg, mockParser, nil)
+	dto, err := service.CreateResumeWithUser(context.Background(), fakePath, userID)
 	require.NoError(t, err)
 	require.NotNil(t, dto)
+	require.Equal(t, []string{"Go"}, dto.Skills)
 	require.Equal(t, "Иван Иванов", dto.FullName)
 	require.Equal(t, "ivan@test.com", dto.Email)
 	require.Equal(t, fakeFileURL, dto.FileURL)
@@ -162,11 +168,11 @@ func TestResumeService_GetListResume_Success(t *testing.T) {
 
 	userID := uuid.New()
 	resumes := []models.Resume{{ID: uuid.New(), FullName: "Test User"}}
-	mockRepo.EXPECT().GetListRes(userID).Return(&resumes, nil)
+	mockRepo.EXPECT().GetListRes(userID, repository.ResumeFilter{}).Return(&resumes, nil)
 	mockRepo.EXPECT().GetResFileURL(resumes[0].ID).Return("./uploads/test.pdf", nil)
 
-	service := NewResumeService(mockRepo, log, cfg, nil)
-	dto, err := service.GetListResume(userID)
+	service := NewResumeService(mockRepo, log, cfg, nil, nil)
+	dto, err := service.GetListResume(userID, repository.ResumeFilter{})
 	require.NoError(t, err)
 	require.NotNil(t, dto)
 	require.Len(t, dto.Resumes, 1)
@@ -182,10 +188,10 @@ func TestResumeService_GetListResume_RepoError(t *testing.T) {
 	cfg := &config.Config{BaseURL: "http://localhost:8080"}
 
 	userID := uuid.New()
-	mockRepo.EXPECT().GetListRes(userID).Return(nil, assert.AnError)
+	mockRepo.EXPECT().GetListRes(userID, repository.ResumeFilter{}).Return(nil, assert.AnError)
 
-	service := NewResumeService(mockRepo, log, cfg, nil)
-	dto, err := service.GetListResume(userID)
+	service := NewResumeService(mockRepo, log, cfg, nil, nil)
+	dto, err := service.GetListResume(userID, repository.ResumeFilter{})
 	require.Error(t, err)
 	require.Nil(t, dto)
 }
@@ -201,7 +207,7 @@ func TestResumeService_GetResumeFileURL_Success(t *testing.T) {
 	resumeID := uuid.New()
 	mockRepo.EXPECT().GetResFileURL(resumeID).Return("./uploads/test.pdf", nil)
 
-	service := NewResumeService(mockRepo, log, cfg, nil)
+	service := NewResumeService(mockRepo, log, cfg, nil, nil)
 	url, err := service.GetResumeFileURL(resumeID)
 	require.NoError(t, err)
 	require.Equal(t, "http://localhost:8080/uploads/test.pdf", url)
@@ -218,7 +224,7 @@ func TestResumeService_GetResumeFileURL_Error(t *testing.T) {
 	resumeID := uuid.New()
 	mockRepo.EXPECT().GetResFileURL(resumeID).Return("", assert.AnError)
 
-	service := NewResumeService(mockRepo, log, cfg, nil)
+	service := NewResumeService(mockRepo, log, cfg, nil, nil)
 	url, err := service.GetResumeFileURL(resumeID)
 	require.Error(t, err)
 	require.Empty(t, url)
@@ -245,7 +251,7 @@ func TestResumeService_GetResumeByID_Success(t *testing.T) {
 	mockRepo.EXPECT().GetResumeByID(userID, resumeID).Return(resume, nil)
 	mockRepo.EXPECT().GetResFileURL(resumeID).Return("./uploads/test.pdf", nil)
 
-	service := NewResumeService(mockRepo, log, cfg, nil)
+	service := NewResumeService(mockRepo, log, cfg, nil, nil)
 	dto, err := service.GetResumeByID(userID, resumeID)
 	require.NoError(t, err)
 	require.NotNil(t, dto)
@@ -265,7 +271,7 @@ func TestResumeService_GetResumeByID_RepoError(t *testing.T) {
 	resumeID := uuid.New()
 	mockRepo.EXPECT().GetResumeByID(userID, resumeID).Return(nil, assert.AnError)
 
-	service := NewResumeService(mockRepo, log, cfg, nil)
+	service := NewResumeService(mockRepo, log, cfg, nil, nil)
 	dto, err := service.GetResumeByID(userID, resumeID)
 	require.Error(t, err)
 	require.Nil(t, dto)
@@ -285,12 +291,117 @@ func TestResumeService_GetResumeByID_GetFileURLError(t *testing.T) {
 	mockRepo.EXPECT().GetResumeByID(userID, resumeID).Return(resume, nil)
 	mockRepo.EXPECT().GetResFileURL(resumeID).Return("", assert.AnError)
 
-	service := NewResumeService(mockRepo, log, cfg, nil)
+	service := NewResumeService(mockRepo, log, cfg, nil, nil)
 	dto, err := service.GetResumeByID(userID, resumeID)
 	require.Error(t, err)
 	require.Nil(t, dto)
 }
 
+func TestResumeService_GetResumeText(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockResumeRepositoryI(ctrl)
+	userID := uuid.New()
+	resumeID := uuid.New()
+	mockRepo.EXPECT().GetResumeByID(userID, resumeID).Return(&models.Resume{ID: resumeID}, nil)
+	mockRepo.EXPECT().GetResumeFile(resumeID).Return(&models.ResumeFile{RawText: "Иван Иванов", PageCount: 1, Language: "ru", ExtractionMethod: "pdf_text"}, nil)
+
+	service := NewResumeService(mockRepo, zap.NewNop(), &config.Config{}, nil, nil)
+	dto, err := service.GetResumeText(userID, resumeID)
+	require.NoError(t, err)
+	require.Equal(t, "Иван Иванов", dto.Text)
+	require.Equal(t, "ru", dto.Language)
+	require.Equal(t, 1, dto.PageCount)
+
+	otherID := uuid.New()
+	mockRepo.EXPECT().GetResumeByID(userID, otherID).Return(nil, gorm.ErrRecordNotFound)
+	_, err = service.GetResumeText(userID, otherID)
+	require.ErrorIs(t, err, ErrResumeNotFound)
+}
+
+func TestResumeService_GetATSReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockResumeRepositoryI(ctrl)
+	userID, resumeID := uuid.New(), uuid.New()
+	mockRepo.EXPECT().GetResumeByID(userID, resumeID).Return(&models.Resume{ID: resumeID}, nil).Times(2)
+	mockRepo.EXPECT().GetResumeFile(resumeID).Return(&models.ResumeFile{Path: "../parser/testdata/resume.pdf", PageCount: 1}, nil)
+	mockRepo.EXPECT().GetResumeFile(resumeID).Return(&models.ResumeFile{Path: "missing.pdf", PageCount: 1}, nil)
+
+	service := NewResumeService(mockRepo, zap.NewNop(), &config.Config{}, nil, nil)
+	// Текст документа не сохранён — он извлекается из PDF заново
+	dto, err := service.GetATSReport(userID, resumeID)
+	require.NoError(t, err)
+	require.True(t, dto.LayoutChecked)
+	require.Greater(t, dto.Score, 0)
+	require.Greater(t, dto.Words, 0)
+	for _, f := range dto.Findings {
+		require.NotEqual(t, "image_only", f.Code)
+	}
+
+	dto, err = service.GetATSReport(userID, resumeID)
+	require.NoError(t, err)
+	require.False(t, dto.LayoutChecked)
+}
+
+func TestResumeService_UpdateResume(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockResumeRepositoryI(ctrl)
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	userID, resumeID := uuid.New(), uuid.New()
+	oldSkill := &models.Skill{ID: uuid.New(), Name: "PHP"}
+	stored := &models.Resume{ID: resumeID, UserID: userID, FullName: "Иван", NeedsReview: true, Version: 1, Skills: []models.Skill{*oldSkill}}
+
+	mockRepo.EXPECT().DB().Return(db).AnyTimes()
+	mockRepo.EXPECT().WithTx(gomock.Any()).Return(mockRepo).AnyTimes()
+	mockRepo.EXPECT().GetResumeByID(userID, resumeID).DoAndReturn(func(_, _ uuid.UUID) (*models.Resume, error) {
+		copied := *stored
+		return &copied, nil
+	}).Times(2)
+	mockRepo.EXPECT().GetSkillsByResumeID(resumeID).Return([]*models.Skill{oldSkill}, nil)
+	mockRepo.EXPECT().DeleteSkillFromResume(resumeID, oldSkill.ID).Return(nil)
+	mockRepo.EXPECT().DeleteUnusedEdAndEx(resumeID).Return(nil)
+	mockRepo.EXPECT().DeleteResumeSections(resumeID).Return(nil)
+	mockRepo.EXPECT().FirstOrCreateSkill("Go").Return(&models.Skill{ID: uuid.New(), Name: "Go"}, nil)
+	mockRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(r *models.Resume) error {
+		require.Equal(t, "Иван Иванов", r.FullName)
+		require.Equal(t, 2, r.Version)
+		require.False(t, r.NeedsReview)
+		require.Equal(t, "backend", r.TitleFamily)
+		require.Len(t, r.Experience, 1)
+		require.Empty(t, r.Skills)
+		stored = r
+		return nil
+	})
+	mockRepo.EXPECT().AssociateSkills(gomock.Any(), gomock.Len(1)).DoAndReturn(func(r *models.Resume, skills []*models.Skill) error {
+		r.Skills = []models.Skill{*skills[0]}
+		return nil
+	})
+	mockRepo.EXPECT().DeleteUnusedSkill(oldSkill.ID).Return(nil)
+	mockRepo.EXPECT().GetResFileURL(resumeID).Return("./uploads/resume.pdf", nil)
+
+	notifier := &countingNotifier{}
+	service := NewResumeService(mockRepo, zap.NewNop(), &config.Config{BaseURL: "http://localhost:8080"}, nil, notifier)
+	dto, err := service.UpdateResume(userID, resumeID, &response.ParsedResumeDTO{
+		FullName: "Иван Иванов",
+		Skills:   []string{"Go", "go"},
+		Experience: []response.ExperienceDTO{
+			{Company: "Яндекс", Position: "Backend Developer", StartDate: "2020-01"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, dto.Version)
+	require.Equal(t, []string{"Go"}, dto.Skills)
+	require.False(t, dto.NeedsReview)
+	require.Equal(t, 1, notifier.calls)
+}
+
 func TestResumeService_DeleteResume_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -310,12 +421,13 @@ func TestResumeService_DeleteResume_Success(t *testing.T) {
 	mockRepo.EXPECT().DeleteSkillFromResume(resumeID, gomock.Any()).Return(nil).AnyTimes()
 	mockRepo.EXPECT().DeleteUnusedSkill(gomock.Any()).Return(nil).AnyTimes()
 	mockRepo.EXPECT().DeleteUnusedEdAndEx(resumeID).Return(nil).AnyTimes()
+	mockRepo.EXPECT().DeleteResumeSections(resumeID).Return(nil).AnyTimes()
 	mockRepo.EXPECT().DeleteUnusedMatching(resumeID).Return(nil).AnyTimes()
 	mockRepo.EXPECT().GetResFileURL(resumeID).Return("", nil).AnyTimes()
 	mockRepo.EXPECT().DeleteResumeFile(resumeID).Return(nil).AnyTimes()
 	mockRepo.EXPECT().DeleteResume(resumeID).Return(nil).AnyTimes()
 
-	service := NewResumeService(mockRepo, log, cfg, nil)
+	service := NewResumeService(mockRepo, log, cfg, nil, nil)
 	err = service.DeleteResume(userID, resumeID)
 	require.NoError(t, err)
 }
@@ -337,6 +449,7 @@ func TestResumeService_DeleteResume_TransactionError(t *testing.T) {
 	mockRepo.EXPECT().GetResumeByID(gomock.Any(), gomock.Any()).Return(&models.Resume{}, nil).AnyTimes()
 	mockRepo.EXPECT().GetSkillsByResumeID(resumeID).Return([]*models.Skill{}, nil).AnyTimes()
 	mockRepo.EXPECT().DeleteUnusedEdAndEx(resumeID).Return(nil).AnyTimes()
+	mockRepo.EXPECT().DeleteResumeSections(resumeID).Return(nil).AnyTimes()
 	mockRepo.EXPECT().DeleteUnusedMatching(resumeID).Return(nil).AnyTimes()
 	mockRepo.EXPECT().GetResFileURL(resumeID).Return("", nil).AnyTimes()
 	mockRepo.EXPECT().DeleteResumeFile(resumeID).Return(nil).AnyTimes()
@@ -346,7 +459,109 @@ func TestResumeService_DeleteResume_TransactionError(t *testing.T) {
 		db.AddError(assert.AnError)
 	})
 
-	service := NewResumeService(mockRepo, log, cfg, nil)
+	service := NewResumeService(mockRepo, log, cfg, nil, nil)
 	err = service.DeleteResume(userID, resumeID)
 	require.Error(t, err)
 }
+
+func TestBuildExperience(t *testing.T) {
+	now := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+	items := []response.ExperienceDTO{
+		{Company: "A", StartDate: "Sep 2020", EndDate: "август 2022"},
+		{Company: "B", StartDate: "2022-09", EndDate: "по настоящее время"},
+		{Company: "C", StartDate: "2019", EndDate: "непонятно"},
+	}
+
+	experience, total := buildExperience(items, now)
+	require.Len(t, experience, 3)
+	require.Equal(t, 24, experience[0].Months)
+	require.False(t, experience[0].IsCurrent)
+	require.Equal(t, 22, experience[1].Months)
+	require.True(t, experience[1].IsCurrent)
+	require.Equal(t, "month", experience[1].StartPrecision)
+	require.Equal(t, 12, experience[2].Months)
+	require.False(t, experience[2].IsCurrent)
+	require.Equal(t, 24+22+12, total)
+	require.Equal(t, 4.8, yearsFromMonths(total))
+}
+
+func TestResumeService_CreateResumeWithUser_ExtendedSections(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockResumeRepositoryI(ctrl)
+	log := zap.NewNop()
+	cfg := &config.Config{BaseURL: "http://localhost:8080"}
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	mockRepo.EXPECT().DB().Return(db).AnyTimes()
+	mockRepo.EXPECT().WithTx(gomock.Any()).Return(mockRepo).AnyTimes()
+	mockRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(resume *models.Resume) error {
+		require.Len(t, resume.Languages, 1)
+		require.Len(t, resume.Projects, 1)
+		require.JSONEq(t, `["Go","Redis"]`, resume.Projects[0].Technologies)
+		require.Equal(t, "github", resume.Links[0].Type)
+		require.Equal(t, "telegram", resume.Links[1].Type)
+		require.Equal(t, 250000, *resume.DesiredSalary)
+		require.Equal(t, "RUB", resume.SalaryCurrency)
+		require.True(t, *resume.ReadyToRelocate)
+		require.Equal(t, "hybrid", resume.RemotePreference)
+		return nil
+	})
+	mockRepo.EXPECT().CreateFile(gomock.Any()).Return(nil)
+	mockRepo.EXPECT().GetResFileURL(gomock.Any()).Return("./uploads/test.pdf", nil)
+	mockParser := mocks.NewMockResumeParserI(ctrl)
+	mockParser.EXPECT().ParseResume(gomock.Any(), gomock.Any(), gomock.Any()).Return(&parser.ParseResult{Output: `{
+		"full_name": "Иван Иванов",
+		"languages": [{"name": "Английский", "level": "B2"}],
+		"projects": [{"name": "cvmatch", "technologies": ["Go", "Redis"]}],
+		"links": [{"type": "website", "url": "https://github.com/ivan"}, {"type": "", "url": "https://t.me/ivan"}],
+		"salary": {"amount": "250 000", "currency": "руб."},
+		"relocation": true,
+		"remote": "Гибрид"
+	}`}, nil)
+
+	service := NewResumeService(mockRepo, log, cfg, mockParser, nil)
+	dto, err := service.CreateResumeWithUser(context.Background(), "test.pdf", uuid.New())
+	require.NoError(t, err)
+	require.Equal(t, "RUB", dto.Salary.Currency)
+	require.Equal(t, "hybrid", dto.Remote)
+	require.Equal(t, "github", dto.Links[0].Type)
+}
+
+func TestResumeService_CreateResumeWithUser_NeedsReview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockResumeRepositoryI(ctrl)
+	log := zap.NewNop()
+	cfg := &config.Config{BaseURL: "http://localhost:8080", ReviewThreshold: 0.6}
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	mockRepo.EXPECT().DB().Return(db).AnyTimes()
+	mockRepo.EXPECT().WithTx(gomock.Any()).Return(mockRepo).AnyTimes()
+	mockRepo.EXPECT().FirstOrCreateSkill("Go").Return(&models.Skill{ID: uuid.New(), Name: "Go"}, nil)
+	mockRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(resume *models.Resume) error {
+		require.True(t, resume.NeedsReview)
+		require.Contains(t, resume.Confidence, `"email"`)
+		return nil
+	})
+	mockRepo.EXPECT().AssociateSkills(gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().CreateFile(gomock.Any()).Return(nil)
+	mockRepo.EXPECT().GetResFileURL(gomock.Any()).Return("./uploads/test.pdf", nil)
+	mockParser := mocks.NewMockResumeParserI(ctrl)
+	mockParser.EXPECT().ParseResume(gomock.Any(), gomock.Any(), gomock.Any()).Return(&parser.ParseResult{
+		Text:   "Иван Иванов\n+7 999 999-99-99\nGo developer",
+		Output: `{"full_name":"Пётр Петров","email":"ivan@test.com","phone":"+79999999999","skills":["Go"],"confidence":{"email":"0.99"}}`,
+	}, nil)
+
+	service := NewResumeService(mockRepo, log, cfg, mockParser, nil)
+	dto, err := service.CreateResumeWithUser(context.Background(), "test.pdf", uuid.New())
+	require.NoError(t, err)
+	require.True(t, dto.NeedsReview)
+	require.Less(t, dto.Confidence["full_name"], 0.6)
+	require.Less(t, dto.Confidence["email"], 0.6)
+	require.Greater(t, dto.Confidence["phone"], 0.6)
+}
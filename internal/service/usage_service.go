@@ -0,0 +1,199 @@
+package service
+
+import (
+	"CVMatch/internal/config"
+	"CVMatch/internal/llm"
+	"CVMatch/internal/models"
+	"CVMatch/internal/repository"
+	"CVMatch/internal/response"
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// UsageService учитывает расход токенов LLM и следит за месячными квотами
+type UsageService struct {
+	repo     repository.LLMUsageRepositoryI
+	userRepo repository.UserRepositoryI
+	log      *zap.Logger
+	cfg      *config.Config
+	now      func() time.Time
+}
+
+func NewUsageService(repo repository.LLMUsageRepositoryI, userRepo repository.UserRepositoryI, log *zap.Logger, cfg *config.Config) *UsageService {
+	return &UsageService{
+		repo:     repo,
+		userRepo: userRepo,
+		log:      log,
+		cfg:      cfg,
+		now:      time.Now,
+	}
+}
+
+// Record сохраняет вызов LLM с оценкой стоимости по цене модели
+func (s *UsageService) Record(_ context.Context, record llm.UsageRecord) error {
+	return s.repo.Create(&models.LLMUsage{
+		UserID:           record.Owner.UserID,
+		ResumeID:         record.Owner.ResumeID,
+		JobID:            record.Owner.JobID,
+		Operation:        record.Owner.Operation,
+		Model:            record.Model,
+		PromptVersion:    record.PromptVersion,
+		InputTokens:      record.Usage.InputTokens,
+		CompletionTokens: record.Usage.CompletionTokens,
+		TotalTokens:      record.Usage.TotalTokens,
+		LatencyMs:        record.Latency.Milliseconds(),
+		Cost:             s.cost(record.Model, record.Usage.TotalTokens),
+		Cached:           record.Cached,
+		Success:          record.Success,
+	})
+}
+
+// CheckQuota возвращает llm.ErrQuotaExceeded, если пользователь израсходовал квоту текущего месяца
+func (s *UsageService) CheckQuota(_ context.Context, userID uuid.UUID) error {
+	quota, err := s.quota(userID)
+	if err != nil {
+		return err
+	}
+	if quota == 0 {
+		return nil
+	}
+	used, err := s.repo.SumTokensSince(userID, monthStart(s.now()))
+	if err != nil {
+		s.log.Error("Failed to sum LLM usage", zap.Error(err))
+		return err
+	}
+	if used >= quota {
+		s.log.Warn("LLM token quota exceeded", zap.String("user_id", userID.String()), zap.Int64("used", used), zap.Int64("quota", quota))
+		return llm.ErrQuotaExceeded
+	}
+	return nil
+}
+
+// GetUserUsage — расход пользователя за [from, to) и состояние квоты текущего месяца
+func (s *UsageService) GetUserUsage(userID uuid.UUID, from, to time.Time) (*response.UsageDTO, error) {
+	rows, err := s.repo.GetUsageByModel(userID, from, to)
+	if err != nil {
+		s.log.Error("Failed to get LLM usage", zap.Error(err))
+		return nil, err
+	}
+	dto := &response.UsageDTO{
+		From:     from,
+		To:       to,
+		Currency: s.cfg.LLM.Currency,
+		ByModel:  []response.UsageByModelDTO{},
+	}
+	for _, row := range rows {
+		dto.Requests += row.Requests
+		dto.CachedRequests += row.CachedRequests
+		dto.InputTokens += row.InputTokens
+		dto.CompletionTokens += row.CompletionTokens
+		dto.TotalTokens += row.TotalTokens
+		dto.Cost += row.Cost
+		dto.ByModel = append(dto.ByModel, response.UsageByModelDTO{
+			Model:            row.Model,
+			Requests:         row.Requests,
+			CachedRequests:   row.CachedRequests,
+			InputTokens:      row.InputTokens,
+			CompletionTokens: row.CompletionTokens,
+			TotalTokens:      row.TotalTokens,
+			Cost:             roundCost(row.Cost),
+		})
+	}
+	dto.Cost = roundCost(dto.Cost)
+
+	quota, err := s.quota(userID)
+	if err != nil {
+		return nil, err
+	}
+	used, err := s.repo.SumTokensSince(userID, monthStart(s.now()))
+	if err != nil {
+		s.log.Error("Failed to sum LLM usage", zap.Error(err))
+		return nil, err
+	}
+	dto.MonthlyTokenQuota = quota
+	dto.QuotaUsed = used
+	if quota > 0 {
+		remaining := max(quota-used, 0)
+		dto.QuotaRemaining = &remaining
+	}
+	return dto, nil
+}
+
+// GetUsageReport — расход всех пользователей за [from, to)
+func (s *UsageService) GetUsageReport(from, to time.Time) (*response.UsageReportDTO, error) {
+	rows, err := s.repo.GetUsageByUser(from, to)
+	if err != nil {
+		s.log.Error("Failed to get LLM usage report", zap.Error(err))
+		return nil, err
+	}
+	dto := &response.UsageReportDTO{
+		From:     from,
+		To:       to,
+		Currency: s.cfg.LLM.Currency,
+		Users:    []response.UserUsageDTO{},
+	}
+	for _, row := range rows {
+		dto.TotalTokens += row.TotalTokens
+		dto.Cost += row.Cost
+		dto.Users = append(dto.Users, response.UserUsageDTO{
+			UserID:           row.UserID.String(),
+			Email:            row.Email,
+			Requests:         row.Requests,
+			CachedRequests:   row.CachedRequests,
+			InputTokens:      row.InputTokens,
+			CompletionTokens: row.CompletionTokens,
+			TotalTokens:      row.TotalTokens,
+			Cost:             roundCost(row.Cost),
+		})
+	}
+	dto.Cost = roundCost(dto.Cost)
+	return dto, nil
+}
+
+// SetUserQuota задаёт месячную квоту пользователя; nil возвращает квоту по умолчанию
+func (s *UsageService) SetUserQuota(userID uuid.UUID, quota *int64) error {
+	if err := s.userRepo.UpdateMonthlyTokenQuota(userID, quota); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+		s.log.Error("Failed to update token quota", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// quota — персональная квота пользователя или квота по умолчанию
+func (s *UsageService) quota(userID uuid.UUID) (int64, error) {
+	user, err := s.userRepo.FindByID(userID.String())
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return s.cfg.LLM.MonthlyTokenQuota, nil
+		}
+		s.log.Error("Failed to find user", zap.Error(err))
+		return 0, err
+	}
+	if user.MonthlyTokenQuota != nil {
+		return *user.MonthlyTokenQuota, nil
+	}
+	return s.cfg.LLM.MonthlyTokenQuota, nil
+}
+
+func (s *UsageService) cost(model string, tokens int) float64 {
+	return float64(tokens) / 1000 * s.cfg.LLM.Prices[model]
+}
+
+// monthStart — начало календарного месяца в UTC
+func monthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func roundCost(v float64) float64 {
+	return math.Round(v*10000) / 10000
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"CVMatch/internal/config"
+	"CVMatch/internal/llm"
+	"CVMatch/internal/models"
+	"CVMatch/internal/repository"
+	"CVMatch/internal/repository/mocks"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func newUsageTestService(t *testing.T, quota int64) (*UsageService, *mocks.MockLLMUsageRepositoryI, *mocks.MockUserRepositoryI) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockLLMUsageRepositoryI(ctrl)
+	userRepo := mocks.NewMockUserRepositoryI(ctrl)
+	cfg := &config.Config{LLM: config.LLMConfig{
+		Prices:            map[string]float64{"yandexgpt": 1.2},
+		Currency:          "RUB",
+		MonthlyTokenQuota: quota,
+	}}
+	s := NewUsageService(repo, userRepo, zap.NewNop(), cfg)
+	s.now = func() time.Time { return time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC) }
+	return s, repo, userRepo
+}
+
+func TestUsageService_CheckQuota(t *testing.T) {
+	s, repo, userRepo := newUsageTestService(t, 1000)
+	userID := uuid.New()
+	monthStart := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	// Квота по умолчанию
+	userRepo.EXPECT().FindByID(userID.String()).Return(&models.User{ID: userID}, nil)
+	repo.EXPECT().SumTokensSince(userID, monthStart).Return(int64(999), nil)
+	require.NoError(t, s.CheckQuota(context.Background(), userID))
+
+	userRepo.EXPECT().FindByID(userID.String()).Return(&models.User{ID: userID}, nil)
+	repo.EXPECT().SumTokensSince(userID, monthStart).Return(int64(1000), nil)
+	require.ErrorIs(t, s.CheckQuota(context.Background(), userID), llm.ErrQuotaExceeded)
+
+	// Персональная квота 0 снимает ограничение
+	unlimited := int64(0)
+	userRepo.EXPECT().FindByID(userID.String()).Return(&models.User{ID: userID, MonthlyTokenQuota: &unlimited}, nil)
+	require.NoError(t, s.CheckQuota(context.Background(), userID))
+}
+
+func TestUsageService_Record(t *testing.T) {
+	s, repo, _ := newUsageTestService(t, 0)
+	userID, resumeID := uuid.New(), uuid.New()
+
+	repo.EXPECT().Create(gomock.Any()).DoAndReturn(func(usage *models.LLMUsage) error {
+		require.Equal(t, userID, usage.UserID)
+		require.Equal(t, &resumeID, usage.ResumeID)
+		require.Equal(t, llm.OperationResumeParse, usage.Operation)
+		require.Equal(t, 1500, usage.TotalTokens)
+		require.InDelta(t, 1.8, usage.Cost, 1e-9)
+		require.Equal(t, int64(250), usage.LatencyMs)
+		return nil
+	})
+	require.NoError(t, s.Record(context.Background(), llm.UsageRecord{
+		Owner:   llm.Owner{UserID: userID, ResumeID: &resumeID, Operation: llm.OperationResumeParse},
+		Model:   "yandexgpt",
+		Usage:   llm.Usage{InputTokens: 1000, CompletionTokens: 500, TotalTokens: 1500},
+		Latency: 250 * time.Millisecond,
+		Success: true,
+	}))
+}
+
+func TestUsageService_GetUserUsage(t *testing.T) {
+	s, repo, userRepo := newUsageTestService(t, 10000)
+	userID := uuid.New()
+	from, to := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 3, 16, 0, 0, 0, 0, time.UTC)
+
+	repo.EXPECT().GetUsageByModel(userID, from, to).Return([]repository.UsageAggregate{
+		{Model: "yandexgpt", Requests: 2, TotalTokens: 3000, Cost: 3.6},
+		{Model: "yandexgpt-lite", Requests: 1, CachedRequests: 1},
+	}, nil)
+	userRepo.EXPECT().FindByID(userID.String()).Return(&models.User{ID: userID}, nil)
+	repo.EXPECT().SumTokensSince(userID, from).Return(int64(3000), nil)
+
+	usage, err := s.GetUserUsage(userID, from, to)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), usage.Requests)
+	require.Equal(t, int64(3000), usage.TotalTokens)
+	require.InDelta(t, 3.6, usage.Cost, 1e-9)
+	require.Equal(t, "RUB", usage.Currency)
+	require.Len(t, usage.ByModel, 2)
+	require.Equal(t, int64(10000), usage.MonthlyTokenQuota)
+	require.Equal(t, int64(7000), *usage.QuotaRemaining)
+}
+
+func TestUsageService_SetUserQuota_NotFound(t *testing.T) {
+	s, _, userRepo := newUsageTestService(t, 0)
+	userID := uuid.New()
+	quota := int64(10)
+	userRepo.EXPECT().UpdateMonthlyTokenQuota(userID, &quota).Return(gorm.ErrRecordNotFound)
+	require.ErrorIs(t, s.SetUserQuota(userID, &quota), ErrUserNotFound)
+}
@@ -0,0 +1,455 @@
+package service
+
+import (
+	"CVMatch/internal/config"
+	"CVMatch/internal/llm"
+	"CVMatch/internal/models"
+	"CVMatch/internal/parser"
+	"CVMatch/internal/repository"
+	"CVMatch/internal/response"
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrVacancyNotFound    = errors.New("vacancy not found")
+	ErrEmptyDocument      = errors.New("document has no text")
+	ErrVacancyTextTooLong = errors.New("vacancy text is too long")
+)
+
+// maxVacancyTextRunes — предел длины текста вакансии, отправляемого в LLM
+const maxVacancyTextRunes = 20000
+
+type VacancyService struct {
+	repo    repository.VacancyRepositoryI
+	log     *zap.Logger
+	cfg     *config.Config
+	parser  parser.VacancyParserI
+	rematch RematchNotifierI
+}
+
+// NewVacancyService создаёт сервис вакансий. rematch получает сигнал после правки вакансии
+// и может быть nil, тогда результаты сравнения пересчитываются только при следующем запуске.
+func NewVacancyService(repo repository.VacancyRepositoryI, log *zap.Logger, cfg *config.Config, parser parser.VacancyParserI, rematch RematchNotifierI) *VacancyService {
+	return &VacancyService{
+		repo:    repo,
+		log:     log,
+		cfg:     cfg,
+		parser:  parser,
+		rematch: rematch,
+	}
+}
+
+// CreateVacancy сохраняет вакансию. Семейство должности и уровень, если не заданы явно,
+// определяются по названию вакансии. Навыки из Skills без описания в SkillRequirements
+// сохраняются как желательные.
+func (s *VacancyService) CreateVacancy(userID uuid.UUID, dto *response.VacancyDTO) (*response.VacancyDTO, error) {
+	inferTitleFamilyAndSeniority(dto)
+
+	var vacancy *models.Vacancy
+	txErr := s.repo.DB().Transaction(func(tx *gorm.DB) error {
+		txRepo := s.repo.WithTx(tx)
+
+		skills, requirements, err := s.resolveRequirements(txRepo, dto)
+		if err != nil {
+			return err
+		}
+
+		vacancy = &models.Vacancy{UserID: userID, Version: 1}
+		applyVacancyDTO(dto, vacancy)
+		if err := txRepo.Create(vacancy); err != nil {
+			s.log.Error("Failed to save vacancy", zap.Error(err))
+			return err
+		}
+
+		return s.associateRequirements(txRepo, vacancy, skills, requirements)
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	return vacancyToDTO(vacancy), nil
+}
+
+// UpdateVacancy заменяет поля и навыки вакансии. Версия вакансии растёт, и результаты
+// сравнения с ней пересчитываются в фоне.
+func (s *VacancyService) UpdateVacancy(userID, vacancyID uuid.UUID, dto *response.VacancyDTO) (*response.VacancyDTO, error) {
+	inferTitleFamilyAndSeniority(dto)
+
+	var vacancy *models.Vacancy
+	txErr := s.repo.DB().Transaction(func(tx *gorm.DB) error {
+		txRepo := s.repo.WithTx(tx)
+
+		var err error
+		vacancy, err = txRepo.GetVacancyByID(userID, vacancyID)
+		if err != nil {
+			s.log.Warn("Failed to get vacancy by ID", zap.Error(err))
+			return ErrVacancyNotFound
+		}
+		previous, err := txRepo.GetSkillsByVacancyID(vacancyID)
+		if err != nil {
+			s.log.Error("Failed to get skills by vacancy ID", zap.Error(err))
+			return err
+		}
+		for _, skill := range previous {
+			if err := txRepo.DeleteSkillFromVacancy(vacancyID, skill.ID); err != nil {
+				s.log.Error("Failed to delete skill from vacancy", zap.Error(err))
+				return err
+			}
+		}
+
+		skills, requirements, err := s.resolveRequirements(txRepo, dto)
+		if err != nil {
+			return err
+		}
+
+		applyVacancyDTO(dto, vacancy)
+		vacancy.Version++
+		vacancy.Skills, vacancy.Requirements = nil, nil
+		if err := txRepo.Update(vacancy); err != nil {
+			s.log.Error("Failed to update vacancy", zap.Error(err))
+			return err
+		}
+		if err := s.associateRequirements(txRepo, vacancy, skills, requirements); err != nil {
+			return err
+		}
+
+		for _, skill := range previous {
+			if err := txRepo.DeleteUnusedSkill(skill.ID); err != nil {
+				s.log.Error("Failed to delete unused skill", zap.Error(err))
+				return err
+			}
+		}
+		return nil
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	notifyRematch(s.rematch)
+	return vacancyToDTO(vacancy), nil
+}
+
+// inferTitleFamilyAndSeniority определяет семейство должности и уровень по названию, если они не заданы явно
+func inferTitleFamilyAndSeniority(dto *response.VacancyDTO) {
+	if dto.TitleFamily == "" {
+		dto.TitleFamily = parser.NormalizeTitleFamily(dto.Title)
+	}
+	if dto.Seniority == "" {
+		dto.Seniority = parser.SeniorityFromTitle(dto.Title)
+	}
+}
+
+// resolveRequirements находит или создаёт навыки вакансии и собирает строки требований к ним
+func (s *VacancyService) resolveRequirements(txRepo repository.VacancyRepositoryI, dto *response.VacancyDTO) ([]*models.Skill, []models.VacancySkill, error) {
+	var skills []*models.Skill
+	var requirements []models.VacancySkill
+	for _, req := range vacancySkillRequirements(dto) {
+		skill, err := txRepo.FirstOrCreateSkill(req.Name)
+		if err != nil {
+			s.log.Error("Failed to find or create skill", zap.String("skill", req.Name), zap.Error(err))
+			return nil, nil, err
+		}
+		skills = append(skills, skill)
+		requirements = append(requirements, models.VacancySkill{
+			SkillID:     skill.ID,
+			Requirement: req.Requirement,
+			Weight:      req.Weight,
+			MinYears:    req.MinYears,
+		})
+	}
+	return skills, requirements, nil
+}
+
+// associateRequirements привязывает навыки к сохранённой вакансии
+func (s *VacancyService) associateRequirements(txRepo repository.VacancyRepositoryI, vacancy *models.Vacancy, skills []*models.Skill, requirements []models.VacancySkill) error {
+	if len(skills) == 0 {
+		return nil
+	}
+	if err := txRepo.AssociateSkills(vacancy, requirements); err != nil {
+		s.log.Error("Failed to associate skills", zap.Error(err))
+		return err
+	}
+	for _, skill := range skills {
+		vacancy.Skills = append(vacancy.Skills, *skill)
+	}
+	vacancy.Requirements = requirements
+	return nil
+}
+
+// applyVacancyDTO переносит поля вакансии из DTO в модель, не трогая навыки
+func applyVacancyDTO(dto *response.VacancyDTO, vacancy *models.Vacancy) {
+	vacancy.Title = dto.Title
+	vacancy.Description = dto.Description
+	vacancy.Location = dto.Location
+	vacancy.TitleFamily = dto.TitleFamily
+	vacancy.Seniority = dto.Seniority
+	vacancy.MinExperienceYears = dto.MinExperienceYears
+	vacancy.MaxExperienceYears = dto.MaxExperienceYears
+	vacancy.EmploymentType = dto.EmploymentType
+	vacancy.RemotePolicy = dto.Remote
+	vacancy.SalaryFrom, vacancy.SalaryTo, vacancy.SalaryCurrency = nil, nil, ""
+	if dto.Salary != nil {
+		vacancy.SalaryFrom = dto.Salary.From
+		vacancy.SalaryTo = dto.Salary.To
+		vacancy.SalaryCurrency = parser.NormalizeCurrency(dto.Salary.Currency)
+	}
+}
+
+// ParseVacancyFile извлекает текст из файла объявления (PDF или текст) и разбирает его в черновик вакансии
+func (s *VacancyService) ParseVacancyFile(ctx context.Context, userID uuid.UUID, path string) (*response.VacancyDraftDTO, error) {
+	extracted, err := parser.ExtractDocument(path)
+	if err != nil {
+		s.log.Warn("Failed to extract vacancy text", zap.Error(err))
+		return nil, err
+	}
+	return s.ParseVacancyText(ctx, userID, extracted.Text)
+}
+
+// ParseVacancyText разбирает текст объявления через LLM и возвращает черновик вакансии.
+// Черновик не сохраняется: его можно поправить и отправить в CreateVacancy.
+func (s *VacancyService) ParseVacancyText(ctx context.Context, userID uuid.UUID, text string) (*response.VacancyDraftDTO, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, ErrEmptyDocument
+	}
+	if utf8.RuneCountInString(text) > maxVacancyTextRunes {
+		return nil, ErrVacancyTextTooLong
+	}
+
+	ctx = llm.WithOwner(ctx, llm.Owner{UserID: userID, Operation: llm.OperationVacancyParse})
+	parsed, err := s.parser.ParseVacancy(ctx, text, s.cfg)
+	if err != nil {
+		s.log.Error("Failed to parse vacancy", zap.Error(err))
+		return nil, err
+	}
+	draft, err := parser.DecodeVacancyOutput(parsed.Output)
+	if err != nil {
+		s.log.Error("Failed to unmarshal vacancy draft", zap.Error(err))
+		return nil, err
+	}
+
+	normalizeVacancyDraft(draft)
+	draft.Language = parsed.Language
+	draft.PromptVersion = parsed.PromptVersion
+	draft.Model = parsed.Model
+	return draft, nil
+}
+
+// vacancySkillRequirements объединяет SkillRequirements и Skills без повторов (без учёта регистра,
+// первое упоминание важнее) и подставляет тип требования и вес по умолчанию
+func vacancySkillRequirements(dto *response.VacancyDTO) []response.SkillRequirementDTO {
+	all := append([]response.SkillRequirementDTO{}, dto.SkillRequirements...)
+	for _, name := range dto.Skills {
+		all = append(all, response.SkillRequirementDTO{Name: name})
+	}
+
+	seen := make(map[string]struct{}, len(all))
+	var result []response.SkillRequirementDTO
+	for _, req := range all {
+		req.Name = strings.TrimSpace(req.Name)
+		key := strings.ToLower(req.Name)
+		if req.Name == "" {
+			continue
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		if req.Requirement != models.SkillRequired {
+			req.Requirement = models.SkillPreferred
+		}
+		if req.Weight <= 0 {
+			req.Weight = models.DefaultSkillWeight(req.Requirement)
+		}
+		result = append(result, req)
+	}
+	return result
+}
+
+// normalizeVacancyDraft приводит ответ LLM к значениям, которые принимает создание вакансии
+func normalizeVacancyDraft(draft *response.VacancyDraftDTO) {
+	draft.Title = strings.TrimSpace(draft.Title)
+	draft.TitleFamily = parser.NormalizeTitleFamily(draft.Title)
+	draft.Seniority = parser.SeniorityFromTitle(draft.Title)
+
+	draft.RequiredSkills = uniqueSkillNames(draft.RequiredSkills)
+	required := make(map[string]struct{}, len(draft.RequiredSkills))
+	for _, skill := range draft.RequiredSkills {
+		required[strings.ToLower(skill)] = struct{}{}
+	}
+	var preferred []string
+	for _, skill := range uniqueSkillNames(draft.PreferredSkills) {
+		if _, ok := required[strings.ToLower(skill)]; !ok {
+			preferred = append(preferred, skill)
+		}
+	}
+	draft.PreferredSkills = preferred
+	draft.Skills = append(append([]string{}, draft.RequiredSkills...), draft.PreferredSkills...)
+	if draft.RequiredSkills == nil {
+		draft.RequiredSkills = []string{}
+	}
+	if draft.PreferredSkills == nil {
+		draft.PreferredSkills = []string{}
+	}
+
+	draft.MinExperienceYears = nonNegative(draft.MinExperienceYears)
+	draft.MaxExperienceYears = nonNegative(draft.MaxExperienceYears)
+	if draft.MinExperienceYears != nil && draft.MaxExperienceYears != nil && *draft.MaxExperienceYears < *draft.MinExperienceYears {
+		draft.MaxExperienceYears = nil
+	}
+	if draft.Seniority == "" && draft.MinExperienceYears != nil {
+		draft.Seniority = parser.SeniorityFromTenure(int(*draft.MinExperienceYears * 12))
+	}
+
+	if draft.Salary != nil {
+		draft.Salary.Currency = parser.NormalizeCurrency(draft.Salary.Currency)
+		if draft.Salary.From != nil && *draft.Salary.From <= 0 {
+			draft.Salary.From = nil
+		}
+		if draft.Salary.To != nil && *draft.Salary.To <= 0 {
+			draft.Salary.To = nil
+		}
+		if draft.Salary.From == nil && draft.Salary.To == nil {
+			draft.Salary = nil
+		}
+	}
+	draft.EmploymentType = parser.NormalizeEmploymentType(draft.EmploymentType)
+	draft.Remote = parser.NormalizeRemotePreference(draft.Remote)
+}
+
+func nonNegative(v *float64) *float64 {
+	if v == nil || *v < 0 {
+		return nil
+	}
+	return v
+}
+
+func (s *VacancyService) GetListVacancy(userID uuid.UUID, filter repository.VacancyFilter) (*response.VacancyListDTO, error) {
+	vacancies, err := s.repo.GetListVacancy(userID, filter)
+	if err != nil {
+		s.log.Error("Failed to get list of vacancies", zap.Error(err))
+		return nil, err
+	}
+
+	dtos := make([]*response.VacancyDTO, 0, len(*vacancies))
+	for i := range *vacancies {
+		dtos = append(dtos, vacancyToDTO(&(*vacancies)[i]))
+	}
+	return &response.VacancyListDTO{Vacancies: dtos}, nil
+}
+
+func (s *VacancyService) GetVacancyByID(userID, vacancyID uuid.UUID) (*response.VacancyDTO, error) {
+	vacancy, err := s.repo.GetVacancyByID(userID, vacancyID)
+	if err != nil {
+		s.log.Warn("Failed to get vacancy by ID", zap.Error(err))
+		return nil, ErrVacancyNotFound
+	}
+	return vacancyToDTO(vacancy), nil
+}
+
+func (s *VacancyService) DeleteVacancy(userID, vacancyID uuid.UUID) error {
+	return s.repo.DB().Transaction(func(tx *gorm.DB) error {
+		txRepo := s.repo.WithTx(tx)
+		if _, err := txRepo.GetVacancyByID(userID, vacancyID); err != nil {
+			s.log.Warn("Failed to get vacancy by ID", zap.Error(err))
+			return ErrVacancyNotFound
+		}
+		skills, err := txRepo.GetSkillsByVacancyID(vacancyID)
+		if err != nil {
+			s.log.Error("Failed to get skills by vacancy ID", zap.Error(err))
+			return err
+		}
+		for _, skill := range skills {
+			if err := txRepo.DeleteSkillFromVacancy(vacancyID, skill.ID); err != nil {
+				s.log.Error("Failed to delete skill from vacancy", zap.Error(err))
+				return err
+			}
+			if err := txRepo.DeleteUnusedSkill(skill.ID); err != nil {
+				s.log.Error("Failed to delete unused skill", zap.Error(err))
+				return err
+			}
+		}
+		if err := txRepo.DeleteUnusedMatching(vacancyID); err != nil {
+			s.log.Error("Failed to delete unused matching", zap.Error(err))
+			return err
+		}
+		if err := txRepo.DeleteVacancy(vacancyID); err != nil {
+			s.log.Error("Failed to delete vacancy", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+}
+
+func vacancyToDTO(vacancy *models.Vacancy) *response.VacancyDTO {
+	dto := &response.VacancyDTO{
+		ID:          vacancy.ID.String(),
+		Title:       vacancy.Title,
+		Description: vacancy.Description,
+		Location:    vacancy.Location,
+		TitleFamily: vacancy.TitleFamily,
+		Seniority:   vacancy.Seniority,
+		Skills:      []string{},
+
+		SkillRequirements: []response.SkillRequirementDTO{},
+
+		MinExperienceYears: vacancy.MinExperienceYears,
+		MaxExperienceYears: vacancy.MaxExperienceYears,
+		EmploymentType:     vacancy.EmploymentType,
+		Remote:             vacancy.RemotePolicy,
+		Version:            vacancy.Version,
+
+		CreatedAt: vacancy.CreatedAt,
+	}
+	if vacancy.MatchingProfileID != nil {
+		id := vacancy.MatchingProfileID.String()
+		dto.MatchingProfileID = &id
+	}
+	if vacancy.SalaryFrom != nil || vacancy.SalaryTo != nil {
+		dto.Salary = &response.SalaryRangeDTO{From: vacancy.SalaryFrom, To: vacancy.SalaryTo, Currency: vacancy.SalaryCurrency}
+	}
+	requirements := make(map[uuid.UUID]models.VacancySkill, len(vacancy.Requirements))
+	for _, req := range vacancy.Requirements {
+		requirements[req.SkillID] = req
+	}
+	for _, skill := range vacancy.Skills {
+		dto.Skills = append(dto.Skills, skill.Name)
+		req, ok := requirements[skill.ID]
+		if !ok {
+			req = models.VacancySkill{Requirement: models.SkillPreferred, Weight: 1}
+		}
+		dto.SkillRequirements = append(dto.SkillRequirements, response.SkillRequirementDTO{
+			Name:        skill.Name,
+			Requirement: req.Requirement,
+			Weight:      req.Weight,
+			MinYears:    req.MinYears,
+		})
+	}
+	return dto
+}
+
+// uniqueSkillNames убирает пустые и повторяющиеся (без учёта регистра) навыки
+func uniqueSkillNames(names []string) []string {
+	seen := make(map[string]struct{}, len(names))
+	var result []string
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		key := strings.ToLower(name)
+		if name == "" {
+			continue
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		result = append(result, name)
+	}
+	return result
+}
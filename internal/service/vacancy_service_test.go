@@ -0,0 +1,250 @@
+package service
+
+import (
+	"CVMatch/internal/config"
+	"CVMatch/internal/llm"
+	"CVMatch/internal/models"
+	"CVMatch/internal/parser"
+	"CVMatch/internal/repository"
+	"CVMatch/internal/repository/mocks"
+	"CVMatch/internal/response"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestVacancyService_CreateVacancy_InfersFamilyAndSeniority(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockVacancyRepositoryI(ctrl)
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	userID := uuid.New()
+	mockRepo.EXPECT().DB().Return(db).AnyTimes()
+	mockRepo.EXPECT().WithTx(gomock.Any()).Return(mockRepo).AnyTimes()
+	mockRepo.EXPECT().FirstOrCreateSkill("Go").Return(&models.Skill{ID: uuid.New(), Name: "Go"}, nil)
+	mockRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(v *models.Vacancy) error {
+		require.Equal(t, "backend", v.TitleFamily)
+		require.Equal(t, "senior", v.Seniority)
+		v.ID = uuid.New()
+		return nil
+	})
+	mockRepo.EXPECT().AssociateSkills(gomock.Any(), gomock.Any()).Return(nil)
+
+	service := NewVacancyService(mockRepo, zap.NewNop(), &config.Config{}, nil, nil)
+	dto, err := service.CreateVacancy(userID, &response.VacancyDTO{
+		Title:  "Senior Backend Developer",
+		Skills: []string{"Go", " go ", ""},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"Go"}, dto.Skills)
+	require.Equal(t, "senior", dto.Seniority)
+}
+
+func TestVacancyService_CreateVacancy_SkillRequirements(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockVacancyRepositoryI(ctrl)
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	mockRepo.EXPECT().DB().Return(db).AnyTimes()
+	mockRepo.EXPECT().WithTx(gomock.Any()).Return(mockRepo).AnyTimes()
+	mockRepo.EXPECT().FirstOrCreateSkill(gomock.Any()).DoAndReturn(func(name string) (*models.Skill, error) {
+		return &models.Skill{ID: uuid.New(), Name: name}, nil
+	}).Times(3)
+	mockRepo.EXPECT().Create(gomock.Any()).Return(nil)
+	mockRepo.EXPECT().AssociateSkills(gomock.Any(), gomock.Any()).DoAndReturn(func(_ *models.Vacancy, reqs []models.VacancySkill) error {
+		require.Len(t, reqs, 3)
+		require.Equal(t, models.SkillRequired, reqs[0].Requirement)
+		require.Equal(t, 3.0, reqs[0].Weight)
+		require.Equal(t, models.SkillRequired, reqs[1].Requirement)
+		require.Equal(t, 2.0, reqs[1].Weight)
+		require.Equal(t, models.SkillPreferred, reqs[2].Requirement)
+		require.Equal(t, 1.0, reqs[2].Weight)
+		return nil
+	})
+
+	minYears := 3.0
+	service := NewVacancyService(mockRepo, zap.NewNop(), &config.Config{}, nil, nil)
+	dto, err := service.CreateVacancy(uuid.New(), &response.VacancyDTO{
+		Title:  "Go Developer",
+		Skills: []string{"Go", "Kafka", "Docker"},
+		SkillRequirements: []response.SkillRequirementDTO{
+			{Name: "Go", Requirement: models.SkillRequired, Weight: 3, MinYears: &minYears},
+			{Name: "kafka", Requirement: models.SkillRequired},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"Go", "kafka", "Docker"}, dto.Skills)
+	require.Equal(t, 3.0, *dto.SkillRequirements[0].MinYears)
+	require.Equal(t, models.SkillPreferred, dto.SkillRequirements[2].Requirement)
+}
+
+func TestVacancyService_GetVacancyByID_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockVacancyRepositoryI(ctrl)
+	userID, vacancyID := uuid.New(), uuid.New()
+	mockRepo.EXPECT().GetVacancyByID(userID, vacancyID).Return(nil, assert.AnError)
+
+	service := NewVacancyService(mockRepo, zap.NewNop(), &config.Config{}, nil, nil)
+	dto, err := service.GetVacancyByID(userID, vacancyID)
+	require.ErrorIs(t, err, ErrVacancyNotFound)
+	require.Nil(t, dto)
+}
+
+func TestVacancyService_GetListVacancy_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockVacancyRepositoryI(ctrl)
+	userID := uuid.New()
+	filter := repository.VacancyFilter{Seniority: "middle"}
+	vacancies := []models.Vacancy{{ID: uuid.New(), Title: "Go Developer", Seniority: "middle"}}
+	mockRepo.EXPECT().GetListVacancy(userID, filter).Return(&vacancies, nil)
+
+	service := NewVacancyService(mockRepo, zap.NewNop(), &config.Config{}, nil, nil)
+	dto, err := service.GetListVacancy(userID, filter)
+	require.NoError(t, err)
+	require.Len(t, dto.Vacancies, 1)
+	require.Equal(t, "Go Developer", dto.Vacancies[0].Title)
+}
+
+func TestVacancyService_ParseVacancyText(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockParser := mocks.NewMockVacancyParserI(ctrl)
+	userID := uuid.New()
+	mockParser.EXPECT().ParseVacancy(gomock.Any(), "Senior Go разработчик", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, _ string, _ *config.Config) (*parser.ParseResult, error) {
+			owner, ok := llm.OwnerFromContext(ctx)
+			require.True(t, ok)
+			require.Equal(t, userID, owner.UserID)
+			require.Equal(t, llm.OperationVacancyParse, owner.Operation)
+			return &parser.ParseResult{
+				Language:      parser.LangRussian,
+				PromptVersion: "v2",
+				Model:         "yandexgpt-lite",
+				Output: "```json\n" + `{
+					"title": "Senior Go Developer",
+					"location": "Москва",
+					"required_skills": ["Go", "PostgreSQL", "go"],
+					"preferred_skills": ["Kafka", "PostgreSQL"],
+					"min_experience_years": 5,
+					"max_experience_years": 2,
+					"salary": {"from": "от 300 000", "to": null, "currency": "руб"},
+					"employment_type": "Полная занятость",
+					"remote": "гибрид"
+				}` + "\n```",
+			}, nil
+		})
+
+	service := NewVacancyService(nil, zap.NewNop(), &config.Config{}, mockParser, nil)
+	draft, err := service.ParseVacancyText(context.Background(), userID, "  Senior Go разработчик ")
+	require.NoError(t, err)
+	require.Equal(t, "Senior Go Developer", draft.Title)
+	require.Equal(t, "backend", draft.TitleFamily)
+	require.Equal(t, "senior", draft.Seniority)
+	require.Equal(t, []string{"Go", "PostgreSQL"}, draft.RequiredSkills)
+	require.Equal(t, []string{"Kafka"}, draft.PreferredSkills)
+	require.Equal(t, []string{"Go", "PostgreSQL", "Kafka"}, draft.Skills)
+	require.Equal(t, 5.0, *draft.MinExperienceYears)
+	require.Nil(t, draft.MaxExperienceYears)
+	require.Equal(t, 300000, *draft.Salary.From)
+	require.Nil(t, draft.Salary.To)
+	require.Equal(t, "RUB", draft.Salary.Currency)
+	require.Equal(t, parser.EmploymentFullTime, draft.EmploymentType)
+	require.Equal(t, parser.RemoteHybrid, draft.Remote)
+	require.Equal(t, "v2", draft.PromptVersion)
+}
+
+func TestVacancyService_ParseVacancyText_Empty(t *testing.T) {
+	service := NewVacancyService(nil, zap.NewNop(), &config.Config{}, nil, nil)
+	_, err := service.ParseVacancyText(context.Background(), uuid.New(), "   ")
+	require.ErrorIs(t, err, ErrEmptyDocument)
+}
+
+// countingNotifier считает сигналы о пересчёте сравнений
+type countingNotifier struct {
+	calls int
+}
+
+func (n *countingNotifier) Notify() {
+	n.calls++
+}
+
+func TestVacancyService_UpdateVacancy_ReplacesSkillsAndBumpsVersion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockVacancyRepositoryI(ctrl)
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	userID, vacancyID := uuid.New(), uuid.New()
+	oldSkill := &models.Skill{ID: uuid.New(), Name: "PHP"}
+	mockRepo.EXPECT().DB().Return(db).AnyTimes()
+	mockRepo.EXPECT().WithTx(gomock.Any()).Return(mockRepo).AnyTimes()
+	mockRepo.EXPECT().GetVacancyByID(userID, vacancyID).Return(&models.Vacancy{
+		ID:      vacancyID,
+		UserID:  userID,
+		Title:   "PHP Developer",
+		Version: 2,
+		Skills:  []models.Skill{*oldSkill},
+	}, nil)
+	mockRepo.EXPECT().GetSkillsByVacancyID(vacancyID).Return([]*models.Skill{oldSkill}, nil)
+	mockRepo.EXPECT().DeleteSkillFromVacancy(vacancyID, oldSkill.ID).Return(nil)
+	mockRepo.EXPECT().FirstOrCreateSkill("Go").Return(&models.Skill{ID: uuid.New(), Name: "Go"}, nil)
+	mockRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(v *models.Vacancy) error {
+		require.Equal(t, 3, v.Version)
+		require.Equal(t, "Senior Go Developer", v.Title)
+		require.Equal(t, "senior", v.Seniority)
+		require.Empty(t, v.Skills)
+		return nil
+	})
+	mockRepo.EXPECT().AssociateSkills(gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().DeleteUnusedSkill(oldSkill.ID).Return(nil)
+
+	notifier := &countingNotifier{}
+	service := NewVacancyService(mockRepo, zap.NewNop(), &config.Config{}, nil, notifier)
+	dto, err := service.UpdateVacancy(userID, vacancyID, &response.VacancyDTO{
+		Title:  "Senior Go Developer",
+		Skills: []string{"Go"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"Go"}, dto.Skills)
+	require.Equal(t, 3, dto.Version)
+	require.Equal(t, 1, notifier.calls)
+}
+
+func TestVacancyService_UpdateVacancy_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockVacancyRepositoryI(ctrl)
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	mockRepo.EXPECT().DB().Return(db).AnyTimes()
+	mockRepo.EXPECT().WithTx(gomock.Any()).Return(mockRepo).AnyTimes()
+	mockRepo.EXPECT().GetVacancyByID(gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
+
+	notifier := &countingNotifier{}
+	service := NewVacancyService(mockRepo, zap.NewNop(), &config.Config{}, nil, notifier)
+	_, err = service.UpdateVacancy(uuid.New(), uuid.New(), &response.VacancyDTO{Title: "Go Developer"})
+	require.ErrorIs(t, err, ErrVacancyNotFound)
+	require.Zero(t, notifier.calls)
+}
@@ -1,24 +1,84 @@
-package storage
-
-import (
-	"CVMatch/internal/models"
-
-	"go.uber.org/zap"
-	"gorm.io/gorm"
-)
-
-func Migrate(db *gorm.DB, log *zap.Logger) {
-	if err := db.AutoMigrate(
-		&models.User{},
-		&models.Resume{},
-		&models.ResumeFile{},
-		&models.Skill{},
-		&models.Experience{},
-		&models.Education{},
-		&models.Vacancy{},
-		&models.MatchingResult{},
-	); err != nil {
-		log.Fatal("Ошибка миграции базы данных", zap.Error(err))
-	}
-	log.Info("Миграция базы данных прошла успешно")
-}
+package storage
+
+import (
+	"CVMatch/internal/models"
+	"CVMatch/internal/repository"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func Migrate(db *gorm.DB, log *zap.Logger) {
+	if err := db.AutoMigrate(
+		&models.User{},
+		&models.Organization{},
+		&models.OrganizationMember{},
+		&models.Resume{},
+		&models.ResumeFile{},
+		&models.Skill{},
+		&models.Experience{},
+		&models.Education{},
+		&models.ResumeLanguage{},
+		&models.Certification{},
+		&models.Project{},
+		&models.ResumeLink{},
+		&models.ResumeNote{},
+		&models.Tag{},
+		&models.Vacancy{},
+		&models.VacancySkill{},
+		&models.MatchingProfile{},
+		&models.MatchingResult{},
+		&models.MatchJob{},
+		&models.CoverLetter{},
+		&models.Application{},
+		&models.ApplicationTransition{},
+		&models.Interview{},
+		&models.InterviewParticipant{},
+		&models.InterviewFeedback{},
+		&models.LLMCacheEntry{},
+		&models.LLMUsage{},
+	); err != nil {
+		log.Fatal("Ошибка миграции базы данных", zap.Error(err))
+	}
+	if err := migrateWorkspaces(db); err != nil {
+		log.Fatal("Ошибка переноса данных в рабочие пространства", zap.Error(err))
+	}
+	log.Info("Миграция базы данных прошла успешно")
+}
+
+// migrateWorkspaces переносит данные, созданные до появления организаций: каждый пользователь
+// без рабочего пространства получает личное, и туда переезжают его резюме, вакансии и метки.
+// Повторный запуск ничего не меняет.
+func migrateWorkspaces(db *gorm.DB) error {
+	// Метки раньше были уникальны в пределах пользователя, теперь — в пределах пространства
+	if db.Migrator().HasIndex(&models.Tag{}, "idx_tag_user_name") {
+		if err := db.Migrator().DropIndex(&models.Tag{}, "idx_tag_user_name"); err != nil {
+			return err
+		}
+	}
+
+	var users []models.User
+	if err := db.Where("active_organization_id IS NULL").Find(&users).Error; err != nil {
+		return err
+	}
+	for i := range users {
+		user := &users[i]
+		err := db.Transaction(func(tx *gorm.DB) error {
+			organization, err := repository.CreatePersonalWorkspace(tx, user)
+			if err != nil {
+				return err
+			}
+			for _, table := range []string{"resumes", "vacancies", "tags"} {
+				if err := tx.Table(table).Where("user_id = ? AND organization_id IS NULL", user.ID).
+					Update("organization_id", organization.ID).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}